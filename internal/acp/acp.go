@@ -0,0 +1,589 @@
+// Package acp implements the Agent Client Protocol, which lets editors run
+// Crush as their coding agent by speaking JSON-RPC over stdio.
+//
+// ACP sessions are Crush sessions and prompts run on the agent coordinator.
+// Message updates are streamed back to the editor as session updates, and
+// permission requests are forwarded to it. When the editor advertises it,
+// files are read and written through its buffers.
+package acp
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/crush/internal/agent"
+	"github.com/charmbracelet/crush/internal/agent/tools"
+	"github.com/charmbracelet/crush/internal/app"
+	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/charmbracelet/crush/internal/message"
+	"github.com/charmbracelet/crush/internal/permission"
+	"github.com/charmbracelet/crush/internal/pubsub"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+// maxSessionDepth bounds the lookup of the ACP session owning a sub-agent
+// session.
+const maxSessionDepth = 8
+
+// Permission options offered to the editor.
+const (
+	optionAllowOnce   = "allow_once"
+	optionAllowAlways = "allow_always"
+	optionRejectOnce  = "reject_once"
+)
+
+// Agent serves the application to an ACP client.
+type Agent struct {
+	app        *app.App
+	workingDir string
+	conn       *jsonrpc2.Conn
+	// ready is closed once conn is set.
+	ready chan struct{}
+
+	mu sync.Mutex
+	fs fsCapabilities
+
+	sessions *csync.Map[string, *sessionState]
+}
+
+// Serve runs the agent over the connection until the client disconnects or
+// the context is done. workingDir is the directory Crush was started in,
+// ACP sessions for other directories are refused.
+func Serve(ctx context.Context, a *app.App, workingDir string, rwc io.ReadWriteCloser) error {
+	ag := &Agent{
+		app:        a,
+		workingDir: workingDir,
+		ready:      make(chan struct{}),
+		sessions:   csync.NewMap[string, *sessionState](),
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Subscribe before accepting requests so that no update is missed.
+	messages := a.Messages.Subscribe(ctx)
+	permissions := a.Permissions.Subscribe(ctx)
+
+	stream := jsonrpc2.NewBufferedStream(rwc, jsonrpc2.PlainObjectCodec{})
+	ag.conn = jsonrpc2.NewConn(ctx, stream, jsonrpc2.AsyncHandler(jsonrpc2.HandlerWithError(ag.handle)))
+	defer ag.conn.Close()
+	close(ag.ready)
+
+	go ag.forwardMessages(ctx, messages)
+	go ag.forwardPermissions(ctx, permissions)
+
+	select {
+	case <-ag.conn.DisconnectNotify():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (ag *Agent) handle(ctx context.Context, _ *jsonrpc2.Conn, req *jsonrpc2.Request) (any, error) {
+	<-ag.ready
+	switch req.Method {
+	case methodInitialize:
+		var params initializeRequest
+		if err := decodeParams(req, &params); err != nil {
+			return nil, err
+		}
+		return ag.initialize(params), nil
+	case methodAuthenticate:
+		// Providers are configured in Crush itself.
+		return struct{}{}, nil
+	case methodSessionNew:
+		var params newSessionRequest
+		if err := decodeParams(req, &params); err != nil {
+			return nil, err
+		}
+		return ag.newSession(ctx, params)
+	case methodSessionLoad:
+		var params loadSessionRequest
+		if err := decodeParams(req, &params); err != nil {
+			return nil, err
+		}
+		return nil, ag.loadSession(ctx, params)
+	case methodSessionPrompt:
+		var params promptRequest
+		if err := decodeParams(req, &params); err != nil {
+			return nil, err
+		}
+		return ag.prompt(ctx, params)
+	case methodSessionCancel:
+		var params cancelNotification
+		if err := decodeParams(req, &params); err != nil {
+			return nil, err
+		}
+		if ag.app.AgentCoordinator != nil {
+			ag.app.AgentCoordinator.Cancel(params.SessionID)
+		}
+		return nil, nil
+	default:
+		return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+}
+
+func (ag *Agent) initialize(params initializeRequest) initializeResponse {
+	ag.mu.Lock()
+	ag.fs = params.ClientCapabilities.FS
+	ag.mu.Unlock()
+	return initializeResponse{
+		ProtocolVersion: ProtocolVersion,
+		AgentCapabilities: agentCapabilities{
+			LoadSession: true,
+			PromptCapabilities: promptCapabilities{
+				Image:           true,
+				EmbeddedContext: true,
+			},
+		},
+		AuthMethods: []any{},
+	}
+}
+
+func (ag *Agent) newSession(ctx context.Context, params newSessionRequest) (newSessionResponse, error) {
+	if err := ag.checkCwd(params.Cwd); err != nil {
+		return newSessionResponse{}, err
+	}
+	sess, err := ag.app.Sessions.Create(ctx, "")
+	if err != nil {
+		return newSessionResponse{}, err
+	}
+	ag.sessions.Set(sess.ID, newSessionState(sess.ID))
+	return newSessionResponse{SessionID: sess.ID}, nil
+}
+
+// loadSession replays the messages of an existing session as updates.
+func (ag *Agent) loadSession(ctx context.Context, params loadSessionRequest) error {
+	if err := ag.checkCwd(params.Cwd); err != nil {
+		return err
+	}
+	sess, err := ag.app.Sessions.Get(ctx, params.SessionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return invalidParams(fmt.Errorf("session not found: %s", params.SessionID))
+	}
+	if err != nil {
+		return err
+	}
+	msgs, err := ag.app.Messages.List(ctx, sess.ID)
+	if err != nil {
+		return err
+	}
+
+	state := newSessionState(sess.ID)
+	ag.sessions.Set(sess.ID, state)
+	for _, msg := range msgs {
+		if msg.Role == message.User {
+			if text := msg.Content().Text; text != "" {
+				ag.notify(ctx, sess.ID, sessionUpdate{
+					SessionUpdate: "user_message_chunk",
+					Content:       contentBlock{Type: "text", Text: text},
+				})
+			}
+			continue
+		}
+		ag.update(ctx, state, msg)
+	}
+	return nil
+}
+
+// checkCwd refuses sessions for another directory than the one Crush runs
+// in, as the tools and the context files are bound to it.
+func (ag *Agent) checkCwd(cwd string) error {
+	if cwd == "" || ag.workingDir == "" {
+		return nil
+	}
+	if filepath.Clean(cwd) != filepath.Clean(ag.workingDir) {
+		return invalidParams(fmt.Errorf("crush runs in %s, can't open a session in %s", ag.workingDir, cwd))
+	}
+	return nil
+}
+
+func (ag *Agent) prompt(ctx context.Context, params promptRequest) (promptResponse, error) {
+	coordinator := ag.app.AgentCoordinator
+	if coordinator == nil {
+		return promptResponse{}, errors.New("no providers configured, run crush to set one up")
+	}
+	state, ok := ag.sessions.Get(params.SessionID)
+	if !ok {
+		return promptResponse{}, invalidParams(fmt.Errorf("session not found: %s", params.SessionID))
+	}
+	prompt, attachments := convertPrompt(params.Prompt)
+	if strings.TrimSpace(prompt) == "" {
+		return promptResponse{}, invalidParams(agent.ErrEmptyPrompt)
+	}
+
+	ag.mu.Lock()
+	fs := ag.fs
+	ag.mu.Unlock()
+	runCtx := ctx
+	if fs.ReadTextFile || fs.WriteTextFile {
+		runCtx = context.WithValue(ctx, tools.EditorFSContextKey, &editorFS{
+			conn:      ag.conn,
+			sessionID: params.SessionID,
+			caps:      fs,
+		})
+	}
+
+	_, runErr := coordinator.Run(runCtx, params.SessionID, prompt, attachments...)
+
+	// The last updates may still be on their way, catch up from the
+	// database so that they are all sent before the turn ends.
+	msgs, err := ag.app.Messages.List(context.WithoutCancel(ctx), params.SessionID)
+	if err != nil {
+		return promptResponse{}, err
+	}
+	for _, msg := range msgs {
+		ag.update(ctx, state, msg)
+	}
+
+	if runErr != nil {
+		if errors.Is(runErr, context.Canceled) || errors.Is(runErr, agent.ErrRequestCancelled) {
+			return promptResponse{StopReason: stopReasonCancelled}, nil
+		}
+		if agent.IsStoppedErr(runErr) {
+			return promptResponse{StopReason: stopReasonMaxTurnRequests}, nil
+		}
+		return promptResponse{}, runErr
+	}
+	return promptResponse{StopReason: stopReason(msgs)}, nil
+}
+
+// stopReason returns why the last assistant message ended.
+func stopReason(msgs []message.Message) string {
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if msgs[i].Role != message.Assistant {
+			continue
+		}
+		if finish := msgs[i].FinishPart(); finish != nil {
+			switch finish.Reason {
+			case message.FinishReasonCanceled, message.FinishReasonPermissionDenied:
+				return stopReasonCancelled
+			case message.FinishReasonMaxTokens:
+				return stopReasonMaxTokens
+			}
+			if finish.Reason.Stopped() {
+				return stopReasonMaxTurnRequests
+			}
+		}
+		break
+	}
+	return stopReasonEndTurn
+}
+
+// convertPrompt turns the content blocks of a prompt into the text of the
+// prompt and its attachments. Embedded text resources and links are added to
+// the text, images and binary resources are attached.
+func convertPrompt(blocks []contentBlock) (string, []message.Attachment) {
+	var text strings.Builder
+	var attachments []message.Attachment
+	for _, block := range blocks {
+		switch block.Type {
+		case "text":
+			text.WriteString(block.Text)
+		case "resource_link":
+			fmt.Fprintf(&text, "\n@%s\n", uriPath(block.URI))
+		case "resource":
+			if block.Resource == nil {
+				continue
+			}
+			if block.Resource.Text != "" {
+				fmt.Fprintf(&text, "\n<file path=%q>\n%s\n</file>\n", uriPath(block.Resource.URI), block.Resource.Text)
+				continue
+			}
+			if data, err := base64.StdEncoding.DecodeString(block.Resource.Blob); err == nil {
+				attachments = append(attachments, message.Attachment{
+					FilePath: uriPath(block.Resource.URI),
+					FileName: filepath.Base(uriPath(block.Resource.URI)),
+					MimeType: block.Resource.MimeType,
+					Content:  data,
+				})
+			}
+		case "image":
+			if data, err := base64.StdEncoding.DecodeString(block.Data); err == nil {
+				name := block.URI
+				if name == "" {
+					name = "image"
+				}
+				attachments = append(attachments, message.Attachment{
+					FilePath: uriPath(name),
+					FileName: filepath.Base(uriPath(name)),
+					MimeType: block.MimeType,
+					Content:  data,
+				})
+			}
+		}
+	}
+	return text.String(), attachments
+}
+
+func uriPath(uri string) string {
+	return strings.TrimPrefix(uri, "file://")
+}
+
+// forwardMessages sends the updates of the messages of the ACP sessions.
+func (ag *Agent) forwardMessages(ctx context.Context, messages <-chan pubsub.Event[message.Message]) {
+	for event := range messages {
+		msg := event.Payload
+		if msg.Role == message.User {
+			continue
+		}
+		if state, ok := ag.sessions.Get(msg.SessionID); ok {
+			ag.update(ctx, state, msg)
+		}
+	}
+}
+
+// update sends what changed in the message since it was last seen.
+func (ag *Agent) update(ctx context.Context, state *sessionState, msg message.Message) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	switch msg.Role {
+	case message.Assistant:
+		if thinking := msg.ReasoningContent().Thinking; len(thinking) > state.thinkingRead[msg.ID] {
+			ag.notify(ctx, state.id, sessionUpdate{
+				SessionUpdate: "agent_thought_chunk",
+				Content:       contentBlock{Type: "text", Text: thinking[state.thinkingRead[msg.ID]:]},
+			})
+			state.thinkingRead[msg.ID] = len(thinking)
+		}
+		if content := msg.Content().String(); len(content) > state.textRead[msg.ID] {
+			ag.notify(ctx, state.id, sessionUpdate{
+				SessionUpdate: "agent_message_chunk",
+				Content:       contentBlock{Type: "text", Text: content[state.textRead[msg.ID]:]},
+			})
+			state.textRead[msg.ID] = len(content)
+		}
+		for _, tc := range msg.ToolCalls() {
+			if !tc.Finished || state.seenCalls[tc.ID] {
+				continue
+			}
+			state.seenCalls[tc.ID] = true
+			update := toolCallUpdate(tc.ID, tc.Name, tc.Input)
+			update.SessionUpdate = "tool_call"
+			update.Status = "in_progress"
+			ag.notify(ctx, state.id, update)
+		}
+	case message.Tool:
+		for _, tr := range msg.ToolResults() {
+			if state.seenResults[tr.ToolCallID] {
+				continue
+			}
+			state.seenResults[tr.ToolCallID] = true
+			status := "completed"
+			if tr.IsError {
+				status = "failed"
+			}
+			ag.notify(ctx, state.id, sessionUpdate{
+				SessionUpdate: "tool_call_update",
+				ToolCallID:    tr.ToolCallID,
+				Status:        status,
+				Content: []toolCallContent{{
+					Type:    "content",
+					Content: contentBlock{Type: "text", Text: tr.Content},
+				}},
+			})
+		}
+	}
+}
+
+func (ag *Agent) notify(ctx context.Context, sessionID string, update sessionUpdate) {
+	if err := ag.conn.Notify(ctx, methodSessionUpdate, sessionNotification{SessionID: sessionID, Update: update}); err != nil {
+		slog.Debug("Failed to send session update", "session_id", sessionID, "error", err)
+	}
+}
+
+// forwardPermissions asks the editor to answer the permission requests of
+// the ACP sessions and of their sub-agents.
+func (ag *Agent) forwardPermissions(ctx context.Context, requests <-chan pubsub.Event[permission.PermissionRequest]) {
+	for event := range requests {
+		req := event.Payload
+		sessionID, ok := ag.acpSession(ctx, req.SessionID)
+		if !ok {
+			continue
+		}
+		go ag.requestPermission(ctx, sessionID, req)
+	}
+}
+
+// acpSession returns the ACP session the session belongs to. Forks have the
+// session they come from as parent, but don't belong to it.
+func (ag *Agent) acpSession(ctx context.Context, sessionID string) (string, bool) {
+	for range maxSessionDepth {
+		if _, ok := ag.sessions.Get(sessionID); ok {
+			return sessionID, true
+		}
+		sess, err := ag.app.Sessions.Get(ctx, sessionID)
+		if err != nil || sess.ParentSessionID == "" || sess.IsFork() {
+			return "", false
+		}
+		sessionID = sess.ParentSessionID
+	}
+	return "", false
+}
+
+func (ag *Agent) requestPermission(ctx context.Context, sessionID string, req permission.PermissionRequest) {
+	params, _ := json.Marshal(req.Params)
+	toolCall := toolCallUpdate(req.ToolCallID, req.ToolName, string(params))
+	if req.Description != "" {
+		toolCall.Title = req.Description
+	}
+	toolCall.Status = "pending"
+
+	var resp requestPermissionResponse
+	err := ag.conn.Call(ctx, methodRequestPermission, requestPermissionRequest{
+		SessionID: sessionID,
+		ToolCall:  toolCall,
+		Options: []permissionOption{
+			{OptionID: optionAllowOnce, Name: "Allow", Kind: optionAllowOnce},
+			{OptionID: optionAllowAlways, Name: "Allow for this session", Kind: optionAllowAlways},
+			{OptionID: optionRejectOnce, Name: "Deny", Kind: optionRejectOnce},
+		},
+	}, &resp)
+	if err != nil {
+		slog.Error("Failed to request permission", "session_id", sessionID, "error", err)
+		ag.app.Permissions.Deny(req)
+		return
+	}
+
+	if resp.Outcome.Outcome != "selected" {
+		ag.app.Permissions.Deny(req)
+		return
+	}
+	switch resp.Outcome.OptionID {
+	case optionAllowOnce:
+		ag.app.Permissions.Grant(req)
+	case optionAllowAlways:
+		ag.app.Permissions.GrantPersistent(req)
+	default:
+		ag.app.Permissions.Deny(req)
+	}
+}
+
+// toolCallUpdate describes a tool call from its name and its JSON input.
+func toolCallUpdate(id, name, input string) sessionUpdate {
+	update := sessionUpdate{
+		ToolCallID: id,
+		Title:      name,
+		Kind:       toolKind(name),
+	}
+	if json.Valid([]byte(input)) {
+		update.RawInput = json.RawMessage(input)
+	}
+
+	var params struct {
+		Command  string `json:"command"`
+		FilePath string `json:"file_path"`
+		Path     string `json:"path"`
+		Pattern  string `json:"pattern"`
+		URL      string `json:"url"`
+	}
+	_ = json.Unmarshal([]byte(input), &params)
+	for _, path := range []string{params.FilePath, params.Path} {
+		if path != "" {
+			update.Locations = append(update.Locations, toolCallLocation{Path: path})
+		}
+	}
+	for _, detail := range []string{params.Command, params.FilePath, params.Pattern, params.URL, params.Path} {
+		if detail != "" {
+			update.Title = name + ": " + detail
+			break
+		}
+	}
+	return update
+}
+
+// toolKind returns the ACP kind of a tool, which editors use to pick icons.
+func toolKind(name string) string {
+	switch name {
+	case tools.ViewToolName, tools.HoverToolName:
+		return "read"
+	case tools.EditToolName, tools.MultiEditToolName, tools.NotebookEditToolName, tools.ApplyPatchToolName, tools.RenameToolName, tools.CodeActionToolName, tools.WriteToolName:
+		return "edit"
+	case tools.GrepToolName, tools.GlobToolName, tools.LSToolName, tools.SourcegraphToolName, tools.ReferencesToolName, tools.DefinitionToolName, tools.DocumentSymbolsToolName, tools.WorkspaceSymbolsToolName:
+		return "search"
+	case tools.BashToolName, tools.JobOutputToolName, tools.JobKillToolName, tools.JobListToolName, tools.JobInputToolName:
+		return "execute"
+	case tools.FetchToolName, tools.AgenticFetchToolName, tools.WebFetchToolName, tools.DownloadToolName:
+		return "fetch"
+	default:
+		return "other"
+	}
+}
+
+// sessionState tracks what was sent of the messages of an ACP session.
+type sessionState struct {
+	id string
+
+	mu           sync.Mutex
+	textRead     map[string]int
+	thinkingRead map[string]int
+	seenCalls    map[string]bool
+	seenResults  map[string]bool
+}
+
+func newSessionState(id string) *sessionState {
+	return &sessionState{
+		id:           id,
+		textRead:     make(map[string]int),
+		thinkingRead: make(map[string]int),
+		seenCalls:    make(map[string]bool),
+		seenResults:  make(map[string]bool),
+	}
+}
+
+// editorFS reads and writes files through the editor, for the operations it
+// supports.
+type editorFS struct {
+	conn      *jsonrpc2.Conn
+	sessionID string
+	caps      fsCapabilities
+}
+
+func (f *editorFS) ReadTextFile(ctx context.Context, path string) (string, error) {
+	if !f.caps.ReadTextFile {
+		content, err := os.ReadFile(path)
+		return string(content), err
+	}
+	var resp readTextFileResponse
+	if err := f.conn.Call(ctx, methodReadTextFile, readTextFileRequest{SessionID: f.sessionID, Path: path}, &resp); err != nil {
+		return "", fmt.Errorf("failed to read %s in the editor: %w", path, err)
+	}
+	return resp.Content, nil
+}
+
+func (f *editorFS) WriteTextFile(ctx context.Context, path, content string) error {
+	if !f.caps.WriteTextFile {
+		return os.WriteFile(path, []byte(content), 0o644)
+	}
+	var resp json.RawMessage
+	if err := f.conn.Call(ctx, methodWriteTextFile, writeTextFileRequest{SessionID: f.sessionID, Path: path, Content: content}, &resp); err != nil {
+		return fmt.Errorf("failed to write %s in the editor: %w", path, err)
+	}
+	return nil
+}
+
+func decodeParams(req *jsonrpc2.Request, v any) error {
+	if req.Params == nil {
+		return nil
+	}
+	if err := json.Unmarshal(*req.Params, v); err != nil {
+		return invalidParams(err)
+	}
+	return nil
+}
+
+func invalidParams(err error) error {
+	return &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: err.Error()}
+}
@@ -0,0 +1,259 @@
+package acp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/agent"
+	"github.com/charmbracelet/crush/internal/agent/tools"
+	"github.com/charmbracelet/crush/internal/app"
+	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/charmbracelet/crush/internal/db"
+	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/message"
+	"github.com/charmbracelet/crush/internal/permission"
+	"github.com/charmbracelet/crush/internal/session"
+	"github.com/sourcegraph/jsonrpc2"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCoordinator answers prompts without a provider. It asks for a
+// permission and reads a file through the editor before answering.
+type fakeCoordinator struct {
+	agent.Coordinator
+	messages    message.Service
+	permissions permission.Service
+}
+
+func (c *fakeCoordinator) Run(ctx context.Context, sessionID, prompt string, _ ...message.Attachment) (*fantasy.AgentResult, error) {
+	if _, err := c.messages.Create(ctx, sessionID, message.CreateMessageParams{
+		Role:  message.User,
+		Parts: []message.ContentPart{message.TextContent{Text: prompt}},
+	}); err != nil {
+		return nil, err
+	}
+	granted := c.permissions.Request(permission.CreatePermissionRequest{
+		SessionID:  sessionID,
+		ToolCallID: "call-1",
+		ToolName:   tools.BashToolName,
+		Action:     "execute",
+		Path:       "/project",
+	})
+	content := "no editor"
+	if fs, ok := ctx.Value(tools.EditorFSContextKey).(tools.EditorFS); ok {
+		var err error
+		if content, err = fs.ReadTextFile(ctx, "/project/main.go"); err != nil {
+			return nil, err
+		}
+	}
+	_, err := c.messages.Create(ctx, sessionID, message.CreateMessageParams{
+		Role: message.Assistant,
+		Parts: []message.ContentPart{
+			message.TextContent{Text: fmt.Sprintf("granted: %v, %s", granted, content)},
+			message.ToolCall{ID: "call-1", Name: tools.BashToolName, Input: `{"command":"ls"}`, Finished: true},
+			message.Finish{Reason: message.FinishReasonEndTurn},
+		},
+	})
+	return &fantasy.AgentResult{}, err
+}
+
+// client is the editor side of the connection.
+type client struct {
+	mu          sync.Mutex
+	updates     []sessionUpdate
+	permissions []requestPermissionRequest
+}
+
+func (c *client) handle(_ context.Context, _ *jsonrpc2.Conn, req *jsonrpc2.Request) (any, error) {
+	switch req.Method {
+	case methodSessionUpdate:
+		var n struct {
+			Update sessionUpdate `json:"update"`
+		}
+		if err := json.Unmarshal(*req.Params, &n); err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.updates = append(c.updates, n.Update)
+		c.mu.Unlock()
+		return nil, nil
+	case methodRequestPermission:
+		var params requestPermissionRequest
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.permissions = append(c.permissions, params)
+		c.mu.Unlock()
+		var resp requestPermissionResponse
+		resp.Outcome.Outcome = "selected"
+		resp.Outcome.OptionID = optionAllowOnce
+		return resp, nil
+	case methodReadTextFile:
+		return readTextFileResponse{Content: "unsaved buffer"}, nil
+	}
+	return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeMethodNotFound}
+}
+
+func setup(t *testing.T) (*jsonrpc2.Conn, *client) {
+	t.Helper()
+	conn, err := db.Connect(t.Context(), t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	q := db.New(conn)
+	a := &app.App{
+		Sessions:    session.NewService(q, conn),
+		Messages:    message.NewService(q),
+		History:     history.NewService(q, conn),
+		Permissions: permission.NewPermissionService("/project", false, nil, permission.Rules{}),
+	}
+	a.AgentCoordinator = &fakeCoordinator{messages: a.Messages, permissions: a.Permissions}
+
+	agentSide, clientSide := net.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = Serve(ctx, a, "/project", agentSide)
+	}()
+
+	// The client handles requests in order, so that the updates sent before
+	// a response are seen before it.
+	c := &client{}
+	rpc := jsonrpc2.NewConn(ctx, jsonrpc2.NewBufferedStream(clientSide, jsonrpc2.PlainObjectCodec{}), jsonrpc2.HandlerWithError(c.handle))
+	t.Cleanup(func() {
+		rpc.Close()
+		cancel()
+		<-done
+	})
+	return rpc, c
+}
+
+func TestPrompt(t *testing.T) {
+	rpc, c := setup(t)
+	ctx := t.Context()
+
+	var initResp initializeResponse
+	require.NoError(t, rpc.Call(ctx, methodInitialize, initializeRequest{
+		ProtocolVersion:    ProtocolVersion,
+		ClientCapabilities: clientCapabilities{FS: fsCapabilities{ReadTextFile: true}},
+	}, &initResp))
+	require.Equal(t, ProtocolVersion, initResp.ProtocolVersion)
+	require.True(t, initResp.AgentCapabilities.LoadSession)
+
+	var sessResp newSessionResponse
+	require.Error(t, rpc.Call(ctx, methodSessionNew, newSessionRequest{Cwd: "/elsewhere"}, &sessResp))
+	require.NoError(t, rpc.Call(ctx, methodSessionNew, newSessionRequest{Cwd: "/project"}, &sessResp))
+	require.NotEmpty(t, sessResp.SessionID)
+
+	var resp promptResponse
+	require.NoError(t, rpc.Call(ctx, methodSessionPrompt, promptRequest{
+		SessionID: sessResp.SessionID,
+		Prompt:    []contentBlock{{Type: "text", Text: "list the files"}},
+	}, &resp))
+	require.Equal(t, stopReasonEndTurn, resp.StopReason)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	require.Len(t, c.permissions, 1)
+	require.Equal(t, "call-1", c.permissions[0].ToolCall.ToolCallID)
+	require.Equal(t, "execute", c.permissions[0].ToolCall.Kind)
+
+	var text string
+	var toolCall *sessionUpdate
+	for _, u := range c.updates {
+		switch u.SessionUpdate {
+		case "agent_message_chunk":
+			text += u.Content.(map[string]any)["text"].(string)
+		case "tool_call":
+			toolCall = &u
+		}
+	}
+	require.Equal(t, "granted: true, unsaved buffer", text)
+	require.NotNil(t, toolCall)
+	require.Equal(t, "bash: ls", toolCall.Title)
+}
+
+func TestLoadSession(t *testing.T) {
+	rpc, c := setup(t)
+	ctx := t.Context()
+
+	var sessResp newSessionResponse
+	require.NoError(t, rpc.Call(ctx, methodSessionNew, newSessionRequest{}, &sessResp))
+	var resp promptResponse
+	require.NoError(t, rpc.Call(ctx, methodSessionPrompt, promptRequest{
+		SessionID: sessResp.SessionID,
+		Prompt:    []contentBlock{{Type: "text", Text: "hi"}},
+	}, &resp))
+
+	c.mu.Lock()
+	c.updates = nil
+	c.mu.Unlock()
+
+	require.Error(t, rpc.Call(ctx, methodSessionLoad, loadSessionRequest{SessionID: "missing"}, nil))
+	require.NoError(t, rpc.Call(ctx, methodSessionLoad, loadSessionRequest{SessionID: sessResp.SessionID}, nil))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var kinds []string
+	for _, u := range c.updates {
+		kinds = append(kinds, u.SessionUpdate)
+	}
+	require.Equal(t, []string{"user_message_chunk", "agent_message_chunk", "tool_call"}, kinds)
+}
+
+func TestACPSession(t *testing.T) {
+	t.Parallel()
+
+	conn, err := db.Connect(t.Context(), t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	q := db.New(conn)
+	a := &app.App{
+		Sessions: session.NewService(q, conn),
+		Messages: message.NewService(q),
+	}
+	ag := &Agent{app: a, sessions: csync.NewMap[string, *sessionState]()}
+
+	sess, err := a.Sessions.Create(t.Context(), "acp")
+	require.NoError(t, err)
+	ag.sessions.Set(sess.ID, &sessionState{id: sess.ID})
+	msg, err := a.Messages.Create(t.Context(), sess.ID, message.CreateMessageParams{
+		Role:  message.User,
+		Parts: []message.ContentPart{message.TextContent{Text: "hi"}},
+	})
+	require.NoError(t, err)
+
+	task, err := a.Sessions.CreateTaskSession(t.Context(), "call-1", sess.ID, "task")
+	require.NoError(t, err)
+	id, ok := ag.acpSession(t.Context(), task.ID)
+	require.True(t, ok)
+	require.Equal(t, sess.ID, id)
+
+	// A fork isn't part of the session it comes from.
+	fork, err := a.Sessions.Fork(t.Context(), sess.ID, msg.ID)
+	require.NoError(t, err)
+	_, ok = ag.acpSession(t.Context(), fork.ID)
+	require.False(t, ok)
+}
+
+func TestConvertPrompt(t *testing.T) {
+	prompt, attachments := convertPrompt([]contentBlock{
+		{Type: "text", Text: "explain"},
+		{Type: "resource_link", URI: "file:///project/a.go", Name: "a.go"},
+		{Type: "resource", Resource: &embeddedResource{URI: "file:///project/b.go", Text: "package b"}},
+		{Type: "image", Data: "aGk=", MimeType: "image/png"},
+	})
+	require.Contains(t, prompt, "explain")
+	require.Contains(t, prompt, "@/project/a.go")
+	require.Contains(t, prompt, "<file path=\"/project/b.go\">\npackage b\n</file>")
+	require.Len(t, attachments, 1)
+	require.Equal(t, "image/png", attachments[0].MimeType)
+	require.Equal(t, []byte("hi"), attachments[0].Content)
+}
@@ -0,0 +1,170 @@
+package acp
+
+import "encoding/json"
+
+// ProtocolVersion is the version of the Agent Client Protocol implemented.
+const ProtocolVersion = 1
+
+// Methods implemented by the agent.
+const (
+	methodInitialize    = "initialize"
+	methodAuthenticate  = "authenticate"
+	methodSessionNew    = "session/new"
+	methodSessionLoad   = "session/load"
+	methodSessionPrompt = "session/prompt"
+	methodSessionCancel = "session/cancel"
+)
+
+// Methods implemented by the client.
+const (
+	methodSessionUpdate     = "session/update"
+	methodRequestPermission = "session/request_permission"
+	methodReadTextFile      = "fs/read_text_file"
+	methodWriteTextFile     = "fs/write_text_file"
+)
+
+// Reasons a prompt turn ended.
+const (
+	stopReasonEndTurn         = "end_turn"
+	stopReasonMaxTokens       = "max_tokens"
+	stopReasonMaxTurnRequests = "max_turn_requests"
+	stopReasonCancelled       = "cancelled"
+)
+
+type initializeRequest struct {
+	ProtocolVersion    int                `json:"protocolVersion"`
+	ClientCapabilities clientCapabilities `json:"clientCapabilities"`
+}
+
+type clientCapabilities struct {
+	FS fsCapabilities `json:"fs"`
+}
+
+type fsCapabilities struct {
+	ReadTextFile  bool `json:"readTextFile"`
+	WriteTextFile bool `json:"writeTextFile"`
+}
+
+type initializeResponse struct {
+	ProtocolVersion   int               `json:"protocolVersion"`
+	AgentCapabilities agentCapabilities `json:"agentCapabilities"`
+	AuthMethods       []any             `json:"authMethods"`
+}
+
+type agentCapabilities struct {
+	LoadSession        bool               `json:"loadSession"`
+	PromptCapabilities promptCapabilities `json:"promptCapabilities"`
+}
+
+type promptCapabilities struct {
+	Image           bool `json:"image"`
+	EmbeddedContext bool `json:"embeddedContext"`
+}
+
+type newSessionRequest struct {
+	Cwd        string            `json:"cwd"`
+	MCPServers []json.RawMessage `json:"mcpServers"`
+}
+
+type newSessionResponse struct {
+	SessionID string `json:"sessionId"`
+}
+
+type loadSessionRequest struct {
+	SessionID  string            `json:"sessionId"`
+	Cwd        string            `json:"cwd"`
+	MCPServers []json.RawMessage `json:"mcpServers"`
+}
+
+type promptRequest struct {
+	SessionID string         `json:"sessionId"`
+	Prompt    []contentBlock `json:"prompt"`
+}
+
+type promptResponse struct {
+	StopReason string `json:"stopReason"`
+}
+
+type cancelNotification struct {
+	SessionID string `json:"sessionId"`
+}
+
+// contentBlock is a piece of content of a prompt or of an update: text,
+// an image, a link to a resource or an embedded resource.
+type contentBlock struct {
+	Type     string            `json:"type"`
+	Text     string            `json:"text,omitempty"`
+	Data     string            `json:"data,omitempty"`
+	MimeType string            `json:"mimeType,omitempty"`
+	URI      string            `json:"uri,omitempty"`
+	Name     string            `json:"name,omitempty"`
+	Resource *embeddedResource `json:"resource,omitempty"`
+}
+
+type embeddedResource struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Blob     string `json:"blob,omitempty"`
+}
+
+type sessionNotification struct {
+	SessionID string        `json:"sessionId"`
+	Update    sessionUpdate `json:"update"`
+}
+
+// sessionUpdate is a message chunk or a tool call update. Content is a
+// contentBlock for chunks and a list of toolCallContent for tool calls.
+type sessionUpdate struct {
+	SessionUpdate string             `json:"sessionUpdate,omitempty"`
+	Content       any                `json:"content,omitempty"`
+	ToolCallID    string             `json:"toolCallId,omitempty"`
+	Title         string             `json:"title,omitempty"`
+	Kind          string             `json:"kind,omitempty"`
+	Status        string             `json:"status,omitempty"`
+	RawInput      json.RawMessage    `json:"rawInput,omitempty"`
+	Locations     []toolCallLocation `json:"locations,omitempty"`
+}
+
+type toolCallContent struct {
+	Type    string       `json:"type"`
+	Content contentBlock `json:"content"`
+}
+
+type toolCallLocation struct {
+	Path string `json:"path"`
+}
+
+type requestPermissionRequest struct {
+	SessionID string             `json:"sessionId"`
+	ToolCall  sessionUpdate      `json:"toolCall"`
+	Options   []permissionOption `json:"options"`
+}
+
+type permissionOption struct {
+	OptionID string `json:"optionId"`
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+}
+
+type requestPermissionResponse struct {
+	Outcome struct {
+		Outcome  string `json:"outcome"`
+		OptionID string `json:"optionId,omitempty"`
+	} `json:"outcome"`
+}
+
+type readTextFileRequest struct {
+	SessionID string `json:"sessionId"`
+	Path      string `json:"path"`
+}
+
+type readTextFileResponse struct {
+	Content string `json:"content"`
+}
+
+type writeTextFileRequest struct {
+	SessionID string `json:"sessionId"`
+	Path      string `json:"path"`
+	Content   string `json:"content"`
+}
@@ -30,10 +30,12 @@ import (
 	"github.com/charmbracelet/crush/internal/agent/tools"
 	"github.com/charmbracelet/crush/internal/config"
 	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/charmbracelet/crush/internal/hooks"
 	"github.com/charmbracelet/crush/internal/message"
 	"github.com/charmbracelet/crush/internal/permission"
 	"github.com/charmbracelet/crush/internal/session"
 	"github.com/charmbracelet/crush/internal/stringext"
+	"github.com/charmbracelet/crush/internal/todo"
 )
 
 //go:embed templates/title.md
@@ -42,6 +44,18 @@ var titlePrompt []byte
 //go:embed templates/summary.md
 var summaryPrompt []byte
 
+// Prompts queued by the agent itself to resume a turn cut by summarization.
+const (
+	continueAfterSummaryPrompt = "The conversation was summarized because it got too long. Continue with the task you were working on."
+	interruptedPromptPrefix    = "The previous session was interrupted because it got too long, the initial user request was: "
+)
+
+// IsContinuationPrompt reports whether a user message was queued by the
+// agent to resume after a summarization rather than sent by the user.
+func IsContinuationPrompt(prompt string) bool {
+	return prompt == continueAfterSummaryPrompt || strings.HasPrefix(prompt, interruptedPromptPrefix)
+}
+
 type SessionAgentCall struct {
 	SessionID        string
 	Prompt           string
@@ -53,6 +67,11 @@ type SessionAgentCall struct {
 	TopK             *int64
 	FrequencyPenalty *float64
 	PresencePenalty  *float64
+
+	// The limits left to the runs that go on with a prompt, after a summary
+	// or for the prompts queued meanwhile.
+	deadline   time.Time
+	stepsTaken int
 }
 
 type SessionAgent interface {
@@ -65,7 +84,7 @@ type SessionAgent interface {
 	IsBusy() bool
 	QueuedPrompts(sessionID string) int
 	ClearQueue(sessionID string)
-	Summarize(context.Context, string, fantasy.ProviderOptions) error
+	Summarize(ctx context.Context, sessionID, instructions string, opts fantasy.ProviderOptions) error
 	Model() Model
 }
 
@@ -84,7 +103,13 @@ type sessionAgent struct {
 	sessions             session.Service
 	messages             message.Service
 	disableAutoSummarize bool
+	compaction           *config.Compaction
+	loopDetection        *config.LoopDetection
+	limits               *config.Limits
+	todos                todo.Service
+	workingDir           string
 	isYolo               bool
+	hooks                *hooks.Runner
 
 	messageQueue   *csync.Map[string, []SessionAgentCall]
 	activeRequests *csync.Map[string, context.CancelFunc]
@@ -100,6 +125,12 @@ type SessionAgentOptions struct {
 	Sessions             session.Service
 	Messages             message.Service
 	Tools                []fantasy.AgentTool
+	Hooks                *hooks.Runner
+	Compaction           *config.Compaction
+	LoopDetection        *config.LoopDetection
+	Limits               *config.Limits
+	Todos                todo.Service
+	WorkingDir           string
 }
 
 func NewSessionAgent(
@@ -115,6 +146,12 @@ func NewSessionAgent(
 		disableAutoSummarize: opts.DisableAutoSummarize,
 		tools:                opts.Tools,
 		isYolo:               opts.IsYolo,
+		hooks:                opts.Hooks,
+		compaction:           opts.Compaction,
+		loopDetection:        opts.LoopDetection,
+		limits:               opts.Limits,
+		todos:                opts.Todos,
+		workingDir:           opts.WorkingDir,
 		messageQueue:         csync.NewMap[string, []SessionAgentCall](),
 		activeRequests:       csync.NewMap[string, context.CancelFunc](),
 	}
@@ -128,6 +165,18 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 		return nil, ErrSessionMissing
 	}
 
+	prompt, err := a.runPromptHooks(ctx, call.SessionID, call.Prompt)
+	if err != nil {
+		return nil, err
+	}
+	call.Prompt = prompt
+	return a.run(ctx, call)
+}
+
+// run runs a prompt whose hooks already ran, or queues it if the session is
+// busy. The prompts queued are run the same way, as well as the ones the
+// agent sends itself to go on after a summary.
+func (a *sessionAgent) run(ctx context.Context, call SessionAgentCall) (*fantasy.AgentResult, error) {
 	// Queue the message if busy
 	if a.IsSessionBusy(call.SessionID) {
 		existing, ok := a.messageQueue.Get(call.SessionID)
@@ -164,6 +213,11 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 	var wg sync.WaitGroup
 	// Generate title if first message.
 	if len(msgs) == 0 {
+		a.hooks.Run(ctx, hooks.Input{
+			Event:     hooks.SessionStart,
+			SessionID: call.SessionID,
+			Prompt:    call.Prompt,
+		})
 		wg.Go(func() {
 			sessionLock.Lock()
 			a.generateTitle(ctx, &currentSession, call.Prompt)
@@ -178,9 +232,24 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 	}
 
 	// Add the session to the context.
+	if tools.GetRootSessionFromContext(ctx) == "" {
+		ctx = context.WithValue(ctx, tools.RootSessionIDContextKey, call.SessionID)
+	}
 	ctx = context.WithValue(ctx, tools.SessionIDContextKey, call.SessionID)
 
-	genCtx, cancel := context.WithCancel(ctx)
+	var (
+		genCtx context.Context
+		cancel context.CancelFunc
+	)
+	deadline := call.deadline
+	if maxDuration := a.maxDuration(); deadline.IsZero() && maxDuration > 0 {
+		deadline = time.Now().Add(maxDuration)
+	}
+	if !deadline.IsZero() {
+		genCtx, cancel = context.WithDeadlineCause(ctx, deadline, ErrMaxDuration)
+	} else {
+		genCtx, cancel = context.WithCancel(ctx)
+	}
 	a.activeRequests.Set(call.SessionID, cancel)
 
 	defer cancel()
@@ -193,6 +262,9 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 
 	var currentAssistant *message.Message
 	var shouldSummarize bool
+	var stop *runStop
+	var stepToolCalls int
+	media := toolMedia(msgs)
 	result, err := agent.Stream(genCtx, fantasy.AgentStreamCall{
 		Prompt:           call.Prompt,
 		Files:            files,
@@ -222,6 +294,10 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 				prepared.Messages = append(prepared.Messages, userMessage.ToAIMessage()...)
 			}
 
+			prepared.Messages = a.withToolMedia(prepared.Messages, media)
+			prepared.Messages = a.withLoopReminders(prepared.Messages)
+			prepared.Messages = a.withNestedContext(prepared.Messages)
+
 			lastSystemRoleInx := 0
 			systemMessageUpdated := false
 			for i, msg := range prepared.Messages {
@@ -254,6 +330,7 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 			}
 			callContext = context.WithValue(callContext, tools.MessageIDContextKey, assistantMsg.ID)
 			currentAssistant = &assistantMsg
+			stepToolCalls = 0
 			return callContext, prepared, err
 		},
 		OnReasoningStart: func(id string, reasoning fantasy.ReasoningContent) error {
@@ -309,6 +386,11 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 			// TODO: implement
 		},
 		OnToolCall: func(tc fantasy.ToolCallContent) error {
+			stepToolCalls++
+			if maxToolCalls := a.maxToolCallsPerStep(); maxToolCalls > 0 && stepToolCalls > maxToolCalls {
+				stop = maxToolCallsStop(maxToolCalls)
+				return stop.Err
+			}
 			toolCall := message.ToolCall{
 				ID:               tc.ToolCallID,
 				Name:             tc.ToolName,
@@ -337,12 +419,18 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 			case fantasy.ToolResultContentTypeMedia:
 				// TODO: handle this message type
 			}
+			toolMediaData, metadata := tools.TakeResponseMedia(result.ClientMetadata)
 			toolResult := message.ToolResult{
 				ToolCallID: result.ToolCallID,
 				Name:       result.ToolName,
 				Content:    resultContent,
 				IsError:    isError,
-				Metadata:   result.ClientMetadata,
+				Metadata:   metadata,
+			}
+			if toolMediaData != nil {
+				toolResult.Data = toolMediaData.Data
+				toolResult.MIMEType = toolMediaData.MIMEType
+				addToolMedia(media, toolResult)
 			}
 			_, createMsgErr := a.messages.Create(genCtx, currentAssistant.SessionID, message.CreateMessageParams{
 				Role: message.Tool,
@@ -380,27 +468,40 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 				cw := int64(a.largeModel.CatwalkCfg.ContextWindow)
 				tokens := currentSession.CompletionTokens + currentSession.PromptTokens
 				remaining := cw - tokens
-				var threshold int64
-				if cw > 200_000 {
-					threshold = 20_000
-				} else {
-					threshold = int64(float64(cw) * 0.2)
-				}
+				threshold := compactionThreshold(cw, a.compaction)
 				if (remaining <= threshold) && !a.disableAutoSummarize {
 					shouldSummarize = true
 					return true
 				}
 				return false
 			},
+			func(steps []fantasy.StepResult) bool {
+				if stalled := a.stalledLoop(steps); stalled != nil {
+					stop = loopStop(*stalled)
+				}
+				return stop != nil
+			},
+			func(steps []fantasy.StepResult) bool {
+				if reached := a.stepLimitReached(steps, call.stepsTaken); reached != nil {
+					stop = reached
+				}
+				return stop != nil
+			},
 		},
 	})
 
 	a.eventPromptResponded(call.SessionID, time.Since(startTime).Truncate(time.Second))
 
 	if err != nil {
+		if stop == nil && errors.Is(context.Cause(genCtx), ErrMaxDuration) {
+			stop = maxDurationStop(a.maxDuration())
+		}
 		isCancelErr := errors.Is(err, context.Canceled)
 		isPermissionErr := errors.Is(err, permission.ErrorPermissionDenied)
 		if currentAssistant == nil {
+			if stop != nil {
+				return nil, stop.runErr()
+			}
 			return result, err
 		}
 		// Ensure we finish thinking on error to close the reasoning state.
@@ -440,7 +541,9 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 				continue
 			}
 			content := "There was an error while executing the tool"
-			if isCancelErr {
+			if stop != nil {
+				content = "Tool execution stopped: " + stop.Details
+			} else if isCancelErr {
 				content = "Tool execution canceled by user"
 			} else if isPermissionErr {
 				content = "User denied permission"
@@ -464,7 +567,9 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 		var fantasyErr *fantasy.Error
 		var providerErr *fantasy.ProviderError
 		const defaultTitle = "Provider Error"
-		if isCancelErr {
+		if stop != nil {
+			currentAssistant.AddFinish(stop.Reason, stop.Title, stop.Details)
+		} else if isCancelErr {
 			currentAssistant.AddFinish(message.FinishReasonCanceled, "User canceled request", "")
 		} else if isPermissionErr {
 			currentAssistant.AddFinish(message.FinishReasonPermissionDenied, "User denied permission", "")
@@ -481,22 +586,53 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 		if updateErr != nil {
 			return nil, updateErr
 		}
+		if stop != nil {
+			// The interrupted message already tells why when it is empty.
+			stopMsg := currentAssistant
+			if len(toolCalls) > 0 || currentAssistant.Content().Text != "" || currentAssistant.ReasoningContent().Thinking != "" {
+				var stopErr error
+				if stopMsg, stopErr = a.addStopMessage(ctx, call.SessionID, stop); stopErr != nil {
+					return nil, stopErr
+				}
+			}
+			a.runStopHooks(ctx, stopMsg)
+			return nil, stop.runErr()
+		}
+		a.runStopHooks(ctx, currentAssistant)
 		return nil, err
 	}
 	wg.Wait()
 
+	if stop != nil {
+		stopMsg, stopErr := a.addStopMessage(ctx, call.SessionID, stop)
+		if stopErr != nil {
+			return nil, stopErr
+		}
+		currentAssistant = stopMsg
+		err = stop.runErr()
+	}
+
+	a.runStopHooks(ctx, currentAssistant)
+
 	if shouldSummarize {
 		a.activeRequests.Del(call.SessionID)
-		if summarizeErr := a.Summarize(genCtx, call.SessionID, call.ProviderOptions); summarizeErr != nil {
+		// The generation context may be past its deadline already.
+		if summarizeErr := a.Summarize(ctx, call.SessionID, "", call.ProviderOptions); summarizeErr != nil {
 			return nil, summarizeErr
 		}
-		// If the agent wasn't done...
-		if len(currentAssistant.ToolCalls()) > 0 {
+		// If the agent wasn't done, nor stopped...
+		if stop == nil && len(currentAssistant.ToolCalls()) > 0 {
 			existing, ok := a.messageQueue.Get(call.SessionID)
 			if !ok {
 				existing = []SessionAgentCall{}
 			}
-			call.Prompt = fmt.Sprintf("The previous session was interrupted because it got too long, the initial user request was: `%s`", call.Prompt)
+			if summarized, getErr := a.sessions.Get(ctx, call.SessionID); getErr == nil && summarized.KeptFromMessageID != "" {
+				// The current turn was kept verbatim, the model can pick up
+				// where it left off.
+				call.Prompt = continueAfterSummaryPrompt
+			} else {
+				call.Prompt = fmt.Sprintf(interruptedPromptPrefix+"`%s`", call.Prompt)
+			}
 			existing = append(existing, call)
 			a.messageQueue.Set(call.SessionID, existing)
 		}
@@ -507,16 +643,34 @@ func (a *sessionAgent) Run(ctx context.Context, call SessionAgentCall) (*fantasy
 	cancel()
 
 	queuedMessages, ok := a.messageQueue.Get(call.SessionID)
-	if !ok || len(queuedMessages) == 0 {
+	if err != nil || !ok || len(queuedMessages) == 0 {
 		return result, err
 	}
-	// There are queued messages restart the loop.
+	// There are queued messages restart the loop, with what is left of the
+	// limits of the prompt.
 	firstQueuedMessage := queuedMessages[0]
 	a.messageQueue.Set(call.SessionID, queuedMessages[1:])
-	return a.Run(ctx, firstQueuedMessage)
+	firstQueuedMessage.deadline = deadline
+	firstQueuedMessage.stepsTaken = call.stepsTaken + len(result.Steps)
+	return a.run(ctx, firstQueuedMessage)
+}
+
+func (a *sessionAgent) runStopHooks(ctx context.Context, assistant *message.Message) {
+	if assistant == nil {
+		return
+	}
+	a.hooks.Run(ctx, hooks.Input{
+		Event:        hooks.Stop,
+		SessionID:    assistant.SessionID,
+		FinishReason: string(assistant.FinishReason()),
+	})
 }
 
-func (a *sessionAgent) Summarize(ctx context.Context, sessionID string, opts fantasy.ProviderOptions) error {
+// Summarize compacts the conversation of a session. The most recent turns
+// are kept verbatim and everything before them, including the previous
+// summary, is replaced by a new summary message. Instructions, when given,
+// tell the model what the summary should focus on.
+func (a *sessionAgent) Summarize(ctx context.Context, sessionID, instructions string, opts fantasy.ProviderOptions) error {
 	if a.IsSessionBusy(sessionID) {
 		return ErrSessionBusy
 	}
@@ -534,7 +688,21 @@ func (a *sessionAgent) Summarize(ctx context.Context, sessionID string, opts fan
 		return nil
 	}
 
-	aiMsgs, _ := a.preparePrompt(msgs)
+	var previousSummary []message.Message
+	if msgs[0].ID == currentSession.SummaryMessageID {
+		previousSummary, msgs = msgs[:1], msgs[1:]
+	}
+	keepTurns, keepTokens := compactionKeepLimits(int64(a.largeModel.CatwalkCfg.ContextWindow), a.compaction)
+	toSummarize, keep := splitForCompaction(msgs, keepTurns, keepTokens)
+	if len(toSummarize) == 0 {
+		// The recent turns are all there is, summarize them too.
+		toSummarize, keep = msgs, nil
+	}
+	if len(toSummarize) == 0 {
+		return nil
+	}
+
+	aiMsgs, _ := a.preparePrompt(append(previousSummary, toSummarize...))
 
 	genCtx, cancel := context.WithCancel(ctx)
 	a.activeRequests.Set(sessionID, cancel)
@@ -544,18 +712,23 @@ func (a *sessionAgent) Summarize(ctx context.Context, sessionID string, opts fan
 	agent := fantasy.NewAgent(a.largeModel.Model,
 		fantasy.WithSystemPrompt(string(summaryPrompt)),
 	)
+	var keptFromMessageID string
+	if len(keep) > 0 {
+		keptFromMessageID = keep[0].ID
+	}
 	summaryMessage, err := a.messages.Create(ctx, sessionID, message.CreateMessageParams{
-		Role:             message.Assistant,
-		Model:            a.largeModel.Model.Model(),
-		Provider:         a.largeModel.Model.Provider(),
-		IsSummaryMessage: true,
+		Role:              message.Assistant,
+		Model:             a.largeModel.Model.Model(),
+		Provider:          a.largeModel.Model.Provider(),
+		IsSummaryMessage:  true,
+		KeptFromMessageID: keptFromMessageID,
 	})
 	if err != nil {
 		return err
 	}
 
 	resp, err := agent.Stream(genCtx, fantasy.AgentStreamCall{
-		Prompt:          "Provide a detailed summary of our conversation above.",
+		Prompt:          summaryRequest(len(previousSummary) > 0, len(keep) > 0, instructions),
 		Messages:        aiMsgs,
 		ProviderOptions: opts,
 		PrepareStep: func(callContext context.Context, options fantasy.PrepareStepFunctionOptions) (_ context.Context, prepared fantasy.PrepareStepResult, err error) {
@@ -617,12 +790,31 @@ func (a *sessionAgent) Summarize(ctx context.Context, sessionID string, opts fan
 	// Just in case, get just the last usage info.
 	usage := resp.Response.Usage
 	currentSession.SummaryMessageID = summaryMessage.ID
+	currentSession.KeptFromMessageID = keptFromMessageID
 	currentSession.CompletionTokens = usage.OutputTokens
-	currentSession.PromptTokens = 0
+	currentSession.PromptTokens = message.EstimateTokens(keep...)
 	_, err = a.sessions.Save(genCtx, currentSession)
 	return err
 }
 
+// summaryRequest builds the prompt asking for the summary of the
+// conversation.
+func summaryRequest(hasPreviousSummary, keepsRecent bool, instructions string) string {
+	var sb strings.Builder
+	sb.WriteString("Provide a detailed summary of our conversation above.")
+	if hasPreviousSummary {
+		sb.WriteString(" The first message is the summary of the conversation that came before, incorporate it into the new summary.")
+	}
+	if keepsRecent {
+		sb.WriteString(" The most recent messages of the conversation are not shown here, they will be kept as is after your summary.")
+	}
+	if instructions = strings.TrimSpace(instructions); instructions != "" {
+		sb.WriteString("\n\nFocus the summary on the following: ")
+		sb.WriteString(instructions)
+	}
+	return sb.String()
+}
+
 func (a *sessionAgent) getCacheControlOptions() fantasy.ProviderOptions {
 	if t, _ := strconv.ParseBool(os.Getenv("CRUSH_DISABLE_ANTHROPIC_CACHE")); t {
 		return fantasy.ProviderOptions{}
@@ -680,6 +872,24 @@ func (a *sessionAgent) preparePrompt(msgs []message.Message, attachments ...mess
 	return history, files
 }
 
+// insertAfterToolResults adds a user message after the tool results of each
+// step, as the results of the tool calls of a step must follow each other.
+// collect returns what each message adds to the next user message, which
+// build makes once the results of the step are over.
+func insertAfterToolResults[T any](msgs []fantasy.Message, collect func(i int, msg fantasy.Message) []T, build func([]T) fantasy.Message) []fantasy.Message {
+	result := make([]fantasy.Message, 0, len(msgs))
+	var pending []T
+	for i, msg := range msgs {
+		result = append(result, msg)
+		pending = append(pending, collect(i, msg)...)
+		if len(pending) > 0 && (i == len(msgs)-1 || msgs[i+1].Role != fantasy.MessageRoleTool) {
+			result = append(result, build(pending))
+			pending = nil
+		}
+	}
+	return result
+}
+
 func (a *sessionAgent) getSessionMessages(ctx context.Context, session session.Session) ([]message.Message, error) {
 	msgs, err := a.messages.List(ctx, session.ID)
 	if err != nil {
@@ -687,21 +897,58 @@ func (a *sessionAgent) getSessionMessages(ctx context.Context, session session.S
 	}
 
 	if session.SummaryMessageID != "" {
-		summaryMsgInex := -1
+		summaryMsgInex, keptMsgIndex := -1, -1
 		for i, msg := range msgs {
-			if msg.ID == session.SummaryMessageID {
+			switch msg.ID {
+			case session.SummaryMessageID:
 				summaryMsgInex = i
-				break
+			case session.KeptFromMessageID:
+				keptMsgIndex = i
 			}
 		}
 		if summaryMsgInex != -1 {
-			msgs = msgs[summaryMsgInex:]
-			msgs[0].Role = message.User
+			summary := msgs[summaryMsgInex]
+			summary.Role = message.User
+			// The todo list was in the tool results that got summarized, add
+			// it back so the agent can carry on with it.
+			if todos := a.todoList(ctx, session.ID); todos != "" {
+				summary.Parts = []message.ContentPart{message.TextContent{
+					Text: summary.Content().Text + "\n\n" + todos,
+				}}
+			}
+			// The summary replaces everything before it, except the recent
+			// messages that were kept verbatim.
+			var kept []message.Message
+			if keptMsgIndex != -1 && keptMsgIndex < summaryMsgInex {
+				kept = msgs[keptMsgIndex:summaryMsgInex]
+			}
+			contextMsgs := make([]message.Message, 0, 1+len(kept)+len(msgs)-summaryMsgInex-1)
+			contextMsgs = append(contextMsgs, summary)
+			contextMsgs = append(contextMsgs, kept...)
+			contextMsgs = append(contextMsgs, msgs[summaryMsgInex+1:]...)
+			msgs = contextMsgs
 		}
 	}
 	return msgs, nil
 }
 
+// todoList returns the todo list of the session to add to its summary, or
+// an empty string when there is none.
+func (a *sessionAgent) todoList(ctx context.Context, sessionID string) string {
+	if a.todos == nil {
+		return ""
+	}
+	todos, err := a.todos.List(ctx, sessionID)
+	if err != nil {
+		slog.Error("Failed to list todos", "session", sessionID, "error", err)
+		return ""
+	}
+	if len(todos) == 0 {
+		return ""
+	}
+	return "Current todo list:\n" + todo.Format(todos)
+}
+
 func (a *sessionAgent) generateTitle(ctx context.Context, session *session.Session, prompt string) {
 	if prompt == "" {
 		return
@@ -803,6 +1050,10 @@ func (a *sessionAgent) updateSessionUsage(model Model, session *session.Session,
 
 	session.CompletionTokens = usage.OutputTokens + usage.CacheReadTokens
 	session.PromptTokens = usage.InputTokens + usage.CacheCreationTokens
+	session.CacheReadTokens += usage.CacheReadTokens
+	session.CacheCreationTokens += usage.CacheCreationTokens
+	session.InputTokens += usage.InputTokens
+	session.OutputTokens += usage.OutputTokens
 }
 
 func (a *sessionAgent) Cancel(sessionID string) {
@@ -75,19 +75,20 @@ func (c *coordinator) agenticFetchTool(_ context.Context, client *http.Client) (
 				return fantasy.NewTextErrorResponse(err.Error()), nil
 			}
 
-			p := c.permissions.Request(
-				permission.CreatePermissionRequest{
-					SessionID:   validationResult.SessionID,
-					Path:        c.cfg.WorkingDir(),
-					ToolCallID:  call.ID,
-					ToolName:    tools.AgenticFetchToolName,
-					Action:      "fetch",
-					Description: fmt.Sprintf("Fetch and analyze content from URL: %s", params.URL),
-					Params:      tools.AgenticFetchPermissionsParams(params),
-				},
-			)
-
-			if !p {
+			permissionReq := permission.CreatePermissionRequest{
+				SessionID:   validationResult.SessionID,
+				Path:        c.cfg.WorkingDir(),
+				ToolCallID:  call.ID,
+				ToolName:    tools.AgenticFetchToolName,
+				Action:      "fetch",
+				Description: fmt.Sprintf("Fetch and analyze content from URL: %s", params.URL),
+				Params:      tools.AgenticFetchPermissionsParams(params),
+				Targets:     []string{params.URL},
+			}
+			if rule, denied := c.permissions.Denied(permissionReq); denied {
+				return tools.NewRuleDeniedResponse(rule), nil
+			}
+			if !c.permissions.Request(permissionReq) {
 				return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
 			}
 
@@ -150,9 +151,9 @@ func (c *coordinator) agenticFetchTool(_ context.Context, client *http.Client) (
 			webFetchTool := tools.NewWebFetchTool(tmpDir, client)
 			fetchTools := []fantasy.AgentTool{
 				webFetchTool,
-				tools.NewGlobTool(tmpDir),
-				tools.NewGrepTool(tmpDir),
-				tools.NewViewTool(c.lspClients, c.permissions, tmpDir),
+				tools.NewGlobTool(c.permissions, tmpDir),
+				tools.NewGrepTool(c.permissions, tmpDir),
+				tools.NewViewTool(c.lspClients, c.permissions, tmpDir, small.CatwalkCfg.SupportsImages),
 			}
 
 			agent := NewSessionAgent(SessionAgentOptions{
@@ -165,6 +166,9 @@ func (c *coordinator) agenticFetchTool(_ context.Context, client *http.Client) (
 				Sessions:             c.sessions,
 				Messages:             c.messages,
 				Tools:                fetchTools,
+				Compaction:           c.cfg.Options.Compaction,
+				LoopDetection:        c.cfg.Options.LoopDetection,
+				Limits:               c.cfg.Options.Limits,
 			})
 
 			agentToolSessionID := c.sessions.CreateAgentToolSessionID(validationResult.AgentMessageID, call.ID)
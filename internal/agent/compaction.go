@@ -0,0 +1,65 @@
+package agent
+
+import (
+	"github.com/charmbracelet/crush/internal/config"
+	"github.com/charmbracelet/crush/internal/message"
+)
+
+const (
+	defaultCompactionThresholdRatio     = 0.2
+	defaultCompactionThresholdMaxTokens = 20_000
+	defaultCompactionKeepTurns          = 2
+)
+
+// compactionThreshold returns the number of remaining tokens in the context
+// window under which the conversation gets summarized.
+func compactionThreshold(contextWindow int64, cfg *config.Compaction) int64 {
+	if cfg != nil && cfg.ThresholdTokens > 0 {
+		return cfg.ThresholdTokens
+	}
+	if cfg != nil && cfg.ThresholdRatio > 0 {
+		return int64(float64(contextWindow) * cfg.ThresholdRatio)
+	}
+	if contextWindow > 200_000 {
+		return defaultCompactionThresholdMaxTokens
+	}
+	return int64(float64(contextWindow) * defaultCompactionThresholdRatio)
+}
+
+// splitForCompaction splits the messages in the ones to summarize and the
+// recent ones to keep verbatim. Messages are only split at the start of a
+// turn, i.e. on a user message, so that tool calls stay next to their
+// results. At most keepTurns turns and keepTokens tokens are kept, a zero
+// limit meaning no limit.
+func splitForCompaction(msgs []message.Message, keepTurns int, keepTokens int64) (summarize, keep []message.Message) {
+	cut := len(msgs)
+	var turns int
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if msgs[i].Role != message.User {
+			continue
+		}
+		if keepTurns > 0 && turns+1 > keepTurns {
+			break
+		}
+		if keepTokens > 0 && message.EstimateTokens(msgs[i:]...) > keepTokens {
+			break
+		}
+		turns++
+		cut = i
+	}
+	return msgs[:cut], msgs[cut:]
+}
+
+// compactionKeepLimits returns how many turns and tokens are kept verbatim
+// when summarizing.
+func compactionKeepLimits(contextWindow int64, cfg *config.Compaction) (turns int, tokens int64) {
+	turns = defaultCompactionKeepTurns
+	tokens = contextWindow / 4
+	if cfg != nil && cfg.KeepTurns > 0 {
+		turns = cfg.KeepTurns
+	}
+	if cfg != nil && cfg.KeepTokens > 0 {
+		tokens = cfg.KeepTokens
+	}
+	return turns, tokens
+}
@@ -0,0 +1,105 @@
+package agent
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/crush/internal/config"
+	"github.com/charmbracelet/crush/internal/db"
+	"github.com/charmbracelet/crush/internal/message"
+	"github.com/charmbracelet/crush/internal/session"
+	"github.com/charmbracelet/crush/internal/todo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactionThreshold(t *testing.T) {
+	require.Equal(t, int64(20_000), compactionThreshold(100_000, nil))
+	require.Equal(t, int64(20_000), compactionThreshold(1_000_000, nil))
+	require.Equal(t, int64(10_000), compactionThreshold(100_000, &config.Compaction{ThresholdRatio: 0.1}))
+	require.Equal(t, int64(5_000), compactionThreshold(100_000, &config.Compaction{ThresholdTokens: 5_000, ThresholdRatio: 0.1}))
+}
+
+func TestSplitForCompaction(t *testing.T) {
+	msg := func(id string, role message.MessageRole, size int) message.Message {
+		return message.Message{
+			ID:    id,
+			Role:  role,
+			Parts: []message.ContentPart{message.TextContent{Text: strings.Repeat("a", size*4)}},
+		}
+	}
+	msgs := []message.Message{
+		msg("u1", message.User, 10),
+		msg("a1", message.Assistant, 10),
+		msg("u2", message.User, 10),
+		msg("a2", message.Assistant, 10),
+		msg("t2", message.Tool, 10),
+		msg("a2b", message.Assistant, 10),
+		msg("u3", message.User, 10),
+		msg("a3", message.Assistant, 10),
+	}
+	ids := func(msgs []message.Message) []string {
+		var ids []string
+		for _, msg := range msgs {
+			ids = append(ids, msg.ID)
+		}
+		return ids
+	}
+
+	t.Run("turns", func(t *testing.T) {
+		summarize, keep := splitForCompaction(msgs, 2, 0)
+		require.Equal(t, []string{"u1", "a1"}, ids(summarize))
+		require.Equal(t, []string{"u2", "a2", "t2", "a2b", "u3", "a3"}, ids(keep))
+	})
+
+	t.Run("tokens", func(t *testing.T) {
+		summarize, keep := splitForCompaction(msgs, 2, 50)
+		require.Equal(t, []string{"u1", "a1", "u2", "a2", "t2", "a2b"}, ids(summarize))
+		require.Equal(t, []string{"u3", "a3"}, ids(keep))
+	})
+
+	t.Run("last turn too large", func(t *testing.T) {
+		summarize, keep := splitForCompaction(msgs, 2, 10)
+		require.Len(t, summarize, len(msgs))
+		require.Empty(t, keep)
+	})
+}
+
+func TestSummaryIncludesTodos(t *testing.T) {
+	conn, err := db.Connect(t.Context(), t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	q := db.New(conn)
+	a := &sessionAgent{
+		sessions: session.NewService(q, conn),
+		messages: message.NewService(q),
+		todos:    todo.NewService(q, conn),
+	}
+	sess, err := a.sessions.Create(t.Context(), "summarized")
+	require.NoError(t, err)
+	summary, err := a.messages.Create(t.Context(), sess.ID, message.CreateMessageParams{
+		Role:             message.Assistant,
+		Parts:            []message.ContentPart{message.TextContent{Text: "We fixed the parser."}},
+		IsSummaryMessage: true,
+	})
+	require.NoError(t, err)
+	sess.SummaryMessageID = summary.ID
+	sess, err = a.sessions.Save(t.Context(), sess)
+	require.NoError(t, err)
+
+	msgs, err := a.getSessionMessages(t.Context(), sess)
+	require.NoError(t, err)
+	require.Equal(t, "We fixed the parser.", msgs[0].Content().Text)
+
+	_, err = a.todos.Set(t.Context(), sess.ID, []todo.Todo{
+		{Content: "fix the parser", Status: todo.StatusDone},
+		{Content: "add tests", Status: todo.StatusPending},
+	})
+	require.NoError(t, err)
+
+	msgs, err = a.getSessionMessages(t.Context(), sess)
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	require.Equal(t, message.User, msgs[0].Role)
+	require.Equal(t, "We fixed the parser.\n\nCurrent todo list:\n1. [done] fix the parser\n2. [pending] add tests\n", msgs[0].Content().Text)
+}
@@ -0,0 +1,90 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/agent/prompt"
+	"github.com/charmbracelet/crush/internal/agent/tools"
+)
+
+// nestedContextTools are the tools that read or edit a file, loading the
+// context files of the subdirectories it is in.
+var nestedContextTools = []string{
+	tools.ViewToolName,
+	tools.EditToolName,
+	tools.MultiEditToolName,
+	tools.NotebookEditToolName,
+	tools.WriteToolName,
+}
+
+// withNestedContext adds the context files of the subdirectories of the
+// working directory as system reminders, right after the result of the first
+// tool call that reads or edits a file under them. They are found again from
+// the messages at every step so that they stay at the same place, which
+// keeps the prompt cache valid, and go away along with the tool calls when
+// the conversation is summarized.
+func (a *sessionAgent) withNestedContext(msgs []fantasy.Message) []fantasy.Message {
+	if a.workingDir == "" {
+		return msgs
+	}
+
+	loaded := make(map[string]bool)
+	pending := make(map[string][]prompt.ContextFile)
+	collect := func(_ int, msg fantasy.Message) []prompt.ContextFile {
+		var files []prompt.ContextFile
+		switch msg.Role {
+		case fantasy.MessageRoleAssistant:
+			for _, part := range msg.Content {
+				call, ok := fantasy.AsMessagePart[fantasy.ToolCallPart](part)
+				if !ok || !slices.Contains(nestedContextTools, call.ToolName) {
+					continue
+				}
+				for _, file := range a.nestedContextFiles(call.Input) {
+					if !loaded[file.Path] {
+						loaded[file.Path] = true
+						pending[call.ToolCallID] = append(pending[call.ToolCallID], file)
+					}
+				}
+			}
+		case fantasy.MessageRoleTool:
+			for _, part := range msg.Content {
+				if toolResult, ok := fantasy.AsMessagePart[fantasy.ToolResultPart](part); ok {
+					files = append(files, pending[toolResult.ToolCallID]...)
+				}
+			}
+		}
+		return files
+	}
+	return insertAfterToolResults(msgs, collect, func(files []prompt.ContextFile) fantasy.Message {
+		return fantasy.NewUserMessage(nestedContextReminder(files))
+	})
+}
+
+func (a *sessionAgent) nestedContextFiles(input string) []prompt.ContextFile {
+	var params struct {
+		FilePath string `json:"file_path"`
+	}
+	if err := json.Unmarshal([]byte(input), &params); err != nil || params.FilePath == "" {
+		return nil
+	}
+	filePath := params.FilePath
+	if !filepath.IsAbs(filePath) {
+		filePath = filepath.Join(a.workingDir, filePath)
+	}
+	return prompt.NestedContextFiles(a.workingDir, filePath)
+}
+
+func nestedContextReminder(files []prompt.ContextFile) string {
+	var sb strings.Builder
+	sb.WriteString("<system-reminder>\nThe following instructions apply to the files in the directory of each file, follow them when working there.\n")
+	for _, file := range files {
+		fmt.Fprintf(&sb, "<file path=%q>\n%s\n</file>\n", file.Path, strings.TrimSpace(file.Content))
+	}
+	sb.WriteString("</system-reminder>")
+	return sb.String()
+}
@@ -0,0 +1,94 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"charm.land/fantasy"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithNestedContext(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "api"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "api", "AGENTS.md"), []byte("Use the api conventions."), 0o644))
+
+	toolCall := func(id, name, input string) fantasy.Message {
+		return fantasy.Message{
+			Role:    fantasy.MessageRoleAssistant,
+			Content: []fantasy.MessagePart{fantasy.ToolCallPart{ToolCallID: id, ToolName: name, Input: input}},
+		}
+	}
+	toolResult := func(id string) fantasy.Message {
+		return fantasy.Message{
+			Role:    fantasy.MessageRoleTool,
+			Content: []fantasy.MessagePart{fantasy.ToolResultPart{ToolCallID: id}},
+		}
+	}
+	msgs := []fantasy.Message{
+		fantasy.NewUserMessage("fix the api"),
+		toolCall("1", "grep", `{"pattern":"api"}`),
+		toolResult("1"),
+		toolCall("2", "view", `{"file_path":"main.go"}`),
+		toolResult("2"),
+		toolCall("3", "view", `{"file_path":"api/handler.go"}`),
+		toolResult("3"),
+		toolCall("4", "edit", `{"file_path":"`+filepath.Join(dir, "api", "handler.go")+`"}`),
+		toolResult("4"),
+	}
+
+	a := &sessionAgent{workingDir: dir}
+	result := a.withNestedContext(msgs)
+	require.Len(t, result, len(msgs)+1)
+	require.Equal(t, msgs[:7], result[:7])
+	reminder := result[7]
+	require.Equal(t, fantasy.MessageRoleUser, reminder.Role)
+	text, ok := fantasy.AsMessagePart[fantasy.TextPart](reminder.Content[0])
+	require.True(t, ok)
+	require.Contains(t, text.Text, "<system-reminder>")
+	require.Contains(t, text.Text, "Use the api conventions.")
+	require.Equal(t, msgs[7:], result[8:])
+
+	// The same messages give the same result, so the reminder stays put.
+	require.Equal(t, result, a.withNestedContext(msgs))
+
+	a = &sessionAgent{}
+	require.Equal(t, msgs, a.withNestedContext(msgs))
+}
+
+func TestWithNestedContextParallelCalls(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, sub := range []string{"api", "web"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, sub), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, sub, "AGENTS.md"), []byte("Use the "+sub+" conventions."), 0o644))
+	}
+
+	msgs := []fantasy.Message{
+		fantasy.NewUserMessage("fix the api and the web"),
+		{
+			Role: fantasy.MessageRoleAssistant,
+			Content: []fantasy.MessagePart{
+				fantasy.ToolCallPart{ToolCallID: "1", ToolName: "view", Input: `{"file_path":"api/handler.go"}`},
+				fantasy.ToolCallPart{ToolCallID: "2", ToolName: "view", Input: `{"file_path":"web/index.go"}`},
+			},
+		},
+		{Role: fantasy.MessageRoleTool, Content: []fantasy.MessagePart{fantasy.ToolResultPart{ToolCallID: "1"}}},
+		{Role: fantasy.MessageRoleTool, Content: []fantasy.MessagePart{fantasy.ToolResultPart{ToolCallID: "2"}}},
+	}
+
+	a := &sessionAgent{workingDir: dir}
+	result := a.withNestedContext(msgs)
+	require.Len(t, result, len(msgs)+1)
+	require.Equal(t, msgs, result[:len(msgs)])
+	reminder := result[len(msgs)]
+	require.Equal(t, fantasy.MessageRoleUser, reminder.Role)
+	text, ok := fantasy.AsMessagePart[fantasy.TextPart](reminder.Content[0])
+	require.True(t, ok)
+	require.Contains(t, text.Text, "Use the api conventions.")
+	require.Contains(t, text.Text, "Use the web conventions.")
+}
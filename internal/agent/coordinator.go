@@ -21,11 +21,14 @@ import (
 	"github.com/charmbracelet/crush/internal/config"
 	"github.com/charmbracelet/crush/internal/csync"
 	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/hooks"
+	"github.com/charmbracelet/crush/internal/job"
 	"github.com/charmbracelet/crush/internal/log"
 	"github.com/charmbracelet/crush/internal/lsp"
 	"github.com/charmbracelet/crush/internal/message"
 	"github.com/charmbracelet/crush/internal/permission"
 	"github.com/charmbracelet/crush/internal/session"
+	"github.com/charmbracelet/crush/internal/todo"
 	"golang.org/x/sync/errgroup"
 
 	"charm.land/fantasy/providers/anthropic"
@@ -49,7 +52,7 @@ type Coordinator interface {
 	IsBusy() bool
 	QueuedPrompts(sessionID string) int
 	ClearQueue(sessionID string)
-	Summarize(context.Context, string) error
+	Summarize(ctx context.Context, sessionID, instructions string) error
 	Model() Model
 	UpdateModels(ctx context.Context) error
 }
@@ -60,7 +63,10 @@ type coordinator struct {
 	messages    message.Service
 	permissions permission.Service
 	history     history.Service
+	todos       todo.Service
+	jobs        job.Service
 	lspClients  *csync.Map[string, *lsp.Client]
+	hooks       *hooks.Runner
 
 	currentAgent SessionAgent
 	agents       map[string]SessionAgent
@@ -75,6 +81,8 @@ func NewCoordinator(
 	messages message.Service,
 	permissions permission.Service,
 	history history.Service,
+	todos todo.Service,
+	jobs job.Service,
 	lspClients *csync.Map[string, *lsp.Client],
 ) (Coordinator, error) {
 	c := &coordinator{
@@ -83,7 +91,10 @@ func NewCoordinator(
 		messages:    messages,
 		permissions: permissions,
 		history:     history,
+		todos:       todos,
+		jobs:        jobs,
 		lspClients:  lspClients,
+		hooks:       hooks.NewRunner(cfg.Hooks, cfg.WorkingDir()),
 		agents:      make(map[string]SessionAgent),
 	}
 
@@ -299,6 +310,13 @@ func (c *coordinator) buildAgent(ctx context.Context, prompt *prompt.Prompt, age
 		return nil, err
 	}
 
+	// Prompt hooks only apply to the main agent, not to the sub-agents it
+	// spawns. Tool hooks apply to every agent.
+	var promptHooks *hooks.Runner
+	if agent.ID == config.AgentCoder {
+		promptHooks = c.hooks
+	}
+
 	largeProviderCfg, _ := c.cfg.Providers.Get(large.ModelCfg.Provider)
 	result := NewSessionAgent(SessionAgentOptions{
 		large,
@@ -310,6 +328,12 @@ func (c *coordinator) buildAgent(ctx context.Context, prompt *prompt.Prompt, age
 		c.sessions,
 		c.messages,
 		nil,
+		promptHooks,
+		c.cfg.Options.Compaction,
+		c.cfg.Options.LoopDetection,
+		c.cfg.Options.Limits,
+		c.todos,
+		c.cfg.WorkingDir(),
 	})
 	c.readyWg.Go(func() error {
 		tools, err := c.buildTools(ctx, agent)
@@ -343,30 +367,46 @@ func (c *coordinator) buildTools(ctx context.Context, agent config.Agent) ([]fan
 
 	// Get the model name for the agent
 	modelName := ""
+	supportsImages := false
 	if modelCfg, ok := c.cfg.Models[agent.Model]; ok {
 		if model := c.cfg.GetModel(modelCfg.Provider, modelCfg.Model); model != nil {
 			modelName = model.Name
+			supportsImages = model.SupportsImages
 		}
 	}
 
 	allTools = append(allTools,
-		tools.NewBashTool(c.permissions, c.cfg.WorkingDir(), c.cfg.Options.Attribution, modelName),
-		tools.NewJobOutputTool(),
+		tools.NewBashTool(c.permissions, c.cfg.WorkingDir(), c.cfg.Options.DataDirectory, c.cfg.Options.Attribution, modelName),
+		tools.NewJobOutputTool(c.cfg.Options.DataDirectory),
 		tools.NewJobKillTool(),
+		tools.NewJobListTool(c.jobs),
+		tools.NewJobInputTool(c.permissions, c.cfg.Options.DataDirectory),
 		tools.NewDownloadTool(c.permissions, c.cfg.WorkingDir(), nil),
 		tools.NewEditTool(c.lspClients, c.permissions, c.history, c.cfg.WorkingDir()),
 		tools.NewMultiEditTool(c.lspClients, c.permissions, c.history, c.cfg.WorkingDir()),
-		tools.NewFetchTool(c.permissions, c.cfg.WorkingDir(), nil),
-		tools.NewGlobTool(c.cfg.WorkingDir()),
-		tools.NewGrepTool(c.cfg.WorkingDir()),
+		tools.NewNotebookEditTool(c.permissions, c.history, c.cfg.WorkingDir()),
+		tools.NewApplyPatchTool(c.lspClients, c.permissions, c.history, c.cfg.WorkingDir()),
+		tools.NewFetchTool(c.permissions, c.cfg.WorkingDir(), c.cfg.Options.DataDirectory, nil),
+		tools.NewGlobTool(c.permissions, c.cfg.WorkingDir()),
+		tools.NewGrepTool(c.permissions, c.cfg.WorkingDir()),
 		tools.NewLsTool(c.permissions, c.cfg.WorkingDir(), c.cfg.Tools.Ls),
 		tools.NewSourcegraphTool(nil),
-		tools.NewViewTool(c.lspClients, c.permissions, c.cfg.WorkingDir()),
+		tools.NewTodosTool(c.todos),
+		tools.NewViewTool(c.lspClients, c.permissions, c.cfg.WorkingDir(), supportsImages),
 		tools.NewWriteTool(c.lspClients, c.permissions, c.history, c.cfg.WorkingDir()),
 	)
 
 	if len(c.cfg.LSP) > 0 {
-		allTools = append(allTools, tools.NewDiagnosticsTool(c.lspClients), tools.NewReferencesTool(c.lspClients))
+		allTools = append(allTools,
+			tools.NewDiagnosticsTool(c.lspClients),
+			tools.NewReferencesTool(c.lspClients),
+			tools.NewDefinitionTool(c.lspClients, c.cfg.WorkingDir()),
+			tools.NewHoverTool(c.lspClients, c.cfg.WorkingDir()),
+			tools.NewDocumentSymbolsTool(c.lspClients, c.cfg.WorkingDir()),
+			tools.NewWorkspaceSymbolsTool(c.lspClients),
+			tools.NewRenameTool(c.lspClients, c.permissions, c.history, c.cfg.WorkingDir()),
+			tools.NewCodeActionTool(c.lspClients, c.permissions, c.history, c.cfg.WorkingDir()),
+		)
 	}
 
 	var filteredTools []fantasy.AgentTool
@@ -401,7 +441,7 @@ func (c *coordinator) buildTools(ctx context.Context, agent config.Agent) ([]fan
 	slices.SortFunc(filteredTools, func(a, b fantasy.AgentTool) int {
 		return strings.Compare(a.Info().Name, b.Info().Name)
 	})
-	return filteredTools, nil
+	return wrapToolsWithHooks(filteredTools, c.hooks, c.permissions), nil
 }
 
 // TODO: when we support multiple agents we need to change this so that we pass in the agent specific model config
@@ -760,10 +800,10 @@ func (c *coordinator) QueuedPrompts(sessionID string) int {
 	return c.currentAgent.QueuedPrompts(sessionID)
 }
 
-func (c *coordinator) Summarize(ctx context.Context, sessionID string) error {
+func (c *coordinator) Summarize(ctx context.Context, sessionID, instructions string) error {
 	providerCfg, ok := c.cfg.Providers.Get(c.currentAgent.Model().ModelCfg.Provider)
 	if !ok {
 		return errors.New("model provider not configured")
 	}
-	return c.currentAgent.Summarize(ctx, sessionID, getProviderOptions(c.currentAgent.Model(), providerCfg))
+	return c.currentAgent.Summarize(ctx, sessionID, instructions, getProviderOptions(c.currentAgent.Model(), providerCfg))
 }
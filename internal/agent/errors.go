@@ -10,8 +10,21 @@ var (
 	ErrSessionBusy      = errors.New("session is currently processing another request")
 	ErrEmptyPrompt      = errors.New("prompt is empty")
 	ErrSessionMissing   = errors.New("session id is missing")
+	ErrLoopDetected     = errors.New("agent stopped repeating the same tool calls")
+	ErrMaxStepsReached  = errors.New("agent reached the maximum number of steps")
+	ErrMaxDuration      = errors.New("agent reached the maximum duration")
+	ErrMaxToolCalls     = errors.New("agent made too many tool calls in a single step")
 )
 
+// IsStoppedErr reports whether the run was stopped for looping or reaching
+// one of its limits, the last message of the session telling why.
+func IsStoppedErr(err error) bool {
+	return errors.Is(err, ErrLoopDetected) ||
+		errors.Is(err, ErrMaxStepsReached) ||
+		errors.Is(err, ErrMaxDuration) ||
+		errors.Is(err, ErrMaxToolCalls)
+}
+
 func isCancelledErr(err error) bool {
 	return errors.Is(err, context.Canceled) || errors.Is(err, ErrRequestCancelled)
 }
@@ -0,0 +1,109 @@
+package agent
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/agent/tools"
+	"github.com/charmbracelet/crush/internal/hooks"
+	"github.com/charmbracelet/crush/internal/permission"
+)
+
+// hookedTool runs the pre_tool_use and post_tool_use hooks around a tool.
+type hookedTool struct {
+	fantasy.AgentTool
+	hooks       *hooks.Runner
+	permissions permission.Service
+}
+
+func wrapToolsWithHooks(agentTools []fantasy.AgentTool, runner *hooks.Runner, permissions permission.Service) []fantasy.AgentTool {
+	if !runner.Has(hooks.PreToolUse) && !runner.Has(hooks.PostToolUse) {
+		return agentTools
+	}
+	wrapped := make([]fantasy.AgentTool, len(agentTools))
+	for i, tool := range agentTools {
+		wrapped[i] = &hookedTool{
+			AgentTool:   tool,
+			hooks:       runner,
+			permissions: permissions,
+		}
+	}
+	return wrapped
+}
+
+func (t *hookedTool) Run(ctx context.Context, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+	sessionID := tools.GetSessionFromContext(ctx)
+
+	pre := t.hooks.Run(ctx, hooks.Input{
+		Event:      hooks.PreToolUse,
+		SessionID:  sessionID,
+		ToolName:   call.Name,
+		ToolCallID: call.ID,
+		Params:     rawParams(call.Input),
+	})
+	if pre.Denied {
+		reason := cmp.Or(pre.Reason, "The tool call was denied by a hook")
+		return fantasy.NewTextErrorResponse(reason), nil
+	}
+	if len(pre.Params) > 0 {
+		call.Input = string(pre.Params)
+	}
+	if pre.Approved {
+		t.permissions.ApproveToolCall(call.ID)
+		defer t.permissions.RevokeToolCall(call.ID)
+	}
+
+	resp, err := t.AgentTool.Run(ctx, call)
+	if err != nil {
+		return resp, err
+	}
+
+	post := t.hooks.Run(ctx, hooks.Input{
+		Event:      hooks.PostToolUse,
+		SessionID:  sessionID,
+		ToolName:   call.Name,
+		ToolCallID: call.ID,
+		Params:     rawParams(call.Input),
+		Result: &hooks.ToolResult{
+			Content: resp.Content,
+			IsError: resp.IsError,
+		},
+	})
+	if post.Append != "" {
+		resp.Content = strings.TrimRight(resp.Content, "\n") + "\n\n" + post.Append
+	}
+	return resp, nil
+}
+
+// runPromptHooks runs the user_prompt_submit hooks, returning the prompt with
+// the context the hooks appended to it.
+func (a *sessionAgent) runPromptHooks(ctx context.Context, sessionID, prompt string) (string, error) {
+	result := a.hooks.Run(ctx, hooks.Input{
+		Event:     hooks.UserPromptSubmit,
+		SessionID: sessionID,
+		Prompt:    prompt,
+	})
+	if result.Denied {
+		if result.Reason != "" {
+			return "", fmt.Errorf("%w: %s", hooks.ErrDenied, result.Reason)
+		}
+		return "", hooks.ErrDenied
+	}
+	if result.Append != "" {
+		prompt += "\n\n" + result.Append
+	}
+	return prompt, nil
+}
+
+// rawParams returns the tool input as JSON, falling back to an empty object
+// for inputs that are not valid JSON.
+func rawParams(input string) json.RawMessage {
+	if !json.Valid([]byte(input)) {
+		return json.RawMessage("{}")
+	}
+	return json.RawMessage(input)
+}
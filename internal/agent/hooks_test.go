@@ -0,0 +1,119 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/config"
+	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/charmbracelet/crush/internal/hooks"
+	"github.com/charmbracelet/crush/internal/permission"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingTool struct {
+	fantasy.AgentTool
+	permissions permission.Service
+	input       string
+	granted     bool
+}
+
+func (t *recordingTool) Run(ctx context.Context, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+	t.input = call.Input
+	t.granted = t.permissions.Request(permission.CreatePermissionRequest{
+		SessionID:  "session",
+		ToolCallID: call.ID,
+		ToolName:   call.Name,
+	})
+	return fantasy.NewTextResponse("done\n"), nil
+}
+
+func TestHookedTool(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands rely on POSIX utilities")
+	}
+
+	run := func(t *testing.T, cfg config.Hooks, allowedTools ...string) (*recordingTool, fantasy.ToolResponse) {
+		permissions := permission.NewPermissionService(t.TempDir(), false, allowedTools, permission.Rules{})
+		tool := &recordingTool{permissions: permissions}
+		wrapped := wrapToolsWithHooks([]fantasy.AgentTool{tool}, hooks.NewRunner(cfg, t.TempDir()), permissions)
+		resp, err := wrapped[0].Run(t.Context(), fantasy.ToolCall{
+			ID:    "call",
+			Name:  "bash",
+			Input: `{"command":"make"}`,
+		})
+		require.NoError(t, err)
+		return tool, resp
+	}
+
+	t.Run("deny", func(t *testing.T) {
+		tool, resp := run(t, config.Hooks{PreToolUse: []config.HookConfig{
+			{Command: "echo 'use the task runner' >&2; exit 2"},
+		}}, "bash")
+		require.Empty(t, tool.input)
+		require.True(t, resp.IsError)
+		require.Equal(t, "use the task runner", resp.Content)
+	})
+
+	t.Run("rewrite params", func(t *testing.T) {
+		tool, _ := run(t, config.Hooks{PreToolUse: []config.HookConfig{
+			{Command: `echo '{"params":{"command":"task build"}}'`},
+		}}, "bash")
+		require.JSONEq(t, `{"command":"task build"}`, tool.input)
+	})
+
+	t.Run("approve", func(t *testing.T) {
+		// Nothing is allowed, the request would block without the hook.
+		tool, _ := run(t, config.Hooks{PreToolUse: []config.HookConfig{
+			{Command: `echo '{"decision":"approve"}'`},
+		}})
+		require.True(t, tool.granted)
+	})
+
+	t.Run("append to result", func(t *testing.T) {
+		_, resp := run(t, config.Hooks{PostToolUse: []config.HookConfig{
+			{Command: `echo '{"append":"formatted 1 file"}'`},
+		}}, "bash")
+		require.Equal(t, "done\n\nformatted 1 file", resp.Content)
+	})
+}
+
+func TestPromptHooksRunOnce(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands rely on POSIX utilities")
+	}
+
+	runs := filepath.Join(t.TempDir(), "runs")
+	a := &sessionAgent{
+		hooks: hooks.NewRunner(config.Hooks{UserPromptSubmit: []config.HookConfig{
+			{Command: fmt.Sprintf(`echo run >> %q; echo '{"append":"ticket ABC-1"}'`, runs)},
+		}}, t.TempDir()),
+		messageQueue:   csync.NewMap[string, []SessionAgentCall](),
+		activeRequests: csync.NewMap[string, context.CancelFunc](),
+	}
+	// The session is busy, the prompt is queued.
+	a.activeRequests.Set("session", func() {})
+
+	_, err := a.Run(t.Context(), SessionAgentCall{SessionID: "session", Prompt: "fix the tests"})
+	require.NoError(t, err)
+	queued, _ := a.messageQueue.Get("session")
+	require.Len(t, queued, 1)
+	require.Equal(t, "fix the tests\n\nticket ABC-1", queued[0].Prompt)
+
+	// Running the queued prompt, still busy, doesn't run the hooks again.
+	a.messageQueue.Del("session")
+	_, err = a.run(t.Context(), queued[0])
+	require.NoError(t, err)
+	queued, _ = a.messageQueue.Get("session")
+	require.Len(t, queued, 1)
+	require.Equal(t, "fix the tests\n\nticket ABC-1", queued[0].Prompt)
+
+	content, err := os.ReadFile(runs)
+	require.NoError(t, err)
+	require.Equal(t, "run\n", string(content))
+}
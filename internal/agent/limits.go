@@ -0,0 +1,109 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/message"
+)
+
+// runStop tells why a run was stopped before the agent was done.
+type runStop struct {
+	Reason  message.FinishReason
+	Title   string
+	Details string
+	Err     error
+}
+
+func (s *runStop) runErr() error {
+	return fmt.Errorf("%w: %s", s.Err, s.Details)
+}
+
+func loopStop(l loop) *runStop {
+	return &runStop{
+		Reason:  message.FinishReasonLoopDetected,
+		Title:   "Loop detected",
+		Details: l.String(),
+		Err:     ErrLoopDetected,
+	}
+}
+
+func maxStepsStop(steps int) *runStop {
+	return &runStop{
+		Reason:  message.FinishReasonMaxSteps,
+		Title:   "Step limit reached",
+		Details: fmt.Sprintf("The agent took %d steps, the most allowed for a prompt.", steps),
+		Err:     ErrMaxStepsReached,
+	}
+}
+
+func maxDurationStop(d time.Duration) *runStop {
+	return &runStop{
+		Reason:  message.FinishReasonMaxDuration,
+		Title:   "Time limit reached",
+		Details: fmt.Sprintf("The agent worked on the prompt for %s, the most allowed.", d),
+		Err:     ErrMaxDuration,
+	}
+}
+
+func maxToolCallsStop(calls int) *runStop {
+	return &runStop{
+		Reason:  message.FinishReasonMaxToolCalls,
+		Title:   "Tool call limit reached",
+		Details: fmt.Sprintf("The agent made more than %d tool calls in a single step.", calls),
+		Err:     ErrMaxToolCalls,
+	}
+}
+
+// maxDuration returns how long the agent can work on a prompt, zero meaning
+// no limit.
+func (a *sessionAgent) maxDuration() time.Duration {
+	if a.limits == nil || a.limits.MaxDuration <= 0 {
+		return 0
+	}
+	return time.Duration(a.limits.MaxDuration) * time.Second
+}
+
+// maxToolCallsPerStep returns how many tool calls the model can make in a
+// single response, zero meaning no limit.
+func (a *sessionAgent) maxToolCallsPerStep() int {
+	if a.limits == nil {
+		return 0
+	}
+	return max(a.limits.MaxToolCallsPerStep, 0)
+}
+
+// stepLimitReached stops the run once the agent took the most steps allowed,
+// counting the ones taken before by the runs of the same prompt, and still
+// has tool calls to follow up on.
+func (a *sessionAgent) stepLimitReached(steps []fantasy.StepResult, taken int) *runStop {
+	if a.limits == nil || a.limits.MaxSteps <= 0 || len(steps) == 0 || taken+len(steps) < a.limits.MaxSteps {
+		return nil
+	}
+	if steps[len(steps)-1].FinishReason != fantasy.FinishReasonToolCalls {
+		return nil
+	}
+	return maxStepsStop(taken + len(steps))
+}
+
+// addStopMessage adds a message telling why the run was stopped. It is a
+// message of its own as the last one of the run is usually made of tool
+// calls only.
+func (a *sessionAgent) addStopMessage(ctx context.Context, sessionID string, stop *runStop) (*message.Message, error) {
+	msg, err := a.messages.Create(ctx, sessionID, message.CreateMessageParams{
+		Role:     message.Assistant,
+		Parts:    []message.ContentPart{},
+		Model:    a.largeModel.ModelCfg.Model,
+		Provider: a.largeModel.ModelCfg.Provider,
+	})
+	if err != nil {
+		return nil, err
+	}
+	msg.AddFinish(stop.Reason, stop.Title, stop.Details)
+	if err := a.messages.Update(ctx, msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
@@ -0,0 +1,70 @@
+package agent
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/config"
+	"github.com/charmbracelet/crush/internal/message"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepLimitReached(t *testing.T) {
+	t.Parallel()
+
+	toolSteps := []fantasy.StepResult{
+		{Response: fantasy.Response{FinishReason: fantasy.FinishReasonToolCalls}},
+		{Response: fantasy.Response{FinishReason: fantasy.FinishReasonToolCalls}},
+	}
+	a := &sessionAgent{}
+	require.Nil(t, a.stepLimitReached(toolSteps, 0))
+
+	a = &sessionAgent{limits: &config.Limits{MaxSteps: 2}}
+	require.Nil(t, a.stepLimitReached(toolSteps[:1], 0))
+	stop := a.stepLimitReached(toolSteps, 0)
+	require.NotNil(t, stop)
+	require.Equal(t, message.FinishReasonMaxSteps, stop.Reason)
+	require.Equal(t, "The agent took 2 steps, the most allowed for a prompt.", stop.Details)
+
+	// The steps taken before a summary count too.
+	stop = a.stepLimitReached(toolSteps[:1], 1)
+	require.NotNil(t, stop)
+	require.Equal(t, "The agent took 2 steps, the most allowed for a prompt.", stop.Details)
+
+	// A last step that ends the turn needs no stopping.
+	done := append(toolSteps[:1:1], fantasy.StepResult{Response: fantasy.Response{FinishReason: fantasy.FinishReasonStop}})
+	require.Nil(t, a.stepLimitReached(done, 0))
+}
+
+func TestLimits(t *testing.T) {
+	t.Parallel()
+
+	a := &sessionAgent{}
+	require.Zero(t, a.maxDuration())
+	require.Zero(t, a.maxToolCallsPerStep())
+
+	a = &sessionAgent{limits: &config.Limits{MaxDuration: 90, MaxToolCallsPerStep: 10}}
+	require.Equal(t, 90*time.Second, a.maxDuration())
+	require.Equal(t, 10, a.maxToolCallsPerStep())
+}
+
+func TestRunStopErr(t *testing.T) {
+	t.Parallel()
+
+	for _, stop := range []*runStop{
+		loopStop(loop{ToolName: "view", Calls: 7}),
+		maxStepsStop(50),
+		maxDurationStop(10 * time.Minute),
+		maxToolCallsStop(20),
+	} {
+		require.True(t, stop.Reason.Stopped())
+		err := fmt.Errorf("run: %w", stop.runErr())
+		require.ErrorIs(t, err, stop.Err)
+		require.True(t, IsStoppedErr(err))
+		require.Contains(t, err.Error(), stop.Details)
+	}
+	require.False(t, IsStoppedErr(ErrRequestCancelled))
+	require.False(t, message.FinishReasonError.Stopped())
+}
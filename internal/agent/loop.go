@@ -0,0 +1,177 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/config"
+)
+
+const (
+	defaultLoopRepeatedCalls = 5
+	defaultLoopFailedCalls   = 8
+	// loopCallsAfterWarning is how many more repeated or failed tool calls
+	// are let through after the agent was warned before the run is stopped.
+	loopCallsAfterWarning = 2
+)
+
+// loopLimits returns the number of identical tool calls and of failed tool
+// calls in a row at which the agent is warned, zero meaning loop detection
+// is disabled.
+func loopLimits(cfg *config.LoopDetection) (repeated, failed int) {
+	if cfg != nil && cfg.Disabled {
+		return 0, 0
+	}
+	repeated, failed = defaultLoopRepeatedCalls, defaultLoopFailedCalls
+	if cfg != nil && cfg.RepeatedCalls > 0 {
+		repeated = cfg.RepeatedCalls
+	}
+	if cfg != nil && cfg.FailedCalls > 0 {
+		failed = cfg.FailedCalls
+	}
+	return repeated, failed
+}
+
+// loop describes the tool calls the agent keeps repeating.
+type loop struct {
+	// ToolName is set when the same call is repeated, and empty when
+	// different calls keep failing.
+	ToolName string
+	Calls    int
+}
+
+func (l loop) String() string {
+	if l.ToolName != "" {
+		return fmt.Sprintf("The agent called the %s tool with the same input %d times in a row.", l.ToolName, l.Calls)
+	}
+	return fmt.Sprintf("The last %d tool calls of the agent failed.", l.Calls)
+}
+
+func (l loop) reminder() string {
+	if l.ToolName != "" {
+		return fmt.Sprintf("<system-reminder>\nYou called the %s tool with the same input %d times in a row. Calling it again will not give a different result. Stop and reconsider your approach: try something different, or ask the user for help if you are stuck. The run will be stopped if you keep repeating it.\n</system-reminder>", l.ToolName, l.Calls)
+	}
+	return fmt.Sprintf("<system-reminder>\nYour last %d tool calls failed. Read the errors carefully and reconsider your approach instead of retrying: try something different, or ask the user for help if you are stuck. The run will be stopped if the tool calls keep failing.\n</system-reminder>", l.Calls)
+}
+
+// loopScan is the result of looking for loops in the tool calls since the
+// last user message.
+type loopScan struct {
+	// warnings maps the index of the tool message that made a streak reach
+	// its limit to the loop it completes.
+	warnings map[int]loop
+	// stalled is set when a streak went on past its limit even after the
+	// warning.
+	stalled *loop
+}
+
+// scanLoops follows the streaks of identical tool calls, a call being
+// identified by the tool name and its normalized input, and of failed tool
+// calls. A user message starts over.
+func scanLoops(msgs []fantasy.Message, repeatedLimit, failedLimit int) loopScan {
+	scan := loopScan{warnings: make(map[int]loop)}
+	signatures := make(map[string]string)
+	names := make(map[string]string)
+	var lastSignature string
+	var repeated, failed int
+	for i, msg := range msgs {
+		switch msg.Role {
+		case fantasy.MessageRoleUser:
+			lastSignature, repeated, failed = "", 0, 0
+			scan.stalled = nil
+		case fantasy.MessageRoleAssistant:
+			for _, part := range msg.Content {
+				if call, ok := fantasy.AsMessagePart[fantasy.ToolCallPart](part); ok {
+					signatures[call.ToolCallID] = toolCallSignature(call.ToolName, call.Input)
+					names[call.ToolCallID] = call.ToolName
+				}
+			}
+		case fantasy.MessageRoleTool:
+			for _, part := range msg.Content {
+				result, ok := fantasy.AsMessagePart[fantasy.ToolResultPart](part)
+				if !ok {
+					continue
+				}
+				signature := signatures[result.ToolCallID]
+				if signature != "" && signature == lastSignature {
+					repeated++
+				} else {
+					lastSignature, repeated = signature, 1
+				}
+				if result.Output != nil && result.Output.GetType() == fantasy.ToolResultContentTypeError {
+					failed++
+				} else {
+					failed = 0
+				}
+
+				switch {
+				case repeatedLimit > 0 && repeated >= repeatedLimit:
+					l := loop{ToolName: names[result.ToolCallID], Calls: repeated}
+					if repeated == repeatedLimit {
+						scan.warnings[i] = l
+					} else if repeated >= repeatedLimit+loopCallsAfterWarning {
+						scan.stalled = &l
+					}
+				case failedLimit > 0 && failed >= failedLimit:
+					l := loop{Calls: failed}
+					if failed == failedLimit {
+						scan.warnings[i] = l
+					} else if failed >= failedLimit+loopCallsAfterWarning {
+						scan.stalled = &l
+					}
+				}
+			}
+		}
+	}
+	return scan
+}
+
+// toolCallSignature identifies a tool call by the tool name and its input,
+// with the keys sorted and the spacing removed so that the same call
+// formatted differently is still recognized.
+func toolCallSignature(name, input string) string {
+	var v any
+	if err := json.Unmarshal([]byte(input), &v); err == nil {
+		if normalized, err := json.Marshal(v); err == nil {
+			return name + ":" + string(normalized)
+		}
+	}
+	return name + ":" + strings.TrimSpace(input)
+}
+
+// withLoopReminders adds a system reminder telling the agent to change
+// approach right after the tool result that made it reach a loop limit.
+// Like the nested context files they are found again from the messages at
+// every step so that they stay at the same place.
+func (a *sessionAgent) withLoopReminders(msgs []fantasy.Message) []fantasy.Message {
+	repeatedLimit, failedLimit := loopLimits(a.loopDetection)
+	scan := scanLoops(msgs, repeatedLimit, failedLimit)
+	if len(scan.warnings) == 0 {
+		return msgs
+	}
+	collect := func(i int, _ fantasy.Message) []string {
+		if l, ok := scan.warnings[i]; ok {
+			return []string{l.reminder()}
+		}
+		return nil
+	}
+	return insertAfterToolResults(msgs, collect, func(reminders []string) fantasy.Message {
+		return fantasy.NewUserMessage(strings.Join(reminders, "\n"))
+	})
+}
+
+// stalledLoop returns the loop the agent kept going in after it was warned,
+// if any, looking at the steps of the current run.
+func (a *sessionAgent) stalledLoop(steps []fantasy.StepResult) *loop {
+	repeatedLimit, failedLimit := loopLimits(a.loopDetection)
+	if repeatedLimit == 0 && failedLimit == 0 {
+		return nil
+	}
+	var msgs []fantasy.Message
+	for _, step := range steps {
+		msgs = append(msgs, step.Messages...)
+	}
+	return scanLoops(msgs, repeatedLimit, failedLimit).stalled
+}
@@ -0,0 +1,188 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoopLimits(t *testing.T) {
+	t.Parallel()
+
+	repeated, failed := loopLimits(nil)
+	require.Equal(t, defaultLoopRepeatedCalls, repeated)
+	require.Equal(t, defaultLoopFailedCalls, failed)
+
+	repeated, failed = loopLimits(&config.LoopDetection{RepeatedCalls: 3})
+	require.Equal(t, 3, repeated)
+	require.Equal(t, defaultLoopFailedCalls, failed)
+
+	repeated, failed = loopLimits(&config.LoopDetection{Disabled: true, RepeatedCalls: 3})
+	require.Zero(t, repeated)
+	require.Zero(t, failed)
+}
+
+func TestToolCallSignature(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t,
+		toolCallSignature("view", `{"file_path": "main.go", "offset": 0}`),
+		toolCallSignature("view", `{"offset":0,"file_path":"main.go"}`),
+	)
+	require.NotEqual(t,
+		toolCallSignature("view", `{"file_path":"main.go"}`),
+		toolCallSignature("view", `{"file_path":"main_test.go"}`),
+	)
+	require.NotEqual(t, toolCallSignature("view", `{}`), toolCallSignature("ls", `{}`))
+	require.Equal(t, "bash:not json", toolCallSignature("bash", " not json\n"))
+}
+
+// loopStep returns the messages of a step calling a tool once.
+func loopStep(id, name, input string, failed bool) []fantasy.Message {
+	var output fantasy.ToolResultOutputContent = fantasy.ToolResultOutputContentText{Text: "ok"}
+	if failed {
+		output = fantasy.ToolResultOutputContentError{Error: errors.New("failed")}
+	}
+	return []fantasy.Message{
+		{
+			Role:    fantasy.MessageRoleAssistant,
+			Content: []fantasy.MessagePart{fantasy.ToolCallPart{ToolCallID: id, ToolName: name, Input: input}},
+		},
+		{
+			Role:    fantasy.MessageRoleTool,
+			Content: []fantasy.MessagePart{fantasy.ToolResultPart{ToolCallID: id, Output: output}},
+		},
+	}
+}
+
+func TestScanLoopsRepeated(t *testing.T) {
+	t.Parallel()
+
+	msgs := []fantasy.Message{fantasy.NewUserMessage("fix the tests")}
+	msgs = append(msgs, loopStep("a", "view", `{"file_path":"other.go"}`, false)...)
+	for i := range 4 {
+		msgs = append(msgs, loopStep(fmt.Sprint(i), "bash", `{"command":"go test ./..."}`, false)...)
+	}
+
+	scan := scanLoops(msgs, 5, 8)
+	require.Empty(t, scan.warnings)
+	require.Nil(t, scan.stalled)
+
+	msgs = append(msgs, loopStep("4", "bash", `{"command": "go test ./..."}`, false)...)
+	scan = scanLoops(msgs, 5, 8)
+	require.Equal(t, map[int]loop{len(msgs) - 1: {ToolName: "bash", Calls: 5}}, scan.warnings)
+	require.Nil(t, scan.stalled)
+
+	msgs = append(msgs, loopStep("5", "bash", `{"command":"go test ./..."}`, false)...)
+	require.Nil(t, scanLoops(msgs, 5, 8).stalled)
+	msgs = append(msgs, loopStep("6", "bash", `{"command":"go test ./..."}`, false)...)
+	require.Equal(t, &loop{ToolName: "bash", Calls: 7}, scanLoops(msgs, 5, 8).stalled)
+
+	// The user stepping in starts over.
+	msgs = append(msgs, fantasy.NewUserMessage("try again"))
+	msgs = append(msgs, loopStep("7", "bash", `{"command":"go test ./..."}`, false)...)
+	scan = scanLoops(msgs, 5, 8)
+	require.Len(t, scan.warnings, 1)
+	require.Nil(t, scan.stalled)
+
+	// A zero limit disables the detection.
+	require.Empty(t, scanLoops(msgs, 0, 0).warnings)
+}
+
+func TestScanLoopsFailed(t *testing.T) {
+	t.Parallel()
+
+	var msgs []fantasy.Message
+	for i := range 3 {
+		msgs = append(msgs, loopStep(fmt.Sprint(i), "edit", fmt.Sprintf(`{"old_string":"%d"}`, i), true)...)
+	}
+	scan := scanLoops(msgs, 5, 3)
+	require.Equal(t, map[int]loop{len(msgs) - 1: {Calls: 3}}, scan.warnings)
+
+	// A successful call breaks the streak.
+	msgs = append(msgs, loopStep("ok", "view", `{}`, false)...)
+	for i := range 4 {
+		msgs = append(msgs, loopStep(fmt.Sprint("retry", i), "edit", fmt.Sprintf(`{"old_string":"retry %d"}`, i), true)...)
+	}
+	scan = scanLoops(msgs, 5, 3)
+	require.Len(t, scan.warnings, 2)
+	require.Nil(t, scan.stalled)
+
+	msgs = append(msgs, loopStep("last", "bash", `{}`, true)...)
+	require.Equal(t, &loop{Calls: 5}, scanLoops(msgs, 5, 3).stalled)
+}
+
+func TestWithLoopReminders(t *testing.T) {
+	t.Parallel()
+
+	msgs := []fantasy.Message{fantasy.NewUserMessage("read it")}
+	for i := range 3 {
+		msgs = append(msgs, loopStep(fmt.Sprint(i), "view", `{"file_path":"main.go"}`, false)...)
+	}
+
+	a := &sessionAgent{loopDetection: &config.LoopDetection{RepeatedCalls: 2}}
+	result := a.withLoopReminders(msgs)
+	require.Len(t, result, len(msgs)+1)
+	require.Equal(t, msgs[:5], result[:5])
+	reminder := result[5]
+	require.Equal(t, fantasy.MessageRoleUser, reminder.Role)
+	text, ok := fantasy.AsMessagePart[fantasy.TextPart](reminder.Content[0])
+	require.True(t, ok)
+	require.Contains(t, text.Text, "<system-reminder>")
+	require.Contains(t, text.Text, "view tool with the same input 2 times")
+	require.Equal(t, msgs[5:], result[6:])
+
+	// The same messages give the same result, so the reminder stays put.
+	require.Equal(t, result, a.withLoopReminders(msgs))
+
+	a = &sessionAgent{loopDetection: &config.LoopDetection{Disabled: true}}
+	require.Equal(t, msgs, a.withLoopReminders(msgs))
+}
+
+func TestWithLoopRemindersParallelCalls(t *testing.T) {
+	t.Parallel()
+
+	msgs := []fantasy.Message{fantasy.NewUserMessage("read it")}
+	msgs = append(msgs, loopStep("1", "view", `{"file_path":"main.go"}`, false)...)
+	msgs = append(msgs,
+		fantasy.Message{
+			Role: fantasy.MessageRoleAssistant,
+			Content: []fantasy.MessagePart{
+				fantasy.ToolCallPart{ToolCallID: "2", ToolName: "view", Input: `{"file_path":"main.go"}`},
+				fantasy.ToolCallPart{ToolCallID: "3", ToolName: "grep", Input: `{"pattern":"main"}`},
+			},
+		},
+		fantasy.Message{Role: fantasy.MessageRoleTool, Content: []fantasy.MessagePart{fantasy.ToolResultPart{ToolCallID: "2"}}},
+		fantasy.Message{Role: fantasy.MessageRoleTool, Content: []fantasy.MessagePart{fantasy.ToolResultPart{ToolCallID: "3"}}},
+	)
+
+	a := &sessionAgent{loopDetection: &config.LoopDetection{RepeatedCalls: 2}}
+	result := a.withLoopReminders(msgs)
+	require.Len(t, result, len(msgs)+1)
+	require.Equal(t, msgs, result[:len(msgs)])
+	reminder := result[len(msgs)]
+	require.Equal(t, fantasy.MessageRoleUser, reminder.Role)
+	text, ok := fantasy.AsMessagePart[fantasy.TextPart](reminder.Content[0])
+	require.True(t, ok)
+	require.Contains(t, text.Text, "view tool with the same input 2 times")
+}
+
+func TestStalledLoop(t *testing.T) {
+	t.Parallel()
+
+	a := &sessionAgent{loopDetection: &config.LoopDetection{RepeatedCalls: 2}}
+	var steps []fantasy.StepResult
+	for i := range 4 {
+		steps = append(steps, fantasy.StepResult{Messages: loopStep(fmt.Sprint(i), "ls", `{"path":"."}`, false)})
+		if i < 3 {
+			require.Nil(t, a.stalledLoop(steps))
+		}
+	}
+	stalled := a.stalledLoop(steps)
+	require.NotNil(t, stalled)
+	require.Equal(t, "The agent called the ls tool with the same input 4 times in a row.", stalled.String())
+}
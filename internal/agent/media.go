@@ -0,0 +1,66 @@
+package agent
+
+import (
+	"encoding/base64"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/message"
+)
+
+// toolMediaReminder goes along with the images returned by tools.
+const toolMediaReminder = "<system-reminder>\nThe images returned by the tool calls above are attached.\n</system-reminder>"
+
+// toolMedia returns the images returned by tools in the messages, by tool
+// call.
+func toolMedia(msgs []message.Message) map[string]fantasy.FilePart {
+	media := make(map[string]fantasy.FilePart)
+	for _, msg := range msgs {
+		for _, result := range msg.ToolResults() {
+			addToolMedia(media, result)
+		}
+	}
+	return media
+}
+
+func addToolMedia(media map[string]fantasy.FilePart, result message.ToolResult) {
+	if result.Data == "" || result.IsError {
+		return
+	}
+	data, err := base64.StdEncoding.DecodeString(result.Data)
+	if err != nil {
+		return
+	}
+	media[result.ToolCallID] = fantasy.FilePart{
+		Data:      data,
+		MediaType: result.MIMEType,
+	}
+}
+
+// withToolMedia attaches the images returned by tools in a user message after
+// their results, as not every provider takes images in tool results. Models
+// without image support only get the text of the results.
+func (a *sessionAgent) withToolMedia(msgs []fantasy.Message, media map[string]fantasy.FilePart) []fantasy.Message {
+	if len(media) == 0 || !a.largeModel.CatwalkCfg.SupportsImages {
+		return msgs
+	}
+	collect := func(_ int, msg fantasy.Message) []fantasy.MessagePart {
+		if msg.Role != fantasy.MessageRoleTool {
+			return nil
+		}
+		var files []fantasy.MessagePart
+		for _, part := range msg.Content {
+			if toolResult, ok := fantasy.AsMessagePart[fantasy.ToolResultPart](part); ok {
+				if file, ok := media[toolResult.ToolCallID]; ok {
+					files = append(files, file)
+				}
+			}
+		}
+		return files
+	}
+	return insertAfterToolResults(msgs, collect, func(files []fantasy.MessagePart) fantasy.Message {
+		return fantasy.Message{
+			Role:    fantasy.MessageRoleUser,
+			Content: append([]fantasy.MessagePart{fantasy.TextPart{Text: toolMediaReminder}}, files...),
+		}
+	})
+}
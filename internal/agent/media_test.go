@@ -0,0 +1,89 @@
+package agent
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/catwalk/pkg/catwalk"
+	"github.com/charmbracelet/crush/internal/message"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithToolMedia(t *testing.T) {
+	t.Parallel()
+
+	media := toolMedia([]message.Message{
+		{
+			Role: message.Tool,
+			Parts: []message.ContentPart{message.ToolResult{
+				ToolCallID: "1",
+				Content:    "This is a PNG image.",
+				Data:       base64.StdEncoding.EncodeToString([]byte("png")),
+				MIMEType:   "image/png",
+			}},
+		},
+		{
+			Role: message.Tool,
+			Parts: []message.ContentPart{message.ToolResult{
+				ToolCallID: "3",
+				Content:    "Could not decode the image.",
+				Data:       base64.StdEncoding.EncodeToString([]byte("gif")),
+				IsError:    true,
+			}},
+		},
+	})
+	addToolMedia(media, message.ToolResult{
+		ToolCallID: "2",
+		Data:       base64.StdEncoding.EncodeToString([]byte("jpeg")),
+		MIMEType:   "image/jpeg",
+	})
+	require.Len(t, media, 2)
+
+	toolResult := func(id string) fantasy.Message {
+		return fantasy.Message{
+			Role:    fantasy.MessageRoleTool,
+			Content: []fantasy.MessagePart{fantasy.ToolResultPart{ToolCallID: id}},
+		}
+	}
+	msgs := []fantasy.Message{
+		fantasy.NewUserMessage("look at the images"),
+		{
+			Role: fantasy.MessageRoleAssistant,
+			Content: []fantasy.MessagePart{
+				fantasy.ToolCallPart{ToolCallID: "1", ToolName: "view"},
+				fantasy.ToolCallPart{ToolCallID: "2", ToolName: "view"},
+				fantasy.ToolCallPart{ToolCallID: "3", ToolName: "view"},
+			},
+		},
+		toolResult("1"),
+		toolResult("2"),
+		toolResult("3"),
+		fantasy.NewUserMessage("thanks"),
+	}
+
+	a := &sessionAgent{largeModel: Model{CatwalkCfg: catwalk.Model{SupportsImages: true}}}
+	result := a.withToolMedia(msgs, media)
+	require.Len(t, result, len(msgs)+1)
+	// The images come after all the results of the step.
+	require.Equal(t, msgs[:5], result[:5])
+	attached := result[5]
+	require.Equal(t, fantasy.MessageRoleUser, attached.Role)
+	require.Len(t, attached.Content, 3)
+	text, ok := fantasy.AsMessagePart[fantasy.TextPart](attached.Content[0])
+	require.True(t, ok)
+	require.Contains(t, text.Text, "<system-reminder>")
+	for i, want := range []fantasy.FilePart{
+		{Data: []byte("png"), MediaType: "image/png"},
+		{Data: []byte("jpeg"), MediaType: "image/jpeg"},
+	} {
+		file, ok := fantasy.AsMessagePart[fantasy.FilePart](attached.Content[i+1])
+		require.True(t, ok)
+		require.Equal(t, want, file)
+	}
+	require.Equal(t, msgs[5:], result[6:])
+
+	// Models without image support only get the text.
+	a = &sessionAgent{}
+	require.Equal(t, msgs, a.withToolMedia(msgs, media))
+}
@@ -0,0 +1,143 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/crush/internal/home"
+)
+
+// maxImportDepth limits how deep @path imports are followed.
+const maxImportDepth = 5
+
+// NestedContextFileNames are the context files loaded from the
+// subdirectories of the working directory as the agent works in them.
+var NestedContextFileNames = []string{"AGENTS.md", "CRUSH.md"}
+
+// NestedContextFiles returns the context files found in the directories
+// between the working directory, which is left out as its context files are
+// part of the system prompt, and the directory of the file, outermost first.
+func NestedContextFiles(workingDir, filePath string) []ContextFile {
+	rel, err := filepath.Rel(workingDir, filepath.Dir(filePath))
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil
+	}
+
+	var files []ContextFile
+	dir := workingDir
+	for part := range strings.SplitSeq(rel, string(filepath.Separator)) {
+		dir = filepath.Join(dir, part)
+		for _, name := range NestedContextFileNames {
+			if result := processFile(filepath.Join(dir, name)); result != nil {
+				files = append(files, *result)
+			}
+		}
+	}
+	return files
+}
+
+// expandImports replaces the @path references of a context file with the
+// content of the files they point to, themselves expanded. Paths are
+// relative to the file they appear in. References inside code, to missing
+// files or to files already being expanded are left as is.
+func expandImports(filePath, content string, stack []string) string {
+	if len(stack) >= maxImportDepth || !strings.Contains(content, "@") {
+		return content
+	}
+	stack = append(stack, filePath)
+
+	var sb strings.Builder
+	inFence := false
+	for line := range strings.Lines(content) {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+		if inFence || !strings.Contains(line, "@") {
+			sb.WriteString(line)
+			continue
+		}
+		// Odd segments are inline code spans.
+		for i, segment := range strings.Split(line, "`") {
+			if i > 0 {
+				sb.WriteString("`")
+			}
+			if i%2 == 1 {
+				sb.WriteString(segment)
+				continue
+			}
+			sb.WriteString(expandSegment(filePath, segment, stack))
+		}
+	}
+	return sb.String()
+}
+
+func expandSegment(filePath, segment string, stack []string) string {
+	var sb strings.Builder
+	for {
+		at := strings.IndexByte(segment, '@')
+		if at == -1 {
+			sb.WriteString(segment)
+			return sb.String()
+		}
+		// Only a whitespace or the start of the line can come before the
+		// reference, leaving out email addresses.
+		if at > 0 && !isSpace(segment[at-1]) {
+			sb.WriteString(segment[:at+1])
+			segment = segment[at+1:]
+			continue
+		}
+		end := at + 1
+		for end < len(segment) && !isSpace(segment[end]) {
+			end++
+		}
+		ref := segment[at+1 : end]
+		imported, ok := importFile(filePath, ref, stack)
+		if !ok {
+			// Allow trailing punctuation, as in "see @docs/style.md."
+			trimmed := strings.TrimRight(ref, ".,;:!?)")
+			if trimmed != ref {
+				if imported, ok = importFile(filePath, trimmed, stack); ok {
+					imported += ref[len(trimmed):]
+				}
+			}
+		}
+		sb.WriteString(segment[:at])
+		if ok {
+			sb.WriteString(imported)
+		} else {
+			sb.WriteString(segment[at:end])
+		}
+		segment = segment[end:]
+	}
+}
+
+func importFile(filePath, ref string, stack []string) (string, bool) {
+	if ref == "" {
+		return "", false
+	}
+	path := home.Long(ref)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(filePath), path)
+	}
+	path = filepath.Clean(path)
+	for _, p := range stack {
+		if p == path {
+			return "", false
+		}
+	}
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return "", false
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimRight(expandImports(path, string(content), stack), "\n"), true
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
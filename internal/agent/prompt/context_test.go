@@ -0,0 +1,81 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func TestExpandImports(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"AGENTS.md": "# Rules\n@docs/style.md\nSee @docs/testing.md.\n" +
+			"Ask me@example.com or @someone.\n" +
+			"Not `@docs/style.md` in code.\n```\n@docs/style.md\n```\n",
+		"docs/style.md":    "Use tabs.\n@../AGENTS.md\n",
+		"docs/testing.md":  "Run @commands.md\n",
+		"docs/commands.md": "go test ./...\n",
+	})
+
+	file := processFile(filepath.Join(dir, "AGENTS.md"))
+	require.NotNil(t, file)
+	require.Equal(t, "# Rules\n"+
+		// The import of AGENTS.md from style.md is a cycle.
+		"Use tabs.\n@../AGENTS.md\n"+
+		"See Run go test ./....\n"+
+		"Ask me@example.com or @someone.\n"+
+		"Not `@docs/style.md` in code.\n```\n@docs/style.md\n```\n", file.Content)
+}
+
+func TestExpandImportsDepth(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files := map[string]string{}
+	for i := range maxImportDepth + 2 {
+		name := string(rune('a' + i))
+		files[name+".md"] = name + " @" + string(rune('a'+i+1)) + ".md"
+	}
+	writeFiles(t, dir, files)
+
+	file := processFile(filepath.Join(dir, "a.md"))
+	require.NotNil(t, file)
+	// Five levels of imports are followed.
+	require.Equal(t, "a b c d e f @g.md", file.Content)
+}
+
+func TestNestedContextFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"AGENTS.md":                     "root",
+		"services/api/AGENTS.md":        "api @conventions.md",
+		"services/api/conventions.md":   "conventions",
+		"services/api/CRUSH.md":         "crush",
+		"services/api/handlers/main.go": "package handlers",
+		"services/web/AGENTS.md":        "web",
+	})
+
+	files := NestedContextFiles(dir, filepath.Join(dir, "services/api/handlers/main.go"))
+	require.Len(t, files, 2)
+	require.Equal(t, filepath.Join(dir, "services/api/AGENTS.md"), files[0].Path)
+	require.Equal(t, "api conventions", files[0].Content)
+	require.Equal(t, filepath.Join(dir, "services/api/CRUSH.md"), files[1].Path)
+
+	require.Empty(t, NestedContextFiles(dir, filepath.Join(dir, "main.go")))
+	require.Empty(t, NestedContextFiles(dir, filepath.Join(filepath.Dir(dir), "other", "main.go")))
+}
@@ -98,7 +98,7 @@ func processFile(filePath string) *ContextFile {
 	}
 	return &ContextFile{
 		Path:    filePath,
-		Content: string(content),
+		Content: expandImports(filepath.Clean(filePath), string(content), nil),
 	}
 }
 
@@ -0,0 +1,392 @@
+package tools
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/charmbracelet/crush/internal/diff"
+	"github.com/charmbracelet/crush/internal/filepathext"
+	"github.com/charmbracelet/crush/internal/fsext"
+	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/lsp"
+	"github.com/charmbracelet/crush/internal/permission"
+)
+
+type ApplyPatchParams struct {
+	Patch string `json:"patch" description:"The patch to apply, in the V4A format between *** Begin Patch and *** End Patch, or as a unified diff"`
+}
+
+// ApplyPatchFile is the change a patch makes to a file.
+type ApplyPatchFile struct {
+	Path       string `json:"path"`
+	MovePath   string `json:"move_path,omitempty"`
+	Kind       string `json:"kind"`
+	OldContent string `json:"old_content,omitempty"`
+	NewContent string `json:"new_content,omitempty"`
+	Additions  int    `json:"additions"`
+	Removals   int    `json:"removals"`
+}
+
+type ApplyPatchPermissionsParams struct {
+	Files []ApplyPatchFile `json:"files"`
+}
+
+type ApplyPatchResponseMetadata struct {
+	Files     []ApplyPatchFile `json:"files"`
+	Additions int              `json:"additions"`
+	Removals  int              `json:"removals"`
+}
+
+const ApplyPatchToolName = "apply_patch"
+
+//go:embed apply_patch.md
+var applyPatchDescription []byte
+
+// patchChange is a file change of a patch ready to be written, with what's
+// needed to undo it.
+type patchChange struct {
+	ApplyPatchFile
+	isCrlf bool
+	// original is the content of the file on disk, to restore it.
+	original []byte
+}
+
+func NewApplyPatchTool(lspClients *csync.Map[string, *lsp.Client], permissions permission.Service, files history.Service, workingDir string) fantasy.AgentTool {
+	return fantasy.NewAgentTool(
+		ApplyPatchToolName,
+		string(applyPatchDescription),
+		func(ctx context.Context, params ApplyPatchParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+			if strings.TrimSpace(params.Patch) == "" {
+				return fantasy.NewTextErrorResponse("patch is required"), nil
+			}
+			filePatches, err := parsePatch(params.Patch)
+			if err != nil {
+				return fantasy.NewTextErrorResponse(fmt.Sprintf("invalid patch: %s", err)), nil
+			}
+
+			// Every file is checked and every hunk applied in memory before
+			// anything is written.
+			changes := make([]patchChange, 0, len(filePatches))
+			touched := make(map[string]bool)
+			for _, filePatch := range filePatches {
+				change, err := preparePatchChange(ctx, filePatch, workingDir)
+				if err != nil {
+					var invalid invalidPatchError
+					if errors.As(err, &invalid) {
+						return fantasy.NewTextErrorResponse(err.Error()), nil
+					}
+					return fantasy.ToolResponse{}, err
+				}
+				for _, path := range []string{change.Path, change.MovePath} {
+					if path == "" {
+						continue
+					}
+					if touched[path] {
+						return fantasy.NewTextErrorResponse(fmt.Sprintf("the patch changes %s more than once, merge the changes to it", path)), nil
+					}
+					touched[path] = true
+				}
+				changes = append(changes, change)
+			}
+
+			sessionID := GetSessionFromContext(ctx)
+			if sessionID == "" {
+				return fantasy.ToolResponse{}, fmt.Errorf("session ID is required for applying a patch")
+			}
+
+			var (
+				patchFiles []ApplyPatchFile
+				targets    []string
+				additions  int
+				removals   int
+			)
+			permissionPath := workingDir
+			for _, change := range changes {
+				patchFiles = append(patchFiles, change.ApplyPatchFile)
+				additions += change.Additions
+				removals += change.Removals
+				for _, path := range []string{change.Path, change.MovePath} {
+					if path == "" {
+						continue
+					}
+					targets = append(targets, path)
+					if permissionPath == workingDir {
+						permissionPath = fsext.PathOrPrefix(path, workingDir)
+					}
+				}
+			}
+
+			description := fmt.Sprintf("Apply a patch to %d files", len(changes))
+			if len(changes) == 1 {
+				description = fmt.Sprintf("Apply a patch to %s", changes[0].Path)
+			}
+			permissionReq := permission.CreatePermissionRequest{
+				SessionID:   sessionID,
+				Path:        permissionPath,
+				ToolCallID:  call.ID,
+				ToolName:    ApplyPatchToolName,
+				Action:      "write",
+				Description: description,
+				Params:      ApplyPatchPermissionsParams{Files: patchFiles},
+				Targets:     targets,
+			}
+			if rule, denied := permissions.Denied(permissionReq); denied {
+				return NewRuleDeniedResponse(rule), nil
+			}
+			if !permissions.Request(permissionReq) {
+				return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
+			}
+
+			if err := writePatchChanges(ctx, changes); err != nil {
+				return fantasy.ToolResponse{}, err
+			}
+
+			var written []string
+			var summary strings.Builder
+			summary.WriteString("Applied the patch:")
+			for _, change := range changes {
+				switch {
+				case change.Kind == string(patchAdd):
+					fmt.Fprintf(&summary, "\nA %s", change.Path)
+				case change.Kind == string(patchDelete):
+					fmt.Fprintf(&summary, "\nD %s", change.Path)
+				case change.MovePath != "":
+					fmt.Fprintf(&summary, "\nR %s -> %s", change.Path, change.MovePath)
+				default:
+					fmt.Fprintf(&summary, "\nM %s", change.Path)
+				}
+				if err := recordPatchHistory(ctx, files, sessionID, change); err != nil {
+					return fantasy.ToolResponse{}, err
+				}
+				if path := change.writtenPath(); path != "" {
+					recordFileWrite(path)
+					recordFileRead(path)
+					written = append(written, path)
+				}
+			}
+
+			var formatting strings.Builder
+			for _, path := range written {
+				formatting.WriteString(formatOnWrite(ctx, lspClients, files, workingDir, path))
+				notifyLSPs(ctx, lspClients, path)
+			}
+			text := fmt.Sprintf("<result>\n%s\n</result>\n", summary.String())
+			text += formatting.String()
+			text += getFilesDiagnostics(lspClients, written...)
+
+			return fantasy.WithResponseMetadata(
+				fantasy.NewTextResponse(text),
+				ApplyPatchResponseMetadata{
+					Files:     patchFiles,
+					Additions: additions,
+					Removals:  removals,
+				},
+			), nil
+		})
+}
+
+// invalidPatchError is an error the model can fix in its patch.
+type invalidPatchError string
+
+func (e invalidPatchError) Error() string { return string(e) }
+
+func invalidPatch(format string, args ...any) error {
+	return invalidPatchError(fmt.Sprintf(format, args...))
+}
+
+// preparePatchChange checks a file patch against the file and applies its
+// hunks to the content of the file.
+func preparePatchChange(ctx context.Context, filePatch filePatch, workingDir string) (patchChange, error) {
+	change := patchChange{
+		ApplyPatchFile: ApplyPatchFile{
+			Path: filepathext.SmartJoin(workingDir, filePatch.Path),
+			Kind: string(filePatch.Op),
+		},
+	}
+	if filePatch.MovePath != "" {
+		change.MovePath = filepathext.SmartJoin(workingDir, filePatch.MovePath)
+		if change.MovePath == change.Path {
+			change.MovePath = ""
+		}
+	}
+
+	if filePatch.Op == patchAdd {
+		if _, err := os.Stat(change.Path); err == nil {
+			return change, invalidPatch("file already exists: %s, update it instead of adding it", change.Path)
+		} else if !os.IsNotExist(err) {
+			return change, fmt.Errorf("failed to access file: %w", err)
+		}
+		newContent, err := applyHunks("", filePatch.Hunks)
+		if err != nil {
+			return change, invalidPatch("%s: %s", change.Path, err)
+		}
+		change.NewContent = newContent
+		_, change.Additions, change.Removals = diff.GenerateDiff("", newContent, strings.TrimPrefix(change.Path, workingDir))
+		return change, nil
+	}
+
+	fileInfo, err := os.Stat(change.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return change, invalidPatch("file not found: %s", change.Path)
+		}
+		return change, fmt.Errorf("failed to access file: %w", err)
+	}
+	if fileInfo.IsDir() {
+		return change, invalidPatch("path is a directory, not a file: %s", change.Path)
+	}
+	lastRead := getLastReadTime(change.Path)
+	if lastRead.IsZero() {
+		return change, invalidPatch("you must read %s before patching it. Use the View tool first", change.Path)
+	}
+	if modTime := fileInfo.ModTime(); modTime.After(lastRead) {
+		return change, invalidPatch("file %s has been modified since it was last read (mod time: %s, last read: %s)",
+			change.Path, modTime.Format(time.RFC3339), lastRead.Format(time.RFC3339))
+	}
+	if change.MovePath != "" {
+		if _, err := os.Stat(change.MovePath); err == nil {
+			return change, invalidPatch("cannot move %s to %s, the file already exists", change.Path, change.MovePath)
+		}
+	}
+
+	change.original, err = readFile(ctx, change.Path)
+	if err != nil {
+		return change, fmt.Errorf("failed to read file: %w", err)
+	}
+	change.OldContent, change.isCrlf = fsext.ToUnixLineEndings(string(change.original))
+	if filePatch.Op == patchUpdate {
+		change.NewContent, err = applyHunks(change.OldContent, filePatch.Hunks)
+		if err != nil {
+			return change, invalidPatch("%s: %s", change.Path, err)
+		}
+	}
+	_, change.Additions, change.Removals = diff.GenerateDiff(change.OldContent, change.NewContent, strings.TrimPrefix(change.Path, workingDir))
+	return change, nil
+}
+
+// writtenPath returns the path the change writes to, if any.
+func (c patchChange) writtenPath() string {
+	switch {
+	case c.Kind == string(patchDelete):
+		return ""
+	case c.MovePath != "":
+		return c.MovePath
+	default:
+		return c.Path
+	}
+}
+
+// writePatchChanges writes all the changes, or none: when one fails, those
+// already written are undone.
+func writePatchChanges(ctx context.Context, changes []patchChange) error {
+	for i, change := range changes {
+		if err := change.write(ctx); err != nil {
+			for j := i; j >= 0; j-- {
+				if undoErr := changes[j].undo(ctx); undoErr != nil {
+					slog.Error("Failed to undo patch change", "path", changes[j].Path, "error", undoErr)
+				}
+			}
+			return fmt.Errorf("failed to apply the patch to %s, no file was changed: %w", change.Path, err)
+		}
+	}
+	return nil
+}
+
+func (c patchChange) write(ctx context.Context) error {
+	if c.Kind == string(patchDelete) {
+		return os.Remove(c.Path)
+	}
+	content := c.NewContent
+	if c.isCrlf {
+		content, _ = fsext.ToWindowsLineEndings(content)
+	}
+	path := c.writtenPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create parent directories: %w", err)
+	}
+	if err := writeFile(ctx, path, []byte(content)); err != nil {
+		return err
+	}
+	if c.MovePath != "" {
+		return os.Remove(c.Path)
+	}
+	return nil
+}
+
+func (c patchChange) undo(ctx context.Context) error {
+	var errs []error
+	if c.Kind != string(patchAdd) {
+		errs = append(errs, writeFile(ctx, c.Path, c.original))
+	}
+	if c.Kind == string(patchAdd) || c.MovePath != "" {
+		if err := os.Remove(c.writtenPath()); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// recordPatchHistory stores the versions of the files a change touched.
+// Deleted files, and the old path of moved ones, end with an empty version.
+func recordPatchHistory(ctx context.Context, files history.Service, sessionID string, change patchChange) error {
+	switch {
+	case change.Kind == string(patchAdd):
+		return recordFileVersions(ctx, files, sessionID, change.Path, "", change.NewContent)
+	case change.Kind == string(patchDelete):
+		return recordFileVersions(ctx, files, sessionID, change.Path, change.OldContent, "")
+	case change.MovePath != "":
+		if err := recordFileVersions(ctx, files, sessionID, change.Path, change.OldContent, ""); err != nil {
+			return err
+		}
+		return recordFileVersions(ctx, files, sessionID, change.MovePath, "", change.NewContent)
+	default:
+		return recordFileVersions(ctx, files, sessionID, change.Path, change.OldContent, change.NewContent)
+	}
+}
+
+func recordFileVersions(ctx context.Context, files history.Service, sessionID, path, oldContent, newContent string) error {
+	// Check if file exists in history
+	file, err := files.GetByPathAndSession(ctx, path, sessionID)
+	if err != nil {
+		_, err = files.Create(ctx, sessionID, path, oldContent)
+		if err != nil {
+			return fmt.Errorf("error creating file history: %w", err)
+		}
+	}
+	if file.Content != oldContent {
+		// User manually changed the content, store an intermediate version
+		_, err = files.CreateVersion(ctx, sessionID, path, oldContent)
+		if err != nil {
+			slog.Error("Error creating file history version", "error", err)
+		}
+	}
+	// Store the new version
+	_, err = files.CreateVersion(ctx, sessionID, path, newContent)
+	if err != nil {
+		slog.Error("Error creating file history version", "error", err)
+	}
+	return nil
+}
+
+// PatchPaths returns the paths of the files a patch changes, as written in
+// the patch, or nothing when it can't be parsed.
+func PatchPaths(patch string) []string {
+	files, err := parsePatch(patch)
+	if err != nil {
+		return nil
+	}
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	return paths
+}
@@ -0,0 +1,244 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/lsp"
+	"github.com/charmbracelet/crush/internal/permission"
+	"github.com/charmbracelet/crush/internal/pubsub"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingPermissionService struct {
+	mockPermissionService
+	requests []permission.CreatePermissionRequest
+}
+
+func (m *recordingPermissionService) Request(req permission.CreatePermissionRequest) bool {
+	m.requests = append(m.requests, req)
+	return true
+}
+
+// pathVersionsHistoryService records the versions of each file.
+type pathVersionsHistoryService struct {
+	mockHistoryService
+	versions map[string][]string
+}
+
+func (m *pathVersionsHistoryService) CreateVersion(ctx context.Context, sessionID, path, content string) (history.File, error) {
+	m.versions[path] = append(m.versions[path], content)
+	return history.File{Path: path, Content: content}, nil
+}
+
+// writeReadFiles writes files in a new directory, as read by the agent.
+func writeReadFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		recordFileRead(path)
+	}
+	return dir
+}
+
+func runApplyPatchTool(t *testing.T, workingDir string, permissions permission.Service, files history.Service, patch string) fantasy.ToolResponse {
+	t.Helper()
+	if permissions == nil {
+		permissions = &mockPermissionService{Broker: pubsub.NewBroker[permission.PermissionRequest]()}
+	}
+	if files == nil {
+		files = &mockHistoryService{Broker: pubsub.NewBroker[history.File]()}
+	}
+	tool := NewApplyPatchTool(csync.NewMap[string, *lsp.Client](), permissions, files, workingDir)
+	input, err := json.Marshal(ApplyPatchParams{Patch: patch})
+	require.NoError(t, err)
+	ctx := context.WithValue(t.Context(), SessionIDContextKey, "session")
+	resp, err := tool.Run(ctx, fantasy.ToolCall{ID: "call", Name: ApplyPatchToolName, Input: string(input)})
+	require.NoError(t, err)
+	return resp
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestApplyPatchTool(t *testing.T) {
+	t.Parallel()
+
+	dir := writeReadFiles(t, map[string]string{
+		"main.go":   "package main\n\nfunc main() {\n\trun()\n}\n",
+		"old.go":    "package main\n\nconst name = \"old\"\n",
+		"unused.go": "package main\n",
+		"win.txt":   "one\r\ntwo\r\n",
+	})
+	permissions := &recordingPermissionService{mockPermissionService: mockPermissionService{Broker: pubsub.NewBroker[permission.PermissionRequest]()}}
+	files := &pathVersionsHistoryService{
+		mockHistoryService: mockHistoryService{Broker: pubsub.NewBroker[history.File]()},
+		versions:           make(map[string][]string),
+	}
+	resp := runApplyPatchTool(t, dir, permissions, files, `*** Begin Patch
+*** Add File: docs/notes.md
++# Notes
+*** Update File: main.go
+@@ func main() {
+-	run()
++	if err := run(); err != nil {
++		panic(err)
++	}
+*** Update File: old.go
+*** Move to: new.go
+@@
+-const name = "old"
++const name = "new"
+*** Delete File: unused.go
+*** Update File: win.txt
+ one
+-two
++2
+*** End Patch`)
+	require.False(t, resp.IsError, resp.Content)
+	require.Contains(t, resp.Content, "A "+filepath.Join(dir, "docs/notes.md"))
+	require.Contains(t, resp.Content, "R "+filepath.Join(dir, "old.go")+" -> "+filepath.Join(dir, "new.go"))
+
+	require.Equal(t, "# Notes\n", readTestFile(t, filepath.Join(dir, "docs/notes.md")))
+	require.Equal(t, "package main\n\nfunc main() {\n\tif err := run(); err != nil {\n\t\tpanic(err)\n\t}\n}\n", readTestFile(t, filepath.Join(dir, "main.go")))
+	require.Equal(t, "package main\n\nconst name = \"new\"\n", readTestFile(t, filepath.Join(dir, "new.go")))
+	require.NoFileExists(t, filepath.Join(dir, "old.go"))
+	require.NoFileExists(t, filepath.Join(dir, "unused.go"))
+	require.Equal(t, "one\r\n2\r\n", readTestFile(t, filepath.Join(dir, "win.txt")))
+
+	// A single permission is asked for all the files.
+	require.Len(t, permissions.requests, 1)
+	params := permissions.requests[0].Params.(ApplyPatchPermissionsParams)
+	require.Len(t, params.Files, 5)
+	require.Len(t, permissions.requests[0].Targets, 6)
+
+	require.Equal(t, []string{"# Notes\n"}, files.versions[filepath.Join(dir, "docs/notes.md")])
+	require.Equal(t, []string{"package main\n", ""}, files.versions[filepath.Join(dir, "unused.go")])
+	require.Equal(t, []string{"package main\n\nconst name = \"old\"\n", ""}, files.versions[filepath.Join(dir, "old.go")])
+	require.Equal(t, []string{"package main\n\nconst name = \"new\"\n"}, files.versions[filepath.Join(dir, "new.go")])
+	require.Len(t, files.versions[filepath.Join(dir, "main.go")], 2)
+
+	var meta ApplyPatchResponseMetadata
+	require.NoError(t, json.Unmarshal([]byte(resp.Metadata), &meta))
+	require.Len(t, meta.Files, 5)
+	require.Equal(t, 6, meta.Additions)
+	require.Equal(t, 4, meta.Removals)
+
+	// The files written can be patched again right away.
+	resp = runApplyPatchTool(t, dir, nil, nil, "--- a/new.go\n+++ b/new.go\n@@ -3 +3 @@\n-const name = \"new\"\n+const name = \"newer\"\n")
+	require.False(t, resp.IsError, resp.Content)
+	require.Equal(t, "package main\n\nconst name = \"newer\"\n", readTestFile(t, filepath.Join(dir, "new.go")))
+}
+
+func TestApplyPatchTool_NothingWrittenWhenAHunkFails(t *testing.T) {
+	t.Parallel()
+
+	dir := writeReadFiles(t, map[string]string{
+		"a.txt": "a\n",
+		"b.txt": "b\n",
+	})
+	resp := runApplyPatchTool(t, dir, nil, nil, `*** Begin Patch
+*** Update File: a.txt
+-a
++A
+*** Add File: c.txt
++c
+*** Update File: b.txt
+-x
++X
+*** End Patch`)
+	require.True(t, resp.IsError)
+	require.Contains(t, resp.Content, "b.txt: hunk 1 does not apply, could not find these lines:\nx")
+	require.Equal(t, "a\n", readTestFile(t, filepath.Join(dir, "a.txt")))
+	require.NoFileExists(t, filepath.Join(dir, "c.txt"))
+}
+
+func TestApplyPatchTool_RollbackWhenAWriteFails(t *testing.T) {
+	t.Parallel()
+
+	dir := writeReadFiles(t, map[string]string{
+		"a.txt":  "a\n",
+		"b.txt":  "b\n",
+		"c.txt":  "c\n",
+		"a-file": "not a directory\n",
+	})
+	permissions := &mockPermissionService{Broker: pubsub.NewBroker[permission.PermissionRequest]()}
+	tool := NewApplyPatchTool(csync.NewMap[string, *lsp.Client](), permissions, &mockHistoryService{Broker: pubsub.NewBroker[history.File]()}, dir)
+	input, err := json.Marshal(ApplyPatchParams{Patch: `*** Begin Patch
+*** Update File: a.txt
+-a
++A
+*** Delete File: b.txt
+*** Update File: c.txt
+*** Move to: a-file/c.txt
+*** End Patch`})
+	require.NoError(t, err)
+	ctx := context.WithValue(t.Context(), SessionIDContextKey, "session")
+	_, err = tool.Run(ctx, fantasy.ToolCall{ID: "call", Name: ApplyPatchToolName, Input: string(input)})
+	require.ErrorContains(t, err, "no file was changed")
+
+	require.Equal(t, "a\n", readTestFile(t, filepath.Join(dir, "a.txt")))
+	require.Equal(t, "b\n", readTestFile(t, filepath.Join(dir, "b.txt")))
+	require.Equal(t, "c\n", readTestFile(t, filepath.Join(dir, "c.txt")))
+}
+
+func TestApplyPatchTool_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := writeReadFiles(t, map[string]string{
+		"a.txt": "a\n",
+		"b.txt": "b\n",
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "unread.txt"), []byte("u\n"), 0o644))
+
+	for name, tc := range map[string]struct {
+		patch string
+		want  string
+	}{
+		"invalid": {
+			patch: "*** Begin Patch\n*** Add File: c.txt\nc\n*** End Patch",
+			want:  "invalid patch: invalid line in added file c.txt",
+		},
+		"unread": {
+			patch: "*** Begin Patch\n*** Update File: unread.txt\n-u\n+U\n*** End Patch",
+			want:  "you must read " + filepath.Join(dir, "unread.txt") + " before patching it",
+		},
+		"missing": {
+			patch: "*** Begin Patch\n*** Delete File: missing.txt\n*** End Patch",
+			want:  "file not found",
+		},
+		"add existing": {
+			patch: "*** Begin Patch\n*** Add File: a.txt\n+a\n*** End Patch",
+			want:  "file already exists",
+		},
+		"move onto existing": {
+			patch: "*** Begin Patch\n*** Update File: a.txt\n*** Move to: b.txt\n*** End Patch",
+			want:  "the file already exists",
+		},
+		"same file twice": {
+			patch: "*** Begin Patch\n*** Update File: a.txt\n-a\n+A\n*** Delete File: a.txt\n*** End Patch",
+			want:  "more than once",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			resp := runApplyPatchTool(t, dir, nil, nil, tc.patch)
+			require.True(t, resp.IsError)
+			require.Contains(t, resp.Content, tc.want)
+		})
+	}
+	require.Equal(t, "a\n", readTestFile(t, filepath.Join(dir, "a.txt")))
+}
@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ArtifactMetadata is the response metadata of tools whose output was too
+// long and got written to an artifact file.
+type ArtifactMetadata struct {
+	ArtifactPath string `json:"artifact_path,omitempty"`
+}
+
+// ArtifactsDir returns the directory holding the artifacts of a session.
+func ArtifactsDir(dataDir, sessionID string) string {
+	return filepath.Join(dataDir, "artifacts", sessionID)
+}
+
+// RemoveArtifacts removes the artifacts of a session.
+func RemoveArtifacts(dataDir, sessionID string) error {
+	if sessionID == "" {
+		return nil
+	}
+	return os.RemoveAll(ArtifactsDir(dataDir, sessionID))
+}
+
+// spillOutput returns content as is when it is at most limit bytes long.
+// Longer content is written in full to an artifact file of the session and
+// only its head and tail are returned, along with the path of the file so
+// the model can page through the rest with the view or grep tools. The path
+// is empty when nothing was written.
+func spillOutput(ctx context.Context, dataDir, callID, content string, limit int) (string, string) {
+	if len(content) <= limit {
+		return content, ""
+	}
+
+	var path string
+	if dataDir != "" {
+		dir := ArtifactsDir(dataDir, GetRootSessionFromContext(ctx))
+		name := strings.NewReplacer("/", "_", "\\", "_").Replace(callID)
+		if name == "" {
+			name = "output"
+		}
+		path = filepath.Join(dir, name+".txt")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			slog.Error("Failed to create artifacts directory", "error", err)
+			path = ""
+		} else if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			slog.Error("Failed to write artifact", "path", path, "error", err)
+			path = ""
+		}
+	}
+
+	half := limit / 2
+	start := content[:half]
+	end := content[len(content)-half:]
+	truncated := countLines(content[half : len(content)-half])
+	if path == "" {
+		return fmt.Sprintf("%s\n\n... [%d lines truncated] ...\n\n%s", start, truncated, end), ""
+	}
+	return fmt.Sprintf(
+		"%s\n\n... [%d lines truncated, the full output (%d lines) is saved in %s, use the view or grep tools to read it] ...\n\n%s",
+		start, truncated, countLines(content), path, end,
+	), path
+}
@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpillOutput(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	ctx := context.WithValue(t.Context(), SessionIDContextKey, "sub-session")
+	ctx = context.WithValue(ctx, RootSessionIDContextKey, "session")
+
+	t.Run("short output", func(t *testing.T) {
+		out, path := spillOutput(ctx, dataDir, "call-1", "hello", 100)
+		require.Equal(t, "hello", out)
+		require.Empty(t, path)
+		require.NoDirExists(t, ArtifactsDir(dataDir, "session"))
+	})
+
+	t.Run("long output", func(t *testing.T) {
+		var lines []string
+		for i := range 100 {
+			lines = append(lines, strings.Repeat("x", 9)+string(rune('a'+i%26)))
+		}
+		content := "HEAD\n" + strings.Join(lines, "\n") + "\nTAIL"
+
+		out, path := spillOutput(ctx, dataDir, "call-2", content, 200)
+		require.Equal(t, filepath.Join(dataDir, "artifacts", "session", "call-2.txt"), path)
+		require.True(t, strings.HasPrefix(out, "HEAD\n"))
+		require.True(t, strings.HasSuffix(out, "\nTAIL"))
+		require.Contains(t, out, path)
+		require.Less(t, len(out), len(content))
+
+		saved, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, content, string(saved))
+
+		require.NoError(t, RemoveArtifacts(dataDir, "session"))
+		require.NoFileExists(t, path)
+	})
+
+	t.Run("without data directory", func(t *testing.T) {
+		out, path := spillOutput(ctx, "", "call-3", strings.Repeat("line\n", 100), 100)
+		require.Empty(t, path)
+		require.Contains(t, out, "lines truncated")
+	})
+}
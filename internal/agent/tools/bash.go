@@ -24,6 +24,9 @@ type BashParams struct {
 	Command         string `json:"command" description:"The command to execute"`
 	WorkingDir      string `json:"working_dir,omitempty" description:"The working directory to execute the command in (defaults to current directory)"`
 	RunInBackground bool   `json:"run_in_background,omitempty" description:"Set to true (boolean) to run this command in the background. Use job_output to read the output later."`
+	PTY             bool   `json:"pty,omitempty" description:"Set to true (boolean) to run this command in a pseudo-terminal, for commands that need a terminal or prompt for input. Use job_input to answer the prompts."`
+	PTYRows         int    `json:"pty_rows,omitempty" description:"The number of rows of the pseudo-terminal (defaults to 24)"`
+	PTYCols         int    `json:"pty_cols,omitempty" description:"The number of columns of the pseudo-terminal (defaults to 80)"`
 }
 
 type BashPermissionsParams struct {
@@ -31,6 +34,9 @@ type BashPermissionsParams struct {
 	Command         string `json:"command"`
 	WorkingDir      string `json:"working_dir"`
 	RunInBackground bool   `json:"run_in_background"`
+	PTY             bool   `json:"pty"`
+	PTYRows         int    `json:"pty_rows"`
+	PTYCols         int    `json:"pty_cols"`
 }
 
 type BashResponseMetadata struct {
@@ -40,13 +46,17 @@ type BashResponseMetadata struct {
 	Description      string `json:"description"`
 	WorkingDirectory string `json:"working_directory"`
 	Background       bool   `json:"background,omitempty"`
+	PTY              bool   `json:"pty,omitempty"`
 	ShellID          string `json:"shell_id,omitempty"`
+	ArtifactPath     string `json:"artifact_path,omitempty"`
 }
 
 const (
 	BashToolName = "bash"
 
 	AutoBackgroundThreshold = 1 * time.Minute // Commands taking longer automatically become background jobs
+	PTYIdleThreshold        = 3 * time.Second // Commands in a pseudo-terminal without output for longer are likely waiting for input
+	MaxPTYSize              = 1000
 	MaxOutputLength         = 30000
 	BashNoOutput            = "no output"
 )
@@ -187,7 +197,7 @@ func blockFuncs() []shell.BlockFunc {
 	}
 }
 
-func NewBashTool(permissions permission.Service, workingDir string, attribution *config.Attribution, modelName string) fantasy.AgentTool {
+func NewBashTool(permissions permission.Service, workingDir, dataDir string, attribution *config.Attribution, modelName string) fantasy.AgentTool {
 	return fantasy.NewAgentTool(
 		BashToolName,
 		string(bashDescription(attribution, modelName)),
@@ -199,37 +209,26 @@ func NewBashTool(permissions permission.Service, workingDir string, attribution
 			// Determine working directory
 			execWorkingDir := cmp.Or(params.WorkingDir, workingDir)
 
-			isSafeReadOnly := false
-			cmdLower := strings.ToLower(params.Command)
-
-			for _, safe := range safeCommands {
-				if strings.HasPrefix(cmdLower, safe) {
-					if len(cmdLower) == len(safe) || cmdLower[len(safe)] == ' ' || cmdLower[len(safe)] == '-' {
-						isSafeReadOnly = true
-						break
-					}
-				}
-			}
-
 			sessionID := GetSessionFromContext(ctx)
 			if sessionID == "" {
 				return fantasy.ToolResponse{}, fmt.Errorf("session ID is required for executing shell command")
 			}
-			if !isSafeReadOnly {
-				p := permissions.Request(
-					permission.CreatePermissionRequest{
-						SessionID:   sessionID,
-						Path:        execWorkingDir,
-						ToolCallID:  call.ID,
-						ToolName:    BashToolName,
-						Action:      "execute",
-						Description: fmt.Sprintf("Execute command: %s", params.Command),
-						Params:      BashPermissionsParams(params),
-					},
-				)
-				if !p {
-					return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
-				}
+			permissionReq := permission.CreatePermissionRequest{
+				SessionID:   sessionID,
+				Path:        execWorkingDir,
+				ToolCallID:  call.ID,
+				ToolName:    BashToolName,
+				Action:      "execute",
+				Description: fmt.Sprintf("Execute command: %s", params.Command),
+				Params:      BashPermissionsParams(params),
+				Targets:     shellCommands(params.Command),
+				ReadOnly:    isSafeReadOnly(params.Command),
+			}
+			if rule, denied := permissions.Denied(permissionReq); denied {
+				return NewRuleDeniedResponse(rule), nil
+			}
+			if !permissions.Request(permissionReq) {
+				return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
 			}
 
 			// If explicitly requested as background, start immediately with detached context
@@ -238,7 +237,7 @@ func NewBashTool(permissions permission.Service, workingDir string, attribution
 				bgManager := shell.GetBackgroundShellManager()
 				bgManager.Cleanup()
 				// Use background context so it continues after tool returns
-				bgShell, err := bgManager.Start(context.Background(), execWorkingDir, blockFuncs(), params.Command, params.Description)
+				bgShell, err := startShell(context.Background(), GetRootSessionFromContext(ctx), execWorkingDir, params)
 				if err != nil {
 					return fantasy.ToolResponse{}, fmt.Errorf("error starting background shell: %w", err)
 				}
@@ -257,7 +256,7 @@ func NewBashTool(permissions permission.Service, workingDir string, attribution
 						return fantasy.ToolResponse{}, fmt.Errorf("[Job %s] error executing command: %w", bgShell.ID, execErr)
 					}
 
-					stdout = formatOutput(stdout, stderr, execErr)
+					stdout, artifactPath := spillOutput(ctx, dataDir, call.ID, formatOutput(stdout, stderr, execErr), MaxOutputLength)
 
 					metadata := BashResponseMetadata{
 						StartTime:        startTime.UnixMilli(),
@@ -266,6 +265,7 @@ func NewBashTool(permissions permission.Service, workingDir string, attribution
 						Description:      params.Description,
 						Background:       params.RunInBackground,
 						WorkingDirectory: bgShell.WorkingDir,
+						ArtifactPath:     artifactPath,
 					}
 					if stdout == "" {
 						return fantasy.WithResponseMetadata(fantasy.NewTextResponse(BashNoOutput), metadata), nil
@@ -275,15 +275,17 @@ func NewBashTool(permissions permission.Service, workingDir string, attribution
 				}
 
 				// Still running after fast-failure check - return as background job
+				bgManager.Background(bgShell)
 				metadata := BashResponseMetadata{
 					StartTime:        startTime.UnixMilli(),
 					EndTime:          time.Now().UnixMilli(),
 					Description:      params.Description,
 					WorkingDirectory: bgShell.WorkingDir,
 					Background:       true,
+					PTY:              bgShell.PTY,
 					ShellID:          bgShell.ID,
 				}
-				response := fmt.Sprintf("Background shell started with ID: %s\n\nUse job_output tool to view output or job_kill to terminate.", bgShell.ID)
+				response := fmt.Sprintf("Background shell started with ID: %s\n\n%s", bgShell.ID, jobToolsHint(bgShell))
 				return fantasy.WithResponseMetadata(fantasy.NewTextResponse(response), metadata), nil
 			}
 
@@ -293,7 +295,7 @@ func NewBashTool(permissions permission.Service, workingDir string, attribution
 			// Start with detached context so it can survive if moved to background
 			bgManager := shell.GetBackgroundShellManager()
 			bgManager.Cleanup()
-			bgShell, err := bgManager.Start(context.Background(), execWorkingDir, blockFuncs(), params.Command, params.Description)
+			bgShell, err := startShell(context.Background(), GetRootSessionFromContext(ctx), execWorkingDir, params)
 			if err != nil {
 				return fantasy.ToolResponse{}, fmt.Errorf("error starting shell: %w", err)
 			}
@@ -306,15 +308,26 @@ func NewBashTool(permissions permission.Service, workingDir string, attribution
 			var stdout, stderr string
 			var done bool
 			var execErr error
+			// A command in a pseudo-terminal going quiet is likely prompting
+			// for input, which is answered with job_input.
+			var waitingInput bool
+			lastOutput := time.Now()
 
 		waitLoop:
 			for {
 				select {
 				case <-ticker.C:
+					previous := stdout
 					stdout, stderr, done, execErr = bgShell.GetOutput()
 					if done {
 						break waitLoop
 					}
+					if stdout != previous {
+						lastOutput = time.Now()
+					} else if bgShell.PTY && time.Since(lastOutput) >= PTYIdleThreshold {
+						waitingInput = true
+						break waitLoop
+					}
 				case <-timeout:
 					stdout, stderr, done, execErr = bgShell.GetOutput()
 					break waitLoop
@@ -338,7 +351,7 @@ func NewBashTool(permissions permission.Service, workingDir string, attribution
 					return fantasy.ToolResponse{}, fmt.Errorf("[Job %s] error executing command: %w", bgShell.ID, execErr)
 				}
 
-				stdout = formatOutput(stdout, stderr, execErr)
+				stdout, artifactPath := spillOutput(ctx, dataDir, call.ID, formatOutput(stdout, stderr, execErr), MaxOutputLength)
 
 				metadata := BashResponseMetadata{
 					StartTime:        startTime.UnixMilli(),
@@ -347,6 +360,7 @@ func NewBashTool(permissions permission.Service, workingDir string, attribution
 					Description:      params.Description,
 					Background:       params.RunInBackground,
 					WorkingDirectory: bgShell.WorkingDir,
+					ArtifactPath:     artifactPath,
 				}
 				if stdout == "" {
 					return fantasy.WithResponseMetadata(fantasy.NewTextResponse(BashNoOutput), metadata), nil
@@ -356,27 +370,57 @@ func NewBashTool(permissions permission.Service, workingDir string, attribution
 			}
 
 			// Still running - keep as background job
+			bgManager.Background(bgShell)
 			metadata := BashResponseMetadata{
 				StartTime:        startTime.UnixMilli(),
 				EndTime:          time.Now().UnixMilli(),
 				Description:      params.Description,
 				WorkingDirectory: bgShell.WorkingDir,
 				Background:       true,
+				PTY:              bgShell.PTY,
 				ShellID:          bgShell.ID,
 			}
-			response := fmt.Sprintf("Command is taking longer than expected and has been moved to background.\n\nBackground shell ID: %s\n\nUse job_output tool to view output or job_kill to terminate.", bgShell.ID)
+			if waitingInput {
+				output, artifactPath := spillOutput(ctx, dataDir, call.ID, stdout, MaxOutputLength)
+				metadata.ArtifactPath = artifactPath
+				response := fmt.Sprintf("Command is waiting for input and has been moved to background.\n\nBackground shell ID: %s\n\nOutput so far:\n%s\n\n%s", bgShell.ID, output, jobToolsHint(bgShell))
+				return fantasy.WithResponseMetadata(fantasy.NewTextResponse(response), metadata), nil
+			}
+			response := fmt.Sprintf("Command is taking longer than expected and has been moved to background.\n\nBackground shell ID: %s\n\n%s", bgShell.ID, jobToolsHint(bgShell))
 			return fantasy.WithResponseMetadata(fantasy.NewTextResponse(response), metadata), nil
 		})
 }
 
+// startShell starts the shell running the command, in a pseudo-terminal when
+// asked to.
+func startShell(ctx context.Context, sessionID, workingDir string, params BashParams) (*shell.BackgroundShell, error) {
+	bgManager := shell.GetBackgroundShellManager()
+	if !params.PTY {
+		return bgManager.Start(ctx, sessionID, workingDir, blockFuncs(), params.Command, params.Description)
+	}
+	size := shell.DefaultPTYSize
+	if params.PTYRows > 0 {
+		size.Rows = uint16(min(params.PTYRows, MaxPTYSize))
+	}
+	if params.PTYCols > 0 {
+		size.Cols = uint16(min(params.PTYCols, MaxPTYSize))
+	}
+	return bgManager.StartPTY(ctx, sessionID, workingDir, blockFuncs(), params.Command, params.Description, size)
+}
+
+// jobToolsHint tells which tools to use with a shell moved to background.
+func jobToolsHint(bgShell *shell.BackgroundShell) string {
+	if bgShell.PTY {
+		return "Use job_input tool to send input, job_output tool to view output or job_kill to terminate."
+	}
+	return "Use job_output tool to view output or job_kill to terminate."
+}
+
 // formatOutput formats the output of a completed command with error handling
 func formatOutput(stdout, stderr string, execErr error) string {
 	interrupted := shell.IsInterrupt(execErr)
 	exitCode := shell.ExitCode(execErr)
 
-	stdout = truncateOutput(stdout)
-	stderr = truncateOutput(stderr)
-
 	errorMessage := stderr
 	if errorMessage == "" && execErr != nil {
 		errorMessage = execErr.Error()
@@ -407,19 +451,6 @@ func formatOutput(stdout, stderr string, execErr error) string {
 	return stdout
 }
 
-func truncateOutput(content string) string {
-	if len(content) <= MaxOutputLength {
-		return content
-	}
-
-	halfLength := MaxOutputLength / 2
-	start := content[:halfLength]
-	end := content[len(content)-halfLength:]
-
-	truncatedLinesCount := countLines(content[halfLength : len(content)-halfLength])
-	return fmt.Sprintf("%s\n\n... [%d lines truncated] ...\n\n%s", start, truncatedLinesCount, end)
-}
-
 func countLines(s string) int {
 	if s == "" {
 		return 0
@@ -11,7 +11,7 @@ Common shell builtins and core utils available on Windows.
 2. Security Check: Banned commands ({{ .BannedCommands }}) return error - explain to user. Safe read-only commands execute without prompts
 3. Command Execution: Execute with proper quoting, capture output
 4. Auto-Background: Commands exceeding 1 minute automatically move to background and return shell ID
-5. Output Processing: Truncate if exceeds {{ .MaxOutputLength }} characters
+5. Output Processing: Outputs over {{ .MaxOutputLength }} characters are saved to a file, only their head and tail are returned along with the file path; use View (with offset) or Grep on that file to read the rest
 6. Return Result: Include errors, metadata with <cwd></cwd> tags
 </execution_steps>
 
@@ -42,6 +42,15 @@ Common shell builtins and core utils available on Windows.
   * Short-lived scripts
 </background_execution>
 
+<pty_execution>
+- Set pty=true to run commands that need a terminal in a pseudo-terminal (e.g., `npm init`, `git rebase -i`, test runners behaving differently without a TTY)
+- The size defaults to 80 columns by 24 rows, set pty_cols and pty_rows to change it
+- Terminal control sequences are stripped from the output, stdout and stderr are merged
+- A command waiting for input is moved to background after a few seconds without output, with the output so far
+- Use job_input tool to answer prompts, with enter=true to press Enter
+- Prefer non-interactive flags (e.g., `npm init -y`, `GIT_EDITOR=true`) when available
+</pty_execution>
+
 <git_commits>
 When user asks to create git commit:
 
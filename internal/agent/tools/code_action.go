@@ -0,0 +1,274 @@
+package tools
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/charmbracelet/crush/internal/filepathext"
+	"github.com/charmbracelet/crush/internal/fsext"
+	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/lsp"
+	"github.com/charmbracelet/crush/internal/permission"
+	"github.com/charmbracelet/x/powernap/pkg/lsp/protocol"
+)
+
+type CodeActionParams struct {
+	FilePath string `json:"file_path" description:"The path to the file to get code actions for"`
+	Line     int    `json:"line" description:"The first line of the range to get code actions for (1-based)"`
+	EndLine  int    `json:"end_line,omitempty" description:"The last line of the range (1-based), defaults to line"`
+	Action   int    `json:"action,omitempty" description:"The number of the code action to apply, as listed by a call without it. Omit it to list the code actions"`
+}
+
+type CodeActionPermissionsParams struct {
+	Title string           `json:"title"`
+	Files []ApplyPatchFile `json:"files"`
+}
+
+type CodeActionResponseMetadata struct {
+	// Actions are the titles of the code actions listed, when none was
+	// applied.
+	Actions   []string         `json:"actions,omitempty"`
+	Title     string           `json:"title,omitempty"`
+	Files     []ApplyPatchFile `json:"files,omitempty"`
+	Additions int              `json:"additions"`
+	Removals  int              `json:"removals"`
+}
+
+const CodeActionToolName = "lsp_code_action"
+
+//go:embed code_action.md
+var codeActionDescription []byte
+
+func NewCodeActionTool(lspClients *csync.Map[string, *lsp.Client], permissions permission.Service, files history.Service, workingDir string) fantasy.AgentTool {
+	return fantasy.NewAgentTool(
+		CodeActionToolName,
+		string(codeActionDescription),
+		func(ctx context.Context, params CodeActionParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+			switch {
+			case params.FilePath == "":
+				return fantasy.NewTextErrorResponse("file_path is required"), nil
+			case params.Line < 1:
+				return fantasy.NewTextErrorResponse("line is required and starts at 1"), nil
+			case params.Action < 0:
+				return fantasy.NewTextErrorResponse("action starts at 1"), nil
+			}
+			if lspClients.Len() == 0 {
+				return fantasy.NewTextErrorResponse("no LSP clients available"), nil
+			}
+			path := filepathext.SmartJoin(workingDir, params.FilePath)
+			client := lspClientFor(lspClients, path)
+			if client == nil {
+				return fantasy.NewTextErrorResponse(fmt.Sprintf("no LSP client handles %s", path)), nil
+			}
+			endLine := max(params.EndLine, params.Line)
+
+			diagnostics, actions, err := codeActionsFor(ctx, client, path, params.Line, endLine)
+			if err != nil {
+				return fantasy.NewTextErrorResponse(err.Error()), nil
+			}
+			if params.Action == 0 {
+				titles := make([]string, 0, len(actions))
+				for _, action := range actions {
+					titles = append(titles, action.Title)
+				}
+				return fantasy.WithResponseMetadata(
+					fantasy.NewTextResponse(formatCodeActions(path, params.Line, endLine, diagnostics, actions)),
+					CodeActionResponseMetadata{Actions: titles},
+				), nil
+			}
+			if params.Action > len(actions) {
+				return fantasy.NewTextErrorResponse(fmt.Sprintf("code action %d doesn't exist, %d were found. List them again without action", params.Action, len(actions))), nil
+			}
+
+			if getLastReadTime(path).IsZero() {
+				return fantasy.NewTextErrorResponse(fmt.Sprintf("you must read %s before applying a code action to it. Use the View tool first", path)), nil
+			}
+			sessionID := GetSessionFromContext(ctx)
+			if sessionID == "" {
+				return fantasy.ToolResponse{}, fmt.Errorf("session ID is required for applying a code action")
+			}
+
+			action, err := client.ResolveCodeAction(ctx, actions[params.Action-1])
+			if err != nil {
+				return fantasy.NewTextErrorResponse(fmt.Sprintf("failed to apply '%s': %s", actions[params.Action-1].Title, err)), nil
+			}
+			if action.Edit == nil {
+				if action.Command != nil {
+					return fantasy.NewTextErrorResponse(fmt.Sprintf("'%s' runs the %s command of the LSP server, which can't be applied as an edit", action.Title, action.Command.Command)), nil
+				}
+				return fantasy.NewTextErrorResponse(fmt.Sprintf("'%s' has no changes to apply", action.Title)), nil
+			}
+			changes, err := prepareWorkspaceEdit(ctx, *action.Edit, workingDir)
+			if err != nil {
+				return fantasy.NewTextErrorResponse(fmt.Sprintf("failed to apply '%s': %s", action.Title, err)), nil
+			}
+			if len(changes) == 0 {
+				return fantasy.NewTextResponse(fmt.Sprintf("Nothing to change for '%s'", action.Title)), nil
+			}
+
+			actionFiles, targets, additions, removals := summarizeWorkspaceEdit(changes)
+			permissionReq := permission.CreatePermissionRequest{
+				SessionID:   sessionID,
+				Path:        fsext.PathOrPrefix(path, workingDir),
+				ToolCallID:  call.ID,
+				ToolName:    CodeActionToolName,
+				Action:      "write",
+				Description: fmt.Sprintf("Apply code action '%s' to %s", action.Title, path),
+				Params: CodeActionPermissionsParams{
+					Title: action.Title,
+					Files: actionFiles,
+				},
+				Targets: targets,
+			}
+			if rule, denied := permissions.Denied(permissionReq); denied {
+				return NewRuleDeniedResponse(rule), nil
+			}
+			if !permissions.Request(permissionReq) {
+				return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
+			}
+
+			written, summary, err := applyWorkspaceEdit(ctx, files, sessionID, *action.Edit, changes)
+			if err != nil {
+				return fantasy.ToolResponse{}, fmt.Errorf("failed to apply code action '%s': %w", action.Title, err)
+			}
+
+			for _, path := range written {
+				notifyLSPs(ctx, lspClients, path)
+			}
+			text := fmt.Sprintf("<result>\nApplied '%s':\n%s\n</result>\n", action.Title, strings.Join(summary, "\n"))
+			if action.Command != nil {
+				text += fmt.Sprintf("\nThe %s command the code action also runs was skipped.\n", action.Command.Command)
+			}
+			text += getFilesDiagnostics(lspClients, written...)
+
+			return fantasy.WithResponseMetadata(
+				fantasy.NewTextResponse(text),
+				CodeActionResponseMetadata{
+					Title:     action.Title,
+					Files:     actionFiles,
+					Additions: additions,
+					Removals:  removals,
+				},
+			), nil
+		})
+}
+
+// codeActionsFor returns the diagnostics of a range of lines of a file, both
+// 1-based, and the code actions the server has for them, of the kinds set in
+// its configuration. The file is read through the editor when there is one,
+// and the server is told its content.
+func codeActionsFor(ctx context.Context, client *lsp.Client, path string, startLine, endLine int) ([]protocol.Diagnostic, []protocol.CodeAction, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("file not found: %s", path)
+	}
+	content, err := readFile(ctx, path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	lines := strings.Split(string(content), "\n")
+	if endLine > len(lines) {
+		return nil, nil, fmt.Errorf("line %d is out of range, %s has %d lines", endLine, path, len(lines))
+	}
+
+	// Diagnostics of a file that was just opened take a moment to come.
+	wasOpen := client.IsFileOpen(path)
+	if err := client.OpenFileOnDemand(ctx, path); err != nil {
+		return nil, nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	// The buffer of the editor can differ from the disk the server read.
+	if getEditorFSFromContext(ctx) != nil {
+		if err := client.NotifyContent(ctx, path, string(content)); err != nil {
+			return nil, nil, fmt.Errorf("failed to sync file: %w", err)
+		}
+		wasOpen = false
+	}
+	if !wasOpen {
+		client.WaitForDiagnostics(ctx, 5*time.Second)
+	}
+
+	rng := protocol.Range{
+		Start: protocol.Position{Line: uint32(startLine - 1)},
+		End: protocol.Position{
+			Line:      uint32(endLine - 1),
+			Character: uint32(len(utf16.Encode([]rune(strings.TrimSuffix(lines[endLine-1], "\r"))))),
+		},
+	}
+	diagnostics := overlappingDiagnostics(client.GetFileDiagnostics(protocol.URIFromPath(path)), rng)
+	actions, err := client.CodeActions(ctx, path, rng, diagnostics, client.GetConfig().CodeActionKinds)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get code actions: %w", err)
+	}
+	return diagnostics, enabledCodeActions(actions), nil
+}
+
+// overlappingDiagnostics returns the diagnostics on the lines of a range.
+func overlappingDiagnostics(diagnostics []protocol.Diagnostic, rng protocol.Range) []protocol.Diagnostic {
+	var overlapping []protocol.Diagnostic
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Range.Start.Line <= rng.End.Line && diagnostic.Range.End.Line >= rng.Start.Line {
+			overlapping = append(overlapping, diagnostic)
+		}
+	}
+	return overlapping
+}
+
+// enabledCodeActions leaves out the code actions the server marked as
+// disabled.
+func enabledCodeActions(actions []protocol.CodeAction) []protocol.CodeAction {
+	var enabled []protocol.CodeAction
+	for _, action := range actions {
+		if action.Disabled == nil {
+			enabled = append(enabled, action)
+		}
+	}
+	return enabled
+}
+
+// formatCodeActions lists the diagnostics of a range of lines and the code
+// actions for them, numbered to choose one.
+func formatCodeActions(path string, startLine, endLine int, diagnostics []protocol.Diagnostic, actions []protocol.CodeAction) string {
+	lines := fmt.Sprintf("line %d", startLine)
+	if endLine > startLine {
+		lines = fmt.Sprintf("lines %d-%d", startLine, endLine)
+	}
+	if len(actions) == 0 {
+		return fmt.Sprintf("No code actions for %s of %s", lines, path)
+	}
+
+	var output strings.Builder
+	fmt.Fprintf(&output, "Found %d code action(s) for %s of %s:\n", len(actions), lines, path)
+	for i, action := range actions {
+		var details []string
+		if action.Kind != "" {
+			details = append(details, string(action.Kind))
+		}
+		if action.IsPreferred {
+			details = append(details, "preferred")
+		}
+		if action.Edit == nil && action.Data == nil && action.Command != nil {
+			details = append(details, "command")
+		}
+		fmt.Fprintf(&output, "\n%d. %s", i+1, action.Title)
+		if len(details) > 0 {
+			fmt.Fprintf(&output, " (%s)", strings.Join(details, ", "))
+		}
+		for _, diagnostic := range action.Diagnostics {
+			fmt.Fprintf(&output, "\n   fixes: %s", diagnostic.Message)
+		}
+	}
+	if len(diagnostics) > 0 {
+		output.WriteString("\n\nDiagnostics:")
+		for _, diagnostic := range diagnostics {
+			fmt.Fprintf(&output, "\n%s", formatDiagnostic(path, diagnostic, ""))
+		}
+	}
+	output.WriteString("\n\nCall this tool again with the number of a code action as action to apply it.")
+	return output.String()
+}
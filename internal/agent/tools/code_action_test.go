@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/x/powernap/pkg/lsp/protocol"
+	"github.com/stretchr/testify/require"
+)
+
+func lineDiagnostic(start, end uint32, message string) protocol.Diagnostic {
+	return protocol.Diagnostic{
+		Range: protocol.Range{
+			Start: protocol.Position{Line: start},
+			End:   protocol.Position{Line: end, Character: 1},
+		},
+		Severity: protocol.SeverityError,
+		Source:   "compiler",
+		Message:  message,
+	}
+}
+
+func TestOverlappingDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	diagnostics := []protocol.Diagnostic{
+		lineDiagnostic(1, 1, "before"),
+		lineDiagnostic(2, 4, "across"),
+		lineDiagnostic(5, 5, "inside"),
+		lineDiagnostic(7, 7, "after"),
+	}
+	got := overlappingDiagnostics(diagnostics, protocol.Range{
+		Start: protocol.Position{Line: 4},
+		End:   protocol.Position{Line: 6, Character: 10},
+	})
+	require.Len(t, got, 2)
+	require.Equal(t, "across", got[0].Message)
+	require.Equal(t, "inside", got[1].Message)
+}
+
+func TestEnabledCodeActions(t *testing.T) {
+	t.Parallel()
+
+	actions := enabledCodeActions([]protocol.CodeAction{
+		{Title: "Extract function", Disabled: &protocol.CodeActionDisabled{Reason: "no selection"}},
+		{Title: "Add import"},
+	})
+	require.Len(t, actions, 1)
+	require.Equal(t, "Add import", actions[0].Title)
+}
+
+func TestFormatCodeActions(t *testing.T) {
+	t.Parallel()
+
+	undefined := lineDiagnostic(4, 4, "undefined: strings")
+	out := formatCodeActions("/src/main.go", 5, 5, []protocol.Diagnostic{undefined}, []protocol.CodeAction{
+		{
+			Title:       `Add import: "strings"`,
+			Kind:        "quickfix",
+			IsPreferred: true,
+			Diagnostics: []protocol.Diagnostic{undefined},
+			Edit:        &protocol.WorkspaceEdit{},
+		},
+		{Title: "Run go generate", Command: &protocol.Command{Command: "gopls.generate"}},
+	})
+	require.Equal(t, `Found 2 code action(s) for line 5 of /src/main.go:
+
+1. Add import: "strings" (quickfix, preferred)
+   fixes: undefined: strings
+2. Run go generate (command)
+
+Diagnostics:
+Error: /src/main.go:5:1 [compiler] undefined: strings
+
+Call this tool again with the number of a code action as action to apply it.`, out)
+
+	require.Equal(t, "No code actions for lines 3-7 of /src/main.go", formatCodeActions("/src/main.go", 3, 7, nil, nil))
+}
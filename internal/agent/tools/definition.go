@@ -0,0 +1,73 @@
+package tools
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/charmbracelet/crush/internal/lsp"
+	"github.com/charmbracelet/x/powernap/pkg/lsp/protocol"
+)
+
+type DefinitionParams struct {
+	FilePath string `json:"file_path" description:"The path to a file where the symbol appears"`
+	Line     int    `json:"line" description:"The line number where the symbol appears (1-based)"`
+	Symbol   string `json:"symbol" description:"The symbol as written on that line (e.g., function name, variable name, type name)"`
+}
+
+const DefinitionToolName = "lsp_definition"
+
+//go:embed definition.md
+var definitionDescription []byte
+
+func NewDefinitionTool(lspClients *csync.Map[string, *lsp.Client], workingDir string) fantasy.AgentTool {
+	return fantasy.NewAgentTool(
+		DefinitionToolName,
+		string(definitionDescription),
+		func(ctx context.Context, params DefinitionParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+			path, client, column, err := lspSymbolAt(lspClients, workingDir, params.FilePath, params.Line, params.Symbol)
+			if err != nil {
+				return fantasy.NewTextErrorResponse(err.Error()), nil
+			}
+
+			locations, err := client.Definition(ctx, path, params.Line, column)
+			if err != nil {
+				return fantasy.NewTextErrorResponse(fmt.Sprintf("failed to find the definition: %s", err)), nil
+			}
+			if len(locations) == 0 {
+				return fantasy.NewTextResponse(fmt.Sprintf("No definition found for symbol '%s'", params.Symbol)), nil
+			}
+			return fantasy.NewTextResponse(formatDefinitions(cleanupLocations(locations))), nil
+		})
+}
+
+// formatDefinitions lists the locations with the line each one starts on.
+func formatDefinitions(locations []protocol.Location) string {
+	var output strings.Builder
+	fmt.Fprintf(&output, "Found %d definition(s):\n", len(locations))
+
+	lines := make(map[string][]string)
+	for _, loc := range locations {
+		path, err := loc.URI.Path()
+		if err != nil {
+			continue
+		}
+		line := int(loc.Range.Start.Line)
+		fmt.Fprintf(&output, "\n%s:%d:%d\n", path, line+1, loc.Range.Start.Character+1)
+
+		if _, ok := lines[path]; !ok {
+			content, _ := os.ReadFile(path)
+			lines[path] = strings.Split(string(content), "\n")
+		}
+		if line < len(lines[path]) {
+			if text := strings.TrimSpace(lines[path][line]); text != "" {
+				fmt.Fprintf(&output, "  %s\n", text)
+			}
+		}
+	}
+	return output.String()
+}
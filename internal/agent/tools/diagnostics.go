@@ -5,6 +5,7 @@ import (
 	_ "embed"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -53,6 +54,12 @@ func notifyLSPs(ctx context.Context, lsps *csync.Map[string, *lsp.Client], filep
 }
 
 func getDiagnostics(filePath string, lsps *csync.Map[string, *lsp.Client]) string {
+	return getFilesDiagnostics(lsps, filePath)
+}
+
+// getFilesDiagnostics returns the diagnostics of the given files, and those
+// of the rest of the project apart.
+func getFilesDiagnostics(lsps *csync.Map[string, *lsp.Client], filePaths ...string) string {
 	fileDiagnostics := []string{}
 	projectDiagnostics := []string{}
 
@@ -63,7 +70,7 @@ func getDiagnostics(filePath string, lsps *csync.Map[string, *lsp.Client]) strin
 				slog.Error("Failed to convert diagnostic location URI to path", "uri", location, "error", err)
 				continue
 			}
-			isCurrentFile := path == filePath
+			isCurrentFile := slices.Contains(filePaths, path)
 			for _, diag := range diags {
 				formattedDiag := formatDiagnostic(path, diag, lspName)
 				if isCurrentFile {
@@ -88,7 +95,11 @@ func getDiagnostics(filePath string, lsps *csync.Map[string, *lsp.Client]) strin
 		projectErrors := countSeverity(projectDiagnostics, "Error")
 		projectWarnings := countSeverity(projectDiagnostics, "Warn")
 		output.WriteString("\n<diagnostic_summary>\n")
-		fmt.Fprintf(&output, "Current file: %d errors, %d warnings\n", fileErrors, fileWarnings)
+		if len(filePaths) > 1 {
+			fmt.Fprintf(&output, "Current files: %d errors, %d warnings\n", fileErrors, fileWarnings)
+		} else {
+			fmt.Fprintf(&output, "Current file: %d errors, %d warnings\n", fileErrors, fileWarnings)
+		}
 		fmt.Fprintf(&output, "Project: %d errors, %d warnings\n", projectErrors, projectWarnings)
 		output.WriteString("</diagnostic_summary>\n")
 	}
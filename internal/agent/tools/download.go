@@ -70,18 +70,19 @@ func NewDownloadTool(permissions permission.Service, workingDir string, client *
 				return fantasy.ToolResponse{}, fmt.Errorf("session ID is required for downloading files")
 			}
 
-			p := permissions.Request(
-				permission.CreatePermissionRequest{
-					SessionID:   sessionID,
-					Path:        filePath,
-					ToolName:    DownloadToolName,
-					Action:      "download",
-					Description: fmt.Sprintf("Download file from URL: %s to %s", params.URL, filePath),
-					Params:      DownloadPermissionsParams(params),
-				},
-			)
-
-			if !p {
+			permissionReq := permission.CreatePermissionRequest{
+				SessionID:   sessionID,
+				Path:        filePath,
+				ToolName:    DownloadToolName,
+				Action:      "download",
+				Description: fmt.Sprintf("Download file from URL: %s to %s", params.URL, filePath),
+				Params:      DownloadPermissionsParams(params),
+				Targets:     []string{params.URL},
+			}
+			if rule, denied := permissions.Denied(permissionReq); denied {
+				return NewRuleDeniedResponse(rule), nil
+			}
+			if !permissions.Request(permissionReq) {
 				return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
 			}
 
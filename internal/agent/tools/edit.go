@@ -93,9 +93,11 @@ func NewEditTool(lspClients *csync.Map[string, *lsp.Client], permissions permiss
 				return response, nil
 			}
 
+			formatting := formatOnWrite(ctx, lspClients, files, workingDir, params.FilePath)
 			notifyLSPs(ctx, lspClients, params.FilePath)
 
 			text := fmt.Sprintf("<result>\n%s\n</result>\n", response.Content)
+			text += formatting
 			text += getDiagnostics(params.FilePath, lspClients)
 			response.Content = text
 			return response, nil
@@ -128,26 +130,28 @@ func createNewFile(edit editContext, filePath, content string, call fantasy.Tool
 		content,
 		strings.TrimPrefix(filePath, edit.workingDir),
 	)
-	p := edit.permissions.Request(
-		permission.CreatePermissionRequest{
-			SessionID:   sessionID,
-			Path:        fsext.PathOrPrefix(filePath, edit.workingDir),
-			ToolCallID:  call.ID,
-			ToolName:    EditToolName,
-			Action:      "write",
-			Description: fmt.Sprintf("Create file %s", filePath),
-			Params: EditPermissionsParams{
-				FilePath:   filePath,
-				OldContent: "",
-				NewContent: content,
-			},
+	permissionReq := permission.CreatePermissionRequest{
+		SessionID:   sessionID,
+		Path:        fsext.PathOrPrefix(filePath, edit.workingDir),
+		ToolCallID:  call.ID,
+		ToolName:    EditToolName,
+		Action:      "write",
+		Description: fmt.Sprintf("Create file %s", filePath),
+		Params: EditPermissionsParams{
+			FilePath:   filePath,
+			OldContent: "",
+			NewContent: content,
 		},
-	)
-	if !p {
+		Targets: []string{filePath},
+	}
+	if rule, denied := edit.permissions.Denied(permissionReq); denied {
+		return NewRuleDeniedResponse(rule), nil
+	}
+	if !edit.permissions.Request(permissionReq) {
 		return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
 	}
 
-	err = os.WriteFile(filePath, []byte(content), 0o644)
+	err = writeFile(edit.ctx, filePath, []byte(content))
 	if err != nil {
 		return fantasy.ToolResponse{}, fmt.Errorf("failed to write file: %w", err)
 	}
@@ -206,7 +210,7 @@ func deleteContent(edit editContext, filePath, oldString string, replaceAll bool
 			)), nil
 	}
 
-	content, err := os.ReadFile(filePath)
+	content, err := readFile(edit.ctx, filePath)
 	if err != nil {
 		return fantasy.ToolResponse{}, fmt.Errorf("failed to read file: %w", err)
 	}
@@ -249,22 +253,24 @@ func deleteContent(edit editContext, filePath, oldString string, replaceAll bool
 		strings.TrimPrefix(filePath, edit.workingDir),
 	)
 
-	p := edit.permissions.Request(
-		permission.CreatePermissionRequest{
-			SessionID:   sessionID,
-			Path:        fsext.PathOrPrefix(filePath, edit.workingDir),
-			ToolCallID:  call.ID,
-			ToolName:    EditToolName,
-			Action:      "write",
-			Description: fmt.Sprintf("Delete content from file %s", filePath),
-			Params: EditPermissionsParams{
-				FilePath:   filePath,
-				OldContent: oldContent,
-				NewContent: newContent,
-			},
+	permissionReq := permission.CreatePermissionRequest{
+		SessionID:   sessionID,
+		Path:        fsext.PathOrPrefix(filePath, edit.workingDir),
+		ToolCallID:  call.ID,
+		ToolName:    EditToolName,
+		Action:      "write",
+		Description: fmt.Sprintf("Delete content from file %s", filePath),
+		Params: EditPermissionsParams{
+			FilePath:   filePath,
+			OldContent: oldContent,
+			NewContent: newContent,
 		},
-	)
-	if !p {
+		Targets: []string{filePath},
+	}
+	if rule, denied := edit.permissions.Denied(permissionReq); denied {
+		return NewRuleDeniedResponse(rule), nil
+	}
+	if !edit.permissions.Request(permissionReq) {
 		return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
 	}
 
@@ -272,7 +278,7 @@ func deleteContent(edit editContext, filePath, oldString string, replaceAll bool
 		newContent, _ = fsext.ToWindowsLineEndings(newContent)
 	}
 
-	err = os.WriteFile(filePath, []byte(newContent), 0o644)
+	err = writeFile(edit.ctx, filePath, []byte(newContent))
 	if err != nil {
 		return fantasy.ToolResponse{}, fmt.Errorf("failed to write file: %w", err)
 	}
@@ -339,7 +345,7 @@ func replaceContent(edit editContext, filePath, oldString, newString string, rep
 			)), nil
 	}
 
-	content, err := os.ReadFile(filePath)
+	content, err := readFile(edit.ctx, filePath)
 	if err != nil {
 		return fantasy.ToolResponse{}, fmt.Errorf("failed to read file: %w", err)
 	}
@@ -384,22 +390,24 @@ func replaceContent(edit editContext, filePath, oldString, newString string, rep
 		strings.TrimPrefix(filePath, edit.workingDir),
 	)
 
-	p := edit.permissions.Request(
-		permission.CreatePermissionRequest{
-			SessionID:   sessionID,
-			Path:        fsext.PathOrPrefix(filePath, edit.workingDir),
-			ToolCallID:  call.ID,
-			ToolName:    EditToolName,
-			Action:      "write",
-			Description: fmt.Sprintf("Replace content in file %s", filePath),
-			Params: EditPermissionsParams{
-				FilePath:   filePath,
-				OldContent: oldContent,
-				NewContent: newContent,
-			},
+	permissionReq := permission.CreatePermissionRequest{
+		SessionID:   sessionID,
+		Path:        fsext.PathOrPrefix(filePath, edit.workingDir),
+		ToolCallID:  call.ID,
+		ToolName:    EditToolName,
+		Action:      "write",
+		Description: fmt.Sprintf("Replace content in file %s", filePath),
+		Params: EditPermissionsParams{
+			FilePath:   filePath,
+			OldContent: oldContent,
+			NewContent: newContent,
 		},
-	)
-	if !p {
+		Targets: []string{filePath},
+	}
+	if rule, denied := edit.permissions.Denied(permissionReq); denied {
+		return NewRuleDeniedResponse(rule), nil
+	}
+	if !edit.permissions.Request(permissionReq) {
 		return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
 	}
 
@@ -407,7 +415,7 @@ func replaceContent(edit editContext, filePath, oldString, newString string, rep
 		newContent, _ = fsext.ToWindowsLineEndings(newContent)
 	}
 
-	err = os.WriteFile(filePath, []byte(newContent), 0o644)
+	err = writeFile(edit.ctx, filePath, []byte(newContent))
 	if err != nil {
 		return fantasy.ToolResponse{}, fmt.Errorf("failed to write file: %w", err)
 	}
@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+)
+
+// EditorFS reads and writes text files through the buffers of an editor, so
+// that the agent sees unsaved changes and its edits land in open buffers.
+type EditorFS interface {
+	ReadTextFile(ctx context.Context, path string) (string, error)
+	WriteTextFile(ctx context.Context, path, content string) error
+}
+
+type editorFSContextKey string
+
+// EditorFSContextKey holds the EditorFS the file tools go through instead of
+// the disk.
+const EditorFSContextKey editorFSContextKey = "editor_fs"
+
+func getEditorFSFromContext(ctx context.Context) EditorFS {
+	fs, _ := ctx.Value(EditorFSContextKey).(EditorFS)
+	return fs
+}
+
+// readFile reads the file through the editor when there is one.
+func readFile(ctx context.Context, path string) ([]byte, error) {
+	if fs := getEditorFSFromContext(ctx); fs != nil {
+		content, err := fs.ReadTextFile(ctx, path)
+		return []byte(content), err
+	}
+	return os.ReadFile(path)
+}
+
+// writeFile writes the file through the editor when there is one.
+func writeFile(ctx context.Context, path string, content []byte) error {
+	if fs := getEditorFSFromContext(ctx); fs != nil {
+		return fs.WriteTextFile(ctx, path, string(content))
+	}
+	return os.WriteFile(path, content, 0o644)
+}
+
+// openFile opens the file for reading, through the editor when there is one.
+func openFile(ctx context.Context, path string) (io.ReadSeekCloser, error) {
+	if fs := getEditorFSFromContext(ctx); fs != nil {
+		content, err := fs.ReadTextFile(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		return nopCloser{strings.NewReader(content)}, nil
+	}
+	return os.Open(path)
+}
+
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error { return nil }
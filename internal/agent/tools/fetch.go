@@ -21,7 +21,7 @@ const FetchToolName = "fetch"
 //go:embed fetch.md
 var fetchDescription []byte
 
-func NewFetchTool(permissions permission.Service, workingDir string, client *http.Client) fantasy.AgentTool {
+func NewFetchTool(permissions permission.Service, workingDir, dataDir string, client *http.Client) fantasy.AgentTool {
 	if client == nil {
 		client = &http.Client{
 			Timeout: 30 * time.Second,
@@ -55,19 +55,20 @@ func NewFetchTool(permissions permission.Service, workingDir string, client *htt
 				return fantasy.ToolResponse{}, fmt.Errorf("session ID is required for creating a new file")
 			}
 
-			p := permissions.Request(
-				permission.CreatePermissionRequest{
-					SessionID:   sessionID,
-					Path:        workingDir,
-					ToolCallID:  call.ID,
-					ToolName:    FetchToolName,
-					Action:      "fetch",
-					Description: fmt.Sprintf("Fetch content from URL: %s", params.URL),
-					Params:      FetchPermissionsParams(params),
-				},
-			)
-
-			if !p {
+			permissionReq := permission.CreatePermissionRequest{
+				SessionID:   sessionID,
+				Path:        workingDir,
+				ToolCallID:  call.ID,
+				ToolName:    FetchToolName,
+				Action:      "fetch",
+				Description: fmt.Sprintf("Fetch content from URL: %s", params.URL),
+				Params:      FetchPermissionsParams(params),
+				Targets:     []string{params.URL},
+			}
+			if rule, denied := permissions.Denied(permissionReq); denied {
+				return NewRuleDeniedResponse(rule), nil
+			}
+			if !permissions.Request(permissionReq) {
 				return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
 			}
 
@@ -152,13 +153,10 @@ func NewFetchTool(permissions permission.Service, workingDir string, client *htt
 					content = "<html>\n<body>\n" + body + "\n</body>\n</html>"
 				}
 			}
-			// calculate byte size of content
-			contentSize := int64(len(content))
-			if contentSize > MaxReadSize {
-				content = content[:MaxReadSize]
-				content += fmt.Sprintf("\n\n[Content truncated to %d bytes]", MaxReadSize)
+			content, artifactPath := spillOutput(ctx, dataDir, call.ID, content, MaxReadSize)
+			if artifactPath != "" {
+				return fantasy.WithResponseMetadata(fantasy.NewTextResponse(content), ArtifactMetadata{ArtifactPath: artifactPath}), nil
 			}
-
 			return fantasy.NewTextResponse(content), nil
 		})
 }
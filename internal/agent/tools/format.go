@@ -0,0 +1,212 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/charmbracelet/crush/internal/fsext"
+	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/lsp"
+	"github.com/charmbracelet/crush/internal/lsp/util"
+	"github.com/charmbracelet/crush/internal/shell"
+	"github.com/charmbracelet/x/powernap/pkg/lsp/protocol"
+)
+
+// formatTimeout bounds each step of formatting a file on write.
+const formatTimeout = 30 * time.Second
+
+// formatOnWrite formats a file the agent wrote with the LSP servers set to
+// format on write, then applies their code actions on write, such as
+// organizing imports. Each change is stored as a new version of the file. It
+// returns a note telling the model the file changed, if it did.
+func formatOnWrite(ctx context.Context, lspClients *csync.Map[string, *lsp.Client], files history.Service, workingDir, path string) string {
+	sessionID := GetSessionFromContext(ctx)
+	if sessionID == "" || lspClients == nil {
+		return ""
+	}
+
+	var names []string
+	for name, client := range lspClients.Seq2() {
+		if client.GetConfig().FormatOnWrite && client.HandlesFile(path) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var steps []string
+	for _, name := range names {
+		client, ok := lspClients.Get(name)
+		if !ok {
+			continue
+		}
+		cfg := client.GetConfig()
+		if err := client.OpenFileOnDemand(ctx, path); err != nil {
+			slog.Warn("Failed to open file to format it", "lsp", name, "path", path, "error", err)
+			continue
+		}
+
+		changed, err := rewriteOnWrite(ctx, client, files, sessionID, path, func(ctx context.Context, content string) (string, error) {
+			if cfg.Formatter != "" {
+				return runFormatter(ctx, cfg.Formatter, workingDir, path, content)
+			}
+			return formatWithLSP(ctx, client, path, content)
+		})
+		if err != nil {
+			slog.Warn("Failed to format file on write", "lsp", name, "path", path, "error", err)
+		} else if changed && cfg.Formatter != "" {
+			steps = append(steps, fmt.Sprintf("formatted with `%s`", cfg.Formatter))
+		} else if changed {
+			steps = append(steps, fmt.Sprintf("formatted by %s", name))
+		}
+
+		for _, kind := range cfg.CodeActionsOnWrite {
+			changed, err := rewriteOnWrite(ctx, client, files, sessionID, path, func(ctx context.Context, content string) (string, error) {
+				return applyCodeActionOnWrite(ctx, client, path, content, kind)
+			})
+			if err != nil {
+				slog.Warn("Failed to apply code action on write", "lsp", name, "path", path, "kind", kind, "error", err)
+			} else if changed {
+				steps = append(steps, fmt.Sprintf("%s by %s", kind, name))
+			}
+		}
+	}
+
+	if len(steps) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n<formatting>\n%s was changed after writing it (%s). Read it again before editing it.\n</formatting>\n", path, strings.Join(steps, ", "))
+}
+
+// rewriteOnWrite replaces the content of a file with what rewrite makes of
+// it, keeping the server in sync, and stores the new version of the file. It
+// reports whether the file changed.
+func rewriteOnWrite(ctx context.Context, client *lsp.Client, files history.Service, sessionID, path string, rewrite func(ctx context.Context, content string) (string, error)) (bool, error) {
+	content, err := readFile(ctx, path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read file: %w", err)
+	}
+	if err := client.NotifyContent(ctx, path, string(content)); err != nil {
+		return false, err
+	}
+	rewriteCtx, cancel := context.WithTimeout(ctx, formatTimeout)
+	newContent, err := rewrite(rewriteCtx, string(content))
+	cancel()
+	if err != nil || newContent == string(content) {
+		return false, err
+	}
+
+	if err := writeFile(ctx, path, []byte(newContent)); err != nil {
+		return false, fmt.Errorf("failed to write file: %w", err)
+	}
+	oldContent, _ := fsext.ToUnixLineEndings(string(content))
+	unixContent, _ := fsext.ToUnixLineEndings(newContent)
+	if err := recordFileVersions(ctx, files, sessionID, path, oldContent, unixContent); err != nil {
+		return true, err
+	}
+	recordFileWrite(path)
+	recordFileRead(path)
+	return true, client.NotifyContent(ctx, path, newContent)
+}
+
+// runFormatter formats content with a shell command reading it on stdin and
+// writing it formatted on stdout, with $FILE set to the path of the file.
+func runFormatter(ctx context.Context, command, workingDir, path, content string) (string, error) {
+	sh := shell.NewShell(&shell.Options{
+		WorkingDir: workingDir,
+		Env:        append(os.Environ(), "FILE="+path),
+	})
+	stdout, stderr, err := sh.ExecStdin(ctx, command, strings.NewReader(content))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr))
+	}
+	if strings.TrimSpace(stdout) == "" && strings.TrimSpace(content) != "" {
+		return "", fmt.Errorf("formatter printed nothing")
+	}
+	return stdout, nil
+}
+
+// formatWithLSP formats content with the formatting of the server, which
+// must know the file has that content.
+func formatWithLSP(ctx context.Context, client *lsp.Client, path, content string) (string, error) {
+	edits, err := client.Format(ctx, path, formattingOptions(content))
+	if err != nil {
+		return "", err
+	}
+	return util.ApplyTextEditsToContent(content, edits)
+}
+
+// formattingOptions guesses the indentation of content for servers that
+// need it to format.
+func formattingOptions(content string) protocol.FormattingOptions {
+	options := protocol.FormattingOptions{TabSize: 4, InsertSpaces: true}
+	for line := range strings.Lines(content) {
+		if strings.HasPrefix(line, "\t") {
+			options.InsertSpaces = false
+			break
+		}
+		if indent := len(line) - len(strings.TrimLeft(line, " ")); indent > 0 && strings.TrimSpace(line) != "" {
+			options.TabSize = uint32(min(indent, 8))
+			break
+		}
+	}
+	return options
+}
+
+// applyCodeActionOnWrite applies the edits a code action of the given kind
+// makes to a file, such as organizing its imports. Actions changing other
+// files or running commands are skipped.
+func applyCodeActionOnWrite(ctx context.Context, client *lsp.Client, path, content, kind string) (string, error) {
+	actions, err := client.CodeActions(ctx, path, contentRange(content), client.GetFileDiagnostics(protocol.URIFromPath(path)), []string{kind})
+	if err != nil {
+		return "", err
+	}
+	uri := protocol.URIFromPath(path)
+	for _, action := range actions {
+		if action.Disabled != nil || (action.Kind != "" && !strings.HasPrefix(string(action.Kind), kind)) {
+			continue
+		}
+		action, err = client.ResolveCodeAction(ctx, action)
+		if err != nil {
+			return "", err
+		}
+		if action.Edit == nil || action.Command != nil {
+			continue
+		}
+		textEdits, err := util.TextEdits(*action.Edit)
+		if err != nil {
+			return "", err
+		}
+		if len(textEdits) != 1 || len(textEdits[uri]) == 0 || hasFileOperations(*action.Edit) {
+			continue
+		}
+		return util.ApplyTextEditsToContent(content, textEdits[uri])
+	}
+	return content, nil
+}
+
+// hasFileOperations reports whether a workspace edit creates, renames or
+// deletes files.
+func hasFileOperations(edit protocol.WorkspaceEdit) bool {
+	return slices.ContainsFunc(edit.DocumentChanges, func(change protocol.DocumentChange) bool {
+		return change.TextDocumentEdit == nil
+	})
+}
+
+// contentRange returns the range of the whole content of a file.
+func contentRange(content string) protocol.Range {
+	lines := strings.Split(content, "\n")
+	last := lines[len(lines)-1]
+	return protocol.Range{
+		End: protocol.Position{
+			Line:      uint32(len(lines) - 1),
+			Character: uint32(len(utf16.Encode([]rune(last)))),
+		},
+	}
+}
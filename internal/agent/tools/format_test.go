@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/charmbracelet/x/powernap/pkg/lsp/protocol"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormattingOptions(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		content string
+		want    protocol.FormattingOptions
+	}{
+		"tabs":     {content: "func main() {\n\trun()\n}\n", want: protocol.FormattingOptions{TabSize: 4}},
+		"spaces":   {content: "def main():\n\n  run()\n", want: protocol.FormattingOptions{TabSize: 2, InsertSpaces: true}},
+		"unindent": {content: "x = 1\n", want: protocol.FormattingOptions{TabSize: 4, InsertSpaces: true}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.want, formattingOptions(tc.content))
+		})
+	}
+}
+
+func TestContentRange(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, protocol.Position{Line: 2}, contentRange("a\nb\n").End)
+	require.Equal(t, protocol.Position{Line: 1, Character: 3}, contentRange("a\nb😀").End)
+	require.Equal(t, protocol.Position{}, contentRange("").End)
+}
+
+func TestRunFormatter(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("formatter commands rely on POSIX utilities")
+	}
+	t.Parallel()
+
+	dir := t.TempDir()
+	formatted, err := runFormatter(t.Context(), `tr a-z A-Z; echo "# $FILE"`, dir, "/src/main.py", "x = 1\n")
+	require.NoError(t, err)
+	require.Equal(t, "X = 1\n# /src/main.py\n", formatted)
+
+	_, err = runFormatter(t.Context(), "echo 'syntax error' >&2; exit 1", dir, "/src/main.py", "x = 1\n")
+	require.ErrorContains(t, err, "syntax error")
+
+	_, err = runFormatter(t.Context(), "true", dir, "/src/main.py", "x = 1\n")
+	require.ErrorContains(t, err, "printed nothing")
+}
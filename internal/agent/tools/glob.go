@@ -8,11 +8,13 @@ import (
 	"log/slog"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
 	"charm.land/fantasy"
 	"github.com/charmbracelet/crush/internal/fsext"
+	"github.com/charmbracelet/crush/internal/permission"
 )
 
 const GlobToolName = "glob"
@@ -30,7 +32,7 @@ type GlobResponseMetadata struct {
 	Truncated     bool `json:"truncated"`
 }
 
-func NewGlobTool(workingDir string) fantasy.AgentTool {
+func NewGlobTool(permissions permission.Service, workingDir string) fantasy.AgentTool {
 	return fantasy.NewAgentTool(
 		GlobToolName,
 		string(globDescription),
@@ -43,11 +45,18 @@ func NewGlobTool(workingDir string) fantasy.AgentTool {
 			if searchPath == "" {
 				searchPath = workingDir
 			}
+			if rule, denied := deniedPath(permissions, GlobToolName, searchPath); denied {
+				return NewRuleDeniedResponse(rule), nil
+			}
 
 			files, truncated, err := globFiles(ctx, params.Pattern, searchPath, 100)
 			if err != nil {
 				return fantasy.ToolResponse{}, fmt.Errorf("error finding files: %w", err)
 			}
+			files = slices.DeleteFunc(files, func(file string) bool {
+				_, denied := deniedPath(permissions, GlobToolName, file)
+				return denied
+			})
 
 			var output string
 			if len(files) == 0 {
@@ -13,6 +13,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -20,6 +21,7 @@ import (
 
 	"charm.land/fantasy"
 	"github.com/charmbracelet/crush/internal/fsext"
+	"github.com/charmbracelet/crush/internal/permission"
 )
 
 // regexCache provides thread-safe caching of compiled regex patterns
@@ -112,7 +114,7 @@ func escapeRegexPattern(pattern string) string {
 	return escaped
 }
 
-func NewGrepTool(workingDir string) fantasy.AgentTool {
+func NewGrepTool(permissions permission.Service, workingDir string) fantasy.AgentTool {
 	return fantasy.NewAgentTool(
 		GrepToolName,
 		string(grepDescription),
@@ -131,11 +133,18 @@ func NewGrepTool(workingDir string) fantasy.AgentTool {
 			if searchPath == "" {
 				searchPath = workingDir
 			}
+			if rule, denied := deniedPath(permissions, GrepToolName, searchPath); denied {
+				return NewRuleDeniedResponse(rule), nil
+			}
 
 			matches, truncated, err := searchFiles(ctx, searchPattern, searchPath, params.Include, 100)
 			if err != nil {
 				return fantasy.NewTextErrorResponse(fmt.Sprintf("error searching files: %v", err)), nil
 			}
+			matches = slices.DeleteFunc(matches, func(match grepMatch) bool {
+				_, denied := deniedPath(permissions, GrepToolName, match.path)
+				return denied
+			})
 
 			var output strings.Builder
 			if len(matches) == 0 {
@@ -1,11 +1,14 @@
 package tools
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"regexp"
 	"testing"
 
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/permission"
 	"github.com/stretchr/testify/require"
 )
 
@@ -121,6 +124,42 @@ func TestGrepWithIgnoreFiles(t *testing.T) {
 	}
 }
 
+func TestGrepToolDeniedFiles(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+
+	for path, content := range map[string]string{
+		"main.go":        "token := load()",
+		".env":           "token=secret",
+		"secrets/key.go": "token := \"secret\"",
+	} {
+		fullPath := filepath.Join(tempDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0o644))
+	}
+
+	permissions := permission.NewPermissionService(tempDir, false, nil, permission.Rules{
+		Deny: []string{"view(**/.env)", "view(secrets/**)"},
+	})
+	tool := NewGrepTool(permissions, tempDir)
+
+	input, err := json.Marshal(GrepParams{Pattern: "token"})
+	require.NoError(t, err)
+	resp, err := tool.Run(t.Context(), fantasy.ToolCall{ID: "call", Name: GrepToolName, Input: string(input)})
+	require.NoError(t, err)
+	require.False(t, resp.IsError, resp.Content)
+	require.Contains(t, resp.Content, "main.go")
+	require.NotContains(t, resp.Content, ".env")
+	require.NotContains(t, resp.Content, "secret")
+
+	input, err = json.Marshal(GrepParams{Pattern: "token", Path: filepath.Join(tempDir, "secrets")})
+	require.NoError(t, err)
+	resp, err = tool.Run(t.Context(), fantasy.ToolCall{ID: "call", Name: GrepToolName, Input: string(input)})
+	require.NoError(t, err)
+	require.True(t, resp.IsError)
+	require.Contains(t, resp.Content, "view(secrets/**)")
+}
+
 func TestSearchImplementations(t *testing.T) {
 	t.Parallel()
 	tempDir := t.TempDir()
@@ -0,0 +1,50 @@
+package tools
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/charmbracelet/crush/internal/lsp"
+)
+
+type HoverParams struct {
+	FilePath string `json:"file_path" description:"The path to a file where the symbol appears"`
+	Line     int    `json:"line" description:"The line number where the symbol appears (1-based)"`
+	Symbol   string `json:"symbol" description:"The symbol as written on that line (e.g., function name, variable name, type name)"`
+}
+
+const HoverToolName = "lsp_hover"
+
+// maxHoverLength is the most hover text returned, documentation can be long.
+const maxHoverLength = 8000
+
+//go:embed hover.md
+var hoverDescription []byte
+
+func NewHoverTool(lspClients *csync.Map[string, *lsp.Client], workingDir string) fantasy.AgentTool {
+	return fantasy.NewAgentTool(
+		HoverToolName,
+		string(hoverDescription),
+		func(ctx context.Context, params HoverParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+			path, client, column, err := lspSymbolAt(lspClients, workingDir, params.FilePath, params.Line, params.Symbol)
+			if err != nil {
+				return fantasy.NewTextErrorResponse(err.Error()), nil
+			}
+
+			text, err := client.Hover(ctx, path, params.Line, column)
+			if err != nil {
+				return fantasy.NewTextErrorResponse(fmt.Sprintf("failed to get hover information: %s", err)), nil
+			}
+			if text == "" {
+				return fantasy.NewTextResponse(fmt.Sprintf("No information found for symbol '%s'", params.Symbol)), nil
+			}
+			if len(text) > maxHoverLength {
+				text = strings.ToValidUTF8(text[:maxHoverLength], "") + "\n\n[truncated]"
+			}
+			return fantasy.NewTextResponse(text), nil
+		})
+}
@@ -0,0 +1,133 @@
+package tools
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/permission"
+	"github.com/charmbracelet/crush/internal/shell"
+)
+
+const (
+	JobInputToolName = "job_input"
+
+	// jobInputWait is how long the output is waited for after sending the
+	// input.
+	jobInputWait = time.Second
+)
+
+//go:embed job_input.md
+var jobInputDescription []byte
+
+type JobInputParams struct {
+	ShellID string `json:"shell_id" description:"The ID of the background shell running in a pseudo-terminal to send input to"`
+	Input   string `json:"input" description:"The text to type in the terminal; control characters can be sent with escapes such as \\u0003 for Ctrl+C"`
+	Enter   bool   `json:"enter,omitempty" description:"Set to true (boolean) to press Enter after the input"`
+}
+
+type JobInputPermissionsParams struct {
+	ShellID string `json:"shell_id"`
+	Command string `json:"command"`
+	Input   string `json:"input"`
+}
+
+type JobInputResponseMetadata struct {
+	ShellID      string `json:"shell_id"`
+	Command      string `json:"command"`
+	Description  string `json:"description"`
+	Done         bool   `json:"done"`
+	ArtifactPath string `json:"artifact_path,omitempty"`
+}
+
+func NewJobInputTool(permissions permission.Service, dataDir string) fantasy.AgentTool {
+	return fantasy.NewAgentTool(
+		JobInputToolName,
+		string(jobInputDescription),
+		func(ctx context.Context, params JobInputParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+			if params.ShellID == "" {
+				return fantasy.NewTextErrorResponse("missing shell_id"), nil
+			}
+			if params.Input == "" && !params.Enter {
+				return fantasy.NewTextErrorResponse("missing input"), nil
+			}
+
+			bgManager := shell.GetBackgroundShellManager()
+			bgShell, ok := bgManager.Get(params.ShellID)
+			if !ok {
+				return fantasy.NewTextErrorResponse(fmt.Sprintf("background shell not found: %s", params.ShellID)), nil
+			}
+
+			// What is typed can be a whole command for the shell or the
+			// interpreter running in the terminal, so it is asked for like
+			// one, the input being matched by the rules.
+			sessionID := GetSessionFromContext(ctx)
+			if sessionID == "" {
+				return fantasy.ToolResponse{}, fmt.Errorf("session ID is required for sending input to a job")
+			}
+			permissionReq := permission.CreatePermissionRequest{
+				SessionID:   sessionID,
+				Path:        bgShell.WorkingDir,
+				ToolCallID:  call.ID,
+				ToolName:    JobInputToolName,
+				Action:      "execute",
+				Description: fmt.Sprintf("Type in %s: %s", bgShell.Command, params.Input),
+				Params: JobInputPermissionsParams{
+					ShellID: params.ShellID,
+					Command: bgShell.Command,
+					Input:   params.Input,
+				},
+				Targets: []string{strings.TrimSpace(params.Input)},
+			}
+			if rule, denied := permissions.Denied(permissionReq); denied {
+				return NewRuleDeniedResponse(rule), nil
+			}
+			if !permissions.Request(permissionReq) {
+				return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
+			}
+
+			offset := bgShell.StdoutOffset()
+			input := params.Input
+			if params.Enter {
+				// Terminals send a carriage return for Enter.
+				input += "\r"
+			}
+			if err := bgShell.WriteInput(input); err != nil {
+				return fantasy.NewTextErrorResponse(err.Error()), nil
+			}
+
+			select {
+			case <-time.After(jobInputWait):
+			case <-ctx.Done():
+				return fantasy.ToolResponse{}, ctx.Err()
+			}
+			// Only the output following the input is returned.
+			stdout, done, err := bgShell.GetStdoutSince(offset)
+
+			status := "running"
+			if done {
+				status = "completed"
+				if exitCode := shell.ExitCode(err); err != nil && exitCode != 0 {
+					stdout += fmt.Sprintf("\nExit code %d", exitCode)
+				}
+			}
+
+			output, artifactPath := spillOutput(ctx, dataDir, call.ID, stdout, MaxOutputLength)
+			if output == "" {
+				output = BashNoOutput
+			}
+
+			metadata := JobInputResponseMetadata{
+				ShellID:      params.ShellID,
+				Command:      bgShell.Command,
+				Description:  bgShell.Description,
+				Done:         done,
+				ArtifactPath: artifactPath,
+			}
+			result := fmt.Sprintf("Status: %s\n\n%s", status, output)
+			return fantasy.WithResponseMetadata(fantasy.NewTextResponse(result), metadata), nil
+		})
+}
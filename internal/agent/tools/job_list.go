@@ -0,0 +1,109 @@
+package tools
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/job"
+	"github.com/charmbracelet/crush/internal/shell"
+)
+
+const (
+	JobListToolName = "job_list"
+)
+
+//go:embed job_list.md
+var jobListDescription []byte
+
+type JobListParams struct{}
+
+type JobListItem struct {
+	ShellID     string `json:"shell_id,omitempty"`
+	Command     string `json:"command"`
+	Description string `json:"description,omitempty"`
+	Status      string `json:"status"`
+	ExitCode    int    `json:"exit_code"`
+	Duration    int64  `json:"duration"` // Milliseconds
+}
+
+type JobListResponseMetadata struct {
+	Jobs []JobListItem `json:"jobs"`
+}
+
+func NewJobListTool(jobs job.Service) fantasy.AgentTool {
+	return fantasy.NewAgentTool(
+		JobListToolName,
+		string(jobListDescription),
+		func(ctx context.Context, params JobListParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+			sessionID := GetRootSessionFromContext(ctx)
+			if sessionID == "" {
+				return fantasy.ToolResponse{}, fmt.Errorf("session ID is required for listing jobs")
+			}
+
+			list, err := jobs.List(ctx, sessionID)
+			if err != nil {
+				return fantasy.ToolResponse{}, fmt.Errorf("error listing jobs: %w", err)
+			}
+			if len(list) == 0 {
+				return fantasy.WithResponseMetadata(fantasy.NewTextResponse("No background jobs"), JobListResponseMetadata{}), nil
+			}
+
+			bgManager := shell.GetBackgroundShellManager()
+			metadata := JobListResponseMetadata{Jobs: make([]JobListItem, 0, len(list))}
+			var sb strings.Builder
+			for _, j := range list {
+				var shellID string
+				if bgShell, ok := bgManager.GetByJobID(j.ID); ok {
+					shellID = bgShell.ID
+				}
+				sb.WriteString(formatJob(j, shellID))
+				metadata.Jobs = append(metadata.Jobs, JobListItem{
+					ShellID:     shellID,
+					Command:     j.Command,
+					Description: j.Description,
+					Status:      string(j.Status),
+					ExitCode:    j.ExitCode,
+					Duration:    j.Duration().Milliseconds(),
+				})
+			}
+			return fantasy.WithResponseMetadata(fantasy.NewTextResponse(sb.String()), metadata), nil
+		})
+}
+
+// formatJob renders a job for the model, with its log files when it can't be
+// reached through its shell ID anymore.
+func formatJob(j job.Job, shellID string) string {
+	var sb strings.Builder
+	duration := j.Duration().Round(time.Second)
+	switch {
+	case j.Status == job.StatusRunning && shellID == "":
+		// The application stopped before the job, or another instance runs it.
+		fmt.Fprintf(&sb, "[-] not running here, started %s ago", duration)
+	case j.Status == job.StatusRunning:
+		fmt.Fprintf(&sb, "[%s] running for %s", shellIDOrDash(shellID), duration)
+	case j.Status == job.StatusFailed:
+		fmt.Fprintf(&sb, "[%s] failed with exit code %d after %s", shellIDOrDash(shellID), j.ExitCode, duration)
+	default:
+		fmt.Fprintf(&sb, "[%s] %s after %s", shellIDOrDash(shellID), j.Status, duration)
+	}
+	fmt.Fprintf(&sb, ": %s", j.Command)
+	if j.Description != "" {
+		fmt.Fprintf(&sb, " (%s)", j.Description)
+	}
+	sb.WriteString("\n")
+	if shellID == "" {
+		fmt.Fprintf(&sb, "    stdout: %s\n    stderr: %s\n", j.StdoutPath, j.StderrPath)
+	}
+	return sb.String()
+}
+
+func shellIDOrDash(shellID string) string {
+	if shellID == "" {
+		return "-"
+	}
+	return shellID
+}
@@ -27,9 +27,10 @@ type JobOutputResponseMetadata struct {
 	Description      string `json:"description"`
 	Done             bool   `json:"done"`
 	WorkingDirectory string `json:"working_directory"`
+	ArtifactPath     string `json:"artifact_path,omitempty"`
 }
 
-func NewJobOutputTool() fantasy.AgentTool {
+func NewJobOutputTool(dataDir string) fantasy.AgentTool {
 	return fantasy.NewAgentTool(
 		JobOutputToolName,
 		string(jobOutputDescription),
@@ -65,7 +66,7 @@ func NewJobOutputTool() fantasy.AgentTool {
 				}
 			}
 
-			output := strings.Join(outputParts, "\n")
+			output, artifactPath := spillOutput(ctx, dataDir, call.ID, strings.Join(outputParts, "\n"), MaxOutputLength)
 
 			metadata := JobOutputResponseMetadata{
 				ShellID:          params.ShellID,
@@ -73,6 +74,7 @@ func NewJobOutputTool() fantasy.AgentTool {
 				Description:      bgShell.Description,
 				Done:             done,
 				WorkingDirectory: bgShell.WorkingDir,
+				ArtifactPath:     artifactPath,
 			}
 
 			if output == "" {
@@ -2,9 +2,15 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/job"
+	"github.com/charmbracelet/crush/internal/permission"
 	"github.com/charmbracelet/crush/internal/shell"
 	"github.com/stretchr/testify/require"
 )
@@ -17,7 +23,7 @@ func TestBackgroundShell_Integration(t *testing.T) {
 
 	// Start a background shell
 	bgManager := shell.GetBackgroundShellManager()
-	bgShell, err := bgManager.Start(ctx, workingDir, nil, "echo 'hello background' && echo 'done'", "")
+	bgShell, err := bgManager.Start(ctx, "", workingDir, nil, "echo 'hello background' && echo 'done'", "")
 	require.NoError(t, err)
 	require.NotEmpty(t, bgShell.ID)
 
@@ -44,7 +50,7 @@ func TestBackgroundShell_Kill(t *testing.T) {
 
 	// Start a long-running background shell
 	bgManager := shell.GetBackgroundShellManager()
-	bgShell, err := bgManager.Start(ctx, workingDir, nil, "sleep 100", "")
+	bgShell, err := bgManager.Start(ctx, "", workingDir, nil, "sleep 100", "")
 	require.NoError(t, err)
 
 	// Kill it
@@ -67,7 +73,7 @@ func TestBackgroundShell_MultipleOutputCalls(t *testing.T) {
 
 	// Start a background shell
 	bgManager := shell.GetBackgroundShellManager()
-	bgShell, err := bgManager.Start(ctx, workingDir, nil, "echo 'step 1' && echo 'step 2' && echo 'step 3'", "")
+	bgShell, err := bgManager.Start(ctx, "", workingDir, nil, "echo 'step 1' && echo 'step 2' && echo 'step 3'", "")
 	require.NoError(t, err)
 	defer bgManager.Kill(bgShell.ID)
 
@@ -103,7 +109,7 @@ func TestBackgroundShell_EmptyOutput(t *testing.T) {
 
 	// Start a background shell with no output
 	bgManager := shell.GetBackgroundShellManager()
-	bgShell, err := bgManager.Start(ctx, workingDir, nil, "sleep 0.1", "")
+	bgShell, err := bgManager.Start(ctx, "", workingDir, nil, "sleep 0.1", "")
 	require.NoError(t, err)
 	defer bgManager.Kill(bgShell.ID)
 
@@ -125,7 +131,7 @@ func TestBackgroundShell_ExitCode(t *testing.T) {
 
 	// Start a background shell that exits with non-zero code
 	bgManager := shell.GetBackgroundShellManager()
-	bgShell, err := bgManager.Start(ctx, workingDir, nil, "echo 'failing' && exit 42", "")
+	bgShell, err := bgManager.Start(ctx, "", workingDir, nil, "echo 'failing' && exit 42", "")
 	require.NoError(t, err)
 	defer bgManager.Kill(bgShell.ID)
 
@@ -153,7 +159,7 @@ func TestBackgroundShell_WithBlockFuncs(t *testing.T) {
 
 	// Start a background shell with a blocked command
 	bgManager := shell.GetBackgroundShellManager()
-	bgShell, err := bgManager.Start(ctx, workingDir, blockFuncs, "curl example.com", "")
+	bgShell, err := bgManager.Start(ctx, "", workingDir, blockFuncs, "curl example.com", "")
 	require.NoError(t, err)
 	defer bgManager.Kill(bgShell.ID)
 
@@ -182,7 +188,7 @@ func TestBackgroundShell_StdoutAndStderr(t *testing.T) {
 
 	// Start a background shell with both stdout and stderr
 	bgManager := shell.GetBackgroundShellManager()
-	bgShell, err := bgManager.Start(ctx, workingDir, nil, "echo 'stdout message' && echo 'stderr message' >&2", "")
+	bgShell, err := bgManager.Start(ctx, "", workingDir, nil, "echo 'stdout message' && echo 'stderr message' >&2", "")
 	require.NoError(t, err)
 	defer bgManager.Kill(bgShell.ID)
 
@@ -204,7 +210,7 @@ func TestBackgroundShell_ConcurrentAccess(t *testing.T) {
 
 	// Start a background shell
 	bgManager := shell.GetBackgroundShellManager()
-	bgShell, err := bgManager.Start(ctx, workingDir, nil, "for i in 1 2 3 4 5; do echo \"line $i\"; sleep 0.05; done", "")
+	bgShell, err := bgManager.Start(ctx, "", workingDir, nil, "for i in 1 2 3 4 5; do echo \"line $i\"; sleep 0.05; done", "")
 	require.NoError(t, err)
 	defer bgManager.Kill(bgShell.ID)
 
@@ -257,7 +263,7 @@ func TestBackgroundShell_List(t *testing.T) {
 	// Start multiple background shells
 	shells := make([]*shell.BackgroundShell, 3)
 	for i := range 3 {
-		bgShell, err := bgManager.Start(ctx, workingDir, nil, "sleep 1", "")
+		bgShell, err := bgManager.Start(ctx, "", workingDir, nil, "sleep 1", "")
 		require.NoError(t, err)
 		shells[i] = bgShell
 	}
@@ -286,7 +292,7 @@ func TestBackgroundShell_AutoBackground(t *testing.T) {
 	t.Run("quick command completes synchronously", func(t *testing.T) {
 		t.Parallel()
 		bgManager := shell.GetBackgroundShellManager()
-		bgShell, err := bgManager.Start(ctx, workingDir, nil, "echo 'quick'", "")
+		bgShell, err := bgManager.Start(ctx, "", workingDir, nil, "echo 'quick'", "")
 		require.NoError(t, err)
 
 		// Wait threshold time
@@ -307,7 +313,7 @@ func TestBackgroundShell_AutoBackground(t *testing.T) {
 	t.Run("long command stays in background", func(t *testing.T) {
 		t.Parallel()
 		bgManager := shell.GetBackgroundShellManager()
-		bgShell, err := bgManager.Start(ctx, workingDir, nil, "sleep 20 && echo '20 seconds completed'", "")
+		bgShell, err := bgManager.Start(ctx, "", workingDir, nil, "sleep 20 && echo '20 seconds completed'", "")
 		require.NoError(t, err)
 		defer bgManager.Kill(bgShell.ID)
 
@@ -327,3 +333,103 @@ func TestBackgroundShell_AutoBackground(t *testing.T) {
 		require.Equal(t, bgShell.ID, retrieved.ID)
 	})
 }
+
+func TestFormatJob(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		job     func(job.Job) job.Job
+		shellID string
+		want    string
+	}{
+		{
+			name: "running",
+			job: func(j job.Job) job.Job {
+				j.Status = job.StatusRunning
+				j.Description = "Dev server"
+				return j
+			},
+			shellID: "003",
+			want:    "[003] running for 1h0m0s: npm run dev (Dev server)\n",
+		},
+		{
+			name: "failed",
+			job: func(j job.Job) job.Job {
+				j.Status, j.ExitCode, j.FinishedAt = job.StatusFailed, 1, j.StartedAt+90_000
+				return j
+			},
+			shellID: "004",
+			want:    "[004] failed with exit code 1 after 1m30s: npm run dev\n",
+		},
+		{
+			name: "previous run",
+			job: func(j job.Job) job.Job {
+				j.Status, j.FinishedAt = job.StatusKilled, j.StartedAt+90_000
+				return j
+			},
+			want: "[-] killed after 1m30s: npm run dev\n    stdout: /data/jobs/s/j.stdout.log\n    stderr: /data/jobs/s/j.stderr.log\n",
+		},
+		{
+			name: "still running elsewhere",
+			job: func(j job.Job) job.Job {
+				j.Status = job.StatusRunning
+				return j
+			},
+			want: "[-] not running here, started 1h0m0s ago: npm run dev\n    stdout: /data/jobs/s/j.stdout.log\n    stderr: /data/jobs/s/j.stderr.log\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			base := job.Job{
+				Command:    "npm run dev",
+				StdoutPath: "/data/jobs/s/j.stdout.log",
+				StderrPath: "/data/jobs/s/j.stderr.log",
+				StartedAt:  time.Now().Add(-time.Hour).UnixMilli(),
+			}
+			require.Equal(t, tt.want, formatJob(tt.job(base), tt.shellID))
+		})
+	}
+}
+
+func TestJobInputTool(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("pseudo-terminals are not available on Windows")
+	}
+
+	bgManager := shell.GetBackgroundShellManager()
+	bgShell, err := bgManager.StartPTY(t.Context(), "", t.TempDir(), nil, `printf 'continue? '; read answer; echo "answer: $answer"`, "", shell.DefaultPTYSize)
+	require.NoError(t, err)
+	defer bgManager.Kill(bgShell.ID)
+
+	require.Eventually(t, func() bool {
+		stdout, _, _, _ := bgShell.GetOutput()
+		return strings.Contains(stdout, "continue? ")
+	}, 5*time.Second, 20*time.Millisecond)
+
+	// What is typed goes through the permission rules like a command.
+	ctx := context.WithValue(t.Context(), SessionIDContextKey, "session")
+	permissions := permission.NewPermissionService(t.TempDir(), true, nil, permission.Rules{Deny: []string{"job_input(rm:*)"}})
+	tool := NewJobInputTool(permissions, "")
+	input, err := json.Marshal(JobInputParams{ShellID: bgShell.ID, Input: "rm -rf ~", Enter: true})
+	require.NoError(t, err)
+	resp, err := tool.Run(ctx, fantasy.ToolCall{ID: "call", Name: JobInputToolName, Input: string(input)})
+	require.NoError(t, err)
+	require.True(t, resp.IsError)
+	require.Contains(t, resp.Content, "job_input(rm:*)")
+
+	input, err = json.Marshal(JobInputParams{ShellID: bgShell.ID, Input: "yes", Enter: true})
+	require.NoError(t, err)
+	resp, err = tool.Run(ctx, fantasy.ToolCall{ID: "call", Name: JobInputToolName, Input: string(input)})
+	require.NoError(t, err)
+	require.False(t, resp.IsError, resp.Content)
+	// The prompt came before the input and isn't returned again.
+	require.Equal(t, "Status: completed\n\nyes\nanswer: yes\n", resp.Content)
+
+	resp, err = tool.Run(ctx, fantasy.ToolCall{ID: "call", Name: JobInputToolName, Input: string(input)})
+	require.NoError(t, err)
+	require.True(t, resp.IsError)
+	require.Contains(t, resp.Content, "has finished")
+}
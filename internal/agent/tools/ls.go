@@ -71,29 +71,33 @@ func NewLsTool(permissions permission.Service, workingDir string, lsConfig confi
 				return fantasy.NewTextErrorResponse(fmt.Sprintf("error resolving search path: %v", err)), nil
 			}
 
+			// Directories outside the working directory need a permission,
+			// the others only when a rule says so
 			relPath, err := filepath.Rel(absWorkingDir, absSearchPath)
-			if err != nil || strings.HasPrefix(relPath, "..") {
-				// Directory is outside working directory, request permission
-				sessionID := GetSessionFromContext(ctx)
-				if sessionID == "" {
-					return fantasy.ToolResponse{}, fmt.Errorf("session ID is required for accessing directories outside working directory")
-				}
-
-				granted := permissions.Request(
-					permission.CreatePermissionRequest{
-						SessionID:   sessionID,
-						Path:        absSearchPath,
-						ToolCallID:  call.ID,
-						ToolName:    LSToolName,
-						Action:      "list",
-						Description: fmt.Sprintf("List directory outside working directory: %s", absSearchPath),
-						Params:      LSPermissionsParams(params),
-					},
-				)
-
-				if !granted {
-					return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
-				}
+			outside := err != nil || strings.HasPrefix(relPath, "..")
+			sessionID := GetSessionFromContext(ctx)
+			if outside && sessionID == "" {
+				return fantasy.ToolResponse{}, fmt.Errorf("session ID is required for accessing directories outside working directory")
+			}
+			permissionReq := permission.CreatePermissionRequest{
+				SessionID:   sessionID,
+				Path:        absSearchPath,
+				ToolCallID:  call.ID,
+				ToolName:    LSToolName,
+				Action:      "list",
+				Description: fmt.Sprintf("List directory outside working directory: %s", absSearchPath),
+				Params:      LSPermissionsParams(params),
+				Targets:     []string{absSearchPath},
+				ReadOnly:    !outside,
+			}
+			if !outside {
+				permissionReq.Description = fmt.Sprintf("List directory: %s", absSearchPath)
+			}
+			if rule, denied := permissions.Denied(permissionReq); denied {
+				return NewRuleDeniedResponse(rule), nil
+			}
+			if !permissions.Request(permissionReq) {
+				return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
 			}
 
 			output, metadata, err := ListDirectoryTree(searchPath, params, lsConfig)
@@ -89,18 +89,19 @@ func (m *Tool) Run(ctx context.Context, params fantasy.ToolCall) (fantasy.ToolRe
 		return fantasy.ToolResponse{}, fmt.Errorf("session ID is required for creating a new file")
 	}
 	permissionDescription := fmt.Sprintf("execute %s with the following parameters:", m.Info().Name)
-	p := m.permissions.Request(
-		permission.CreatePermissionRequest{
-			SessionID:   sessionID,
-			ToolCallID:  params.ID,
-			Path:        m.workingDir,
-			ToolName:    m.Info().Name,
-			Action:      "execute",
-			Description: permissionDescription,
-			Params:      params.Input,
-		},
-	)
-	if !p {
+	permissionReq := permission.CreatePermissionRequest{
+		SessionID:   sessionID,
+		ToolCallID:  params.ID,
+		Path:        m.workingDir,
+		ToolName:    m.Info().Name,
+		Action:      "execute",
+		Description: permissionDescription,
+		Params:      params.Input,
+	}
+	if rule, denied := m.permissions.Denied(permissionReq); denied {
+		return NewRuleDeniedResponse(rule), nil
+	}
+	if !m.permissions.Request(permissionReq) {
 		return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
 	}
 
@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"charm.land/fantasy"
+)
+
+// mediaMetadataKey is the key of the media in the response metadata.
+const mediaMetadataKey = "media"
+
+// ToolMedia is an image returned by a tool for the model to look at, next to
+// the text of the result.
+type ToolMedia struct {
+	// Data is the base64 encoded content.
+	Data     string `json:"data"`
+	MIMEType string `json:"mime_type"`
+}
+
+// WithResponseMedia adds media to the response. It travels in the metadata,
+// the agent takes it out with TakeResponseMedia before storing the result.
+func WithResponseMedia(response fantasy.ToolResponse, data []byte, mimeType string) fantasy.ToolResponse {
+	metadata := map[string]any{}
+	if response.Metadata != "" {
+		if err := json.Unmarshal([]byte(response.Metadata), &metadata); err != nil {
+			return response
+		}
+	}
+	metadata[mediaMetadataKey] = ToolMedia{
+		Data:     base64.StdEncoding.EncodeToString(data),
+		MIMEType: mimeType,
+	}
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return response
+	}
+	response.Metadata = string(encoded)
+	return response
+}
+
+// TakeResponseMedia returns the media added to the metadata of a response,
+// if any, and the metadata without it.
+func TakeResponseMedia(metadata string) (*ToolMedia, string) {
+	if metadata == "" {
+		return nil, metadata
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(metadata), &fields); err != nil {
+		return nil, metadata
+	}
+	raw, ok := fields[mediaMetadataKey]
+	if !ok {
+		return nil, metadata
+	}
+	var media ToolMedia
+	if err := json.Unmarshal(raw, &media); err != nil || media.Data == "" {
+		return nil, metadata
+	}
+	delete(fields, mediaMetadataKey)
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return nil, metadata
+	}
+	return &media, string(encoded)
+}
@@ -96,11 +96,13 @@ func NewMultiEditTool(lspClients *csync.Map[string, *lsp.Client], permissions pe
 				return response, nil
 			}
 
-			// Notify LSP clients about the change
+			// Format the file and notify LSP clients about the change
+			formatting := formatOnWrite(ctx, lspClients, files, workingDir, params.FilePath)
 			notifyLSPs(ctx, lspClients, params.FilePath)
 
 			// Wait for LSP diagnostics and add them to the response
 			text := fmt.Sprintf("<result>\n%s\n</result>\n", response.Content)
+			text += formatting
 			text += getDiagnostics(params.FilePath, lspClients)
 			response.Content = text
 			return response, nil
@@ -165,7 +167,7 @@ func processMultiEditWithCreation(edit editContext, params MultiEditParams, call
 	// Check permissions
 	_, additions, removals := diff.GenerateDiff("", currentContent, strings.TrimPrefix(params.FilePath, edit.workingDir))
 
-	p := edit.permissions.Request(permission.CreatePermissionRequest{
+	permissionReq := permission.CreatePermissionRequest{
 		SessionID:   sessionID,
 		Path:        fsext.PathOrPrefix(params.FilePath, edit.workingDir),
 		ToolCallID:  call.ID,
@@ -177,13 +179,17 @@ func processMultiEditWithCreation(edit editContext, params MultiEditParams, call
 			OldContent: "",
 			NewContent: currentContent,
 		},
-	})
-	if !p {
+		Targets: []string{params.FilePath},
+	}
+	if rule, denied := edit.permissions.Denied(permissionReq); denied {
+		return NewRuleDeniedResponse(rule), nil
+	}
+	if !edit.permissions.Request(permissionReq) {
 		return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
 	}
 
 	// Write the file
-	err := os.WriteFile(params.FilePath, []byte(currentContent), 0o644)
+	err := writeFile(edit.ctx, params.FilePath, []byte(currentContent))
 	if err != nil {
 		return fantasy.ToolResponse{}, fmt.Errorf("failed to write file: %w", err)
 	}
@@ -253,7 +259,7 @@ func processMultiEditExistingFile(edit editContext, params MultiEditParams, call
 	}
 
 	// Read current file content
-	content, err := os.ReadFile(params.FilePath)
+	content, err := readFile(edit.ctx, params.FilePath)
 	if err != nil {
 		return fantasy.ToolResponse{}, fmt.Errorf("failed to read file: %w", err)
 	}
@@ -299,7 +305,7 @@ func processMultiEditExistingFile(edit editContext, params MultiEditParams, call
 
 	// Generate diff and check permissions
 	_, additions, removals := diff.GenerateDiff(oldContent, currentContent, strings.TrimPrefix(params.FilePath, edit.workingDir))
-	p := edit.permissions.Request(permission.CreatePermissionRequest{
+	permissionReq := permission.CreatePermissionRequest{
 		SessionID:   sessionID,
 		Path:        fsext.PathOrPrefix(params.FilePath, edit.workingDir),
 		ToolCallID:  call.ID,
@@ -311,8 +317,12 @@ func processMultiEditExistingFile(edit editContext, params MultiEditParams, call
 			OldContent: oldContent,
 			NewContent: currentContent,
 		},
-	})
-	if !p {
+		Targets: []string{params.FilePath},
+	}
+	if rule, denied := edit.permissions.Denied(permissionReq); denied {
+		return NewRuleDeniedResponse(rule), nil
+	}
+	if !edit.permissions.Request(permissionReq) {
 		return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
 	}
 
@@ -321,7 +331,7 @@ func processMultiEditExistingFile(edit editContext, params MultiEditParams, call
 	}
 
 	// Write the updated content
-	err = os.WriteFile(params.FilePath, []byte(currentContent), 0o644)
+	err = writeFile(edit.ctx, params.FilePath, []byte(currentContent))
 	if err != nil {
 		return fantasy.ToolResponse{}, fmt.Errorf("failed to write file: %w", err)
 	}
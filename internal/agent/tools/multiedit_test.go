@@ -22,6 +22,10 @@ func (m *mockPermissionService) Request(req permission.CreatePermissionRequest)
 	return true
 }
 
+func (m *mockPermissionService) Denied(req permission.CreatePermissionRequest) (string, bool) {
+	return "", false
+}
+
 func (m *mockPermissionService) Grant(req permission.PermissionRequest) {}
 
 func (m *mockPermissionService) Deny(req permission.PermissionRequest) {}
@@ -30,6 +34,10 @@ func (m *mockPermissionService) GrantPersistent(req permission.PermissionRequest
 
 func (m *mockPermissionService) AutoApproveSession(sessionID string) {}
 
+func (m *mockPermissionService) ApproveToolCall(toolCallID string) {}
+
+func (m *mockPermissionService) RevokeToolCall(toolCallID string) {}
+
 func (m *mockPermissionService) SetSkipRequests(skip bool) {}
 
 func (m *mockPermissionService) SkipRequests() bool {
@@ -0,0 +1,512 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/diff"
+	"github.com/charmbracelet/crush/internal/filepathext"
+	"github.com/charmbracelet/crush/internal/fsext"
+	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/permission"
+	"github.com/google/uuid"
+)
+
+type NotebookEditParams struct {
+	FilePath  string `json:"file_path" description:"The path to the notebook to edit"`
+	CellID    string `json:"cell_id,omitempty" description:"The ID of the cell to edit. When inserting, the new cell goes after it"`
+	CellIndex *int   `json:"cell_index,omitempty" description:"The index of the cell to edit (0-based), when cell_id isn't given. When inserting, the index the new cell gets"`
+	EditMode  string `json:"edit_mode,omitempty" description:"replace (default), insert or delete"`
+	CellType  string `json:"cell_type,omitempty" description:"The type of the cell, code or markdown. Required to insert a cell, changes the type of the cell when replacing"`
+	NewSource string `json:"new_source,omitempty" description:"The new source of the cell, to replace or insert"`
+}
+
+type NotebookEditPermissionsParams struct {
+	FilePath   string `json:"file_path"`
+	OldContent string `json:"old_content,omitempty"`
+	NewContent string `json:"new_content,omitempty"`
+}
+
+// NotebookEditResponseMetadata holds the cells of the notebook before and
+// after the edit as text, the diff of the JSON being unreadable.
+type NotebookEditResponseMetadata struct {
+	Additions  int    `json:"additions"`
+	Removals   int    `json:"removals"`
+	OldContent string `json:"old_content,omitempty"`
+	NewContent string `json:"new_content,omitempty"`
+	EditMode   string `json:"edit_mode"`
+	CellIndex  int    `json:"cell_index"`
+	CellID     string `json:"cell_id,omitempty"`
+}
+
+const (
+	NotebookEditToolName = "notebook_edit"
+
+	NotebookEditReplace = "replace"
+	NotebookEditInsert  = "insert"
+	NotebookEditDelete  = "delete"
+)
+
+//go:embed notebook_edit.md
+var notebookEditDescription []byte
+
+func NewNotebookEditTool(permissions permission.Service, files history.Service, workingDir string) fantasy.AgentTool {
+	return fantasy.NewAgentTool(
+		NotebookEditToolName,
+		string(notebookEditDescription),
+		func(ctx context.Context, params NotebookEditParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+			if params.FilePath == "" {
+				return fantasy.NewTextErrorResponse("file_path is required"), nil
+			}
+			if params.EditMode == "" {
+				params.EditMode = NotebookEditReplace
+			}
+			switch params.EditMode {
+			case NotebookEditReplace, NotebookEditInsert, NotebookEditDelete:
+			default:
+				return fantasy.NewTextErrorResponse(fmt.Sprintf("invalid edit_mode %q, use replace, insert or delete", params.EditMode)), nil
+			}
+			switch params.CellType {
+			case "", "code", "markdown", "raw":
+			default:
+				return fantasy.NewTextErrorResponse(fmt.Sprintf("invalid cell_type %q, use code or markdown", params.CellType)), nil
+			}
+			if params.EditMode == NotebookEditInsert && params.CellType == "" {
+				return fantasy.NewTextErrorResponse("cell_type is required to insert a cell"), nil
+			}
+
+			filePath := filepathext.SmartJoin(workingDir, params.FilePath)
+			fileInfo, err := os.Stat(filePath)
+			if err != nil {
+				if os.IsNotExist(err) {
+					return fantasy.NewTextErrorResponse(fmt.Sprintf("file not found: %s", filePath)), nil
+				}
+				return fantasy.ToolResponse{}, fmt.Errorf("failed to access file: %w", err)
+			}
+			if fileInfo.IsDir() {
+				return fantasy.NewTextErrorResponse(fmt.Sprintf("path is a directory, not a file: %s", filePath)), nil
+			}
+			lastRead := getLastReadTime(filePath)
+			if lastRead.IsZero() {
+				return fantasy.NewTextErrorResponse("you must read the notebook before editing it. Use the View tool first"), nil
+			}
+			if modTime := fileInfo.ModTime(); modTime.After(lastRead) {
+				return fantasy.NewTextErrorResponse(
+					fmt.Sprintf("file %s has been modified since it was last read (mod time: %s, last read: %s)",
+						filePath, modTime.Format(time.RFC3339), lastRead.Format(time.RFC3339),
+					)), nil
+			}
+
+			content, err := readFile(ctx, filePath)
+			if err != nil {
+				return fantasy.ToolResponse{}, fmt.Errorf("failed to read file: %w", err)
+			}
+			doc, err := parseNotebookDocument(content)
+			if err != nil {
+				return fantasy.NewTextErrorResponse(err.Error()), nil
+			}
+			oldCells := doc.cellsText()
+
+			index, err := doc.cellIndex(params)
+			if err != nil {
+				return fantasy.NewTextErrorResponse(err.Error()), nil
+			}
+			var result string
+			switch params.EditMode {
+			case NotebookEditReplace:
+				doc.replaceCell(index, params.CellType, params.NewSource)
+				result = "Replaced the source of"
+			case NotebookEditInsert:
+				doc.insertCell(index, params.CellType, params.NewSource)
+				result = "Inserted"
+			case NotebookEditDelete:
+				doc.cells = slices.Delete(doc.cells, index, index+1)
+				result = "Deleted"
+			}
+			cellID := params.CellID
+			if params.EditMode != NotebookEditDelete {
+				cellID = doc.cellID(index)
+			}
+			result = fmt.Sprintf("%s cell %d", result, index)
+			if cellID != "" {
+				result += fmt.Sprintf(" (id: %s)", cellID)
+			}
+			result += " in notebook: " + filePath
+
+			newContent, err := doc.encode()
+			if err != nil {
+				return fantasy.ToolResponse{}, fmt.Errorf("failed to encode notebook: %w", err)
+			}
+			newCells := doc.cellsText()
+
+			sessionID := GetSessionFromContext(ctx)
+			if sessionID == "" {
+				return fantasy.ToolResponse{}, fmt.Errorf("session ID is required for editing a notebook")
+			}
+			_, additions, removals := diff.GenerateDiff(oldCells, newCells, strings.TrimPrefix(filePath, workingDir))
+
+			permissionReq := permission.CreatePermissionRequest{
+				SessionID:   sessionID,
+				Path:        fsext.PathOrPrefix(filePath, workingDir),
+				ToolCallID:  call.ID,
+				ToolName:    NotebookEditToolName,
+				Action:      "write",
+				Description: fmt.Sprintf("Edit notebook %s", filePath),
+				Params: NotebookEditPermissionsParams{
+					FilePath:   filePath,
+					OldContent: oldCells,
+					NewContent: newCells,
+				},
+				Targets: []string{filePath},
+			}
+			if rule, denied := permissions.Denied(permissionReq); denied {
+				return NewRuleDeniedResponse(rule), nil
+			}
+			if !permissions.Request(permissionReq) {
+				return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
+			}
+
+			if err := writeFile(ctx, filePath, newContent); err != nil {
+				return fantasy.ToolResponse{}, fmt.Errorf("failed to write file: %w", err)
+			}
+
+			// Check if file exists in history
+			file, err := files.GetByPathAndSession(ctx, filePath, sessionID)
+			if err != nil {
+				_, err = files.Create(ctx, sessionID, filePath, string(content))
+				if err != nil {
+					return fantasy.ToolResponse{}, fmt.Errorf("error creating file history: %w", err)
+				}
+			}
+			if file.Content != string(content) {
+				// User manually changed the content, store an intermediate version
+				_, err = files.CreateVersion(ctx, sessionID, filePath, string(content))
+				if err != nil {
+					slog.Debug("Error creating file history version", "error", err)
+				}
+			}
+			// Store the new version
+			_, err = files.CreateVersion(ctx, sessionID, filePath, string(newContent))
+			if err != nil {
+				slog.Error("Error creating file history version", "error", err)
+			}
+
+			recordFileWrite(filePath)
+			recordFileRead(filePath)
+
+			return fantasy.WithResponseMetadata(
+				fantasy.NewTextResponse(result),
+				NotebookEditResponseMetadata{
+					Additions:  additions,
+					Removals:   removals,
+					OldContent: oldCells,
+					NewContent: newCells,
+					EditMode:   params.EditMode,
+					CellIndex:  index,
+					CellID:     cellID,
+				},
+			), nil
+		})
+}
+
+// jsonField is a field of a JSON object, with its value as it was written.
+type jsonField struct {
+	Key   string
+	Value json.RawMessage
+}
+
+// jsonObject is a JSON object which keeps its fields in order and their
+// values as they were written, so that only what's edited changes.
+type jsonObject []jsonField
+
+func parseJSONObject(data []byte) (jsonObject, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, errors.New("not a JSON object")
+	}
+	var obj jsonObject
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, errors.New("invalid JSON object key")
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		obj = append(obj, jsonField{Key: key, Value: value})
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (o jsonObject) get(key string) (json.RawMessage, bool) {
+	for _, field := range o {
+		if field.Key == key {
+			return field.Value, true
+		}
+	}
+	return nil, false
+}
+
+// set sets the value of a field, new fields are added in alphabetical order
+// like Jupyter writes them.
+func (o *jsonObject) set(key string, value json.RawMessage) {
+	for i, field := range *o {
+		if field.Key == key {
+			(*o)[i].Value = value
+			return
+		}
+	}
+	i, _ := slices.BinarySearchFunc(*o, key, func(field jsonField, key string) int {
+		return strings.Compare(field.Key, key)
+	})
+	*o = slices.Insert(*o, i, jsonField{Key: key, Value: value})
+}
+
+func (o *jsonObject) remove(key string) {
+	*o = slices.DeleteFunc(*o, func(field jsonField) bool { return field.Key == key })
+}
+
+func (o jsonObject) writeTo(buf *bytes.Buffer) {
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(encodeJSON(field.Key))
+		buf.WriteByte(':')
+		buf.Write(field.Value)
+	}
+	buf.WriteByte('}')
+}
+
+// encodeJSON encodes a value without escaping HTML characters, as Jupyter
+// doesn't.
+func encodeJSON(value any) json.RawMessage {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(value); err != nil {
+		return json.RawMessage("null")
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+// notebookDocument is a notebook being edited, which keeps the fields of the
+// notebook and of its cells as they were written.
+type notebookDocument struct {
+	fields jsonObject
+	cells  []jsonObject
+	// nb is the notebook as parsed to be viewed.
+	nb       *notebook
+	indent   string
+	trailing []byte
+}
+
+func parseNotebookDocument(data []byte) (*notebookDocument, error) {
+	nb, err := parseNotebook(data)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := parseJSONObject(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notebook: %w", err)
+	}
+	rawCells, _ := fields.get("cells")
+	var cells []json.RawMessage
+	if err := json.Unmarshal(rawCells, &cells); err != nil {
+		return nil, fmt.Errorf("invalid notebook cells: %w", err)
+	}
+	doc := &notebookDocument{
+		fields:   fields,
+		nb:       nb,
+		trailing: data[len(bytes.TrimRight(data, " \t\r\n")):],
+	}
+	for i, rawCell := range cells {
+		cell, err := parseJSONObject(rawCell)
+		if err != nil {
+			return nil, fmt.Errorf("invalid notebook cell %d: %w", i, err)
+		}
+		doc.cells = append(doc.cells, cell)
+	}
+	// The indentation is that of the first field, Jupyter uses one space.
+	if _, rest, ok := bytes.Cut(data, []byte("\n")); ok {
+		doc.indent = string(rest[:len(rest)-len(bytes.TrimLeft(rest, " \t"))])
+	}
+	return doc, nil
+}
+
+func (d *notebookDocument) encode() ([]byte, error) {
+	var cells bytes.Buffer
+	cells.WriteByte('[')
+	for i, cell := range d.cells {
+		if i > 0 {
+			cells.WriteByte(',')
+		}
+		cell.writeTo(&cells)
+	}
+	cells.WriteByte(']')
+	d.fields.set("cells", cells.Bytes())
+
+	var compact bytes.Buffer
+	d.fields.writeTo(&compact)
+	var out bytes.Buffer
+	if d.indent == "" {
+		if err := json.Compact(&out, compact.Bytes()); err != nil {
+			return nil, err
+		}
+	} else if err := json.Indent(&out, compact.Bytes(), "", d.indent); err != nil {
+		return nil, err
+	}
+	out.Write(d.trailing)
+	return out.Bytes(), nil
+}
+
+// cellIndex returns the index of the cell the edit is about. For inserts, it
+// is the index the new cell gets.
+func (d *notebookDocument) cellIndex(params NotebookEditParams) (int, error) {
+	if params.CellID != "" {
+		for i := range d.cells {
+			if d.cellID(i) == params.CellID {
+				if params.EditMode == NotebookEditInsert {
+					return i + 1, nil
+				}
+				return i, nil
+			}
+		}
+		return 0, fmt.Errorf("cell %q not found in the notebook", params.CellID)
+	}
+	if params.CellIndex == nil {
+		if params.EditMode == NotebookEditInsert {
+			return 0, nil
+		}
+		return 0, errors.New("cell_id or cell_index is required")
+	}
+	index := *params.CellIndex
+	last := len(d.cells) - 1
+	if params.EditMode == NotebookEditInsert {
+		last = len(d.cells)
+	}
+	if index < 0 || index > last {
+		return 0, fmt.Errorf("cell_index %d is out of range, the notebook has %d cells", index, len(d.cells))
+	}
+	return index, nil
+}
+
+func (d *notebookDocument) cellID(index int) string {
+	var id string
+	if raw, ok := d.cells[index].get("id"); ok {
+		_ = json.Unmarshal(raw, &id)
+	}
+	return id
+}
+
+func (d *notebookDocument) cellType(index int) string {
+	var cellType string
+	if raw, ok := d.cells[index].get("cell_type"); ok {
+		_ = json.Unmarshal(raw, &cellType)
+	}
+	return cellType
+}
+
+func (d *notebookDocument) replaceCell(index int, cellType, source string) {
+	cell := d.cells[index]
+	// Keep the source as a string or a list of lines, like it was.
+	raw, _ := cell.get("source")
+	if bytes.HasPrefix(bytes.TrimSpace(raw), []byte(`"`)) {
+		cell.set("source", encodeJSON(source))
+	} else {
+		cell.set("source", encodeJSON(notebookSourceLines(source)))
+	}
+	if cellType != "" && cellType != d.cellType(index) {
+		cell.set("cell_type", encodeJSON(cellType))
+		if cellType == "code" {
+			cell.set("execution_count", json.RawMessage("null"))
+			cell.set("outputs", json.RawMessage("[]"))
+		} else {
+			cell.remove("execution_count")
+			cell.remove("outputs")
+		}
+	}
+	d.cells[index] = cell
+}
+
+func (d *notebookDocument) insertCell(index int, cellType, source string) {
+	var cell jsonObject
+	cell.set("cell_type", encodeJSON(cellType))
+	cell.set("metadata", json.RawMessage("{}"))
+	cell.set("source", encodeJSON(notebookSourceLines(source)))
+	if cellType == "code" {
+		cell.set("execution_count", json.RawMessage("null"))
+		cell.set("outputs", json.RawMessage("[]"))
+	}
+	// Cells have IDs from nbformat 4.5.
+	if d.nb.NBFormat > 4 || d.nb.NBFormatMinor >= 5 {
+		cell.set("id", encodeJSON(d.newCellID()))
+	}
+	d.cells = slices.Insert(d.cells, index, cell)
+}
+
+func (d *notebookDocument) newCellID() string {
+	for {
+		id := strings.ReplaceAll(uuid.NewString(), "-", "")[:8]
+		if !slices.ContainsFunc(d.cells, func(cell jsonObject) bool {
+			raw, _ := cell.get("id")
+			return string(raw) == `"`+id+`"`
+		}) {
+			return id
+		}
+	}
+}
+
+// cellsText returns the cells as text, with a header for each cell, to show
+// what an edit changed.
+func (d *notebookDocument) cellsText() string {
+	var sb strings.Builder
+	for i, rawCell := range d.cells {
+		var cell notebookCell
+		var buf bytes.Buffer
+		rawCell.writeTo(&buf)
+		_ = json.Unmarshal(buf.Bytes(), &cell)
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "# %%%% [%s]", cell.CellType)
+		if cell.ID != "" {
+			fmt.Fprintf(&sb, " id=%s", cell.ID)
+		}
+		sb.WriteString("\n")
+		if source := strings.TrimRight(string(cell.Source), "\n"); source != "" {
+			sb.WriteString(source)
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// notebookSourceLines splits a source in lines the way notebooks store them,
+// each line but the last ending with its line break.
+func notebookSourceLines(source string) []string {
+	lines := strings.SplitAfter(source, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,318 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/permission"
+	"github.com/charmbracelet/crush/internal/pubsub"
+	"github.com/stretchr/testify/require"
+)
+
+// testNotebook is formatted the way Jupyter writes notebooks.
+const testNotebook = `{
+ "cells": [
+  {
+   "cell_type": "markdown",
+   "id": "intro",
+   "metadata": {},
+   "source": [
+    "# Analysis <draft>\n",
+    "Loads the data."
+   ]
+  },
+  {
+   "cell_type": "code",
+   "execution_count": 3,
+   "id": "load",
+   "metadata": {
+    "tags": [
+     "setup"
+    ]
+   },
+   "outputs": [
+    {
+     "name": "stdout",
+     "output_type": "stream",
+     "text": [
+      "42 rows\n"
+     ]
+    }
+   ],
+   "source": [
+    "import pandas as pd\n",
+    "df = pd.read_csv(\"data.csv\")"
+   ]
+  }
+ ],
+ "metadata": {
+  "kernelspec": {
+   "display_name": "Python 3",
+   "language": "python",
+   "name": "python3"
+  }
+ },
+ "nbformat": 4,
+ "nbformat_minor": 5
+}
+`
+
+type recordingHistoryService struct {
+	mockHistoryService
+	versions []string
+}
+
+func (m *recordingHistoryService) CreateVersion(ctx context.Context, sessionID, path, content string) (history.File, error) {
+	m.versions = append(m.versions, content)
+	return history.File{Path: path, Content: content}, nil
+}
+
+func cellIndex(index int) *int {
+	return &index
+}
+
+func writeTestNotebook(t *testing.T, content string) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "analysis.ipynb")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	recordFileRead(path)
+	return dir, path
+}
+
+func runNotebookEditTool(t *testing.T, workingDir string, files history.Service, params NotebookEditParams) fantasy.ToolResponse {
+	t.Helper()
+	if files == nil {
+		files = &mockHistoryService{Broker: pubsub.NewBroker[history.File]()}
+	}
+	permissions := &mockPermissionService{Broker: pubsub.NewBroker[permission.PermissionRequest]()}
+	tool := NewNotebookEditTool(permissions, files, workingDir)
+	input, err := json.Marshal(params)
+	require.NoError(t, err)
+	ctx := context.WithValue(t.Context(), SessionIDContextKey, "session")
+	resp, err := tool.Run(ctx, fantasy.ToolCall{ID: "call", Name: NotebookEditToolName, Input: string(input)})
+	require.NoError(t, err)
+	return resp
+}
+
+func readTestNotebook(t *testing.T, path string) *notebookDocument {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	doc, err := parseNotebookDocument(data)
+	require.NoError(t, err)
+	return doc
+}
+
+func TestNotebookDocument_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for name, content := range map[string]string{
+		"jupyter": testNotebook,
+		"compact": `{"cells":[{"cell_type":"code","source":"x = 1","metadata":{},"outputs":[],"execution_count":null}],"metadata":{},"nbformat":4,"nbformat_minor":2}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			doc, err := parseNotebookDocument([]byte(content))
+			require.NoError(t, err)
+			encoded, err := doc.encode()
+			require.NoError(t, err)
+			require.Equal(t, content, string(encoded))
+		})
+	}
+}
+
+func TestNotebookDocument_CellsText(t *testing.T) {
+	t.Parallel()
+
+	doc, err := parseNotebookDocument([]byte(testNotebook))
+	require.NoError(t, err)
+	require.Equal(t, "# %% [markdown] id=intro\n# Analysis <draft>\nLoads the data.\n"+
+		"\n# %% [code] id=load\nimport pandas as pd\ndf = pd.read_csv(\"data.csv\")\n", doc.cellsText())
+}
+
+func TestNotebookSourceLines(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []string{"a\n", "b"}, notebookSourceLines("a\nb"))
+	require.Equal(t, []string{"a\n", "b\n"}, notebookSourceLines("a\nb\n"))
+	require.Empty(t, notebookSourceLines(""))
+}
+
+func TestNotebookEditTool_Replace(t *testing.T) {
+	t.Parallel()
+
+	dir, path := writeTestNotebook(t, testNotebook)
+	files := &recordingHistoryService{mockHistoryService: mockHistoryService{Broker: pubsub.NewBroker[history.File]()}}
+	resp := runNotebookEditTool(t, dir, files, NotebookEditParams{
+		FilePath:  "analysis.ipynb",
+		CellID:    "load",
+		NewSource: "import polars as pl\ndf = pl.read_csv(\"data.csv\")",
+	})
+	require.False(t, resp.IsError, resp.Content)
+	require.Contains(t, resp.Content, "Replaced the source of cell 1 (id: load)")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	// Only the source changed, outputs, metadata and formatting are kept.
+	want := strings.NewReplacer("pandas as pd", "polars as pl", "pd.read_csv", "pl.read_csv").Replace(testNotebook)
+	require.Equal(t, want, string(data))
+
+	// The notebook before the edit, then after it.
+	require.Equal(t, []string{testNotebook, string(data)}, files.versions)
+
+	var meta NotebookEditResponseMetadata
+	require.NoError(t, json.Unmarshal([]byte(resp.Metadata), &meta))
+	require.Equal(t, NotebookEditReplace, meta.EditMode)
+	require.Equal(t, 1, meta.CellIndex)
+	require.Equal(t, "load", meta.CellID)
+	require.Equal(t, 2, meta.Additions)
+	require.Equal(t, 2, meta.Removals)
+}
+
+func TestNotebookEditTool_ChangeType(t *testing.T) {
+	t.Parallel()
+
+	dir, path := writeTestNotebook(t, testNotebook)
+	resp := runNotebookEditTool(t, dir, nil, NotebookEditParams{
+		FilePath:  "analysis.ipynb",
+		CellIndex: cellIndex(1),
+		CellType:  "markdown",
+		NewSource: "Loading is done elsewhere.",
+	})
+	require.False(t, resp.IsError, resp.Content)
+
+	doc := readTestNotebook(t, path)
+	require.Equal(t, "markdown", doc.cellType(1))
+	_, hasOutputs := doc.cells[1].get("outputs")
+	require.False(t, hasOutputs)
+	_, hasCount := doc.cells[1].get("execution_count")
+	require.False(t, hasCount)
+	metadata, _ := doc.cells[1].get("metadata")
+	require.Contains(t, string(metadata), "setup")
+}
+
+func TestNotebookEditTool_Insert(t *testing.T) {
+	t.Parallel()
+
+	dir, path := writeTestNotebook(t, testNotebook)
+	resp := runNotebookEditTool(t, dir, nil, NotebookEditParams{
+		FilePath:  "analysis.ipynb",
+		CellID:    "load",
+		EditMode:  NotebookEditInsert,
+		CellType:  "code",
+		NewSource: "df.describe()\n",
+	})
+	require.False(t, resp.IsError, resp.Content)
+	require.Contains(t, resp.Content, "Inserted cell 2")
+
+	doc := readTestNotebook(t, path)
+	require.Len(t, doc.cells, 3)
+	require.Equal(t, "code", doc.cellType(2))
+	require.Len(t, doc.cellID(2), 8)
+	require.Contains(t, resp.Content, doc.cellID(2))
+	require.Equal(t, "df.describe()\n", string(doc.nb.Cells[2].Source))
+	outputs, _ := doc.cells[2].get("outputs")
+	require.Equal(t, "[]", string(outputs))
+
+	resp = runNotebookEditTool(t, dir, nil, NotebookEditParams{
+		FilePath:  "analysis.ipynb",
+		CellIndex: cellIndex(0),
+		EditMode:  NotebookEditInsert,
+		CellType:  "markdown",
+		NewSource: "Draft",
+	})
+	require.False(t, resp.IsError, resp.Content)
+	doc = readTestNotebook(t, path)
+	require.Len(t, doc.cells, 4)
+	require.Equal(t, "Draft", string(doc.nb.Cells[0].Source))
+	require.Equal(t, "intro", doc.cellID(1))
+}
+
+func TestNotebookEditTool_InsertWithoutIDs(t *testing.T) {
+	t.Parallel()
+
+	dir, path := writeTestNotebook(t, `{"cells":[],"metadata":{},"nbformat":4,"nbformat_minor":4}`)
+	resp := runNotebookEditTool(t, dir, nil, NotebookEditParams{
+		FilePath:  "analysis.ipynb",
+		EditMode:  NotebookEditInsert,
+		CellType:  "code",
+		NewSource: "print(1)",
+	})
+	require.False(t, resp.IsError, resp.Content)
+	require.NotContains(t, resp.Content, "id:")
+
+	doc := readTestNotebook(t, path)
+	require.Len(t, doc.cells, 1)
+	require.Empty(t, doc.cellID(0))
+}
+
+func TestNotebookEditTool_Delete(t *testing.T) {
+	t.Parallel()
+
+	dir, path := writeTestNotebook(t, testNotebook)
+	resp := runNotebookEditTool(t, dir, nil, NotebookEditParams{
+		FilePath: "analysis.ipynb",
+		CellID:   "intro",
+		EditMode: NotebookEditDelete,
+	})
+	require.False(t, resp.IsError, resp.Content)
+	require.Contains(t, resp.Content, "Deleted cell 0 (id: intro)")
+
+	doc := readTestNotebook(t, path)
+	require.Len(t, doc.cells, 1)
+	require.Equal(t, "load", doc.cellID(0))
+	metadata, _ := doc.fields.get("metadata")
+	require.Contains(t, string(metadata), "kernelspec")
+}
+
+func TestNotebookEditTool_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir, _ := writeTestNotebook(t, testNotebook)
+	unread := filepath.Join(dir, "unread.ipynb")
+	require.NoError(t, os.WriteFile(unread, []byte(testNotebook), 0o644))
+
+	for name, tc := range map[string]struct {
+		params NotebookEditParams
+		want   string
+	}{
+		"unread": {
+			params: NotebookEditParams{FilePath: "unread.ipynb", CellID: "load", NewSource: "x"},
+			want:   "you must read the notebook",
+		},
+		"missing cell": {
+			params: NotebookEditParams{FilePath: "analysis.ipynb", CellID: "nope", NewSource: "x"},
+			want:   `cell "nope" not found`,
+		},
+		"index out of range": {
+			params: NotebookEditParams{FilePath: "analysis.ipynb", CellIndex: cellIndex(2), NewSource: "x"},
+			want:   "out of range, the notebook has 2 cells",
+		},
+		"no cell": {
+			params: NotebookEditParams{FilePath: "analysis.ipynb", NewSource: "x"},
+			want:   "cell_id or cell_index is required",
+		},
+		"insert without type": {
+			params: NotebookEditParams{FilePath: "analysis.ipynb", EditMode: NotebookEditInsert, NewSource: "x"},
+			want:   "cell_type is required",
+		},
+		"invalid mode": {
+			params: NotebookEditParams{FilePath: "analysis.ipynb", CellID: "load", EditMode: "append"},
+			want:   "invalid edit_mode",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			resp := runNotebookEditTool(t, dir, nil, tc.params)
+			require.True(t, resp.IsError)
+			require.Contains(t, resp.Content, tc.want)
+		})
+	}
+}
@@ -0,0 +1,593 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// patchOp is what a patch does to a file.
+type patchOp string
+
+const (
+	patchAdd    patchOp = "add"
+	patchUpdate patchOp = "update"
+	patchDelete patchOp = "delete"
+)
+
+// filePatch is the change a patch makes to a file. New files are a single
+// hunk of added lines.
+type filePatch struct {
+	Op       patchOp
+	Path     string
+	MovePath string
+	Hunks    []patchHunk
+}
+
+// patchHunk is a run of context, removed and added lines.
+type patchHunk struct {
+	// Anchors are lines the hunk comes after, from the "@@" headers of V4A
+	// patches.
+	Anchors []string
+	// Start is the index of the line the hunk starts at in the original file,
+	// from the "@@ -l,n +l,n @@" headers of unified diffs, or -1.
+	Start int
+	Lines []patchLine
+	// EOF is set when the hunk ends at the end of the file.
+	EOF bool
+	// NoNewlineOld and NoNewlineNew are set when the file has no line break
+	// at its end before or after the hunk.
+	NoNewlineOld bool
+	NoNewlineNew bool
+}
+
+// patchLine is a line of a hunk, its op is ' ', '-' or '+'.
+type patchLine struct {
+	Op   byte
+	Text string
+}
+
+// oldLines returns the lines the hunk expects in the file.
+func (h patchHunk) oldLines() []string {
+	var lines []string
+	for _, line := range h.Lines {
+		if line.Op != '+' {
+			lines = append(lines, line.Text)
+		}
+	}
+	return lines
+}
+
+const (
+	v4aBegin      = "*** Begin Patch"
+	v4aEnd        = "*** End Patch"
+	v4aAddFile    = "*** Add File: "
+	v4aDeleteFile = "*** Delete File: "
+	v4aUpdateFile = "*** Update File: "
+	v4aMoveTo     = "*** Move to: "
+	v4aEndOfFile  = "*** End of File"
+	devNull       = "/dev/null"
+)
+
+// parsePatch parses a patch in the V4A format, between "*** Begin Patch" and
+// "*** End Patch", or as a unified diff.
+func parsePatch(patch string) ([]filePatch, error) {
+	patch = strings.ReplaceAll(patch, "\r\n", "\n")
+	lines := strings.Split(strings.TrimSuffix(patch, "\n"), "\n")
+	lines = trimCodeFence(lines)
+
+	var files []filePatch
+	var err error
+	if begin := findV4ABegin(lines); begin >= 0 {
+		files, err = parseV4APatch(lines[begin+1:])
+	} else {
+		files, err = parseUnifiedDiff(lines)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, errors.New("no file changes found in the patch")
+	}
+	return files, nil
+}
+
+// trimCodeFence removes the markdown code fence models sometimes wrap
+// patches in.
+func trimCodeFence(lines []string) []string {
+	if len(lines) >= 2 && strings.HasPrefix(lines[0], "```") && strings.TrimSpace(lines[len(lines)-1]) == "```" {
+		return lines[1 : len(lines)-1]
+	}
+	return lines
+}
+
+func findV4ABegin(lines []string) int {
+	for i, line := range lines {
+		if strings.TrimSpace(line) == v4aBegin {
+			return i
+		}
+	}
+	return -1
+}
+
+func isV4AFileHeader(line string) bool {
+	return strings.HasPrefix(line, v4aAddFile) ||
+		strings.HasPrefix(line, v4aDeleteFile) ||
+		strings.HasPrefix(line, v4aUpdateFile) ||
+		strings.TrimSpace(line) == v4aEnd
+}
+
+func parseV4APatch(lines []string) ([]filePatch, error) {
+	var files []filePatch
+	i := 0
+	for i < len(lines) {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == v4aEnd:
+			return files, nil
+		case strings.TrimSpace(line) == "":
+			i++
+		case strings.HasPrefix(line, v4aAddFile):
+			path, err := v4aPath(line, v4aAddFile)
+			if err != nil {
+				return nil, err
+			}
+			file := filePatch{Op: patchAdd, Path: path}
+			hunk := patchHunk{Start: 0}
+			for i++; i < len(lines) && !isV4AFileHeader(lines[i]); i++ {
+				switch {
+				case strings.HasPrefix(lines[i], "+"):
+					hunk.Lines = append(hunk.Lines, patchLine{Op: '+', Text: lines[i][1:]})
+				case strings.TrimSpace(lines[i]) == "" && onlyBlankLinesLeft(lines[i:]):
+				default:
+					return nil, fmt.Errorf("invalid line in added file %s, every line must start with '+': %q", file.Path, lines[i])
+				}
+			}
+			file.Hunks = []patchHunk{hunk}
+			files = append(files, file)
+		case strings.HasPrefix(line, v4aDeleteFile):
+			path, err := v4aPath(line, v4aDeleteFile)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, filePatch{Op: patchDelete, Path: path})
+			i++
+		case strings.HasPrefix(line, v4aUpdateFile):
+			path, err := v4aPath(line, v4aUpdateFile)
+			if err != nil {
+				return nil, err
+			}
+			file := filePatch{Op: patchUpdate, Path: path}
+			i++
+			if i < len(lines) && strings.HasPrefix(lines[i], v4aMoveTo) {
+				if file.MovePath, err = v4aPath(lines[i], v4aMoveTo); err != nil {
+					return nil, err
+				}
+				i++
+			}
+			file.Hunks, i, err = parseV4AHunks(lines, i)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", file.Path, err)
+			}
+			if len(file.Hunks) == 0 && file.MovePath == "" {
+				return nil, fmt.Errorf("update of %s has no changes", file.Path)
+			}
+			files = append(files, file)
+		default:
+			return nil, fmt.Errorf("invalid patch line %q, expected a file header like %q", line, v4aUpdateFile+"path")
+		}
+	}
+	return files, nil
+}
+
+// v4aPath returns the path of a file header.
+func v4aPath(line, header string) (string, error) {
+	path := strings.TrimSpace(strings.TrimPrefix(line, header))
+	if path == "" {
+		return "", fmt.Errorf("%q has no path", strings.TrimSpace(line))
+	}
+	return path, nil
+}
+
+// onlyBlankLinesLeft reports whether the lines up to the next file header
+// are blank.
+func onlyBlankLinesLeft(lines []string) bool {
+	for _, line := range lines {
+		if isV4AFileHeader(line) {
+			return true
+		}
+		if strings.TrimSpace(line) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseV4AHunks parses the hunks of an updated file up to the next file
+// header, and returns the index of that header.
+func parseV4AHunks(lines []string, i int) ([]patchHunk, int, error) {
+	var hunks []patchHunk
+	hunk := patchHunk{Start: -1}
+	flush := func() {
+		if len(hunk.Lines) > 0 {
+			hunks = append(hunks, hunk)
+		}
+		hunk = patchHunk{Start: -1}
+	}
+	for ; i < len(lines) && !isV4AFileHeader(lines[i]); i++ {
+		line := lines[i]
+		switch {
+		case line == "@@" || strings.HasPrefix(line, "@@ "):
+			// Consecutive headers narrow down where the hunk is.
+			if len(hunk.Lines) > 0 || hunk.EOF {
+				flush()
+			}
+			if anchor := strings.TrimSpace(strings.TrimPrefix(line, "@@")); anchor != "" {
+				hunk.Anchors = append(hunk.Anchors, anchor)
+			}
+		case strings.TrimSpace(line) == v4aEndOfFile:
+			hunk.EOF = true
+		case line == "":
+			hunk.Lines = append(hunk.Lines, patchLine{Op: ' '})
+		case line[0] == ' ' || line[0] == '-' || line[0] == '+':
+			if hunk.EOF {
+				return nil, i, fmt.Errorf("invalid hunk line %q after %q", line, v4aEndOfFile)
+			}
+			hunk.Lines = append(hunk.Lines, patchLine{Op: line[0], Text: line[1:]})
+		default:
+			return nil, i, fmt.Errorf("invalid hunk line %q, lines must start with ' ', '-' or '+'", line)
+		}
+	}
+	if len(hunk.Lines) == 0 && len(hunk.Anchors) > 0 {
+		return nil, i, fmt.Errorf("hunk %q has no lines", "@@ "+strings.Join(hunk.Anchors, " "))
+	}
+	flush()
+	return hunks, i, nil
+}
+
+// parseUnifiedDiff parses a unified diff, as written by diff -u or git diff.
+// The line counts of the hunk headers are not trusted, models often get them
+// wrong; hunks end at the next header instead.
+func parseUnifiedDiff(lines []string) ([]filePatch, error) {
+	var files []filePatch
+	var (
+		file       *filePatch
+		oldPath    string
+		newPath    string
+		renameFrom string
+		renameTo   string
+		gitOp      patchOp
+	)
+	finish := func() error {
+		if file == nil {
+			return nil
+		}
+		defer func() { file = nil }()
+		// Git prefixes the paths with a/ and b/, but not those of renames.
+		if (strings.HasPrefix(oldPath, "a/") || oldPath == devNull) && (strings.HasPrefix(newPath, "b/") || newPath == devNull) {
+			oldPath, newPath = strings.TrimPrefix(oldPath, "a/"), strings.TrimPrefix(newPath, "b/")
+		}
+		if renameFrom != "" && renameTo != "" {
+			oldPath, newPath = renameFrom, renameTo
+		}
+		switch {
+		case gitOp == patchAdd || oldPath == devNull:
+			file.Op, file.Path = patchAdd, newPath
+			if len(file.Hunks) == 0 {
+				file.Hunks = []patchHunk{{Start: 0}}
+			}
+		case gitOp == patchDelete || newPath == devNull:
+			file.Op, file.Path = patchDelete, oldPath
+		default:
+			file.Op, file.Path = patchUpdate, oldPath
+			if newPath != oldPath {
+				file.MovePath = newPath
+			}
+			if len(file.Hunks) == 0 && file.MovePath == "" {
+				return fmt.Errorf("diff of %s has no hunks", oldPath)
+			}
+		}
+		if file.Path == "" || file.Path == devNull {
+			return errors.New("diff without a file name")
+		}
+		files = append(files, *file)
+		return nil
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			if err := finish(); err != nil {
+				return nil, err
+			}
+			file = &filePatch{}
+			oldPath, newPath = parseGitDiffHeader(strings.TrimPrefix(line, "diff --git "))
+			renameFrom, renameTo, gitOp = "", "", ""
+		case file != nil && len(file.Hunks) == 0 && strings.HasPrefix(line, "rename from "):
+			renameFrom = unifiedDiffPath(strings.TrimPrefix(line, "rename from "))
+		case file != nil && len(file.Hunks) == 0 && strings.HasPrefix(line, "rename to "):
+			renameTo = unifiedDiffPath(strings.TrimPrefix(line, "rename to "))
+		case file != nil && len(file.Hunks) == 0 && strings.HasPrefix(line, "new file mode"):
+			gitOp = patchAdd
+		case file != nil && len(file.Hunks) == 0 && strings.HasPrefix(line, "deleted file mode"):
+			gitOp = patchDelete
+		case strings.HasPrefix(line, "GIT binary patch") || strings.HasPrefix(line, "Binary files "):
+			return nil, errors.New("binary patches are not supported")
+		case strings.HasPrefix(line, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ "):
+			// A new file, unless it's the header following "diff --git".
+			if file == nil || len(file.Hunks) > 0 {
+				if err := finish(); err != nil {
+					return nil, err
+				}
+				file = &filePatch{}
+				renameFrom, renameTo, gitOp = "", "", ""
+			}
+			oldPath = unifiedDiffPath(strings.TrimPrefix(line, "--- "))
+			newPath = unifiedDiffPath(strings.TrimPrefix(lines[i+1], "+++ "))
+			i++
+		case strings.HasPrefix(line, "@@"):
+			if file == nil {
+				return nil, fmt.Errorf("hunk %q comes before any file header", line)
+			}
+			hunk, next, err := parseUnifiedHunk(lines, i)
+			if err != nil {
+				return nil, err
+			}
+			file.Hunks = append(file.Hunks, hunk)
+			i = next - 1
+		}
+		// Anything else, like "index" lines or a commit message, is ignored.
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// parseGitDiffHeader returns the paths of a "diff --git a/path b/path"
+// header, they are replaced by those of the "---" and "+++" lines if any.
+func parseGitDiffHeader(header string) (string, string) {
+	// The paths are ambiguous when they contain spaces, the header is
+	// split in the middle when both are the same.
+	if half := (len(header) - 1) / 2; len(header)%2 == 1 && header[half] == ' ' {
+		a, b := header[:half], header[half+1:]
+		if strings.TrimPrefix(a, "a/") == strings.TrimPrefix(b, "b/") {
+			return a, b
+		}
+	}
+	a, b, _ := strings.Cut(header, " ")
+	return a, b
+}
+
+// unifiedDiffPath returns the path of a "---" or "+++" line, without the
+// timestamp diff -u adds.
+func unifiedDiffPath(path string) string {
+	path, _, _ = strings.Cut(path, "\t")
+	path = strings.TrimSpace(path)
+	if unquoted, err := strconv.Unquote(path); err == nil && strings.HasPrefix(path, `"`) {
+		path = unquoted
+	}
+	return path
+}
+
+// parseUnifiedHunk parses the hunk whose header is at index i, and returns
+// the index of the line after it.
+func parseUnifiedHunk(lines []string, i int) (patchHunk, int, error) {
+	hunk := patchHunk{Start: -1}
+	if start, ok := parseHunkHeader(lines[i]); ok {
+		hunk.Start = start
+	}
+	i++
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if strings.HasPrefix(line, "@@") ||
+			strings.HasPrefix(line, "diff --git ") ||
+			(strings.HasPrefix(line, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ ")) {
+			break
+		}
+		switch {
+		case line == "":
+			hunk.Lines = append(hunk.Lines, patchLine{Op: ' '})
+		case line[0] == ' ' || line[0] == '-' || line[0] == '+':
+			hunk.Lines = append(hunk.Lines, patchLine{Op: line[0], Text: line[1:]})
+		case strings.HasPrefix(line, `\`):
+			// "\ No newline at end of file" is about the line before it.
+			if len(hunk.Lines) == 0 {
+				return hunk, i, fmt.Errorf("%q without a line before it", line)
+			}
+			switch hunk.Lines[len(hunk.Lines)-1].Op {
+			case '-':
+				hunk.NoNewlineOld = true
+			case '+':
+				hunk.NoNewlineNew = true
+			default:
+				hunk.NoNewlineOld, hunk.NoNewlineNew = true, true
+			}
+		default:
+			// The end of the diff, like a signature or a trailer.
+			return trimHunk(hunk), i, nil
+		}
+	}
+	return trimHunk(hunk), i, nil
+}
+
+// trimHunk removes the blank context lines at the end of a hunk, which are
+// more likely separators than context. Less context still applies.
+func trimHunk(hunk patchHunk) patchHunk {
+	for len(hunk.Lines) > 0 {
+		last := hunk.Lines[len(hunk.Lines)-1]
+		if last.Op != ' ' || last.Text != "" {
+			break
+		}
+		hunk.Lines = hunk.Lines[:len(hunk.Lines)-1]
+	}
+	return hunk
+}
+
+// parseHunkHeader returns the index of the first line of a hunk, from its
+// "@@ -l,n +l,n @@" header.
+func parseHunkHeader(header string) (int, bool) {
+	rest, ok := strings.CutPrefix(header, "@@ -")
+	if !ok {
+		return 0, false
+	}
+	old, _, ok := strings.Cut(rest, " ")
+	if !ok {
+		return 0, false
+	}
+	startText, countText, hasCount := strings.Cut(old, ",")
+	start, err := strconv.Atoi(startText)
+	if err != nil || start < 0 {
+		return 0, false
+	}
+	count := 1
+	if hasCount {
+		if count, err = strconv.Atoi(countText); err != nil {
+			return 0, false
+		}
+	}
+	// An empty range is the line before the hunk.
+	if count == 0 {
+		return start, true
+	}
+	return max(start-1, 0), true
+}
+
+// applyHunks applies hunks to the content of a file. Hunks are looked for in
+// order, from where the previous one ended, first exactly and then ignoring
+// differences in whitespace and punctuation.
+func applyHunks(content string, hunks []patchHunk) (string, error) {
+	lines := strings.Split(content, "\n")
+	endsWithNewline := true
+	if content == "" {
+		lines = nil
+	} else if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	} else {
+		endsWithNewline = false
+	}
+
+	type replacement struct {
+		start, length int
+		lines         []string
+	}
+	var replacements []replacement
+	cursor := 0
+	for i, hunk := range hunks {
+		for _, anchor := range hunk.Anchors {
+			index := seekLines(lines, []string{anchor}, cursor, false)
+			if index < 0 {
+				return "", fmt.Errorf("hunk %d: could not find the line %q", i+1, anchor)
+			}
+			cursor = index + 1
+		}
+
+		oldLines := hunk.oldLines()
+		var start int
+		switch {
+		case len(oldLines) > 0:
+			start = -1
+			if hunk.Start >= cursor {
+				start = seekLines(lines, oldLines, hunk.Start, hunk.EOF)
+			}
+			if start < 0 {
+				start = seekLines(lines, oldLines, cursor, hunk.EOF)
+			}
+			if start < 0 {
+				return "", fmt.Errorf("hunk %d does not apply, could not find these lines:\n%s", i+1, strings.Join(oldLines, "\n"))
+			}
+		case hunk.Start >= cursor && hunk.Start <= len(lines):
+			start = hunk.Start
+		case len(hunk.Anchors) > 0:
+			start = cursor
+		default:
+			// Lines added without context go at the end.
+			start = len(lines)
+		}
+
+		// Context lines are kept as they are in the file, which may differ
+		// from the patch in whitespace.
+		var newLines []string
+		at := start
+		for _, line := range hunk.Lines {
+			switch line.Op {
+			case ' ':
+				newLines = append(newLines, lines[at])
+				at++
+			case '-':
+				at++
+			case '+':
+				newLines = append(newLines, line.Text)
+			}
+		}
+		replacements = append(replacements, replacement{start, len(oldLines), newLines})
+		cursor = start + len(oldLines)
+		if cursor == len(lines) {
+			if hunk.NoNewlineNew {
+				endsWithNewline = false
+			} else if hunk.NoNewlineOld {
+				endsWithNewline = true
+			}
+		}
+	}
+
+	for i := len(replacements) - 1; i >= 0; i-- {
+		r := replacements[i]
+		lines = append(lines[:r.start], append(r.lines, lines[r.start+r.length:]...)...)
+	}
+	if len(lines) == 0 {
+		return "", nil
+	}
+	result := strings.Join(lines, "\n")
+	if endsWithNewline {
+		result += "\n"
+	}
+	return result, nil
+}
+
+// seekLines returns the index of the first run of lines matching pattern
+// from start, or -1. Lines match exactly, then ignoring trailing whitespace,
+// then ignoring surrounding whitespace and then with typographic punctuation
+// replaced by ASCII. With eof, the end of the file is tried first.
+func seekLines(lines, pattern []string, start int, eof bool) int {
+	if len(pattern) > len(lines) {
+		return -1
+	}
+	normalizers := []func(string) string{
+		func(s string) string { return s },
+		func(s string) string { return strings.TrimRight(s, " \t") },
+		strings.TrimSpace,
+		func(s string) string { return patchPunctuation.Replace(strings.TrimSpace(s)) },
+	}
+	last := len(lines) - len(pattern)
+	for _, normalize := range normalizers {
+		matches := func(at int) bool {
+			for j, line := range pattern {
+				if normalize(lines[at+j]) != normalize(line) {
+					return false
+				}
+			}
+			return true
+		}
+		if eof && last >= start && matches(last) {
+			return last
+		}
+		for at := start; at <= last; at++ {
+			if matches(at) {
+				return at
+			}
+		}
+	}
+	return -1
+}
+
+// patchPunctuation replaces the typographic punctuation models sometimes
+// write instead of the ASCII one in the file.
+var patchPunctuation = strings.NewReplacer(
+	"‐", "-", "‑", "-", "‒", "-", "–", "-", "—", "-", "−", "-",
+	"‘", "'", "’", "'", "‚", "'", "‛", "'",
+	"“", `"`, "”", `"`, "„", `"`, "‟", `"`,
+	" ", " ", " ", " ", " ", " ", " ", " ", " ", " ",
+)
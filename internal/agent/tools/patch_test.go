@@ -0,0 +1,344 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/crush/internal/diff"
+	"github.com/stretchr/testify/require"
+)
+
+const testV4APatch = `*** Begin Patch
+*** Add File: docs/new.md
++# New
++
++Hello.
+*** Update File: main.go
+@@ func main() {
+ 	ctx := context.Background()
+-	run(ctx)
++	if err := run(ctx); err != nil {
++		log.Fatal(err)
++	}
+ }
+@@
+-// end
++// the end
+*** End of File
+*** Update File: old.go
+*** Move to: new.go
+*** Delete File: unused.go
+*** End Patch`
+
+const testUnifiedDiff = `diff --git a/main.go b/main.go
+index 3b18e51..a5c1a0c 100644
+--- a/main.go
++++ b/main.go
+@@ -3,3 +3,5 @@ func main() {
+ 	ctx := context.Background()
+-	run(ctx)
++	if err := run(ctx); err != nil {
++		log.Fatal(err)
++	}
+ }
+diff --git a/docs/new.md b/docs/new.md
+new file mode 100644
+--- /dev/null
++++ b/docs/new.md
+@@ -0,0 +1 @@
++# New
+\ No newline at end of file
+diff --git a/old name.go b/new name.go
+similarity index 100%
+rename from old name.go
+rename to new name.go
+diff --git a/unused.go b/unused.go
+deleted file mode 100644
+--- a/unused.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package unused
+`
+
+func TestParsePatch_V4A(t *testing.T) {
+	t.Parallel()
+
+	files, err := parsePatch(testV4APatch)
+	require.NoError(t, err)
+	require.Len(t, files, 4)
+
+	require.Equal(t, patchAdd, files[0].Op)
+	require.Equal(t, "docs/new.md", files[0].Path)
+	require.Equal(t, []patchLine{{'+', "# New"}, {'+', ""}, {'+', "Hello."}}, files[0].Hunks[0].Lines)
+
+	require.Equal(t, patchUpdate, files[1].Op)
+	require.Equal(t, "main.go", files[1].Path)
+	require.Len(t, files[1].Hunks, 2)
+	require.Equal(t, []string{"func main() {"}, files[1].Hunks[0].Anchors)
+	require.Equal(t, []string{"\tctx := context.Background()", "\trun(ctx)", "}"}, files[1].Hunks[0].oldLines())
+	require.Equal(t, -1, files[1].Hunks[0].Start)
+	require.Empty(t, files[1].Hunks[1].Anchors)
+	require.True(t, files[1].Hunks[1].EOF)
+
+	require.Equal(t, patchUpdate, files[2].Op)
+	require.Equal(t, "old.go", files[2].Path)
+	require.Equal(t, "new.go", files[2].MovePath)
+	require.Empty(t, files[2].Hunks)
+
+	require.Equal(t, patchDelete, files[3].Op)
+	require.Equal(t, "unused.go", files[3].Path)
+}
+
+func TestParsePatch_Unified(t *testing.T) {
+	t.Parallel()
+
+	files, err := parsePatch(testUnifiedDiff)
+	require.NoError(t, err)
+	require.Len(t, files, 4)
+
+	require.Equal(t, patchUpdate, files[0].Op)
+	require.Equal(t, "main.go", files[0].Path)
+	require.Empty(t, files[0].MovePath)
+	require.Equal(t, 2, files[0].Hunks[0].Start)
+	require.Equal(t, []string{"\tctx := context.Background()", "\trun(ctx)", "}"}, files[0].Hunks[0].oldLines())
+
+	require.Equal(t, patchAdd, files[1].Op)
+	require.Equal(t, "docs/new.md", files[1].Path)
+	require.True(t, files[1].Hunks[0].NoNewlineNew)
+
+	require.Equal(t, patchUpdate, files[2].Op)
+	require.Equal(t, "old name.go", files[2].Path)
+	require.Equal(t, "new name.go", files[2].MovePath)
+
+	require.Equal(t, patchDelete, files[3].Op)
+	require.Equal(t, "unused.go", files[3].Path)
+}
+
+func TestParsePatch_UnifiedVariants(t *testing.T) {
+	t.Parallel()
+
+	t.Run("diff -u with timestamps", func(t *testing.T) {
+		t.Parallel()
+		files, err := parsePatch("--- main.go\t2025-01-01 10:00:00\n+++ main.go\t2025-01-02 10:00:00\n@@ -1 +1 @@\n-a\n+b\n")
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		require.Equal(t, "main.go", files[0].Path)
+		require.Empty(t, files[0].MovePath)
+	})
+
+	t.Run("code fence and headers without numbers", func(t *testing.T) {
+		t.Parallel()
+		files, err := parsePatch("```diff\n--- a/main.go\n+++ b/main.go\n@@\n a\n-b\n+c\n\n```")
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		require.Equal(t, "main.go", files[0].Path)
+		require.Equal(t, -1, files[0].Hunks[0].Start)
+		require.Equal(t, []string{"a", "b"}, files[0].Hunks[0].oldLines())
+	})
+
+	t.Run("wrong line counts", func(t *testing.T) {
+		t.Parallel()
+		files, err := parsePatch("--- a/main.go\n+++ b/main.go\n@@ -1,2 +1,2 @@\n a\n-b\n+c\n d\n e\n@@ -10,1 +10,1 @@\n-x\n+y\n")
+		require.NoError(t, err)
+		require.Len(t, files[0].Hunks, 2)
+		require.Equal(t, []string{"a", "b", "d", "e"}, files[0].Hunks[0].oldLines())
+		require.Equal(t, 9, files[0].Hunks[1].Start)
+	})
+
+	t.Run("windows line endings", func(t *testing.T) {
+		t.Parallel()
+		files, err := parsePatch("*** Begin Patch\r\n*** Update File: a.txt\r\n-a\r\n+b\r\n*** End Patch\r\n")
+		require.NoError(t, err)
+		require.Equal(t, []string{"a"}, files[0].Hunks[0].oldLines())
+	})
+}
+
+func TestParsePatch_Errors(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		patch string
+		want  string
+	}{
+		"empty":            {"", "no file changes"},
+		"no changes":       {"*** Begin Patch\n*** End Patch", "no file changes"},
+		"unknown header":   {"*** Begin Patch\n*** Rename File: a\n*** End Patch", "expected a file header"},
+		"bad added line":   {"*** Begin Patch\n*** Add File: a\nhello\n*** End Patch", "every line must start with '+'"},
+		"bad hunk line":    {"*** Begin Patch\n*** Update File: a\n@@\n-a\n*b\n*** End Patch", "lines must start with"},
+		"no path":          {"*** Begin Patch\n*** Add File: \n+a\n*** End Patch", `"*** Add File:" has no path`},
+		"empty update":     {"*** Begin Patch\n*** Update File: a\n*** End Patch", "has no changes"},
+		"anchor only":      {"*** Begin Patch\n*** Update File: a\n@@ func a() {\n*** End Patch", "has no lines"},
+		"hunk before file": {"@@ -1 +1 @@\n-a\n+b\n", "before any file header"},
+		"binary":           {"diff --git a/x.png b/x.png\nGIT binary patch\nliteral 0\n", "binary patches"},
+		"no hunks":         {"--- a/main.go\n+++ b/main.go\n", "has no hunks"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := parsePatch(tc.patch)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.want)
+		})
+	}
+}
+
+func TestApplyHunks(t *testing.T) {
+	t.Parallel()
+
+	const source = "package main\n\nfunc a() {\n\treturn\n}\n\nfunc b() {\n\treturn\n}\n"
+	for name, tc := range map[string]struct {
+		content string
+		patch   string
+		want    string
+	}{
+		"exact": {
+			content: source,
+			patch:   "@@\n func a() {\n-\treturn\n+\tprintln(\"a\")\n }",
+			want:    "package main\n\nfunc a() {\n\tprintln(\"a\")\n}\n\nfunc b() {\n\treturn\n}\n",
+		},
+		"anchor": {
+			content: source,
+			patch:   "@@ func b() {\n-\treturn\n+\tprintln(\"b\")",
+			want:    "package main\n\nfunc a() {\n\treturn\n}\n\nfunc b() {\n\tprintln(\"b\")\n}\n",
+		},
+		"hunks in order": {
+			content: source,
+			patch:   "@@\n-\treturn\n+\tprintln(\"a\")\n@@\n-\treturn\n+\tprintln(\"b\")",
+			want:    "package main\n\nfunc a() {\n\tprintln(\"a\")\n}\n\nfunc b() {\n\tprintln(\"b\")\n}\n",
+		},
+		"whitespace differences": {
+			content: source,
+			patch:   "@@\n func b() {  \n-    return\n+\tpanic(\"b\")",
+			want:    "package main\n\nfunc a() {\n\treturn\n}\n\nfunc b() {\n\tpanic(\"b\")\n}\n",
+		},
+		"typographic punctuation": {
+			content: "say(\"it's\")\n",
+			patch:   "@@\n-say(“it’s”)\n+say(\"it is\")",
+			want:    "say(\"it is\")\n",
+		},
+		"end of file": {
+			content: "}\nx\n}\n",
+			patch:   "@@\n-}\n+};\n*** End of File",
+			want:    "}\nx\n};\n",
+		},
+		"append without context": {
+			content: "a\n",
+			patch:   "@@\n+b",
+			want:    "a\nb\n",
+		},
+		"delete everything": {
+			content: "a\nb\n",
+			patch:   "@@\n-a\n-b",
+			want:    "",
+		},
+		"no newline at end": {
+			content: "a\nb",
+			patch:   "@@\n a\n-b\n+c",
+			want:    "a\nc",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			files, err := parsePatch("*** Begin Patch\n*** Update File: f\n" + tc.patch + "\n*** End Patch")
+			require.NoError(t, err)
+			got, err := applyHunks(tc.content, files[0].Hunks)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestApplyHunks_Unified(t *testing.T) {
+	t.Parallel()
+
+	// The line numbers pick the right one of identical runs of lines.
+	content := "x\ny\nx\ny\n"
+	files, err := parsePatch("--- a/f\n+++ b/f\n@@ -3,2 +3,2 @@\n x\n-y\n+z\n")
+	require.NoError(t, err)
+	got, err := applyHunks(content, files[0].Hunks)
+	require.NoError(t, err)
+	require.Equal(t, "x\ny\nx\nz\n", got)
+
+	// A line break is added to a file without one.
+	files, err = parsePatch("--- a/f\n+++ b/f\n@@ -1 +1,2 @@\n-a\n\\ No newline at end of file\n+a\n+b\n")
+	require.NoError(t, err)
+	got, err = applyHunks("a", files[0].Hunks)
+	require.NoError(t, err)
+	require.Equal(t, "a\nb\n", got)
+}
+
+func TestApplyHunks_Errors(t *testing.T) {
+	t.Parallel()
+
+	files, err := parsePatch("*** Begin Patch\n*** Update File: f\n@@ func c() {\n-\treturn\n*** End Patch")
+	require.NoError(t, err)
+	_, err = applyHunks("func a() {\n\treturn\n}\n", files[0].Hunks)
+	require.ErrorContains(t, err, `could not find the line "func c() {"`)
+
+	files, err = parsePatch("*** Begin Patch\n*** Update File: f\n@@\n-b\n+c\n@@\n-a\n+d\n*** End Patch")
+	require.NoError(t, err)
+	_, err = applyHunks("a\nb\n", files[0].Hunks)
+	require.ErrorContains(t, err, "hunk 2 does not apply, could not find these lines:\na")
+}
+
+func FuzzParsePatch(f *testing.F) {
+	f.Add(testV4APatch)
+	f.Add(testUnifiedDiff)
+	f.Add("--- a/f\n+++ b/f\n@@ -1,2 +1,2 @@\n a\n-b\n+c\n\\ No newline at end of file\n")
+	f.Add("*** Begin Patch\n*** Update File: f\n@@ a\n@@ b\n x\n*** End of File\n*** End Patch")
+	f.Add("diff --git a/x b/y\nrename from x\nrename to y\n")
+	f.Add("@@ -0,0 +1 @@")
+
+	f.Fuzz(func(t *testing.T, patch string) {
+		files, err := parsePatch(patch)
+		if err != nil {
+			return
+		}
+		require.NotEmpty(t, files)
+		for _, file := range files {
+			require.NotEmpty(t, file.Path)
+			require.Contains(t, []patchOp{patchAdd, patchUpdate, patchDelete}, file.Op)
+			for _, hunk := range file.Hunks {
+				for _, line := range hunk.Lines {
+					require.Contains(t, []byte{' ', '-', '+'}, line.Op)
+				}
+			}
+			// Applying any hunks to any content must not panic.
+			_, _ = applyHunks(patch, file.Hunks)
+			_, _ = applyHunks("", file.Hunks)
+		}
+	})
+}
+
+func FuzzApplyUnifiedDiff(f *testing.F) {
+	f.Add("a\nb\nc\n", "a\nB\nc\n")
+	f.Add("", "new\nfile\n")
+	f.Add("old\n", "")
+	f.Add("x\ny\nx\ny\nx\n", "x\ny\nx\nz\nx\n")
+	f.Add("no newline", "no newline\nat the end")
+	f.Add("a\n\n\nb\n", "a\n\nb\n\n")
+
+	f.Fuzz(func(t *testing.T, before, after string) {
+		// Lines starting with "-- " and "++ " make ambiguous diffs, as
+		// they do for patch and git.
+		for _, content := range []string{before, after} {
+			// The diff replaces invalid UTF-8.
+			if !utf8.ValidString(content) {
+				return
+			}
+			if strings.Contains(content, "\r") || strings.Contains("\n"+content, "\n-- ") || strings.Contains("\n"+content, "\n++ ") {
+				return
+			}
+		}
+		if before == after {
+			return
+		}
+		unified, _, _ := diff.GenerateDiff(before, after, "file.txt")
+		files, err := parsePatch(unified)
+		require.NoError(t, err, unified)
+		require.Len(t, files, 1)
+		got, err := applyHunks(before, files[0].Hunks)
+		require.NoError(t, err, unified)
+		require.Equal(t, after, got, unified)
+	})
+}
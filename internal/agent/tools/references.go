@@ -8,14 +8,19 @@ import (
 	"fmt"
 	"log/slog"
 	"maps"
+	"os"
 	"path/filepath"
 	"regexp"
 	"slices"
 	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
 
 	"charm.land/fantasy"
 	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/charmbracelet/crush/internal/filepathext"
 	"github.com/charmbracelet/crush/internal/lsp"
 	"github.com/charmbracelet/x/powernap/pkg/lsp/protocol"
 )
@@ -97,14 +102,7 @@ func find(ctx context.Context, lspClients *csync.Map[string, *lsp.Client], symbo
 		return nil, fmt.Errorf("failed to get absolute path: %s", err)
 	}
 
-	var client *lsp.Client
-	for c := range lspClients.Seq() {
-		if c.HandlesFile(absPath) {
-			client = c
-			break
-		}
-	}
-
+	client := lspClientFor(lspClients, absPath)
 	if client == nil {
 		slog.Warn("No LSP clients to handle", "path", match.path)
 		return nil, nil
@@ -119,6 +117,90 @@ func find(ctx context.Context, lspClients *csync.Map[string, *lsp.Client], symbo
 	)
 }
 
+// lspClientFor returns the first LSP client handling the file, if any.
+func lspClientFor(lspClients *csync.Map[string, *lsp.Client], path string) *lsp.Client {
+	for c := range lspClients.Seq() {
+		if c.HandlesFile(path) {
+			return c
+		}
+	}
+	return nil
+}
+
+// lspSymbolAt returns the absolute path of a file, the LSP client handling
+// it and the column of a symbol on a line of it. Its errors are meant for the
+// model.
+func lspSymbolAt(lspClients *csync.Map[string, *lsp.Client], workingDir, filePath string, line int, symbol string) (string, *lsp.Client, int, error) {
+	switch {
+	case filePath == "":
+		return "", nil, 0, errors.New("file_path is required")
+	case line < 1:
+		return "", nil, 0, errors.New("line is required and starts at 1")
+	case symbol == "":
+		return "", nil, 0, errors.New("symbol is required")
+	}
+	if lspClients.Len() == 0 {
+		return "", nil, 0, errors.New("no LSP clients available")
+	}
+
+	path := filepathext.SmartJoin(workingDir, filePath)
+	client := lspClientFor(lspClients, path)
+	if client == nil {
+		return "", nil, 0, fmt.Errorf("no LSP client handles %s", path)
+	}
+	column, err := symbolPosition(path, line, symbol)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	return path, client, column, nil
+}
+
+// symbolPosition returns the column of a symbol on a line of a file, both
+// 1-based, the column counted in UTF-16 code units as LSP servers expect.
+// A whole-word occurrence of the symbol is preferred to one in a longer name.
+func symbolPosition(path string, line int, symbol string) (int, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, fmt.Errorf("file not found: %s", path)
+		}
+		return 0, fmt.Errorf("failed to read file: %w", err)
+	}
+	lines := strings.Split(string(content), "\n")
+	if line < 1 || line > len(lines) {
+		return 0, fmt.Errorf("line %d is out of range, %s has %d lines", line, path, len(lines))
+	}
+	text := strings.TrimSuffix(lines[line-1], "\r")
+
+	idx := -1
+	for i := 0; i+len(symbol) <= len(text); {
+		found := strings.Index(text[i:], symbol)
+		if found == -1 {
+			break
+		}
+		found += i
+		if idx == -1 {
+			idx = found
+		}
+		before, _ := utf8.DecodeLastRuneInString(text[:found])
+		after, _ := utf8.DecodeRuneInString(text[found+len(symbol):])
+		if !isIdentRune(before) && !isIdentRune(after) {
+			idx = found
+			break
+		}
+		i = found + 1
+	}
+	if idx == -1 {
+		return 0, fmt.Errorf("symbol '%s' not found on line %d of %s", symbol, line, path)
+	}
+	idx += getSymbolOffset(symbol)
+	return len(utf16.Encode([]rune(text[:idx]))) + 1, nil
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
 // getSymbolOffset returns the character offset to the actual symbol name
 // in a qualified symbol (e.g., "Bar" in "foo.Bar" or "method" in "Class::method").
 func getSymbolOffset(symbol string) int {
@@ -0,0 +1,125 @@
+package tools
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/charmbracelet/crush/internal/fsext"
+	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/lsp"
+	"github.com/charmbracelet/crush/internal/permission"
+)
+
+type RenameParams struct {
+	FilePath string `json:"file_path" description:"The path to a file where the symbol appears"`
+	Line     int    `json:"line" description:"The line number where the symbol appears (1-based)"`
+	Symbol   string `json:"symbol" description:"The symbol to rename as written on that line"`
+	NewName  string `json:"new_name" description:"The new name of the symbol"`
+}
+
+type RenamePermissionsParams struct {
+	Symbol  string           `json:"symbol"`
+	NewName string           `json:"new_name"`
+	Files   []ApplyPatchFile `json:"files"`
+}
+
+type RenameResponseMetadata struct {
+	Symbol    string           `json:"symbol"`
+	NewName   string           `json:"new_name"`
+	Files     []ApplyPatchFile `json:"files"`
+	Additions int              `json:"additions"`
+	Removals  int              `json:"removals"`
+}
+
+const RenameToolName = "lsp_rename"
+
+//go:embed rename.md
+var renameDescription []byte
+
+func NewRenameTool(lspClients *csync.Map[string, *lsp.Client], permissions permission.Service, files history.Service, workingDir string) fantasy.AgentTool {
+	return fantasy.NewAgentTool(
+		RenameToolName,
+		string(renameDescription),
+		func(ctx context.Context, params RenameParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+			if params.NewName == "" {
+				return fantasy.NewTextErrorResponse("new_name is required"), nil
+			}
+			path, client, column, err := lspSymbolAt(lspClients, workingDir, params.FilePath, params.Line, params.Symbol)
+			if err != nil {
+				return fantasy.NewTextErrorResponse(err.Error()), nil
+			}
+			if getLastReadTime(path).IsZero() {
+				return fantasy.NewTextErrorResponse(fmt.Sprintf("you must read %s before renaming a symbol in it. Use the View tool first", path)), nil
+			}
+
+			sessionID := GetSessionFromContext(ctx)
+			if sessionID == "" {
+				return fantasy.ToolResponse{}, fmt.Errorf("session ID is required for renaming a symbol")
+			}
+
+			edit, err := client.Rename(ctx, path, params.Line, column, params.NewName)
+			if err != nil {
+				return fantasy.NewTextErrorResponse(fmt.Sprintf("failed to rename '%s': %s", params.Symbol, err)), nil
+			}
+			changes, err := prepareWorkspaceEdit(ctx, *edit, workingDir)
+			if err != nil {
+				return fantasy.NewTextErrorResponse(fmt.Sprintf("failed to rename '%s': %s", params.Symbol, err)), nil
+			}
+			if len(changes) == 0 {
+				return fantasy.NewTextResponse(fmt.Sprintf("Nothing to rename for symbol '%s'", params.Symbol)), nil
+			}
+
+			renameFiles, targets, additions, removals := summarizeWorkspaceEdit(changes)
+
+			description := fmt.Sprintf("Rename %s to %s in %d files", params.Symbol, params.NewName, len(changes))
+			if len(changes) == 1 {
+				description = fmt.Sprintf("Rename %s to %s in %s", params.Symbol, params.NewName, changes[0].Path)
+			}
+			permissionReq := permission.CreatePermissionRequest{
+				SessionID:   sessionID,
+				Path:        fsext.PathOrPrefix(path, workingDir),
+				ToolCallID:  call.ID,
+				ToolName:    RenameToolName,
+				Action:      "write",
+				Description: description,
+				Params: RenamePermissionsParams{
+					Symbol:  params.Symbol,
+					NewName: params.NewName,
+					Files:   renameFiles,
+				},
+				Targets: targets,
+			}
+			if rule, denied := permissions.Denied(permissionReq); denied {
+				return NewRuleDeniedResponse(rule), nil
+			}
+			if !permissions.Request(permissionReq) {
+				return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
+			}
+
+			written, summary, err := applyWorkspaceEdit(ctx, files, sessionID, *edit, changes)
+			if err != nil {
+				return fantasy.ToolResponse{}, fmt.Errorf("failed to apply the rename of %s: %w", params.Symbol, err)
+			}
+
+			for _, path := range written {
+				notifyLSPs(ctx, lspClients, path)
+			}
+			text := fmt.Sprintf("<result>\nRenamed '%s' to '%s':\n%s\n</result>\n", params.Symbol, params.NewName, strings.Join(summary, "\n"))
+			text += getFilesDiagnostics(lspClients, written...)
+
+			return fantasy.WithResponseMetadata(
+				fantasy.NewTextResponse(text),
+				RenameResponseMetadata{
+					Symbol:    params.Symbol,
+					NewName:   params.NewName,
+					Files:     renameFiles,
+					Additions: additions,
+					Removals:  removals,
+				},
+			), nil
+		})
+}
@@ -1,6 +1,12 @@
 package tools
 
-import "runtime"
+import (
+	"runtime"
+	"slices"
+	"strings"
+
+	"mvdan.cc/sh/v3/syntax"
+)
 
 var safeCommands = []string{
 	// Bash builtins and core utils
@@ -14,8 +20,6 @@ var safeCommands = []string{
 	"groups",
 	"hostname",
 	"id",
-	"kill",
-	"killall",
 	"ls",
 	"nice",
 	"nohup",
@@ -28,7 +32,6 @@ var safeCommands = []string{
 	"top",
 	"type",
 	"uname",
-	"unset",
 	"uptime",
 	"whatis",
 	"whereis",
@@ -68,3 +71,321 @@ func init() {
 		)
 	}
 }
+
+// commandWrappers are the safe commands that run the command given in their
+// arguments, which then has to be safe too. They return the wrapped command,
+// empty when there is none, or false when their arguments can't be followed.
+var commandWrappers = map[string]func(args []*syntax.Word) ([]*syntax.Word, bool){
+	"env": func(args []*syntax.Word) ([]*syntax.Word, bool) {
+		for i := 0; i < len(args); i++ {
+			lit, ok := literal(args[i])
+			switch {
+			case !ok:
+				return args[i:], true
+			case strings.HasPrefix(lit, "-S") || strings.HasPrefix(lit, "--split-string"):
+				// The command is in a single string we don't parse.
+				return nil, false
+			case lit == "--":
+				return args[i+1:], true
+			case slices.Contains([]string{"-u", "--unset", "-C", "--chdir"}, lit):
+				i++
+			case strings.HasPrefix(lit, "-") && lit != "-" || strings.Contains(lit, "="):
+			default:
+				return args[i:], true
+			}
+		}
+		return nil, true
+	},
+	"nice": func(args []*syntax.Word) ([]*syntax.Word, bool) {
+		return skipOptions(args, "-n", "--adjustment")
+	},
+	"nohup": func(args []*syntax.Word) ([]*syntax.Word, bool) {
+		return skipOptions(args)
+	},
+	"time": func(args []*syntax.Word) ([]*syntax.Word, bool) {
+		return skipOptions(args, "-f", "--format", "-o", "--output")
+	},
+	"timeout": func(args []*syntax.Word) ([]*syntax.Word, bool) {
+		rest, ok := skipOptions(args, "-s", "--signal", "-k", "--kill-after")
+		if !ok || len(rest) == 0 {
+			return rest, ok
+		}
+		// Skip the duration.
+		return rest[1:], true
+	},
+}
+
+// readOnlyArgs checks the arguments of the safe commands that also change
+// things depending on them.
+var readOnlyArgs = map[string]func(args []string) bool{
+	"date": func(args []string) bool {
+		return !slices.ContainsFunc(args, func(arg string) bool {
+			return arg == "-s" || strings.HasPrefix(arg, "--set")
+		})
+	},
+	"set": func(args []string) bool {
+		// Only listing the variables, the arguments set options.
+		return len(args) == 0
+	},
+	"hostname": func(args []string) bool {
+		// A name sets the hostname.
+		return !slices.ContainsFunc(args, func(arg string) bool {
+			return !strings.HasPrefix(arg, "-")
+		})
+	},
+	"git branch": gitListing{
+		short:        "alrv",
+		long:         []string{"--all", "--remotes", "--verbose", "--list", "--show-current", "--merged", "--no-merged", "--contains", "--no-contains", "--points-at", "--sort", "--format", "--color", "--no-color", "--column", "--no-column", "--abbrev", "--no-abbrev", "--ignore-case", "--omit-empty"},
+		listingShort: "l",
+		listing:      []string{"--list", "--merged", "--no-merged", "--contains", "--no-contains", "--points-at"},
+	}.readOnly,
+	"git tag": gitListing{
+		short:        "lnv0123456789",
+		long:         []string{"--list", "--verify", "--merged", "--no-merged", "--contains", "--no-contains", "--points-at", "--sort", "--format", "--color", "--no-color", "--column", "--no-column", "--ignore-case", "--omit-empty"},
+		listingShort: "lv",
+		listing:      []string{"--list", "--verify", "--merged", "--no-merged", "--contains", "--no-contains", "--points-at"},
+	}.readOnly,
+	"git remote": func(args []string) bool {
+		for len(args) > 0 && (args[0] == "-v" || args[0] == "--verbose") {
+			args = args[1:]
+		}
+		return len(args) == 0 || args[0] == "show" || args[0] == "get-url"
+	},
+	"git grep": func(args []string) bool {
+		// Opening the files in a pager runs the given command.
+		return !slices.ContainsFunc(args, func(arg string) bool {
+			return strings.HasPrefix(arg, "-O") || strings.HasPrefix(arg, "--open-files-in-pager")
+		})
+	},
+}
+
+// gitListing checks the arguments of git branch and git tag, which create,
+// delete or move refs unless they only list them.
+type gitListing struct {
+	short string
+	long  []string
+	// The flags that make the positional arguments patterns or commits to
+	// filter the list with, rather than refs to create.
+	listingShort string
+	listing      []string
+}
+
+func (g gitListing) readOnly(args []string) bool {
+	var listing, positional bool
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--"):
+			name, _, _ := strings.Cut(arg, "=")
+			if !slices.Contains(g.long, name) {
+				return false
+			}
+			listing = listing || slices.Contains(g.listing, name)
+		case strings.HasPrefix(arg, "-") && arg != "-":
+			for _, flag := range arg[1:] {
+				if !strings.ContainsRune(g.short, flag) {
+					return false
+				}
+				listing = listing || strings.ContainsRune(g.listingShort, flag)
+			}
+		default:
+			positional = true
+		}
+	}
+	return !positional || listing
+}
+
+// isSafeReadOnly reports whether the command only reads: every command it
+// runs, in pipelines, lists, subshells and substitutions alike, is a safe
+// one, nothing is redirected to a file and no variable is set, as they stay
+// set in the shell for the next commands.
+func isSafeReadOnly(command string) bool {
+	file, err := syntax.NewParser().Parse(strings.NewReader(command), "")
+	if err != nil {
+		return false
+	}
+	safe := true
+	syntax.Walk(file, func(node syntax.Node) bool {
+		// Walk goes on with the siblings of a node it isn't told to go into,
+		// so once unsafe the rest is skipped, or they would set it back.
+		if !safe {
+			return false
+		}
+		switch node := node.(type) {
+		case *syntax.CallExpr:
+			safe = len(node.Assigns) == 0 && isSafeCommand(node.Args)
+		case *syntax.Redirect:
+			safe = isSafeRedirect(node)
+		case *syntax.ParamExp:
+			safe = node.Exp == nil || node.Exp.Op != syntax.AssignUnset && node.Exp.Op != syntax.AssignUnsetOrNull
+		case *syntax.BinaryArithm:
+			safe = !isArithmAssign(node.Op)
+		case *syntax.UnaryArithm:
+			safe = node.Op != syntax.Inc && node.Op != syntax.Dec
+		case *syntax.ForClause, *syntax.DeclClause, *syntax.FuncDecl, *syntax.CoprocClause:
+			safe = false
+		}
+		return safe
+	})
+	return safe
+}
+
+// shellCommands returns the simple commands run by the command, for the
+// permission rules to be matched against each of them. The redirections that
+// write to a file are left out of the commands and returned on their own, so
+// that allowing bash(go test:*) doesn't allow go test > ~/.bashrc.
+func shellCommands(command string) []string {
+	file, err := syntax.NewParser().Parse(strings.NewReader(command), "")
+	if err != nil {
+		return []string{strings.TrimSpace(command)}
+	}
+	source := func(node syntax.Node) string {
+		return command[node.Pos().Offset():node.End().Offset()]
+	}
+	var commands []string
+	syntax.Walk(file, func(node syntax.Node) bool {
+		stmt, ok := node.(*syntax.Stmt)
+		if !ok {
+			return true
+		}
+		if call, ok := stmt.Cmd.(*syntax.CallExpr); ok && len(call.Args) > 0 {
+			words := make([]string, 0, len(call.Assigns)+len(call.Args))
+			for _, assign := range call.Assigns {
+				words = append(words, source(assign))
+			}
+			for _, arg := range call.Args {
+				words = append(words, source(arg))
+			}
+			commands = append(commands, strings.Join(words, " "))
+		}
+		for _, redirect := range stmt.Redirs {
+			if !isSafeRedirect(redirect) {
+				commands = append(commands, source(redirect))
+			}
+		}
+		return true
+	})
+	return commands
+}
+
+func isSafeCommand(args []*syntax.Word) bool {
+	if len(args) == 0 {
+		return true
+	}
+	name, ok := literal(args[0])
+	if !ok {
+		return false
+	}
+	if wrapper, ok := commandWrappers[name]; ok {
+		wrapped, ok := wrapper(args[1:])
+		return ok && isSafeCommand(wrapped)
+	}
+	if name == "git" && len(args) > 1 {
+		if opt, _ := literal(args[1]); opt == "--no-pager" {
+			args = slices.Delete(slices.Clone(args), 1, 2)
+		}
+	}
+
+	for _, safe := range safeCommands {
+		fields := strings.Fields(safe)
+		if len(args) < len(fields) || !slices.EqualFunc(fields, args[:len(fields)], func(field string, arg *syntax.Word) bool {
+			lit, ok := literal(arg)
+			return ok && lit == field
+		}) {
+			continue
+		}
+		check, hasCheck := readOnlyArgs[safe]
+		if !hasCheck && fields[0] != "git" {
+			return true
+		}
+		// The arguments have to be known to be checked.
+		rest := make([]string, 0, len(args)-len(fields))
+		for _, arg := range args[len(fields):] {
+			lit, ok := literal(arg)
+			if !ok {
+				return false
+			}
+			rest = append(rest, lit)
+		}
+		if fields[0] == "git" && slices.ContainsFunc(rest, func(arg string) bool {
+			return arg == "--output" || strings.HasPrefix(arg, "--output=")
+		}) {
+			return false
+		}
+		return !hasCheck || check(rest)
+	}
+	return false
+}
+
+func isSafeRedirect(redirect *syntax.Redirect) bool {
+	switch redirect.Op {
+	case syntax.RdrIn, syntax.DplIn, syntax.Hdoc, syntax.DashHdoc, syntax.WordHdoc:
+		return true
+	case syntax.DplOut:
+		// Duplicating or closing a file descriptor, as in 2>&1.
+		target, ok := literal(redirect.Word)
+		return ok && (target == "-" || target != "" && strings.Trim(target, "0123456789") == "")
+	case syntax.RdrOut, syntax.AppOut, syntax.ClbOut, syntax.RdrAll, syntax.AppAll:
+		target, ok := literal(redirect.Word)
+		return ok && target == "/dev/null"
+	}
+	return false
+}
+
+func isArithmAssign(op syntax.BinAritOperator) bool {
+	switch op {
+	case syntax.Assgn, syntax.AddAssgn, syntax.SubAssgn, syntax.MulAssgn, syntax.QuoAssgn,
+		syntax.RemAssgn, syntax.AndAssgn, syntax.OrAssgn, syntax.XorAssgn, syntax.ShlAssgn, syntax.ShrAssgn:
+		return true
+	}
+	return false
+}
+
+// skipOptions skips the leading options of a wrapper, the ones given
+// taking a value, returning the wrapped command.
+func skipOptions(args []*syntax.Word, withValue ...string) ([]*syntax.Word, bool) {
+	for i := 0; i < len(args); i++ {
+		lit, ok := literal(args[i])
+		switch {
+		case !ok:
+			return args[i:], true
+		case lit == "--":
+			return args[i+1:], true
+		case !strings.HasPrefix(lit, "-") || lit == "-":
+			return args[i:], true
+		case slices.Contains(withValue, lit):
+			i++
+		}
+	}
+	return nil, true
+}
+
+// literal returns the value of a word made of literal and quoted text only.
+func literal(word *syntax.Word) (string, bool) {
+	if word == nil {
+		return "", false
+	}
+	var sb strings.Builder
+	for _, part := range word.Parts {
+		switch part := part.(type) {
+		case *syntax.Lit:
+			// Escaped characters are left as is, the word won't match.
+			sb.WriteString(part.Value)
+		case *syntax.SglQuoted:
+			if part.Dollar {
+				return "", false
+			}
+			sb.WriteString(part.Value)
+		case *syntax.DblQuoted:
+			for _, quoted := range part.Parts {
+				lit, ok := quoted.(*syntax.Lit)
+				if !ok {
+					return "", false
+				}
+				sb.WriteString(lit.Value)
+			}
+		default:
+			return "", false
+		}
+	}
+	return sb.String(), true
+}
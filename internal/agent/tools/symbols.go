@@ -0,0 +1,196 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/charmbracelet/crush/internal/filepathext"
+	"github.com/charmbracelet/crush/internal/lsp"
+	"github.com/charmbracelet/x/powernap/pkg/lsp/protocol"
+)
+
+type DocumentSymbolsParams struct {
+	FilePath string `json:"file_path" description:"The path to the file to list the symbols of"`
+}
+
+type WorkspaceSymbolsParams struct {
+	Query string `json:"query" description:"The name, or part of the name, of the symbols to look for"`
+}
+
+const (
+	DocumentSymbolsToolName  = "lsp_document_symbols"
+	WorkspaceSymbolsToolName = "lsp_workspace_symbols"
+	maxWorkspaceSymbols      = 100
+)
+
+//go:embed document_symbols.md
+var documentSymbolsDescription []byte
+
+//go:embed workspace_symbols.md
+var workspaceSymbolsDescription []byte
+
+func NewDocumentSymbolsTool(lspClients *csync.Map[string, *lsp.Client], workingDir string) fantasy.AgentTool {
+	return fantasy.NewAgentTool(
+		DocumentSymbolsToolName,
+		string(documentSymbolsDescription),
+		func(ctx context.Context, params DocumentSymbolsParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+			if params.FilePath == "" {
+				return fantasy.NewTextErrorResponse("file_path is required"), nil
+			}
+			if lspClients.Len() == 0 {
+				return fantasy.NewTextErrorResponse("no LSP clients available"), nil
+			}
+
+			path := filepathext.SmartJoin(workingDir, params.FilePath)
+			client := lspClientFor(lspClients, path)
+			if client == nil {
+				return fantasy.NewTextErrorResponse(fmt.Sprintf("no LSP client handles %s", path)), nil
+			}
+
+			symbols, err := client.DocumentSymbols(ctx, path)
+			if err != nil {
+				return fantasy.NewTextErrorResponse(fmt.Sprintf("failed to list symbols: %s", err)), nil
+			}
+			if len(symbols) == 0 {
+				return fantasy.NewTextResponse(fmt.Sprintf("No symbols found in %s", path)), nil
+			}
+			return fantasy.NewTextResponse(formatDocumentSymbols(path, symbols)), nil
+		})
+}
+
+func NewWorkspaceSymbolsTool(lspClients *csync.Map[string, *lsp.Client]) fantasy.AgentTool {
+	return fantasy.NewAgentTool(
+		WorkspaceSymbolsToolName,
+		string(workspaceSymbolsDescription),
+		func(ctx context.Context, params WorkspaceSymbolsParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+			if params.Query == "" {
+				return fantasy.NewTextErrorResponse("query is required"), nil
+			}
+			if lspClients.Len() == 0 {
+				return fantasy.NewTextErrorResponse("no LSP clients available"), nil
+			}
+
+			var allSymbols []protocol.SymbolInformation
+			var allErrs error
+			for name, client := range lspClients.Seq2() {
+				symbols, err := client.WorkspaceSymbols(ctx, params.Query)
+				if err != nil {
+					slog.Error("Failed to find workspace symbols", "error", err, "lsp", name, "query", params.Query)
+					allErrs = errors.Join(allErrs, fmt.Errorf("%s: %w", name, err))
+					continue
+				}
+				allSymbols = append(allSymbols, symbols...)
+			}
+
+			if len(allSymbols) > 0 {
+				return fantasy.NewTextResponse(formatWorkspaceSymbols(params.Query, cleanupSymbols(allSymbols))), nil
+			}
+			if allErrs != nil {
+				return fantasy.NewTextErrorResponse(allErrs.Error()), nil
+			}
+			return fantasy.NewTextResponse(fmt.Sprintf("No symbols found matching '%s'", params.Query)), nil
+		})
+}
+
+func symbolKindName(kind protocol.SymbolKind) string {
+	if name, ok := protocol.TableKindMap[kind]; ok {
+		return name
+	}
+	return "Symbol"
+}
+
+// formatLineRange returns "line N" or "lines N-M" for a range.
+func formatLineRange(r protocol.Range) string {
+	if r.Start.Line == r.End.Line {
+		return fmt.Sprintf("line %d", r.Start.Line+1)
+	}
+	return fmt.Sprintf("lines %d-%d", r.Start.Line+1, r.End.Line+1)
+}
+
+// formatDocumentSymbols lists the symbols as an outline, children indented
+// under their parent.
+func formatDocumentSymbols(path string, symbols []protocol.DocumentSymbol) string {
+	var outline strings.Builder
+	var count int
+	var write func(symbols []protocol.DocumentSymbol, depth int)
+	write = func(symbols []protocol.DocumentSymbol, depth int) {
+		for _, symbol := range symbols {
+			count++
+			outline.WriteString(strings.Repeat("  ", depth))
+			outline.WriteString(symbolKindName(symbol.Kind))
+			outline.WriteString(" ")
+			outline.WriteString(symbol.Name)
+			if detail := strings.TrimSpace(strings.ReplaceAll(symbol.Detail, "\n", " ")); detail != "" {
+				outline.WriteString(" ")
+				outline.WriteString(detail)
+			}
+			fmt.Fprintf(&outline, " (%s)\n", formatLineRange(symbol.Range))
+			write(symbol.Children, depth+1)
+		}
+	}
+	write(symbols, 0)
+	return fmt.Sprintf("%d symbol(s) in %s:\n\n%s", count, path, outline.String())
+}
+
+func cleanupSymbols(symbols []protocol.SymbolInformation) []protocol.SymbolInformation {
+	slices.SortFunc(symbols, func(a, b protocol.SymbolInformation) int {
+		return cmp.Or(
+			strings.Compare(string(a.Location.URI), string(b.Location.URI)),
+			cmp.Compare(a.Location.Range.Start.Line, b.Location.Range.Start.Line),
+			strings.Compare(a.Name, b.Name),
+		)
+	})
+	return slices.CompactFunc(symbols, func(a, b protocol.SymbolInformation) bool {
+		return a.Location.URI == b.Location.URI &&
+			a.Location.Range.Start.Line == b.Location.Range.Start.Line &&
+			a.Name == b.Name
+	})
+}
+
+// formatWorkspaceSymbols lists the symbols grouped by file.
+func formatWorkspaceSymbols(query string, symbols []protocol.SymbolInformation) string {
+	var output strings.Builder
+	total := len(symbols)
+	if total > maxWorkspaceSymbols {
+		symbols = symbols[:maxWorkspaceSymbols]
+	}
+
+	var files int
+	var lastURI protocol.DocumentURI
+	var body strings.Builder
+	for _, symbol := range symbols {
+		if symbol.Location.URI != lastURI {
+			lastURI = symbol.Location.URI
+			files++
+			path, err := lastURI.Path()
+			if err != nil {
+				path = string(lastURI)
+			}
+			if files > 1 {
+				body.WriteString("\n")
+			}
+			fmt.Fprintf(&body, "%s:\n", path)
+		}
+		fmt.Fprintf(&body, "  Line %d: %s %s", symbol.Location.Range.Start.Line+1, symbolKindName(symbol.Kind), symbol.Name)
+		if symbol.ContainerName != "" {
+			fmt.Fprintf(&body, " (in %s)", symbol.ContainerName)
+		}
+		body.WriteString("\n")
+	}
+
+	fmt.Fprintf(&output, "Found %d symbol(s) matching '%s' in %d file(s)", total, query, files)
+	if total > len(symbols) {
+		fmt.Fprintf(&output, ", showing the first %d. Refine the query to see the others", len(symbols))
+	}
+	output.WriteString(":\n\n")
+	output.WriteString(body.String())
+	return output.String()
+}
@@ -0,0 +1,97 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/x/powernap/pkg/lsp/protocol"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSymbolPosition(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "main.go")
+	require.NoError(t, os.WriteFile(path, []byte("package main\r\n\r\n\tx := runner.run(run) // ☃ run\n\ts := \"☃\"; run()\n"), 0o644))
+
+	for name, tc := range map[string]struct {
+		line   int
+		symbol string
+		want   int
+		err    string
+	}{
+		"whole word":      {line: 3, symbol: "run", want: 14},
+		"qualified":       {line: 3, symbol: "runner.run", want: 14},
+		"utf16 columns":   {line: 4, symbol: "run", want: 12},
+		"not a word":      {line: 1, symbol: "pack", want: 1},
+		"missing symbol":  {line: 1, symbol: "main()", err: "symbol 'main()' not found on line 1"},
+		"line past end":   {line: 9, symbol: "run", err: "line 9 is out of range"},
+		"line before one": {line: 0, symbol: "run", err: "line 0 is out of range"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			column, err := symbolPosition(path, tc.line, tc.symbol)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, column)
+		})
+	}
+}
+
+func TestFormatDocumentSymbols(t *testing.T) {
+	t.Parallel()
+
+	lines := func(start, end uint32) protocol.Range {
+		return protocol.Range{Start: protocol.Position{Line: start}, End: protocol.Position{Line: end}}
+	}
+	got := formatDocumentSymbols("/src/main.go", []protocol.DocumentSymbol{
+		{
+			Name:  "Server",
+			Kind:  protocol.Struct,
+			Range: lines(2, 5),
+			Children: []protocol.DocumentSymbol{
+				{Name: "addr", Detail: "string", Kind: protocol.Field, Range: lines(3, 3)},
+			},
+		},
+		{Name: "main", Detail: "func()", Kind: protocol.Function, Range: lines(7, 9)},
+	})
+	require.Equal(t, `3 symbol(s) in /src/main.go:
+
+Struct Server (lines 3-6)
+  Field addr string (line 4)
+Function main func() (lines 8-10)
+`, got)
+}
+
+func TestFormatWorkspaceSymbols(t *testing.T) {
+	t.Parallel()
+
+	symbol := func(path string, line uint32, name, container string, kind protocol.SymbolKind) protocol.SymbolInformation {
+		return protocol.SymbolInformation{
+			Name:          name,
+			Kind:          kind,
+			ContainerName: container,
+			Location: protocol.Location{
+				URI:   protocol.URIFromPath(path),
+				Range: protocol.Range{Start: protocol.Position{Line: line}},
+			},
+		}
+	}
+	symbols := cleanupSymbols([]protocol.SymbolInformation{
+		symbol("/src/b.go", 4, "runAll", "", protocol.Function),
+		symbol("/src/a.go", 9, "Run", "Server", protocol.Method),
+		symbol("/src/a.go", 9, "Run", "Server", protocol.Method),
+	})
+	require.Equal(t, `Found 2 symbol(s) matching 'run' in 2 file(s):
+
+/src/a.go:
+  Line 10: Method Run (in Server)
+
+/src/b.go:
+  Line 5: Function runAll
+`, formatWorkspaceSymbols("run", symbols))
+}
@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/todo"
+)
+
+const (
+	TodosToolName = "todos"
+)
+
+//go:embed todos.md
+var todosDescription []byte
+
+type TodoItem struct {
+	Content string `json:"content" description:"The description of the step"`
+	Status  string `json:"status" description:"The status of the step" enum:"pending,in_progress,done"`
+}
+
+type TodosParams struct {
+	Todos []TodoItem `json:"todos" description:"The complete todo list, replacing the previous one"`
+}
+
+type TodosResponseMetadata struct {
+	Todos []TodoItem `json:"todos"`
+}
+
+func NewTodosTool(todos todo.Service) fantasy.AgentTool {
+	return fantasy.NewAgentTool(
+		TodosToolName,
+		string(todosDescription),
+		func(ctx context.Context, params TodosParams, call fantasy.ToolCall) (fantasy.ToolResponse, error) {
+			sessionID := GetSessionFromContext(ctx)
+			if sessionID == "" {
+				return fantasy.ToolResponse{}, fmt.Errorf("session ID is required for updating the todo list")
+			}
+
+			items := make([]todo.Todo, 0, len(params.Todos))
+			for i, item := range params.Todos {
+				if strings.TrimSpace(item.Content) == "" {
+					return fantasy.NewTextErrorResponse(fmt.Sprintf("todo %d has no content", i+1)), nil
+				}
+				status := todo.Status(item.Status)
+				if !status.Valid() {
+					return fantasy.NewTextErrorResponse(fmt.Sprintf("todo %d has an invalid status %q, expected pending, in_progress or done", i+1, item.Status)), nil
+				}
+				items = append(items, todo.Todo{Content: item.Content, Status: status})
+			}
+
+			saved, err := todos.Set(ctx, sessionID, items)
+			if err != nil {
+				return fantasy.ToolResponse{}, fmt.Errorf("error saving todo list: %w", err)
+			}
+
+			metadata := TodosResponseMetadata{Todos: make([]TodoItem, len(saved))}
+			for i, t := range saved {
+				metadata.Todos[i] = TodoItem{Content: t.Content, Status: string(t.Status)}
+			}
+			if len(saved) == 0 {
+				return fantasy.WithResponseMetadata(fantasy.NewTextResponse("Todo list cleared"), metadata), nil
+			}
+			return fantasy.WithResponseMetadata(fantasy.NewTextResponse("Todo list updated:\n"+todo.Format(saved)), metadata), nil
+		})
+}
@@ -0,0 +1,50 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/db"
+	"github.com/charmbracelet/crush/internal/session"
+	"github.com/charmbracelet/crush/internal/todo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodosTool(t *testing.T) {
+	conn, err := db.Connect(t.Context(), t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	q := db.New(conn)
+	todos := todo.NewService(q, conn)
+	sess, err := session.NewService(q, conn).Create(t.Context(), "todos")
+	require.NoError(t, err)
+
+	tool := NewTodosTool(todos)
+	ctx := context.WithValue(t.Context(), SessionIDContextKey, sess.ID)
+	run := func(input string) fantasy.ToolResponse {
+		resp, err := tool.Run(ctx, fantasy.ToolCall{ID: "call", Name: TodosToolName, Input: input})
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := run(`{"todos":[{"content":"read the code","status":"done"},{"content":"fix the bug","status":"in_progress"}]}`)
+	require.False(t, resp.IsError)
+	require.Contains(t, resp.Content, "2. [in_progress] fix the bug")
+	list, err := todos.List(t.Context(), sess.ID)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	resp = run(`{"todos":[{"content":"fix the bug","status":"blocked"}]}`)
+	require.True(t, resp.IsError)
+	resp = run(`{"todos":[{"content":" ","status":"pending"}]}`)
+	require.True(t, resp.IsError)
+	list, err = todos.List(t.Context(), sess.ID)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	resp = run(`{"todos":[]}`)
+	require.False(t, resp.IsError)
+	require.Equal(t, "Todo list cleared", resp.Content)
+}
@@ -2,16 +2,24 @@ package tools
 
 import (
 	"context"
+	"fmt"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/permission"
 )
 
 type (
-	sessionIDContextKey string
-	messageIDContextKey string
+	sessionIDContextKey     string
+	rootSessionIDContextKey string
+	messageIDContextKey     string
 )
 
 const (
 	SessionIDContextKey sessionIDContextKey = "session_id"
-	MessageIDContextKey messageIDContextKey = "message_id"
+	// RootSessionIDContextKey holds the session of the main agent, which is
+	// the same as SessionIDContextKey except for tools run by sub-agents.
+	RootSessionIDContextKey rootSessionIDContextKey = "root_session_id"
+	MessageIDContextKey     messageIDContextKey     = "message_id"
 )
 
 func GetSessionFromContext(ctx context.Context) string {
@@ -26,6 +34,15 @@ func GetSessionFromContext(ctx context.Context) string {
 	return s
 }
 
+// GetRootSessionFromContext returns the session of the main agent, falling
+// back to the current session.
+func GetRootSessionFromContext(ctx context.Context) string {
+	if s, ok := ctx.Value(RootSessionIDContextKey).(string); ok && s != "" {
+		return s
+	}
+	return GetSessionFromContext(ctx)
+}
+
 func GetMessageFromContext(ctx context.Context) string {
 	messageID := ctx.Value(MessageIDContextKey)
 	if messageID == nil {
@@ -37,3 +54,20 @@ func GetMessageFromContext(ctx context.Context) string {
 	}
 	return s
 }
+
+// NewRuleDeniedResponse tells the model a permission rule from the config
+// denies the call, so that it can go on with something else rather than the
+// run being stopped as when the user denies it.
+func NewRuleDeniedResponse(rule string) fantasy.ToolResponse {
+	return fantasy.NewTextErrorResponse(fmt.Sprintf("Permission denied by the rule %q of the configuration. Do not try to work around it, do something else or ask the user.", rule))
+}
+
+// deniedPath reports whether a deny rule of the tool covers the path, for the
+// tools that read files without asking, such as grep and glob.
+func deniedPath(permissions permission.Service, toolName, path string) (string, bool) {
+	return permissions.Denied(permission.CreatePermissionRequest{
+		ToolName: toolName,
+		Path:     path,
+		Targets:  []string{path},
+	})
+}
@@ -2,10 +2,13 @@ package tools
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -25,12 +28,14 @@ type ViewParams struct {
 	FilePath string `json:"file_path" description:"The path to the file to read"`
 	Offset   int    `json:"offset,omitempty" description:"The line number to start reading from (0-based)"`
 	Limit    int    `json:"limit,omitempty" description:"The number of lines to read (defaults to 2000)"`
+	Pages    string `json:"pages,omitempty" description:"The pages of a PDF to read, like \"3\", \"1-5\" or \"4-\" (defaults to the first 20)"`
 }
 
 type ViewPermissionsParams struct {
 	FilePath string `json:"file_path"`
 	Offset   int    `json:"offset"`
 	Limit    int    `json:"limit"`
+	Pages    string `json:"pages,omitempty"`
 }
 
 type viewTool struct {
@@ -42,8 +47,22 @@ type viewTool struct {
 type ViewResponseMetadata struct {
 	FilePath string `json:"file_path"`
 	Content  string `json:"content"`
+	// Kind is the kind of file read when it isn't text, the content is
+	// empty then.
+	Kind string `json:"kind,omitempty"`
 }
 
+// fileKind is the kind of content of a file, which decides how the view tool
+// shows it.
+type fileKind string
+
+const (
+	fileKindText     fileKind = ""
+	fileKindPDF      fileKind = "pdf"
+	fileKindImage    fileKind = "image"
+	fileKindNotebook fileKind = "notebook"
+)
+
 const (
 	ViewToolName     = "view"
 	MaxReadSize      = 250 * 1024
@@ -51,7 +70,7 @@ const (
 	MaxLineLength    = 2000
 )
 
-func NewViewTool(lspClients *csync.Map[string, *lsp.Client], permissions permission.Service, workingDir string) fantasy.AgentTool {
+func NewViewTool(lspClients *csync.Map[string, *lsp.Client], permissions permission.Service, workingDir string, supportsImages bool) fantasy.AgentTool {
 	return fantasy.NewAgentTool(
 		ViewToolName,
 		string(viewDescription),
@@ -74,29 +93,33 @@ func NewViewTool(lspClients *csync.Map[string, *lsp.Client], permissions permiss
 				return fantasy.ToolResponse{}, fmt.Errorf("error resolving file path: %w", err)
 			}
 
+			// Files outside the working directory need a permission, the
+			// others only when a rule says so
 			relPath, err := filepath.Rel(absWorkingDir, absFilePath)
-			if err != nil || strings.HasPrefix(relPath, "..") {
-				// File is outside working directory, request permission
-				sessionID := GetSessionFromContext(ctx)
-				if sessionID == "" {
-					return fantasy.ToolResponse{}, fmt.Errorf("session ID is required for accessing files outside working directory")
-				}
-
-				granted := permissions.Request(
-					permission.CreatePermissionRequest{
-						SessionID:   sessionID,
-						Path:        absFilePath,
-						ToolCallID:  call.ID,
-						ToolName:    ViewToolName,
-						Action:      "read",
-						Description: fmt.Sprintf("Read file outside working directory: %s", absFilePath),
-						Params:      ViewPermissionsParams(params),
-					},
-				)
-
-				if !granted {
-					return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
-				}
+			outside := err != nil || strings.HasPrefix(relPath, "..")
+			sessionID := GetSessionFromContext(ctx)
+			if outside && sessionID == "" {
+				return fantasy.ToolResponse{}, fmt.Errorf("session ID is required for accessing files outside working directory")
+			}
+			permissionReq := permission.CreatePermissionRequest{
+				SessionID:   sessionID,
+				Path:        absFilePath,
+				ToolCallID:  call.ID,
+				ToolName:    ViewToolName,
+				Action:      "read",
+				Description: fmt.Sprintf("Read file outside working directory: %s", absFilePath),
+				Params:      ViewPermissionsParams(params),
+				Targets:     []string{absFilePath},
+				ReadOnly:    !outside,
+			}
+			if !outside {
+				permissionReq.Description = fmt.Sprintf("Read file: %s", absFilePath)
+			}
+			if rule, denied := permissions.Denied(permissionReq); denied {
+				return NewRuleDeniedResponse(rule), nil
+			}
+			if !permissions.Request(permissionReq) {
+				return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
 			}
 
 			// Check if file exists
@@ -136,26 +159,44 @@ func NewViewTool(lspClients *csync.Map[string, *lsp.Client], permissions permiss
 				return fantasy.NewTextErrorResponse(fmt.Sprintf("Path is a directory, not a file: %s", filePath)), nil
 			}
 
-			// Check file size
-			if fileInfo.Size() > MaxReadSize {
-				return fantasy.NewTextErrorResponse(fmt.Sprintf("File is too large (%d bytes). Maximum size is %d bytes",
-					fileInfo.Size(), MaxReadSize)), nil
-			}
-
 			// Set default limit if not provided
 			if params.Limit <= 0 {
 				params.Limit = DefaultReadLimit
 			}
 
-			// Check if it's an image file
-			isImage, imageType := isImageFile(filePath)
-			// TODO: handle images
-			if isImage {
-				return fantasy.NewTextErrorResponse(fmt.Sprintf("This is an image file of type: %s\n", imageType)), nil
+			kind, mimeType, err := detectFileKind(filePath)
+			if err != nil {
+				return fantasy.ToolResponse{}, fmt.Errorf("error reading file: %w", err)
+			}
+
+			// Check file size
+			if maxSize := maxViewSize(kind); fileInfo.Size() > maxSize {
+				return fantasy.NewTextErrorResponse(fmt.Sprintf("File is too large (%d bytes). Maximum size is %d bytes",
+					fileInfo.Size(), maxSize)), nil
+			}
+
+			switch kind {
+			case fileKindPDF:
+				return viewPDF(ctx, filePath, params.Pages)
+			case fileKindImage:
+				return viewImage(filePath, mimeType, supportsImages)
+			case fileKindNotebook:
+				data, err := os.ReadFile(filePath)
+				if err != nil {
+					return fantasy.ToolResponse{}, fmt.Errorf("error reading file: %w", err)
+				}
+				if nb, err := parseNotebook(data); err == nil {
+					return viewNotebook(filePath, nb, params.Offset, params.Limit), nil
+				}
+				// Not a notebook after all, show it as text.
+				if fileInfo.Size() > MaxReadSize {
+					return fantasy.NewTextErrorResponse(fmt.Sprintf("File is too large (%d bytes). Maximum size is %d bytes",
+						fileInfo.Size(), MaxReadSize)), nil
+				}
 			}
 
 			// Read the file content
-			content, lineCount, err := readTextFile(filePath, params.Offset, params.Limit)
+			content, lineCount, err := readTextFile(ctx, filePath, params.Offset, params.Limit)
 			isValidUt8 := utf8.ValidString(content)
 			if !isValidUt8 {
 				return fantasy.NewTextErrorResponse("File content is not valid UTF-8"), nil
@@ -212,8 +253,8 @@ func addLineNumbers(content string, startLine int) string {
 	return strings.Join(result, "\n")
 }
 
-func readTextFile(filePath string, offset, limit int) (string, int, error) {
-	file, err := os.Open(filePath)
+func readTextFile(ctx context.Context, filePath string, offset, limit int) (string, int, error) {
+	file, err := openFile(ctx, filePath)
 	if err != nil {
 		return "", 0, err
 	}
@@ -263,23 +304,53 @@ func readTextFile(filePath string, offset, limit int) (string, int, error) {
 	return strings.Join(lines, "\n"), lineCount, nil
 }
 
-func isImageFile(filePath string) (bool, string) {
-	ext := strings.ToLower(filepath.Ext(filePath))
-	switch ext {
-	case ".jpg", ".jpeg":
-		return true, "JPEG"
-	case ".png":
-		return true, "PNG"
-	case ".gif":
-		return true, "GIF"
-	case ".bmp":
-		return true, "BMP"
-	case ".svg":
-		return true, "SVG"
-	case ".webp":
-		return true, "WebP"
+// detectFileKind tells the kind of a file from the start of its content,
+// along with its MIME type for images. Extensions can't be trusted, except
+// to tell notebooks from other JSON files.
+func detectFileKind(filePath string) (fileKind, string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fileKindText, "", err
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return fileKindText, "", err
+	}
+	head = head[:n]
+
+	mimeType, _, _ := strings.Cut(http.DetectContentType(head), ";")
+	switch {
+	case mimeType == "application/pdf":
+		return fileKindPDF, mimeType, nil
+	case imageTypes[mimeType] != "":
+		return fileKindImage, mimeType, nil
+	case strings.EqualFold(filepath.Ext(filePath), ".ipynb"):
+		return fileKindNotebook, "", nil
+	}
+	// Notebooks start with their cells or their metadata.
+	trimmed := bytes.TrimLeft(head, " \t\r\n")
+	if bytes.HasPrefix(trimmed, []byte("{")) &&
+		(bytes.Contains(trimmed, []byte(`"cells"`)) || bytes.Contains(trimmed, []byte(`"nbformat"`))) {
+		return fileKindNotebook, "", nil
+	}
+	return fileKindText, "", nil
+}
+
+// maxViewSize returns the size of the largest file of a kind the view tool
+// reads.
+func maxViewSize(kind fileKind) int64 {
+	switch kind {
+	case fileKindPDF:
+		return MaxPDFSize
+	case fileKindImage:
+		return MaxImageFileSize
+	case fileKindNotebook:
+		return MaxNotebookSize
 	default:
-		return false, ""
+		return MaxReadSize
 	}
 }
 
@@ -0,0 +1,132 @@
+package tools
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"os"
+
+	"charm.land/fantasy"
+	"github.com/disintegration/imageorient"
+	"github.com/nfnt/resize"
+	_ "golang.org/x/image/bmp"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// MaxImageFileSize is the size of the largest image file the view tool
+	// opens.
+	MaxImageFileSize = 20 * 1024 * 1024
+	// MaxImageSize is the size of the largest image sent to the model, bigger
+	// ones get scaled down. Base64 encoded it stays under the 5MB most
+	// providers accept.
+	MaxImageSize = 3 * 1024 * 1024
+	// MaxImageDimension is the largest width or height of an image sent to
+	// the model.
+	MaxImageDimension = 2000
+	// MaxImagePixels is the number of pixels of the largest image the view
+	// tool decodes to scale it down, as a small file can hold a huge image.
+	MaxImagePixels = 40 * 1000 * 1000
+	// minImageDimension is as far as an image gets scaled down to fit in
+	// MaxImageSize.
+	minImageDimension = 250
+)
+
+// imageTypes are the names of the image types the view tool reads, by MIME
+// type.
+var imageTypes = map[string]string{
+	"image/png":  "PNG",
+	"image/jpeg": "JPEG",
+	"image/gif":  "GIF",
+	"image/webp": "WebP",
+	"image/bmp":  "BMP",
+}
+
+// modelImageTypes are the image types models take as they are, the others
+// get converted.
+var modelImageTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+func viewImage(filePath, mimeType string, supportsImages bool) (fantasy.ToolResponse, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fantasy.ToolResponse{}, fmt.Errorf("error reading file: %w", err)
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return fantasy.NewTextErrorResponse(fmt.Sprintf("Could not decode %s image: %s", imageTypes[mimeType], err)), nil
+	}
+
+	metadata := ViewResponseMetadata{
+		FilePath: filePath,
+		Kind:     string(fileKindImage),
+	}
+	description := fmt.Sprintf("%s image, %dx%d pixels, %d bytes", imageTypes[mimeType], config.Width, config.Height, len(data))
+	if !supportsImages {
+		return fantasy.WithResponseMetadata(
+			fantasy.NewTextResponse(fmt.Sprintf("This is a %s. The current model doesn't support images, so its content can't be shown.", description)),
+			metadata,
+		), nil
+	}
+
+	if config.Width*config.Height > MaxImagePixels {
+		return fantasy.WithResponseMetadata(
+			fantasy.NewTextResponse(fmt.Sprintf("This is a %s. It is too large to be shown, images of up to %d pixels are.", description, MaxImagePixels)),
+			metadata,
+		), nil
+	}
+
+	output := fmt.Sprintf("This is a %s. The image is attached.", description)
+	if config.Width > MaxImageDimension || config.Height > MaxImageDimension || len(data) > MaxImageSize || !modelImageTypes[mimeType] {
+		scaled, scaledType, bounds, err := scaleImage(data, mimeType)
+		if err != nil {
+			return fantasy.NewTextErrorResponse(fmt.Sprintf("Could not convert %s image: %s", imageTypes[mimeType], err)), nil
+		}
+		if bounds.Dx() != config.Width || bounds.Dy() != config.Height {
+			output = fmt.Sprintf("This is a %s. The image is attached, scaled down to %dx%d pixels.", description, bounds.Dx(), bounds.Dy())
+		}
+		data, mimeType = scaled, scaledType
+	}
+	recordFileRead(filePath)
+	return WithResponseMedia(
+		fantasy.WithResponseMetadata(fantasy.NewTextResponse(output), metadata),
+		data,
+		mimeType,
+	), nil
+}
+
+// scaleImage re-encodes an image so it fits in MaxImageDimension and
+// MaxImageSize, as a PNG, or as a JPEG for photos and when a PNG is too
+// large.
+func scaleImage(data []byte, mimeType string) ([]byte, string, image.Rectangle, error) {
+	img, _, err := imageorient.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", image.Rectangle{}, err
+	}
+	for dimension := uint(MaxImageDimension); ; dimension /= 2 {
+		scaled := resize.Thumbnail(dimension, dimension, img, resize.Lanczos3)
+		var buf bytes.Buffer
+		if mimeType != "image/jpeg" {
+			if err := png.Encode(&buf, scaled); err != nil {
+				return nil, "", image.Rectangle{}, err
+			}
+			if buf.Len() <= MaxImageSize {
+				return buf.Bytes(), "image/png", scaled.Bounds(), nil
+			}
+			buf.Reset()
+		}
+		if err := jpeg.Encode(&buf, scaled, &jpeg.Options{Quality: 85}); err != nil {
+			return nil, "", image.Rectangle{}, err
+		}
+		if buf.Len() <= MaxImageSize || dimension/2 < minImageDimension {
+			return buf.Bytes(), "image/jpeg", scaled.Bounds(), nil
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/ansiext"
+)
+
+const (
+	// MaxNotebookSize is the size of the largest notebook the view tool
+	// opens, outputs with images make them a lot bigger than their text.
+	MaxNotebookSize = 10 * 1024 * 1024
+	// MaxNotebookOutputLength is the length outputs of cells are truncated
+	// to.
+	MaxNotebookOutputLength = 2000
+)
+
+// notebook is a Jupyter notebook, in the nbformat 4 format.
+type notebook struct {
+	Cells         []notebookCell   `json:"cells"`
+	Metadata      notebookMetadata `json:"metadata"`
+	NBFormat      int              `json:"nbformat"`
+	NBFormatMinor int              `json:"nbformat_minor"`
+}
+
+type notebookMetadata struct {
+	KernelSpec struct {
+		Language string `json:"language"`
+	} `json:"kernelspec"`
+	LanguageInfo struct {
+		Name string `json:"name"`
+	} `json:"language_info"`
+}
+
+type notebookCell struct {
+	ID             string           `json:"id,omitempty"`
+	CellType       string           `json:"cell_type"`
+	Source         notebookText     `json:"source"`
+	ExecutionCount *int             `json:"execution_count,omitempty"`
+	Outputs        []notebookOutput `json:"outputs,omitempty"`
+}
+
+type notebookOutput struct {
+	OutputType string                  `json:"output_type"`
+	Name       string                  `json:"name,omitempty"`
+	Text       notebookText            `json:"text,omitempty"`
+	Data       map[string]notebookText `json:"data,omitempty"`
+	EName      string                  `json:"ename,omitempty"`
+	EValue     string                  `json:"evalue,omitempty"`
+	Traceback  []string                `json:"traceback,omitempty"`
+}
+
+// notebookText is a multiline string, stored either as a string or as a list
+// of lines.
+type notebookText string
+
+func (t *notebookText) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*t = notebookText(text)
+		return nil
+	}
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err != nil {
+		// Outputs like application/json hold objects, they aren't shown.
+		*t = ""
+		return nil //nolint:nilerr
+	}
+	*t = notebookText(strings.Join(lines, ""))
+	return nil
+}
+
+func parseNotebook(data []byte) (*notebook, error) {
+	var nb notebook
+	if err := json.Unmarshal(data, &nb); err != nil {
+		return nil, fmt.Errorf("invalid notebook: %w", err)
+	}
+	if nb.NBFormat == 0 || nb.Cells == nil {
+		return nil, errors.New("invalid notebook: no nbformat or cells")
+	}
+	if nb.NBFormat < 4 {
+		return nil, fmt.Errorf("unsupported notebook format %d, only nbformat 4 is supported", nb.NBFormat)
+	}
+	return &nb, nil
+}
+
+// language returns the programming language of the code cells.
+func (nb *notebook) language() string {
+	if nb.Metadata.KernelSpec.Language != "" {
+		return nb.Metadata.KernelSpec.Language
+	}
+	return nb.Metadata.LanguageInfo.Name
+}
+
+func viewNotebook(filePath string, nb *notebook, offset, limit int) fantasy.ToolResponse {
+	if offset > len(nb.Cells) {
+		offset = len(nb.Cells)
+	}
+	end := min(offset+limit, len(nb.Cells))
+
+	var output strings.Builder
+	output.WriteString("<file>\n")
+	fmt.Fprintf(&output, "<notebook cells=\"%d\"", len(nb.Cells))
+	if language := nb.language(); language != "" {
+		fmt.Fprintf(&output, " language=%q", language)
+	}
+	output.WriteString(">\n")
+	for i := offset; i < end; i++ {
+		writeNotebookCell(&output, i, nb.Cells[i])
+	}
+	output.WriteString("</notebook>\n")
+	if end < len(nb.Cells) {
+		fmt.Fprintf(&output, "\n(Notebook has more cells. Use 'offset' parameter to read beyond cell %d)\n", end)
+	}
+	output.WriteString("</file>\n")
+
+	recordFileRead(filePath)
+	return fantasy.WithResponseMetadata(
+		fantasy.NewTextResponse(output.String()),
+		ViewResponseMetadata{
+			FilePath: filePath,
+			Kind:     string(fileKindNotebook),
+		},
+	)
+}
+
+func writeNotebookCell(output *strings.Builder, index int, cell notebookCell) {
+	fmt.Fprintf(output, "<cell index=\"%d\"", index)
+	if cell.ID != "" {
+		fmt.Fprintf(output, " id=%q", cell.ID)
+	}
+	fmt.Fprintf(output, " type=%q", cell.CellType)
+	if cell.ExecutionCount != nil {
+		fmt.Fprintf(output, " execution_count=\"%d\"", *cell.ExecutionCount)
+	}
+	output.WriteString(">\n")
+	if source := strings.TrimRight(string(cell.Source), "\n"); source != "" {
+		output.WriteString(source)
+		output.WriteString("\n")
+	}
+	if len(cell.Outputs) > 0 {
+		output.WriteString("<outputs>\n")
+		for _, out := range cell.Outputs {
+			writeNotebookOutput(output, out)
+		}
+		output.WriteString("</outputs>\n")
+	}
+	output.WriteString("</cell>\n")
+}
+
+func writeNotebookOutput(output *strings.Builder, out notebookOutput) {
+	var text string
+	switch out.OutputType {
+	case "stream":
+		fmt.Fprintf(output, "<output type=\"stream\" name=%q>\n", out.Name)
+		text = ansiext.Strip(string(out.Text))
+	case "error":
+		output.WriteString("<output type=\"error\">\n")
+		text = out.EName + ": " + out.EValue
+		if len(out.Traceback) > 0 {
+			text = ansiext.Strip(strings.Join(out.Traceback, "\n"))
+		}
+	default:
+		fmt.Fprintf(output, "<output type=%q>\n", out.OutputType)
+		text = notebookDataText(out.Data)
+	}
+	output.WriteString(truncateNotebookOutput(strings.TrimRight(text, "\n")))
+	output.WriteString("\n</output>\n")
+}
+
+// notebookDataText returns the text of a rich output, the data in other
+// formats like images is only named.
+func notebookDataText(data map[string]notebookText) string {
+	for _, mimeType := range []string{"text/plain", "text/markdown"} {
+		if text, ok := data[mimeType]; ok {
+			return ansiext.Strip(string(text))
+		}
+	}
+	mimeTypes := make([]string, 0, len(data))
+	for mimeType := range data {
+		mimeTypes = append(mimeTypes, mimeType)
+	}
+	slices.Sort(mimeTypes)
+	return fmt.Sprintf("(%s output not shown)", strings.Join(mimeTypes, ", "))
+}
+
+func truncateNotebookOutput(text string) string {
+	if len(text) <= MaxNotebookOutputLength {
+		return text
+	}
+	cut := MaxNotebookOutputLength
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s\n... (%d more bytes)", text[:cut], len(text)-cut)
+}
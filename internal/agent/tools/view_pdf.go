@@ -0,0 +1,170 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"charm.land/fantasy"
+	"github.com/ledongthuc/pdf"
+)
+
+const (
+	// MaxPDFSize is the size of the largest PDF file the view tool opens.
+	MaxPDFSize = 20 * 1024 * 1024
+	// DefaultPDFPages is the number of pages read when no range is given.
+	DefaultPDFPages = 20
+	// pdfWordGap is the space between two glyphs, relative to the font size,
+	// from which they are in different words.
+	pdfWordGap = 0.2
+)
+
+// pdfLineBreaks removes the line breaks some glyphs decode to.
+var pdfLineBreaks = strings.NewReplacer("\r", "", "\n", "")
+
+func viewPDF(ctx context.Context, filePath, pages string) (fantasy.ToolResponse, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fantasy.ToolResponse{}, fmt.Errorf("error reading file: %w", err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return fantasy.ToolResponse{}, fmt.Errorf("error reading file: %w", err)
+	}
+
+	reader, err := openPDF(file, info.Size())
+	if err != nil {
+		return fantasy.NewTextErrorResponse(fmt.Sprintf("Could not read PDF: %s", err)), nil
+	}
+	numPages := reader.NumPage()
+	if numPages == 0 {
+		return fantasy.NewTextErrorResponse("The PDF has no pages"), nil
+	}
+	first, last, err := parsePageRange(pages, numPages)
+	if err != nil {
+		return fantasy.NewTextErrorResponse(err.Error()), nil
+	}
+
+	var output strings.Builder
+	fmt.Fprintf(&output, "<file>\n<pdf pages=\"%d\">\n", numPages)
+	page := first
+	for ; page <= last && output.Len() < MaxReadSize; page++ {
+		if err := ctx.Err(); err != nil {
+			return fantasy.ToolResponse{}, err
+		}
+		text, err := pdfPageText(reader, page)
+		if err != nil {
+			text = fmt.Sprintf("(Could not extract the text of this page: %s)", err)
+		} else if text == "" {
+			text = "(No text on this page, it may be scanned or only hold images)"
+		}
+		fmt.Fprintf(&output, "<page number=\"%d\">\n%s\n</page>\n", page, text)
+	}
+	output.WriteString("</pdf>\n")
+	if page <= numPages {
+		fmt.Fprintf(&output, "\n(PDF has more pages. Use the 'pages' parameter to read from page %d)\n", page)
+	}
+	output.WriteString("</file>\n")
+
+	recordFileRead(filePath)
+	return fantasy.WithResponseMetadata(
+		fantasy.NewTextResponse(output.String()),
+		ViewResponseMetadata{
+			FilePath: filePath,
+			Kind:     string(fileKindPDF),
+		},
+	), nil
+}
+
+// openPDF opens a PDF, the parser panics on some malformed files.
+func openPDF(file *os.File, size int64) (reader *pdf.Reader, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("malformed PDF: %v", r)
+		}
+	}()
+	return pdf.NewReader(file, size)
+}
+
+// pdfPageText returns the text of a page, line by line from the top.
+func pdfPageText(reader *pdf.Reader, number int) (text string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("malformed page: %v", r)
+		}
+	}()
+	page := reader.Page(number)
+	if page.V.IsNull() {
+		return "", nil
+	}
+
+	// The glyphs are grouped in lines by their baseline, in the order they
+	// appear in the lines rather than in the page content.
+	lines := make(map[float64][]pdf.Text)
+	for _, glyph := range page.Content().Text {
+		// Line breaks come from the positions, not from the glyphs.
+		if glyph.S = pdfLineBreaks.Replace(glyph.S); glyph.S == "" {
+			continue
+		}
+		y := math.Round(glyph.Y)
+		lines[y] = append(lines[y], glyph)
+	}
+	ys := slices.Collect(maps.Keys(lines))
+	slices.SortFunc(ys, func(a, b float64) int { return cmp.Compare(b, a) })
+
+	var result strings.Builder
+	for _, y := range ys {
+		glyphs := lines[y]
+		slices.SortStableFunc(glyphs, func(a, b pdf.Text) int { return cmp.Compare(a.X, b.X) })
+		var line strings.Builder
+		for i, glyph := range glyphs {
+			// Words are often placed apart rather than separated by spaces.
+			if i > 0 {
+				prev := glyphs[i-1]
+				gap := glyph.X - (prev.X + prev.W)
+				if gap > pdfWordGap*max(glyph.FontSize, 1) && prev.S != " " && glyph.S != " " {
+					line.WriteString(" ")
+				}
+			}
+			line.WriteString(glyph.S)
+		}
+		result.WriteString(strings.TrimRight(line.String(), " \t"))
+		result.WriteString("\n")
+	}
+	return strings.TrimSpace(result.String()), nil
+}
+
+// parsePageRange parses a page range like "3", "1-5" or "4-" into its first
+// and last pages. An empty range is the first DefaultPDFPages pages.
+func parsePageRange(pages string, numPages int) (int, int, error) {
+	pages = strings.TrimSpace(pages)
+	if pages == "" {
+		return 1, min(DefaultPDFPages, numPages), nil
+	}
+	invalid := fmt.Errorf("invalid page range %q, use a page like \"3\" or a range like \"1-5\" or \"4-\"", pages)
+	start, end, isRange := strings.Cut(pages, "-")
+	first, err := strconv.Atoi(strings.TrimSpace(start))
+	if err != nil || first < 1 {
+		return 0, 0, invalid
+	}
+	last := first
+	if isRange {
+		last = numPages
+		if end = strings.TrimSpace(end); end != "" {
+			if last, err = strconv.Atoi(end); err != nil || last < first {
+				return 0, 0, invalid
+			}
+		}
+	}
+	if first > numPages {
+		return 0, 0, fmt.Errorf("page %d is out of range, the PDF has %d pages", first, numPages)
+	}
+	return first, min(last, numPages), nil
+}
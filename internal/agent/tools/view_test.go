@@ -0,0 +1,351 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/charmbracelet/crush/internal/lsp"
+	"github.com/charmbracelet/crush/internal/permission"
+	"github.com/charmbracelet/crush/internal/pubsub"
+	"github.com/stretchr/testify/require"
+)
+
+// writePDF writes a PDF with a page for each of the pages, which are lines of
+// text.
+func writePDF(t *testing.T, path string, pages ...[]string) {
+	t.Helper()
+	var objects []string
+	kids := make([]string, len(pages))
+	for i, lines := range pages {
+		var content strings.Builder
+		for j, line := range lines {
+			fmt.Fprintf(&content, "BT /F1 12 Tf 72 %d Td (%s) Tj ET\n", 720-j*20, line)
+		}
+		page := 4 + 2*i
+		kids[i] = fmt.Sprintf("%d 0 R", page)
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", page+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+		)
+	}
+	objects = append([]string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	}, objects...)
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o644))
+}
+
+// writePNG writes a PNG image of the size.
+func writePNG(t *testing.T, path string, width, height int) {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := range width {
+		img.Set(x, 0, color.RGBA{R: 255, A: 255})
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o644))
+}
+
+// writePNGHeader writes the start of a PNG of the given size, enough to tell
+// its size but not to decode it.
+func writePNGHeader(t *testing.T, path string, width, height uint32) {
+	t.Helper()
+	chunk := []byte("IHDR")
+	chunk = binary.BigEndian.AppendUint32(chunk, width)
+	chunk = binary.BigEndian.AppendUint32(chunk, height)
+	chunk = append(chunk, 8, 6, 0, 0, 0)
+	data := []byte("\x89PNG\r\n\x1a\n")
+	data = binary.BigEndian.AppendUint32(data, uint32(len(chunk)-4))
+	data = append(data, chunk...)
+	data = binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(chunk))
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+}
+
+func runViewTool(t *testing.T, workingDir string, supportsImages bool, params ViewParams) fantasy.ToolResponse {
+	t.Helper()
+	permissions := &mockPermissionService{Broker: pubsub.NewBroker[permission.PermissionRequest]()}
+	tool := NewViewTool(csync.NewMap[string, *lsp.Client](), permissions, workingDir, supportsImages)
+	input, err := json.Marshal(params)
+	require.NoError(t, err)
+	resp, err := tool.Run(t.Context(), fantasy.ToolCall{ID: "call", Name: ViewToolName, Input: string(input)})
+	require.NoError(t, err)
+	return resp
+}
+
+func TestDetectFileKind(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writePDF(t, filepath.Join(dir, "report"), []string{"hello"})
+	writePNG(t, filepath.Join(dir, "image.dat"), 2, 2)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "fake.png"), []byte("not an image\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "drawing.svg"), []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "analysis.json"), []byte(`{"cells": [], "nbformat": 4}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"name": "app"}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "empty.ipynb"), nil, 0o644))
+
+	for name, want := range map[string]fileKind{
+		"report":        fileKindPDF,
+		"image.dat":     fileKindImage,
+		"fake.png":      fileKindText,
+		"drawing.svg":   fileKindText,
+		"analysis.json": fileKindNotebook,
+		"package.json":  fileKindText,
+		"empty.ipynb":   fileKindNotebook,
+	} {
+		kind, _, err := detectFileKind(filepath.Join(dir, name))
+		require.NoError(t, err)
+		require.Equal(t, want, kind, name)
+	}
+}
+
+func TestParsePageRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pages       string
+		first, last int
+		err         string
+	}{
+		{pages: "", first: 1, last: 20},
+		{pages: "3", first: 3, last: 3},
+		{pages: "2-5", first: 2, last: 5},
+		{pages: "4-", first: 4, last: 30},
+		{pages: " 10 - 40 ", first: 10, last: 30},
+		{pages: "0", err: "invalid page range"},
+		{pages: "5-2", err: "invalid page range"},
+		{pages: "a-b", err: "invalid page range"},
+		{pages: "31", err: "out of range"},
+	}
+	for _, tt := range tests {
+		first, last, err := parsePageRange(tt.pages, 30)
+		if tt.err != "" {
+			require.ErrorContains(t, err, tt.err, tt.pages)
+			continue
+		}
+		require.NoError(t, err, tt.pages)
+		require.Equal(t, tt.first, first, tt.pages)
+		require.Equal(t, tt.last, last, tt.pages)
+	}
+}
+
+func TestViewTool_PDF(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writePDF(t, filepath.Join(dir, "report.pdf"),
+		[]string{"Quarterly report", "Revenue grew"},
+		nil,
+		[]string{"Appendix"},
+	)
+
+	resp := runViewTool(t, dir, false, ViewParams{FilePath: "report.pdf"})
+	require.False(t, resp.IsError, resp.Content)
+	require.Contains(t, resp.Content, "<pdf pages=\"3\">")
+	require.Contains(t, resp.Content, "<page number=\"1\">\nQuarterly report\nRevenue grew\n</page>")
+	require.Contains(t, resp.Content, "<page number=\"2\">\n(No text on this page")
+	require.Contains(t, resp.Content, "<page number=\"3\">\nAppendix\n</page>")
+	require.NotContains(t, resp.Content, "more pages")
+
+	var meta ViewResponseMetadata
+	require.NoError(t, json.Unmarshal([]byte(resp.Metadata), &meta))
+	require.Equal(t, "pdf", meta.Kind)
+	require.Empty(t, meta.Content)
+
+	resp = runViewTool(t, dir, false, ViewParams{FilePath: "report.pdf", Pages: "1"})
+	require.Contains(t, resp.Content, "Quarterly report")
+	require.NotContains(t, resp.Content, "Appendix")
+	require.Contains(t, resp.Content, "Use the 'pages' parameter to read from page 2")
+
+	resp = runViewTool(t, dir, false, ViewParams{FilePath: "report.pdf", Pages: "5"})
+	require.True(t, resp.IsError)
+	require.Contains(t, resp.Content, "page 5 is out of range")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.pdf"), []byte("%PDF-1.4\ngarbage"), 0o644))
+	resp = runViewTool(t, dir, false, ViewParams{FilePath: "broken.pdf"})
+	require.True(t, resp.IsError)
+	require.Contains(t, resp.Content, "Could not read PDF")
+}
+
+func TestViewTool_Image(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writePNG(t, filepath.Join(dir, "small.png"), 40, 30)
+	writePNG(t, filepath.Join(dir, "wide.png"), 4000, 100)
+	writePNGHeader(t, filepath.Join(dir, "huge.png"), 100000, 100000)
+
+	t.Run("vision model", func(t *testing.T) {
+		t.Parallel()
+		resp := runViewTool(t, dir, true, ViewParams{FilePath: "small.png"})
+		require.False(t, resp.IsError, resp.Content)
+		require.Contains(t, resp.Content, "PNG image, 40x30 pixels")
+		require.Contains(t, resp.Content, "The image is attached.")
+
+		media, metadata := TakeResponseMedia(resp.Metadata)
+		require.NotNil(t, media)
+		require.Equal(t, "image/png", media.MIMEType)
+		data, err := base64.StdEncoding.DecodeString(media.Data)
+		require.NoError(t, err)
+		original, err := os.ReadFile(filepath.Join(dir, "small.png"))
+		require.NoError(t, err)
+		require.Equal(t, original, data)
+
+		var meta ViewResponseMetadata
+		require.NoError(t, json.Unmarshal([]byte(metadata), &meta))
+		require.Equal(t, "image", meta.Kind)
+		require.NotContains(t, metadata, "media")
+	})
+
+	t.Run("scaled down", func(t *testing.T) {
+		t.Parallel()
+		resp := runViewTool(t, dir, true, ViewParams{FilePath: "wide.png"})
+		require.False(t, resp.IsError, resp.Content)
+		require.Contains(t, resp.Content, "scaled down to 2000x50 pixels")
+
+		media, _ := TakeResponseMedia(resp.Metadata)
+		require.NotNil(t, media)
+		data, err := base64.StdEncoding.DecodeString(media.Data)
+		require.NoError(t, err)
+		config, _, err := image.DecodeConfig(bytes.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, 2000, config.Width)
+		require.Equal(t, 50, config.Height)
+	})
+
+	t.Run("too many pixels", func(t *testing.T) {
+		t.Parallel()
+		resp := runViewTool(t, dir, true, ViewParams{FilePath: "huge.png"})
+		require.False(t, resp.IsError, resp.Content)
+		require.Contains(t, resp.Content, "PNG image, 100000x100000 pixels")
+		require.Contains(t, resp.Content, "too large to be shown")
+		media, _ := TakeResponseMedia(resp.Metadata)
+		require.Nil(t, media)
+	})
+
+	t.Run("text fallback", func(t *testing.T) {
+		t.Parallel()
+		resp := runViewTool(t, dir, false, ViewParams{FilePath: "small.png"})
+		require.False(t, resp.IsError, resp.Content)
+		require.Contains(t, resp.Content, "PNG image, 40x30 pixels")
+		require.Contains(t, resp.Content, "doesn't support images")
+		media, _ := TakeResponseMedia(resp.Metadata)
+		require.Nil(t, media)
+	})
+}
+
+func TestViewTool_Notebook(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	notebook := map[string]any{
+		"nbformat":       4,
+		"nbformat_minor": 5,
+		"metadata": map[string]any{
+			"kernelspec": map[string]any{"language": "python"},
+		},
+		"cells": []any{
+			map[string]any{
+				"id":        "intro",
+				"cell_type": "markdown",
+				"metadata":  map[string]any{},
+				"source":    []string{"# Analysis\n", "Loads the data."},
+			},
+			map[string]any{
+				"id":              "load",
+				"cell_type":       "code",
+				"execution_count": 1,
+				"metadata":        map[string]any{},
+				"source":          "import pandas as pd\ndf = pd.read_csv('data.csv')",
+				"outputs": []any{
+					map[string]any{"output_type": "stream", "name": "stdout", "text": []string{"loaded\n", strings.Repeat("x", 3000)}},
+					map[string]any{
+						"output_type":     "execute_result",
+						"execution_count": 1,
+						"data": map[string]any{
+							"text/plain": []string{"   a  b\n", "0  1  2"},
+							"text/html":  []string{"<table></table>"},
+						},
+					},
+					map[string]any{"output_type": "display_data", "data": map[string]any{"image/png": "iVBORw0KGgo="}},
+				},
+			},
+			map[string]any{
+				"id":              "fail",
+				"cell_type":       "code",
+				"execution_count": 2,
+				"metadata":        map[string]any{},
+				"source":          "df.missing",
+				"outputs": []any{
+					map[string]any{
+						"output_type": "error",
+						"ename":       "AttributeError",
+						"evalue":      "no attribute 'missing'",
+						"traceback":   []string{"\x1b[0;31mAttributeError\x1b[0m: no attribute 'missing'"},
+					},
+				},
+			},
+		},
+	}
+	data, err := json.Marshal(notebook)
+	require.NoError(t, err)
+	// Notebooks are told by their content, not only their extension.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "analysis.json"), data, 0o644))
+
+	resp := runViewTool(t, dir, false, ViewParams{FilePath: "analysis.json"})
+	require.False(t, resp.IsError, resp.Content)
+	require.Contains(t, resp.Content, `<notebook cells="3" language="python">`)
+	require.Contains(t, resp.Content, "<cell index=\"0\" id=\"intro\" type=\"markdown\">\n# Analysis\nLoads the data.\n</cell>")
+	require.Contains(t, resp.Content, "<cell index=\"1\" id=\"load\" type=\"code\" execution_count=\"1\">\nimport pandas as pd\n")
+	require.Contains(t, resp.Content, "<output type=\"stream\" name=\"stdout\">\nloaded\n")
+	require.Contains(t, resp.Content, "... (1007 more bytes)")
+	require.Contains(t, resp.Content, "<output type=\"execute_result\">\n   a  b\n0  1  2\n</output>")
+	require.Contains(t, resp.Content, "<output type=\"display_data\">\n(image/png output not shown)\n</output>")
+	require.Contains(t, resp.Content, "<output type=\"error\">\nAttributeError: no attribute 'missing'\n</output>")
+	require.NotContains(t, resp.Content, "more cells")
+
+	var meta ViewResponseMetadata
+	require.NoError(t, json.Unmarshal([]byte(resp.Metadata), &meta))
+	require.Equal(t, "notebook", meta.Kind)
+
+	resp = runViewTool(t, dir, false, ViewParams{FilePath: "analysis.json", Offset: 1, Limit: 1})
+	require.NotContains(t, resp.Content, `id="intro"`)
+	require.Contains(t, resp.Content, `id="load"`)
+	require.NotContains(t, resp.Content, `id="fail"`)
+	require.Contains(t, resp.Content, "Use 'offset' parameter to read beyond cell 2")
+
+	// JSON that only looks like a notebook is shown as text.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cells.json"), []byte(`{"cells": "none"}`), 0o644))
+	resp = runViewTool(t, dir, false, ViewParams{FilePath: "cells.json"})
+	require.False(t, resp.IsError, resp.Content)
+	require.Contains(t, resp.Content, `     1|{"cells": "none"}`)
+}
@@ -0,0 +1,253 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/crush/internal/diff"
+	"github.com/charmbracelet/crush/internal/fsext"
+	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/lsp/util"
+	"github.com/charmbracelet/x/powernap/pkg/lsp/protocol"
+)
+
+// workspaceEditChange is a file changed by a workspace edit from an LSP
+// server, with what's needed to undo it.
+type workspaceEditChange struct {
+	ApplyPatchFile
+	// original is the content of the file on disk, to restore it.
+	original []byte
+}
+
+// prepareWorkspaceEdit works out what a workspace edit does to each file,
+// without changing any.
+func prepareWorkspaceEdit(ctx context.Context, edit protocol.WorkspaceEdit, workingDir string) ([]workspaceEditChange, error) {
+	textEdits, err := util.TextEdits(edit)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []workspaceEditChange
+	for uri, edits := range textEdits {
+		path, err := uri.Path()
+		if err != nil {
+			return nil, fmt.Errorf("invalid URI: %w", err)
+		}
+		var original []byte
+		if _, err := os.Stat(path); err == nil {
+			if original, err = readFile(ctx, path); err != nil {
+				return nil, fmt.Errorf("failed to read file: %w", err)
+			}
+		} else if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		newContent, err := util.ApplyTextEditsToContent(string(original), edits)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		change := workspaceEditChange{
+			ApplyPatchFile: ApplyPatchFile{Path: path, Kind: string(patchUpdate)},
+			original:       original,
+		}
+		change.OldContent, _ = fsext.ToUnixLineEndings(string(original))
+		change.NewContent, _ = fsext.ToUnixLineEndings(newContent)
+		if change.OldContent == change.NewContent {
+			continue
+		}
+		_, change.Additions, change.Removals = diff.GenerateDiff(change.OldContent, change.NewContent, strings.TrimPrefix(path, workingDir))
+		changes = append(changes, change)
+	}
+
+	// Files created, renamed or deleted are listed without a diff.
+	for _, documentChange := range edit.DocumentChanges {
+		var (
+			change workspaceEditChange
+			uri    protocol.DocumentURI
+		)
+		switch {
+		case documentChange.CreateFile != nil:
+			change.Kind, uri = string(patchAdd), documentChange.CreateFile.URI
+		case documentChange.DeleteFile != nil:
+			change.Kind, uri = string(patchDelete), documentChange.DeleteFile.URI
+		case documentChange.RenameFile != nil:
+			change.Kind, uri = string(patchUpdate), documentChange.RenameFile.OldURI
+			change.MovePath, err = documentChange.RenameFile.NewURI.Path()
+			if err != nil {
+				return nil, fmt.Errorf("invalid URI: %w", err)
+			}
+		default:
+			continue
+		}
+		if change.Path, err = uri.Path(); err != nil {
+			return nil, fmt.Errorf("invalid URI: %w", err)
+		}
+		if change.Kind != string(patchAdd) {
+			if content, err := readFile(ctx, change.Path); err == nil {
+				change.OldContent, _ = fsext.ToUnixLineEndings(string(content))
+			}
+			if change.MovePath != "" {
+				change.NewContent = change.OldContent
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	slices.SortStableFunc(changes, func(a, b workspaceEditChange) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+	return changes, nil
+}
+
+// summarizeWorkspaceEdit returns the files changed by a workspace edit, to
+// show them, the paths it writes to, to check them, and its line counts.
+func summarizeWorkspaceEdit(changes []workspaceEditChange) (editFiles []ApplyPatchFile, targets []string, additions, removals int) {
+	for _, change := range changes {
+		editFiles = append(editFiles, change.ApplyPatchFile)
+		additions += change.Additions
+		removals += change.Removals
+		targets = append(targets, change.Path)
+		if change.MovePath != "" {
+			targets = append(targets, change.MovePath)
+		}
+	}
+	return editFiles, targets, additions, removals
+}
+
+// applyWorkspaceEdit applies a workspace edit whose changes were prepared by
+// prepareWorkspaceEdit, and stores the new versions of the files. It returns
+// the paths of the files written and a summary line per change.
+func applyWorkspaceEdit(ctx context.Context, files history.Service, sessionID string, edit protocol.WorkspaceEdit, changes []workspaceEditChange) ([]string, []string, error) {
+	if err := writeWorkspaceEdit(ctx, edit); err != nil {
+		undoWorkspaceEdit(ctx, changes)
+		return nil, nil, err
+	}
+
+	var written, summary []string
+	for _, change := range changes {
+		switch {
+		case change.Kind == string(patchAdd):
+			summary = append(summary, fmt.Sprintf("A %s", change.Path))
+		case change.Kind == string(patchDelete):
+			summary = append(summary, fmt.Sprintf("D %s", change.Path))
+		case change.MovePath != "":
+			summary = append(summary, fmt.Sprintf("R %s -> %s", change.Path, change.MovePath))
+		default:
+			summary = append(summary, fmt.Sprintf("M %s (+%d -%d)", change.Path, change.Additions, change.Removals))
+		}
+		if err := recordWorkspaceEditHistory(ctx, files, sessionID, change); err != nil {
+			return nil, nil, err
+		}
+		if path := change.writtenPath(); path != "" {
+			recordFileWrite(path)
+			recordFileRead(path)
+			written = append(written, path)
+		}
+	}
+	return written, summary, nil
+}
+
+// writeWorkspaceEdit applies a workspace edit with util.ApplyWorkspaceEdit,
+// or in the same order with the text edits going through the editor when
+// there is one. Files are still created, renamed and deleted on disk.
+func writeWorkspaceEdit(ctx context.Context, edit protocol.WorkspaceEdit) error {
+	if getEditorFSFromContext(ctx) == nil {
+		return util.ApplyWorkspaceEdit(edit)
+	}
+	for uri, edits := range edit.Changes {
+		if err := writeTextEdits(ctx, uri, edits); err != nil {
+			return fmt.Errorf("failed to apply text edits: %w", err)
+		}
+	}
+	for _, change := range edit.DocumentChanges {
+		if change.TextDocumentEdit == nil {
+			if err := util.ApplyDocumentChange(change); err != nil {
+				return fmt.Errorf("failed to apply document change: %w", err)
+			}
+			continue
+		}
+		edits := make([]protocol.TextEdit, len(change.TextDocumentEdit.Edits))
+		for i, e := range change.TextDocumentEdit.Edits {
+			var err error
+			if edits[i], err = e.AsTextEdit(); err != nil {
+				return fmt.Errorf("invalid edit type: %w", err)
+			}
+		}
+		if err := writeTextEdits(ctx, change.TextDocumentEdit.TextDocument.URI, edits); err != nil {
+			return fmt.Errorf("failed to apply text edits: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeTextEdits applies text edits to the current content of a file.
+func writeTextEdits(ctx context.Context, uri protocol.DocumentURI, edits []protocol.TextEdit) error {
+	path, err := uri.Path()
+	if err != nil {
+		return fmt.Errorf("invalid URI: %w", err)
+	}
+	content, err := readFile(ctx, path)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	newContent, err := util.ApplyTextEditsToContent(string(content), edits)
+	if err != nil {
+		return err
+	}
+	if err := writeFile(ctx, path, []byte(newContent)); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// writtenPath returns the path the change leaves a file at, if any.
+func (c workspaceEditChange) writtenPath() string {
+	switch {
+	case c.Kind == string(patchDelete):
+		return ""
+	case c.MovePath != "":
+		return c.MovePath
+	default:
+		return c.Path
+	}
+}
+
+// undoWorkspaceEdit restores the content of the files whose text was
+// changed, after a workspace edit failed halfway.
+func undoWorkspaceEdit(ctx context.Context, changes []workspaceEditChange) {
+	for _, change := range changes {
+		if change.original == nil {
+			continue
+		}
+		if err := writeFile(ctx, change.Path, change.original); err != nil {
+			slog.Error("Failed to undo workspace edit change", "path", change.Path, "error", err)
+		}
+	}
+}
+
+// recordWorkspaceEditHistory stores the versions of a file changed by a
+// workspace edit. The content of a file left somewhere is read back, as files
+// can be both edited and moved.
+func recordWorkspaceEditHistory(ctx context.Context, files history.Service, sessionID string, change workspaceEditChange) error {
+	var newContent string
+	if path := change.writtenPath(); path != "" {
+		content, err := readFile(ctx, path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+		newContent, _ = fsext.ToUnixLineEndings(string(content))
+	}
+	if change.MovePath != "" {
+		if err := recordFileVersions(ctx, files, sessionID, change.Path, change.OldContent, ""); err != nil {
+			return err
+		}
+		return recordFileVersions(ctx, files, sessionID, change.MovePath, "", newContent)
+	}
+	return recordFileVersions(ctx, files, sessionID, change.Path, change.OldContent, newContent)
+}
@@ -0,0 +1,149 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/x/powernap/pkg/lsp/protocol"
+	"github.com/stretchr/testify/require"
+)
+
+func textEdit(line, start, end uint32, newText string) protocol.TextEdit {
+	return protocol.TextEdit{
+		Range: protocol.Range{
+			Start: protocol.Position{Line: line, Character: start},
+			End:   protocol.Position{Line: line, Character: end},
+		},
+		NewText: newText,
+	}
+}
+
+func TestPrepareWorkspaceEdit(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	mainPath := filepath.Join(dir, "main.go")
+	runPath := filepath.Join(dir, "run.go")
+	require.NoError(t, os.WriteFile(mainPath, []byte("package main\n\nfunc main() {\n\trun()\n}\n"), 0o644))
+	require.NoError(t, os.WriteFile(runPath, []byte("package main\r\n\r\nfunc run() {}\r\n"), 0o644))
+
+	edit := protocol.WorkspaceEdit{
+		Changes: map[protocol.DocumentURI][]protocol.TextEdit{
+			protocol.URIFromPath(mainPath): {textEdit(3, 1, 4, "start")},
+		},
+		DocumentChanges: []protocol.DocumentChange{{
+			TextDocumentEdit: &protocol.TextDocumentEdit{
+				TextDocument: protocol.OptionalVersionedTextDocumentIdentifier{
+					TextDocumentIdentifier: protocol.TextDocumentIdentifier{URI: protocol.URIFromPath(runPath)},
+				},
+				Edits: []protocol.Or_TextDocumentEdit_edits_Elem{{Value: textEdit(2, 5, 8, "start")}},
+			},
+		}},
+	}
+	changes, err := prepareWorkspaceEdit(t.Context(), edit, dir)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+
+	require.Equal(t, mainPath, changes[0].Path)
+	require.Equal(t, "package main\n\nfunc main() {\n\tstart()\n}\n", changes[0].NewContent)
+	require.Equal(t, 1, changes[0].Additions)
+	require.Equal(t, 1, changes[0].Removals)
+
+	// The diff is shown without the CRLF line endings the file keeps.
+	require.Equal(t, runPath, changes[1].Path)
+	require.Equal(t, "package main\n\nfunc run() {}\n", changes[1].OldContent)
+	require.Equal(t, "package main\n\nfunc start() {}\n", changes[1].NewContent)
+
+	// Nothing is written until the edit is applied.
+	require.Equal(t, "package main\r\n\r\nfunc run() {}\r\n", readTestFile(t, runPath))
+	require.NoError(t, writeWorkspaceEdit(t.Context(), edit))
+	require.Equal(t, "package main\r\n\r\nfunc start() {}\r\n", readTestFile(t, runPath))
+	require.Equal(t, changes[0].NewContent, readTestFile(t, mainPath))
+}
+
+func TestPrepareWorkspaceEdit_FileOperations(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "old.go")
+	newPath := filepath.Join(dir, "new.go")
+	require.NoError(t, os.WriteFile(oldPath, []byte("package main\n"), 0o644))
+
+	changes, err := prepareWorkspaceEdit(t.Context(), protocol.WorkspaceEdit{
+		DocumentChanges: []protocol.DocumentChange{{
+			RenameFile: &protocol.RenameFile{
+				Kind:   "rename",
+				OldURI: protocol.URIFromPath(oldPath),
+				NewURI: protocol.URIFromPath(newPath),
+			},
+		}},
+	}, dir)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, oldPath, changes[0].Path)
+	require.Equal(t, newPath, changes[0].MovePath)
+	require.Equal(t, newPath, changes[0].writtenPath())
+	require.Equal(t, "package main\n", changes[0].OldContent)
+	require.Equal(t, changes[0].OldContent, changes[0].NewContent)
+}
+
+func TestPrepareWorkspaceEdit_OverlappingEdits(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.go")
+	require.NoError(t, os.WriteFile(path, []byte("package main\n"), 0o644))
+
+	_, err := prepareWorkspaceEdit(t.Context(), protocol.WorkspaceEdit{
+		Changes: map[protocol.DocumentURI][]protocol.TextEdit{
+			protocol.URIFromPath(path): {textEdit(0, 0, 7, "x"), textEdit(0, 3, 9, "y")},
+		},
+	}, dir)
+	require.ErrorContains(t, err, "overlapping edits")
+}
+
+// bufferEditor is an editor whose buffers differ from the disk.
+type bufferEditor map[string]string
+
+func (e bufferEditor) ReadTextFile(_ context.Context, path string) (string, error) {
+	if content, ok := e[path]; ok {
+		return content, nil
+	}
+	content, err := os.ReadFile(path)
+	return string(content), err
+}
+
+func (e bufferEditor) WriteTextFile(_ context.Context, path, content string) error {
+	e[path] = content
+	return nil
+}
+
+func TestWriteWorkspaceEdit_Editor(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.go")
+	require.NoError(t, os.WriteFile(path, []byte("package main\n\nfunc main() {}\n"), 0o644))
+	editor := bufferEditor{path: "package main\n\nfunc main() {\n\trun()\n}\n"}
+	ctx := context.WithValue(t.Context(), EditorFSContextKey, EditorFS(editor))
+
+	edit := protocol.WorkspaceEdit{
+		Changes: map[protocol.DocumentURI][]protocol.TextEdit{
+			protocol.URIFromPath(path): {textEdit(3, 1, 4, "start")},
+		},
+	}
+	changes, err := prepareWorkspaceEdit(ctx, edit, dir)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, "package main\n\nfunc main() {\n\trun()\n}\n", changes[0].OldContent)
+
+	// The edit lands in the buffer, not on the disk.
+	require.NoError(t, writeWorkspaceEdit(ctx, edit))
+	require.Equal(t, "package main\n\nfunc main() {\n\tstart()\n}\n", editor[path])
+	require.Equal(t, "package main\n\nfunc main() {}\n", readTestFile(t, path))
+
+	undoWorkspaceEdit(ctx, changes)
+	require.Equal(t, "package main\n\nfunc main() {\n\trun()\n}\n", editor[path])
+}
@@ -78,7 +78,7 @@ func NewWriteTool(lspClients *csync.Map[string, *lsp.Client], permissions permis
 						filePath, modTime.Format(time.RFC3339), lastRead.Format(time.RFC3339))), nil
 				}
 
-				oldContent, readErr := os.ReadFile(filePath)
+				oldContent, readErr := readFile(ctx, filePath)
 				if readErr == nil && string(oldContent) == params.Content {
 					return fantasy.NewTextErrorResponse(fmt.Sprintf("File %s already contains the exact content. No changes made.", filePath)), nil
 				}
@@ -93,7 +93,7 @@ func NewWriteTool(lspClients *csync.Map[string, *lsp.Client], permissions permis
 
 			oldContent := ""
 			if fileInfo != nil && !fileInfo.IsDir() {
-				oldBytes, readErr := os.ReadFile(filePath)
+				oldBytes, readErr := readFile(ctx, filePath)
 				if readErr == nil {
 					oldContent = string(oldBytes)
 				}
@@ -110,26 +110,28 @@ func NewWriteTool(lspClients *csync.Map[string, *lsp.Client], permissions permis
 				strings.TrimPrefix(filePath, workingDir),
 			)
 
-			p := permissions.Request(
-				permission.CreatePermissionRequest{
-					SessionID:   sessionID,
-					Path:        fsext.PathOrPrefix(filePath, workingDir),
-					ToolCallID:  call.ID,
-					ToolName:    WriteToolName,
-					Action:      "write",
-					Description: fmt.Sprintf("Create file %s", filePath),
-					Params: WritePermissionsParams{
-						FilePath:   filePath,
-						OldContent: oldContent,
-						NewContent: params.Content,
-					},
+			permissionReq := permission.CreatePermissionRequest{
+				SessionID:   sessionID,
+				Path:        fsext.PathOrPrefix(filePath, workingDir),
+				ToolCallID:  call.ID,
+				ToolName:    WriteToolName,
+				Action:      "write",
+				Description: fmt.Sprintf("Create file %s", filePath),
+				Params: WritePermissionsParams{
+					FilePath:   filePath,
+					OldContent: oldContent,
+					NewContent: params.Content,
 				},
-			)
-			if !p {
+				Targets: []string{filePath},
+			}
+			if rule, denied := permissions.Denied(permissionReq); denied {
+				return NewRuleDeniedResponse(rule), nil
+			}
+			if !permissions.Request(permissionReq) {
 				return fantasy.ToolResponse{}, permission.ErrorPermissionDenied
 			}
 
-			err = os.WriteFile(filePath, []byte(params.Content), 0o644)
+			err = writeFile(ctx, filePath, []byte(params.Content))
 			if err != nil {
 				return fantasy.ToolResponse{}, fmt.Errorf("error writing file: %w", err)
 			}
@@ -159,10 +161,12 @@ func NewWriteTool(lspClients *csync.Map[string, *lsp.Client], permissions permis
 			recordFileWrite(filePath)
 			recordFileRead(filePath)
 
+			formatting := formatOnWrite(ctx, lspClients, files, workingDir, filePath)
 			notifyLSPs(ctx, lspClients, params.FilePath)
 
 			result := fmt.Sprintf("File successfully written: %s", filePath)
 			result = fmt.Sprintf("<result>\n%s\n</result>", result)
+			result += formatting
 			result += getDiagnostics(filePath, lspClients)
 			return fantasy.WithResponseMetadata(fantasy.NewTextResponse(result),
 				WriteResponseMetadata{
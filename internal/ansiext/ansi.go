@@ -23,3 +23,23 @@ func Escape(content string) string {
 	}
 	return sb.String()
 }
+
+// Strip removes the escape sequences and control characters from output
+// captured from a terminal. Of the text a line is rewritten with through
+// carriage returns, like progress bars do, only the last one is kept.
+func Strip(content string) string {
+	lines := strings.Split(ansi.Strip(content), "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		if j := strings.LastIndexByte(line, '\r'); j >= 0 {
+			line = line[j+1:]
+		}
+		lines[i] = strings.Map(func(r rune) rune {
+			if (r >= 0 && r <= 0x1f && r != '\t') || r == ansi.DEL {
+				return -1
+			}
+			return r
+		}, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,30 @@
+package ansiext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"plain", "hello\nworld", "hello\nworld"},
+		{"colors", "\x1b[31mred\x1b[0m text", "red text"},
+		{"crlf", "one\r\ntwo\r\n", "one\ntwo\n"},
+		{"carriage return", "10%\r50%\r100%\ndone", "100%\ndone"},
+		{"cursor", "\x1b[?25l\x1b[2Kname: \x1b[1Gname: crush\x1b[?25h", "name: name: crush"},
+		{"controls", "a\x07b\x08c\td", "abc\td"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, Strip(tt.content))
+		})
+	}
+}
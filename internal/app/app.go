@@ -5,7 +5,6 @@ package app
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -14,15 +13,17 @@ import (
 	"time"
 
 	tea "charm.land/bubbletea/v2"
-	"charm.land/fantasy"
 	"charm.land/lipgloss/v2"
 	"github.com/charmbracelet/crush/internal/agent"
+	"github.com/charmbracelet/crush/internal/agent/tools"
 	"github.com/charmbracelet/crush/internal/agent/tools/mcp"
 	"github.com/charmbracelet/crush/internal/config"
 	"github.com/charmbracelet/crush/internal/csync"
 	"github.com/charmbracelet/crush/internal/db"
 	"github.com/charmbracelet/crush/internal/format"
 	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/home"
+	"github.com/charmbracelet/crush/internal/job"
 	"github.com/charmbracelet/crush/internal/log"
 	"github.com/charmbracelet/crush/internal/lsp"
 	"github.com/charmbracelet/crush/internal/message"
@@ -31,6 +32,7 @@ import (
 	"github.com/charmbracelet/crush/internal/session"
 	"github.com/charmbracelet/crush/internal/shell"
 	"github.com/charmbracelet/crush/internal/term"
+	"github.com/charmbracelet/crush/internal/todo"
 	"github.com/charmbracelet/crush/internal/tui/components/anim"
 	"github.com/charmbracelet/crush/internal/tui/styles"
 	"github.com/charmbracelet/crush/internal/update"
@@ -43,6 +45,8 @@ type App struct {
 	Sessions    session.Service
 	Messages    message.Service
 	History     history.Service
+	Todos       todo.Service
+	Jobs        job.Service
 	Permissions permission.Service
 
 	AgentCoordinator agent.Coordinator
@@ -64,20 +68,32 @@ type App struct {
 // New initializes a new applcation instance.
 func New(ctx context.Context, conn *sql.DB, cfg *config.Config) (*App, error) {
 	q := db.New(conn)
-	sessions := session.NewService(q)
+	sessions := session.NewService(q, conn)
 	messages := message.NewService(q)
 	files := history.NewService(q, conn)
+	todos := todo.NewService(q, conn)
+	jobs := job.NewService(q, conn)
 	skipPermissionsRequests := cfg.Permissions != nil && cfg.Permissions.SkipRequests
 	allowedTools := []string{}
-	if cfg.Permissions != nil && cfg.Permissions.AllowedTools != nil {
-		allowedTools = cfg.Permissions.AllowedTools
+	var permissionRules permission.Rules
+	if cfg.Permissions != nil {
+		if cfg.Permissions.AllowedTools != nil {
+			allowedTools = cfg.Permissions.AllowedTools
+		}
+		permissionRules = permission.Rules{
+			Allow: cfg.Permissions.Allow,
+			Ask:   cfg.Permissions.Ask,
+			Deny:  cfg.Permissions.Deny,
+		}
 	}
 
 	app := &App{
 		Sessions:    sessions,
 		Messages:    messages,
 		History:     files,
-		Permissions: permission.NewPermissionService(cfg.WorkingDir(), skipPermissionsRequests, allowedTools),
+		Todos:       todos,
+		Jobs:        jobs,
+		Permissions: permission.NewPermissionService(cfg.WorkingDir(), skipPermissionsRequests, allowedTools, permissionRules),
 		LSPClients:  csync.NewMap[string, *lsp.Client](),
 
 		globalCtx: ctx,
@@ -89,6 +105,8 @@ func New(ctx context.Context, conn *sql.DB, cfg *config.Config) (*App, error) {
 		tuiWG:           &sync.WaitGroup{},
 	}
 
+	app.setupSandbox()
+	app.setupJobs(ctx)
 	app.setupEvents()
 
 	// Initialize LSP clients in the background.
@@ -122,15 +140,16 @@ func (app *App) Config() *config.Config {
 }
 
 // RunNonInteractive runs the application in non-interactive mode with the
-// given prompt, printing to stdout.
-func (app *App) RunNonInteractive(ctx context.Context, output io.Writer, prompt string, quiet bool) error {
+// given prompt, printing to output in the given format. When the run fails,
+// the returned error is a *RunError.
+func (app *App) RunNonInteractive(ctx context.Context, output io.Writer, prompt string, outputFormat OutputFormat, quiet bool) error {
 	slog.Info("Running in non-interactive mode")
 
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	var spinner *format.Spinner
-	if !quiet {
+	if !quiet && outputFormat == OutputFormatText {
 		t := styles.CurrentTheme()
 
 		// Detect background color to set the appropriate color for the
@@ -155,7 +174,7 @@ func (app *App) RunNonInteractive(ctx context.Context, output io.Writer, prompt
 
 	// Helper function to stop spinner once.
 	stopSpinner := func() {
-		if !quiet && spinner != nil {
+		if spinner != nil {
 			spinner.Stop()
 			spinner = nil
 		}
@@ -175,7 +194,7 @@ func (app *App) RunNonInteractive(ctx context.Context, output io.Writer, prompt
 
 	sess, err := app.Sessions.Create(ctx, title)
 	if err != nil {
-		return fmt.Errorf("failed to create session for non-interactive mode: %w", err)
+		return newRunError(fmt.Errorf("failed to create session for non-interactive mode: %w", err))
 	}
 	slog.Info("Created session for non-interactive run", "session_id", sess.ID)
 
@@ -183,38 +202,50 @@ func (app *App) RunNonInteractive(ctx context.Context, output io.Writer, prompt
 	// session.
 	app.Permissions.AutoApproveSession(sess.ID)
 
-	type response struct {
-		result *fantasy.AgentResult
-		err    error
-	}
-	done := make(chan response, 1)
+	out := newRunOutput(output, outputFormat, sess.ID)
+	out.start(app.AgentCoordinator.Model())
+
+	// Subscribe before starting the agent so that no update is missed.
+	messageEvents := app.Messages.Subscribe(ctx)
+	permissionEvents := app.Permissions.SubscribeNotifications(ctx)
 
+	done := make(chan error, 1)
 	go func(ctx context.Context, sessionID, prompt string) {
-		result, err := app.AgentCoordinator.Run(ctx, sess.ID, prompt)
-		if err != nil {
-			done <- response{
-				err: fmt.Errorf("failed to start agent processing stream: %w", err),
-			}
-		}
-		done <- response{
-			result: result,
-		}
+		_, err := app.AgentCoordinator.Run(ctx, sessionID, prompt)
+		done <- err
 	}(ctx, sess.ID, prompt)
 
-	messageEvents := app.Messages.Subscribe(ctx)
-	messageReadBytes := make(map[string]int)
 	supportsProgressBar := term.SupportsProgressBar()
-
 	defer func() {
 		if supportsProgressBar {
 			_, _ = fmt.Fprintf(os.Stderr, ansi.ResetProgressBar)
 		}
-
-		// Always print a newline at the end. If output is a TTY this will
-		// prevent the prompt from overwriting the last line of output.
-		_, _ = fmt.Fprintln(output)
 	}()
 
+	// finish outputs what is left of the session and the result of the run.
+	finish := func(err error) error {
+		stopSpinner()
+		var runErr *RunError
+		if err != nil {
+			runErr = newRunError(err)
+		}
+		// Use a fresh context, the run context may be cancelled by now.
+		finishCtx := context.WithoutCancel(ctx)
+		if msgs, listErr := app.Messages.List(finishCtx, sess.ID); listErr == nil {
+			for _, msg := range msgs {
+				_ = out.message(msg)
+			}
+		}
+		if updated, getErr := app.Sessions.Get(finishCtx, sess.ID); getErr == nil {
+			sess = updated
+		}
+		out.finish(sess, runErr)
+		if runErr != nil {
+			return runErr
+		}
+		return nil
+	}
+
 	for {
 		if supportsProgressBar {
 			// HACK: Reinitialize the terminal progress bar on every iteration so
@@ -223,38 +254,27 @@ func (app *App) RunNonInteractive(ctx context.Context, output io.Writer, prompt
 		}
 
 		select {
-		case result := <-done:
-			stopSpinner()
-			if result.err != nil {
-				if errors.Is(result.err, context.Canceled) || errors.Is(result.err, agent.ErrRequestCancelled) {
-					slog.Info("Non-interactive: agent processing cancelled", "session_id", sess.ID)
-					return nil
-				}
-				return fmt.Errorf("agent processing failed: %w", result.err)
+		case err := <-done:
+			if err != nil {
+				slog.Info("Non-interactive: agent processing failed", "session_id", sess.ID, "error", err)
 			}
-			return nil
+			return finish(err)
 
 		case event := <-messageEvents:
 			msg := event.Payload
 			if msg.SessionID == sess.ID && msg.Role == message.Assistant && len(msg.Parts) > 0 {
 				stopSpinner()
-
-				content := msg.Content().String()
-				readBytes := messageReadBytes[msg.ID]
-
-				if len(content) < readBytes {
-					slog.Error("Non-interactive: message content is shorter than read bytes", "message_length", len(content), "read_bytes", readBytes)
-					return fmt.Errorf("message content is shorter than read bytes: %d < %d", len(content), readBytes)
-				}
-
-				part := content[readBytes:]
-				fmt.Fprint(output, part)
-				messageReadBytes[msg.ID] = len(content)
 			}
+			if err := out.message(msg); err != nil {
+				slog.Error("Non-interactive: failed to output message", "error", err)
+				return newRunError(err)
+			}
+
+		case event := <-permissionEvents:
+			out.permission(event.Payload)
 
 		case <-ctx.Done():
-			stopSpinner()
-			return ctx.Err()
+			return finish(ctx.Err())
 		}
 	}
 }
@@ -271,8 +291,11 @@ func (app *App) setupEvents() {
 	setupSubscriber(ctx, app.serviceEventsWG, "permissions", app.Permissions.Subscribe, app.events)
 	setupSubscriber(ctx, app.serviceEventsWG, "permissions-notifications", app.Permissions.SubscribeNotifications, app.events)
 	setupSubscriber(ctx, app.serviceEventsWG, "history", app.History.Subscribe, app.events)
+	setupSubscriber(ctx, app.serviceEventsWG, "todos", app.Todos.Subscribe, app.events)
+	setupSubscriber(ctx, app.serviceEventsWG, "jobs", app.Jobs.Subscribe, app.events)
 	setupSubscriber(ctx, app.serviceEventsWG, "mcp", mcp.SubscribeEvents, app.events)
 	setupSubscriber(ctx, app.serviceEventsWG, "lsp", SubscribeLSPEvents, app.events)
+	app.serviceEventsWG.Go(func() { app.removeArtifactsOfDeletedSessions(ctx) })
 	cleanupFunc := func() error {
 		cancel()
 		app.serviceEventsWG.Wait()
@@ -281,6 +304,22 @@ func (app *App) setupEvents() {
 	app.cleanupFuncs = append(app.cleanupFuncs, cleanupFunc)
 }
 
+// removeArtifactsOfDeletedSessions removes the tool output artifacts of the
+// sessions as they get deleted.
+func (app *App) removeArtifactsOfDeletedSessions(ctx context.Context) {
+	for event := range app.Sessions.Subscribe(ctx) {
+		if event.Type != pubsub.DeletedEvent {
+			continue
+		}
+		if err := tools.RemoveArtifacts(app.config.Options.DataDirectory, event.Payload.ID); err != nil {
+			slog.Error("Failed to remove session artifacts", "session", event.Payload.ID, "error", err)
+		}
+		if err := app.removeJobLogs(event.Payload.ID); err != nil {
+			slog.Error("Failed to remove session job logs", "session", event.Payload.ID, "error", err)
+		}
+	}
+}
+
 func setupSubscriber[T any](
 	ctx context.Context,
 	wg *sync.WaitGroup,
@@ -327,6 +366,8 @@ func (app *App) InitCoderAgent(ctx context.Context) error {
 		app.Messages,
 		app.Permissions,
 		app.History,
+		app.Todos,
+		app.Jobs,
 		app.LSPClients,
 	)
 	if err != nil {
@@ -368,6 +409,25 @@ func (app *App) Subscribe(program *tea.Program) {
 	}
 }
 
+// setupSandbox makes the bash commands run in the sandbox when it is
+// enabled. Where it isn't available they run without it after a warning.
+func (app *App) setupSandbox() {
+	cfg := app.config.Options.Sandbox
+	if cfg == nil || !cfg.Enabled {
+		shell.GetBackgroundShellManager().SetSandbox(nil)
+		return
+	}
+	writable := make([]string, 0, len(cfg.WritablePaths))
+	for _, path := range cfg.WritablePaths {
+		writable = append(writable, home.Long(path))
+	}
+	shell.GetBackgroundShellManager().SetSandbox(&shell.Sandbox{
+		WorkingDir:     app.config.WorkingDir(),
+		WritablePaths:  writable,
+		IsolateNetwork: cfg.IsolateNetwork,
+	})
+}
+
 // Shutdown performs a graceful shutdown of the application.
 func (app *App) Shutdown() {
 	if app.AgentCoordinator != nil {
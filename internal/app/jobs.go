@@ -0,0 +1,91 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/charmbracelet/crush/internal/job"
+	"github.com/charmbracelet/crush/internal/shell"
+)
+
+// jobsDir returns the directory the output of the background shells is
+// logged to, in a subdirectory per session.
+func jobsDir(dataDir string) string {
+	return filepath.Join(dataDir, "jobs")
+}
+
+// setupJobs makes the background shells log their output in the data
+// directory and records them as jobs of their session. The jobs a previous
+// run left running, as it crashed or was killed, are recorded as killed since
+// their shells are gone with it.
+func (app *App) setupJobs(ctx context.Context) {
+	if killed, err := app.Jobs.KillRunning(ctx, time.Now()); err != nil {
+		slog.Error("Failed to record the jobs left running as killed", "error", err)
+	} else if killed > 0 {
+		slog.Info("Recorded the jobs left running as killed", "count", killed)
+	}
+
+	manager := shell.GetBackgroundShellManager()
+	manager.SetLogDir(jobsDir(app.config.Options.DataDirectory))
+	manager.SetRecorder(jobRecorder{jobs: app.Jobs})
+}
+
+// removeJobLogs removes the output logs of the jobs of a session.
+func (app *App) removeJobLogs(sessionID string) error {
+	if sessionID == "" {
+		return nil
+	}
+	return os.RemoveAll(filepath.Join(jobsDir(app.config.Options.DataDirectory), sessionID))
+}
+
+// jobRecorder records the background shells in the job service.
+type jobRecorder struct {
+	jobs job.Service
+}
+
+// The shells are recorded after the agent moved them to the background or
+// while shutting down, when the context of the caller may be gone.
+const jobRecordTimeout = 5 * time.Second
+
+func (r jobRecorder) JobStarted(info shell.BackgroundShellInfo) {
+	if info.SessionID == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), jobRecordTimeout)
+	defer cancel()
+	_, err := r.jobs.Create(ctx, job.Job{
+		ID:          info.JobID,
+		SessionID:   info.SessionID,
+		ShellID:     info.ID,
+		Command:     info.Command,
+		Description: info.Description,
+		WorkingDir:  info.WorkingDir,
+		StdoutPath:  info.StdoutPath,
+		StderrPath:  info.StderrPath,
+		StartedAt:   info.StartedAt.UnixMilli(),
+	})
+	if err != nil {
+		slog.Error("Failed to record job", "shell", info.ID, "error", err)
+	}
+}
+
+func (r jobRecorder) JobFinished(info shell.BackgroundShellInfo) {
+	if info.SessionID == "" {
+		return
+	}
+	status := job.StatusCompleted
+	switch {
+	case info.Killed:
+		status = job.StatusKilled
+	case info.ExitCode != 0:
+		status = job.StatusFailed
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), jobRecordTimeout)
+	defer cancel()
+	if _, err := r.jobs.Finish(ctx, info.JobID, status, info.ExitCode, info.FinishedAt); err != nil {
+		slog.Error("Failed to record the end of job", "shell", info.ID, "error", err)
+	}
+}
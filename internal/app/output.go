@@ -0,0 +1,308 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/agent"
+	"github.com/charmbracelet/crush/internal/message"
+	"github.com/charmbracelet/crush/internal/permission"
+	"github.com/charmbracelet/crush/internal/session"
+)
+
+// OutputFormat is the format of the output of non-interactive runs.
+type OutputFormat string
+
+const (
+	// OutputFormatText prints the text of the assistant as it's generated.
+	OutputFormatText OutputFormat = "text"
+	// OutputFormatJSON prints a single JSON object once the run is over.
+	OutputFormatJSON OutputFormat = "json"
+	// OutputFormatStreamJSON prints one JSON event per line as the run
+	// progresses.
+	OutputFormatStreamJSON OutputFormat = "stream-json"
+)
+
+// OutputFormats lists the supported output formats.
+var OutputFormats = []OutputFormat{OutputFormatText, OutputFormatJSON, OutputFormatStreamJSON}
+
+// ParseOutputFormat validates the name of an output format.
+func ParseOutputFormat(s string) (OutputFormat, error) {
+	if s == "" {
+		return OutputFormatText, nil
+	}
+	if f := OutputFormat(s); slices.Contains(OutputFormats, f) {
+		return f, nil
+	}
+	return "", fmt.Errorf("invalid output format %q, must be one of: text, json, stream-json", s)
+}
+
+// Exit codes of non-interactive runs.
+const (
+	ExitCodeError            = 1
+	ExitCodeProviderError    = 2
+	ExitCodePermissionDenied = 3
+	ExitCodeLoopDetected     = 4
+	ExitCodeLimitReached     = 5
+	ExitCodeCanceled         = 130
+)
+
+// RunError is returned when a non-interactive run fails. The exit code tells
+// apart provider errors, permission denials, loops, limits and cancellations.
+type RunError struct {
+	ExitCode int
+	Err      error
+}
+
+func (e *RunError) Error() string { return e.Err.Error() }
+func (e *RunError) Unwrap() error { return e.Err }
+
+// newRunError wraps err with the exit code matching its cause.
+func newRunError(err error) *RunError {
+	code := ExitCodeError
+	var providerErr *fantasy.ProviderError
+	var fantasyErr *fantasy.Error
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, agent.ErrRequestCancelled):
+		code = ExitCodeCanceled
+	case errors.Is(err, permission.ErrorPermissionDenied):
+		code = ExitCodePermissionDenied
+	case errors.Is(err, agent.ErrLoopDetected):
+		code = ExitCodeLoopDetected
+	case agent.IsStoppedErr(err):
+		code = ExitCodeLimitReached
+	case errors.As(err, &providerErr), errors.As(err, &fantasyErr):
+		code = ExitCodeProviderError
+	}
+	return &RunError{ExitCode: code, Err: err}
+}
+
+// RunEvent is a line of the stream-json output.
+type RunEvent struct {
+	Type         string         `json:"type"`
+	SessionID    string         `json:"session_id,omitempty"`
+	MessageID    string         `json:"message_id,omitempty"`
+	Model        string         `json:"model,omitempty"`
+	Provider     string         `json:"provider,omitempty"`
+	Text         string         `json:"text,omitempty"`
+	ToolCall     *RunToolCall   `json:"tool_call,omitempty"`
+	ToolResult   *RunToolResult `json:"tool_result,omitempty"`
+	Permission   *RunPermission `json:"permission,omitempty"`
+	FinishReason string         `json:"finish_reason,omitempty"`
+	Usage        *RunUsage      `json:"usage,omitempty"`
+	Error        string         `json:"error,omitempty"`
+	Result       *RunResult     `json:"result,omitempty"`
+}
+
+// RunToolCall is a tool call made by the agent.
+type RunToolCall struct {
+	ID    string          `json:"id"`
+	Name  string          `json:"name"`
+	Input json.RawMessage `json:"input"`
+}
+
+// RunToolResult is the result of a tool call.
+type RunToolResult struct {
+	ToolCallID string `json:"tool_call_id"`
+	Name       string `json:"name"`
+	Content    string `json:"content"`
+	IsError    bool   `json:"is_error"`
+}
+
+// RunPermission is the outcome of a permission request.
+type RunPermission struct {
+	ToolCallID string `json:"tool_call_id"`
+	Granted    bool   `json:"granted"`
+}
+
+// RunUsage is the token usage and cost of a run.
+type RunUsage struct {
+	PromptTokens        int64   `json:"prompt_tokens"`
+	CompletionTokens    int64   `json:"completion_tokens"`
+	CacheReadTokens     int64   `json:"cache_read_tokens"`
+	CacheCreationTokens int64   `json:"cache_creation_tokens"`
+	Cost                float64 `json:"cost"`
+}
+
+// RunResult is the output of the json format, and the last event of the
+// stream-json format.
+type RunResult struct {
+	SessionID    string          `json:"session_id"`
+	Result       string          `json:"result"`
+	FinishReason string          `json:"finish_reason,omitempty"`
+	ToolCalls    []RunToolCall   `json:"tool_calls,omitempty"`
+	ToolResults  []RunToolResult `json:"tool_results,omitempty"`
+	Usage        RunUsage        `json:"usage"`
+	IsError      bool            `json:"is_error"`
+	Error        string          `json:"error,omitempty"`
+	ExitCode     int             `json:"exit_code"`
+}
+
+// runOutput turns the message updates of a session into the output of a
+// non-interactive run.
+type runOutput struct {
+	w      io.Writer
+	format OutputFormat
+
+	sessionID    string
+	textRead     map[string]int
+	thinkingRead map[string]int
+	seenCalls    map[string]bool
+	seenResults  map[string]bool
+	finished     map[string]bool
+
+	result RunResult
+}
+
+func newRunOutput(w io.Writer, format OutputFormat, sessionID string) *runOutput {
+	return &runOutput{
+		w:            w,
+		format:       format,
+		sessionID:    sessionID,
+		textRead:     make(map[string]int),
+		thinkingRead: make(map[string]int),
+		seenCalls:    make(map[string]bool),
+		seenResults:  make(map[string]bool),
+		finished:     make(map[string]bool),
+		result:       RunResult{SessionID: sessionID},
+	}
+}
+
+func (o *runOutput) emit(event RunEvent) {
+	if o.format != OutputFormatStreamJSON {
+		return
+	}
+	event.SessionID = o.sessionID
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	_, _ = fmt.Fprintln(o.w, string(data))
+}
+
+func (o *runOutput) start(model agent.Model) {
+	o.emit(RunEvent{
+		Type:     "session_start",
+		Model:    model.ModelCfg.Model,
+		Provider: model.ModelCfg.Provider,
+	})
+}
+
+// message handles the latest state of a message of the session. It can be
+// called several times with the same message, only what changed since the
+// previous call is output.
+func (o *runOutput) message(msg message.Message) error {
+	if msg.SessionID != o.sessionID {
+		return nil
+	}
+	switch msg.Role {
+	case message.Assistant:
+		if thinking := msg.ReasoningContent().Thinking; len(thinking) > o.thinkingRead[msg.ID] {
+			o.emit(RunEvent{Type: "reasoning_delta", MessageID: msg.ID, Text: thinking[o.thinkingRead[msg.ID]:]})
+			o.thinkingRead[msg.ID] = len(thinking)
+		}
+
+		content := msg.Content().String()
+		read := o.textRead[msg.ID]
+		if len(content) < read {
+			return fmt.Errorf("message content is shorter than read bytes: %d < %d", len(content), read)
+		}
+		if delta := content[read:]; delta != "" {
+			if o.format == OutputFormatText {
+				_, _ = fmt.Fprint(o.w, delta)
+			}
+			o.emit(RunEvent{Type: "text_delta", MessageID: msg.ID, Text: delta})
+			o.textRead[msg.ID] = len(content)
+		}
+		if content != "" {
+			o.result.Result = content
+		}
+
+		for _, tc := range msg.ToolCalls() {
+			if !tc.Finished || o.seenCalls[tc.ID] {
+				continue
+			}
+			o.seenCalls[tc.ID] = true
+			input := json.RawMessage(tc.Input)
+			if !json.Valid(input) {
+				input, _ = json.Marshal(tc.Input)
+			}
+			call := RunToolCall{ID: tc.ID, Name: tc.Name, Input: input}
+			o.result.ToolCalls = append(o.result.ToolCalls, call)
+			o.emit(RunEvent{Type: "tool_call", MessageID: msg.ID, ToolCall: &call})
+		}
+
+		if finish := msg.FinishPart(); finish != nil && !o.finished[msg.ID] {
+			o.finished[msg.ID] = true
+			o.result.FinishReason = string(finish.Reason)
+			event := RunEvent{Type: "finish", MessageID: msg.ID, FinishReason: string(finish.Reason)}
+			if finish.Reason == message.FinishReasonError || finish.Reason.Stopped() {
+				event.Error = strings.TrimSpace(finish.Message + ": " + finish.Details)
+			}
+			o.emit(event)
+		}
+	case message.Tool:
+		for _, tr := range msg.ToolResults() {
+			if o.seenResults[tr.ToolCallID] {
+				continue
+			}
+			o.seenResults[tr.ToolCallID] = true
+			result := RunToolResult{
+				ToolCallID: tr.ToolCallID,
+				Name:       tr.Name,
+				Content:    tr.Content,
+				IsError:    tr.IsError,
+			}
+			o.result.ToolResults = append(o.result.ToolResults, result)
+			o.emit(RunEvent{Type: "tool_result", MessageID: msg.ID, ToolResult: &result})
+		}
+	}
+	return nil
+}
+
+// permission handles the outcome of a permission request made by one of the
+// tool calls of the session.
+func (o *runOutput) permission(n permission.PermissionNotification) {
+	if !n.Granted && !n.Denied || !o.seenCalls[n.ToolCallID] {
+		return
+	}
+	o.emit(RunEvent{Type: "permission", Permission: &RunPermission{ToolCallID: n.ToolCallID, Granted: n.Granted}})
+}
+
+// finish outputs the usage of the session and the result of the run.
+func (o *runOutput) finish(sess session.Session, runErr *RunError) {
+	o.result.Usage = RunUsage{
+		PromptTokens:        sess.PromptTokens,
+		CompletionTokens:    sess.CompletionTokens,
+		CacheReadTokens:     sess.CacheReadTokens,
+		CacheCreationTokens: sess.CacheCreationTokens,
+		Cost:                sess.Cost,
+	}
+	if runErr != nil {
+		o.result.IsError = true
+		o.result.Error = runErr.Error()
+		o.result.ExitCode = runErr.ExitCode
+	}
+
+	switch o.format {
+	case OutputFormatText:
+		// Always print a newline at the end. If output is a TTY this will
+		// prevent the prompt from overwriting the last line of output.
+		_, _ = fmt.Fprintln(o.w)
+	case OutputFormatStreamJSON:
+		o.emit(RunEvent{Type: "usage", Usage: &o.result.Usage})
+		o.emit(RunEvent{Type: "result", Result: &o.result})
+	case OutputFormatJSON:
+		data, err := json.MarshalIndent(o.result, "", "  ")
+		if err != nil {
+			return
+		}
+		_, _ = fmt.Fprintln(o.w, string(data))
+	}
+}
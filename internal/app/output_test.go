@@ -0,0 +1,130 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/agent"
+	"github.com/charmbracelet/crush/internal/message"
+	"github.com/charmbracelet/crush/internal/permission"
+	"github.com/charmbracelet/crush/internal/session"
+	"github.com/stretchr/testify/require"
+)
+
+func feedRun(t *testing.T, out *runOutput) {
+	t.Helper()
+	assistant := message.Message{ID: "m1", SessionID: "s1", Role: message.Assistant}
+	updates := [][]message.ContentPart{
+		{message.ReasoningContent{Thinking: "let me"}},
+		{message.ReasoningContent{Thinking: "let me look"}, message.TextContent{Text: "Listing"}},
+		{message.ReasoningContent{Thinking: "let me look"}, message.TextContent{Text: "Listing files"}, message.ToolCall{ID: "c1", Name: "ls", Input: `{"path":"."}`, Finished: true}},
+		{message.ReasoningContent{Thinking: "let me look"}, message.TextContent{Text: "Listing files"}, message.ToolCall{ID: "c1", Name: "ls", Input: `{"path":"."}`, Finished: true}, message.Finish{Reason: message.FinishReasonToolUse}},
+	}
+	for _, parts := range updates {
+		assistant.Parts = parts
+		require.NoError(t, out.message(assistant))
+	}
+	out.permission(permission.PermissionNotification{ToolCallID: "c1", Granted: true})
+	tool := message.Message{ID: "m2", SessionID: "s1", Role: message.Tool, Parts: []message.ContentPart{
+		message.ToolResult{ToolCallID: "c1", Name: "ls", Content: "main.go"},
+	}}
+	require.NoError(t, out.message(tool))
+	require.NoError(t, out.message(tool))
+	// Messages of other sessions, like the ones of sub-agents, are ignored.
+	require.NoError(t, out.message(message.Message{ID: "m3", SessionID: "s2", Role: message.Assistant, Parts: []message.ContentPart{message.TextContent{Text: "nested"}}}))
+	final := message.Message{ID: "m4", SessionID: "s1", Role: message.Assistant, Parts: []message.ContentPart{
+		message.TextContent{Text: "Done"},
+		message.Finish{Reason: message.FinishReasonEndTurn},
+	}}
+	require.NoError(t, out.message(final))
+	require.NoError(t, out.message(final))
+}
+
+func TestRunOutput(t *testing.T) {
+	t.Parallel()
+
+	sess := session.Session{ID: "s1", PromptTokens: 10, CompletionTokens: 5, Cost: 0.5}
+
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		out := newRunOutput(&buf, OutputFormatText, "s1")
+		feedRun(t, out)
+		out.finish(sess, nil)
+		require.Equal(t, "Listing filesDone\n", buf.String())
+	})
+
+	t.Run("stream-json", func(t *testing.T) {
+		var buf bytes.Buffer
+		out := newRunOutput(&buf, OutputFormatStreamJSON, "s1")
+		feedRun(t, out)
+		out.finish(sess, nil)
+
+		var types []string
+		var events []RunEvent
+		for line := range strings.Lines(buf.String()) {
+			var event RunEvent
+			require.NoError(t, json.Unmarshal([]byte(line), &event))
+			require.Equal(t, "s1", event.SessionID)
+			types = append(types, event.Type)
+			events = append(events, event)
+		}
+		require.Equal(t, []string{
+			"reasoning_delta", "reasoning_delta", "text_delta", "text_delta", "tool_call", "finish",
+			"permission", "tool_result", "text_delta", "finish", "usage", "result",
+		}, types)
+		require.Equal(t, " look", events[1].Text)
+		require.Equal(t, " files", events[3].Text)
+		require.JSONEq(t, `{"path":"."}`, string(events[4].ToolCall.Input))
+		require.Equal(t, "main.go", events[7].ToolResult.Content)
+		require.Equal(t, "end_turn", events[9].FinishReason)
+		require.Equal(t, 0.5, events[10].Usage.Cost)
+		require.Equal(t, "Done", events[11].Result.Result)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		out := newRunOutput(&buf, OutputFormatJSON, "s1")
+		feedRun(t, out)
+		out.finish(sess, newRunError(fmt.Errorf("agent failed: %w", permission.ErrorPermissionDenied)))
+
+		var result RunResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		require.Equal(t, "Done", result.Result)
+		require.Equal(t, "end_turn", result.FinishReason)
+		require.Len(t, result.ToolCalls, 1)
+		require.Len(t, result.ToolResults, 1)
+		require.Equal(t, RunUsage{PromptTokens: 10, CompletionTokens: 5, Cost: 0.5}, result.Usage)
+		require.True(t, result.IsError)
+		require.Equal(t, ExitCodePermissionDenied, result.ExitCode)
+	})
+}
+
+func TestRunErrorExitCode(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, ExitCodeCanceled, newRunError(context.Canceled).ExitCode)
+	require.Equal(t, ExitCodePermissionDenied, newRunError(permission.ErrorPermissionDenied).ExitCode)
+	require.Equal(t, ExitCodeProviderError, newRunError(fmt.Errorf("stream: %w", &fantasy.ProviderError{Message: "overloaded"})).ExitCode)
+	require.Equal(t, ExitCodeLoopDetected, newRunError(fmt.Errorf("%w: the agent called view 7 times", agent.ErrLoopDetected)).ExitCode)
+	require.Equal(t, ExitCodeLimitReached, newRunError(fmt.Errorf("%w: the agent took 50 steps", agent.ErrMaxStepsReached)).ExitCode)
+	require.Equal(t, ExitCodeError, newRunError(errors.New("boom")).ExitCode)
+}
+
+func TestParseOutputFormat(t *testing.T) {
+	t.Parallel()
+
+	f, err := ParseOutputFormat("")
+	require.NoError(t, err)
+	require.Equal(t, OutputFormatText, f)
+	f, err = ParseOutputFormat("stream-json")
+	require.NoError(t, err)
+	require.Equal(t, OutputFormatStreamJSON, f)
+	_, err = ParseOutputFormat("yaml")
+	require.Error(t, err)
+}
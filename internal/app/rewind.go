@@ -0,0 +1,152 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/message"
+)
+
+// ErrRewindMessageNotFound is returned when the message to rewind to is not
+// part of the session.
+var ErrRewindMessageNotFound = errors.New("message not found in session")
+
+// RewindSession deletes the given message and every message after it from
+// the session so that the conversation can be resumed from that point. When
+// revertFiles is set, the files changed after the message was sent are
+// restored to the content they had at that time.
+func (app *App) RewindSession(ctx context.Context, sessionID, messageID string, revertFiles bool) error {
+	if app.AgentCoordinator != nil && app.AgentCoordinator.IsSessionBusy(sessionID) {
+		return errors.New("session is busy")
+	}
+
+	msgs, err := app.Messages.List(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to list messages: %w", err)
+	}
+	cut := -1
+	for i, msg := range msgs {
+		if msg.ID == messageID {
+			cut = i
+			break
+		}
+	}
+	if cut < 0 {
+		return ErrRewindMessageNotFound
+	}
+
+	if revertFiles {
+		if err := app.revertFilesSince(ctx, sessionID, msgs[cut].CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	for _, msg := range msgs[cut:] {
+		if err := app.Messages.Delete(ctx, msg.ID); err != nil {
+			return fmt.Errorf("failed to delete message: %w", err)
+		}
+	}
+
+	sess, err := app.Sessions.Get(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	remaining := msgs[:cut]
+	summaryIdx := slices.IndexFunc(remaining, func(msg message.Message) bool {
+		return msg.ID == sess.SummaryMessageID
+	})
+	if summaryIdx < 0 {
+		// The summary was rewound, an older one takes over if there is one.
+		sess.SummaryMessageID = ""
+		sess.KeptFromMessageID = ""
+		for i := len(remaining) - 1; i >= 0; i-- {
+			msg := remaining[i]
+			if msg.IsSummaryMessage && msg.FinishReason() == message.FinishReasonEndTurn {
+				summaryIdx = i
+				sess.SummaryMessageID = msg.ID
+				sess.KeptFromMessageID = msg.KeptFromMessageID
+				break
+			}
+		}
+	}
+	if summaryIdx >= 0 {
+		keptIdx := slices.IndexFunc(remaining[:summaryIdx], func(msg message.Message) bool {
+			return msg.ID == sess.KeptFromMessageID
+		})
+		if keptIdx >= 0 {
+			remaining = remaining[keptIdx:]
+		} else {
+			remaining = remaining[summaryIdx:]
+		}
+	}
+
+	// The token counts of a session reflect the size of the context sent on
+	// the last request, which is gone now. Estimate the size of what is left
+	// until the next request reports the real usage. The cost is kept as is
+	// since it was already spent.
+	sess.PromptTokens = message.EstimateTokens(remaining...)
+	sess.CompletionTokens = 0
+	_, err = app.Sessions.Save(ctx, sess)
+	return err
+}
+
+// revertFilesSince restores the files changed in the session at or after the
+// given time and drops the history entries recorded for those changes.
+func (app *App) revertFilesSince(ctx context.Context, sessionID string, since int64) error {
+	files, err := app.History.ListBySession(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to list file history: %w", err)
+	}
+
+	before := make(map[string]history.File)
+	after := make(map[string][]history.File)
+	for _, file := range files {
+		if file.CreatedAt < since {
+			if prev, ok := before[file.Path]; !ok || file.Version > prev.Version {
+				before[file.Path] = file
+			}
+			continue
+		}
+		after[file.Path] = append(after[file.Path], file)
+	}
+
+	for path, changes := range after {
+		restore, ok := before[path]
+		if !ok {
+			// The file was first touched after the message, so its oldest
+			// entry holds the content it had before any change.
+			restore = changes[0]
+			for _, change := range changes[1:] {
+				if change.Version < restore.Version {
+					restore = change
+				}
+			}
+		}
+
+		target := path
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(app.config.WorkingDir(), target)
+		}
+		if !ok && restore.Version == history.InitialVersion && restore.Content == "" {
+			// The file did not exist before.
+			if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove %s: %w", path, err)
+			}
+		} else if err := os.WriteFile(target, []byte(restore.Content), 0o644); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", path, err)
+		}
+
+		for _, change := range changes {
+			if err := app.History.Delete(ctx, change.ID); err != nil {
+				slog.Error("Failed to delete file history entry", "path", path, "error", err)
+			}
+		}
+	}
+	return nil
+}
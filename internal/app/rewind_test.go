@@ -0,0 +1,166 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/crush/internal/db"
+	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/message"
+	"github.com/charmbracelet/crush/internal/session"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewindSession(t *testing.T) {
+	conn, err := db.Connect(t.Context(), t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	q := db.New(conn)
+	app := &App{
+		Sessions: session.NewService(q, conn),
+		Messages: message.NewService(q),
+		History:  history.NewService(q, conn),
+	}
+
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing.go")
+	created := filepath.Join(dir, "created.go")
+
+	setup := func(t *testing.T) (session.Session, message.Message) {
+		require.NoError(t, os.WriteFile(existing, []byte("original"), 0o644))
+		require.NoError(t, os.Remove(created))
+
+		sess, err := app.Sessions.Create(t.Context(), "rewind")
+		require.NoError(t, err)
+		_, err = app.Messages.Create(t.Context(), sess.ID, message.CreateMessageParams{
+			Role:  message.User,
+			Parts: []message.ContentPart{message.TextContent{Text: "keep me"}},
+		})
+		require.NoError(t, err)
+		_, err = app.History.Create(t.Context(), sess.ID, existing, "original")
+		require.NoError(t, err)
+
+		// Make sure the edited prompt is strictly newer than the history
+		// recorded before it, timestamps have a one second resolution.
+		for {
+			edited, err := app.Messages.Create(t.Context(), sess.ID, message.CreateMessageParams{
+				Role:  message.User,
+				Parts: []message.ContentPart{message.TextContent{Text: "edit me"}},
+			})
+			require.NoError(t, err)
+			files, err := app.History.ListBySession(t.Context(), sess.ID)
+			require.NoError(t, err)
+			if edited.CreatedAt > files[0].CreatedAt {
+				_, err = app.Messages.Create(t.Context(), sess.ID, message.CreateMessageParams{
+					Role:  message.Assistant,
+					Parts: []message.ContentPart{message.TextContent{Text: "reply"}},
+				})
+				require.NoError(t, err)
+
+				require.NoError(t, os.WriteFile(existing, []byte("changed"), 0o644))
+				_, err = app.History.CreateVersion(t.Context(), sess.ID, existing, "changed")
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(created, []byte("new"), 0o644))
+				_, err = app.History.Create(t.Context(), sess.ID, created, "")
+				require.NoError(t, err)
+				_, err = app.History.CreateVersion(t.Context(), sess.ID, created, "new")
+				require.NoError(t, err)
+
+				sess.PromptTokens = 1000
+				sess.CompletionTokens = 500
+				sess, err = app.Sessions.Save(t.Context(), sess)
+				require.NoError(t, err)
+				return sess, edited
+			}
+			require.NoError(t, app.Messages.Delete(t.Context(), edited.ID))
+		}
+	}
+
+	t.Run("keeps files", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(created, nil, 0o644))
+		sess, edited := setup(t)
+
+		require.NoError(t, app.RewindSession(t.Context(), sess.ID, edited.ID, false))
+
+		msgs, err := app.Messages.List(t.Context(), sess.ID)
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		require.Equal(t, "keep me", msgs[0].Content().Text)
+
+		got, err := app.Sessions.Get(t.Context(), sess.ID)
+		require.NoError(t, err)
+		require.EqualValues(t, len("keep me")/4, got.PromptTokens)
+		require.Zero(t, got.CompletionTokens)
+
+		content, err := os.ReadFile(existing)
+		require.NoError(t, err)
+		require.Equal(t, "changed", string(content))
+		require.FileExists(t, created)
+	})
+
+	t.Run("reverts files", func(t *testing.T) {
+		sess, edited := setup(t)
+
+		require.NoError(t, app.RewindSession(t.Context(), sess.ID, edited.ID, true))
+
+		content, err := os.ReadFile(existing)
+		require.NoError(t, err)
+		require.Equal(t, "original", string(content))
+		require.NoFileExists(t, created)
+
+		files, err := app.History.ListBySession(t.Context(), sess.ID)
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		require.Equal(t, existing, files[0].Path)
+	})
+
+	t.Run("falls back to an older summary", func(t *testing.T) {
+		sess, err := app.Sessions.Create(t.Context(), "summarized")
+		require.NoError(t, err)
+		create := func(params message.CreateMessageParams) message.Message {
+			msg, err := app.Messages.Create(t.Context(), sess.ID, params)
+			require.NoError(t, err)
+			return msg
+		}
+		summary := func(text, keptFrom string) message.Message {
+			return create(message.CreateMessageParams{
+				Role:              message.Assistant,
+				Parts:             []message.ContentPart{message.TextContent{Text: text}, message.Finish{Reason: message.FinishReasonEndTurn}},
+				IsSummaryMessage:  true,
+				KeptFromMessageID: keptFrom,
+			})
+		}
+		prompt := func(text string) message.Message {
+			return create(message.CreateMessageParams{
+				Role:  message.User,
+				Parts: []message.ContentPart{message.TextContent{Text: text}},
+			})
+		}
+
+		first := prompt("first")
+		older := summary("older summary", first.ID)
+		second := prompt("second")
+		latest := summary("latest summary", second.ID)
+		prompt("third")
+		sess.SummaryMessageID = latest.ID
+		sess.KeptFromMessageID = second.ID
+		sess, err = app.Sessions.Save(t.Context(), sess)
+		require.NoError(t, err)
+
+		require.NoError(t, app.RewindSession(t.Context(), sess.ID, second.ID, false))
+
+		got, err := app.Sessions.Get(t.Context(), sess.ID)
+		require.NoError(t, err)
+		require.Equal(t, older.ID, got.SummaryMessageID)
+		require.Equal(t, first.ID, got.KeptFromMessageID)
+	})
+
+	t.Run("unknown message", func(t *testing.T) {
+		sess, err := app.Sessions.Create(t.Context(), "empty")
+		require.NoError(t, err)
+		err = app.RewindSession(t.Context(), sess.ID, "missing", false)
+		require.ErrorIs(t, err, ErrRewindMessageNotFound)
+	})
+}
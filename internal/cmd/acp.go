@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+
+	"github.com/charmbracelet/crush/internal/acp"
+	"github.com/charmbracelet/crush/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var acpCmd = &cobra.Command{
+	Use:   "acp",
+	Short: "Run Crush as an Agent Client Protocol agent over stdio",
+	Long: `Run Crush as an agent for editors speaking the Agent Client Protocol (ACP),
+such as Zed. The editor starts this command and talks JSON-RPC to it over
+stdin and stdout.
+
+Sessions are regular Crush sessions, and permission requests are answered in
+the editor. When the editor supports it, files are read and written through
+its buffers, so unsaved changes are seen by the agent.`,
+	Example: `
+# Zed settings.json
+{
+  "agent_servers": {
+    "Crush": {
+      "command": "crush",
+      "args": ["acp"]
+    }
+  }
+}
+  `,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app, err := setupApp(cmd)
+		if err != nil {
+			return err
+		}
+		defer app.Shutdown()
+
+		if app.AgentCoordinator == nil {
+			return errors.New("no providers configured - please run 'crush' to set up a provider interactively")
+		}
+
+		return acp.Serve(cmd.Context(), app, config.Get().WorkingDir(), stdio{})
+	},
+}
+
+// stdio is the connection to the editor.
+type stdio struct{}
+
+func (stdio) Read(p []byte) (int, error)  { return os.Stdin.Read(p) }
+func (stdio) Write(p []byte) (int, error) { return os.Stdout.Write(p) }
+
+func (stdio) Close() error {
+	return errors.Join(os.Stdin.Close(), os.Stdout.Close())
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/crush/internal/app"
+	"github.com/charmbracelet/crush/internal/config"
+	"github.com/charmbracelet/crush/internal/db"
+	"github.com/charmbracelet/crush/internal/eval"
+	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/message"
+	"github.com/charmbracelet/crush/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var evalCmd = &cobra.Command{
+	Use:   "eval",
+	Short: "Evaluate models on recorded sessions",
+}
+
+var evalReplayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Replay a session against another model",
+	Long: `Replay the prompts of a recorded session against another model, and compare
+the tokens, cost, wall time, tool calls and changes of both runs.
+
+The prompts are replayed in a temporary git worktree checked out at the
+commit HEAD pointed to when the session started, with every permission
+approved. Uncommitted changes present at that time are not reproduced.`,
+	Example: `
+# Replay a session with GPT-5
+crush eval replay --session 7f3c... --model openai/gpt-5
+
+# Output the comparison as JSON and keep the worktree to inspect it
+crush eval replay --session 7f3c... --model openai/gpt-5 --json --keep
+  `,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sessionID, _ := cmd.Flags().GetString("session")
+		modelName, _ := cmd.Flags().GetString("model")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		keep, _ := cmd.Flags().GetBool("keep")
+		debug, _ := cmd.Flags().GetBool("debug")
+		dataDir, _ := cmd.Flags().GetString("data-dir")
+		ctx := cmd.Context()
+
+		providerID, modelID, ok := strings.Cut(modelName, "/")
+		if !ok || providerID == "" || modelID == "" {
+			return fmt.Errorf("invalid model %q, expected provider/model", modelName)
+		}
+
+		cwd, err := ResolveCwd(cmd)
+		if err != nil {
+			return err
+		}
+		cfg, err := config.Init(cwd, dataDir, debug)
+		if err != nil {
+			return err
+		}
+		recorded, err := loadRecordedSession(ctx, cfg.Options.DataDirectory, sessionID)
+		if err != nil {
+			return err
+		}
+		if len(recorded.prompts) == 0 {
+			return fmt.Errorf("session %s has no prompts to replay", sessionID)
+		}
+
+		commit, err := eval.CommitAt(ctx, cwd, time.Unix(recorded.session.CreatedAt, 0))
+		if err != nil {
+			return err
+		}
+		prefix, err := eval.Prefix(ctx, cwd)
+		if err != nil {
+			return err
+		}
+		tmpDir, err := os.MkdirTemp("", "crush-eval-")
+		if err != nil {
+			return err
+		}
+		worktree := filepath.Join(tmpDir, "repo")
+		if err := eval.AddWorktree(ctx, cwd, worktree, commit); err != nil {
+			return err
+		}
+		if keep {
+			fmt.Fprintf(os.Stderr, "Worktree: %s\n", worktree)
+		} else {
+			defer func() {
+				if err := eval.RemoveWorktree(context.WithoutCancel(ctx), cwd, worktree); err != nil {
+					slog.Error("Failed to remove worktree", "path", worktree, "error", err)
+				}
+				_ = os.RemoveAll(tmpDir)
+			}()
+		}
+
+		// Relative paths resolve against the process directory, move to the
+		// worktree so that they don't reach the original repository.
+		replayDir := filepath.Join(worktree, prefix)
+		if err := os.Chdir(replayDir); err != nil {
+			return err
+		}
+		replayCfg, err := config.Init(replayDir, filepath.Join(tmpDir, "data"), debug)
+		if err != nil {
+			return err
+		}
+		if replayCfg.GetModel(providerID, modelID) == nil {
+			return fmt.Errorf("model %s not found in the configured providers", modelName)
+		}
+		replayCfg.Models[config.SelectedModelTypeLarge] = config.SelectedModel{
+			Provider: providerID,
+			Model:    modelID,
+		}
+		if replayCfg.Permissions == nil {
+			replayCfg.Permissions = &config.Permissions{}
+		}
+		replayCfg.Permissions.SkipRequests = true
+		if err := createDotCrushDir(replayCfg.Options.DataDirectory); err != nil {
+			return err
+		}
+		conn, err := db.Connect(ctx, replayCfg.Options.DataDirectory)
+		if err != nil {
+			return err
+		}
+		a, err := app.New(ctx, conn, replayCfg)
+		if err != nil {
+			return err
+		}
+		defer a.Shutdown()
+
+		fmt.Fprintf(os.Stderr, "Replaying %d prompts with %s at %.12s\n", len(recorded.prompts), modelName, commit)
+		replaySession, elapsed, err := eval.Replay(ctx, a, recorded.session.Title, recorded.prompts)
+		if err != nil {
+			return err
+		}
+		replayMsgs, err := a.Messages.List(ctx, replaySession.ID)
+		if err != nil {
+			return err
+		}
+
+		report := eval.Report{
+			Commit:   commit,
+			Original: recorded.stats,
+			Replay:   eval.SessionStats(replaySession, replayMsgs),
+		}
+		report.Replay.WallTime = elapsed
+		report.Replay.Diff, report.Replay.FilesChanged, report.Replay.Additions, report.Replay.Removals, err = eval.WorktreeDiff(ctx, worktree)
+		if err != nil {
+			return err
+		}
+
+		if asJSON {
+			return report.WriteJSON(os.Stdout)
+		}
+		return report.WriteText(os.Stdout)
+	},
+}
+
+func init() {
+	evalReplayCmd.Flags().String("session", "", "ID of the session to replay")
+	evalReplayCmd.Flags().String("model", "", "Model to replay the session with, as provider/model")
+	evalReplayCmd.Flags().Bool("json", false, "Output the comparison as JSON")
+	evalReplayCmd.Flags().Bool("keep", false, "Keep the worktree of the replay")
+	_ = evalReplayCmd.MarkFlagRequired("session")
+	_ = evalReplayCmd.MarkFlagRequired("model")
+	evalCmd.AddCommand(evalReplayCmd)
+}
+
+type recordedSession struct {
+	session session.Session
+	prompts []eval.Prompt
+	stats   eval.Stats
+}
+
+// loadRecordedSession reads the session to replay from the database of the
+// project.
+func loadRecordedSession(ctx context.Context, dataDir, sessionID string) (recordedSession, error) {
+	conn, err := db.Connect(ctx, dataDir)
+	if err != nil {
+		return recordedSession{}, err
+	}
+	defer conn.Close()
+
+	q := db.New(conn)
+	sess, err := session.NewService(q, conn).Get(ctx, sessionID)
+	if err != nil {
+		return recordedSession{}, fmt.Errorf("failed to get session %s: %w", sessionID, err)
+	}
+	msgs, err := message.NewService(q).List(ctx, sess.ID)
+	if err != nil {
+		return recordedSession{}, err
+	}
+	files, err := history.NewService(q, conn).ListBySession(ctx, sess.ID)
+	if err != nil {
+		return recordedSession{}, err
+	}
+
+	stats := eval.SessionStats(sess, msgs)
+	stats.Diff, stats.FilesChanged, stats.Additions, stats.Removals = eval.HistoryDiff(files)
+	return recordedSession{
+		session: sess,
+		prompts: eval.Prompts(msgs),
+		stats:   stats,
+	}, nil
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/crush/internal/config"
+	"github.com/charmbracelet/crush/internal/mcpserver"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/cobra"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Model Context Protocol commands",
+}
+
+var mcpServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the Crush tools over MCP",
+	Long: `Serve the edit, multiedit, grep, glob and view tools of Crush, and the
+lsp_diagnostics and lsp_references tools when language servers are configured,
+as a Model Context Protocol server.
+
+The server speaks over stdio, or over streamable HTTP with --http. HTTP
+requests must carry the token with an "Authorization: Bearer <token>" header.
+The token is read from --token or the CRUSH_SERVER_TOKEN environment variable,
+and generated otherwise.
+
+Nobody is asked for permissions: with --permissions deny (the default) only the
+tools and paths allowed in the configuration can be used, with
+--permissions approve everything is allowed.`,
+	Example: `
+# Serve over stdio
+crush mcp serve
+
+# Serve over HTTP and let the tools edit files
+crush mcp serve --http 127.0.0.1:7655 --permissions approve
+  `,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		httpAddr, _ := cmd.Flags().GetString("http")
+		token, _ := cmd.Flags().GetString("token")
+		modeName, _ := cmd.Flags().GetString("permissions")
+		mode, err := mcpserver.ParsePermissionMode(modeName)
+		if err != nil {
+			return err
+		}
+
+		if httpAddr != "" {
+			if token == "" {
+				token = os.Getenv("CRUSH_SERVER_TOKEN")
+			}
+			if token == "" {
+				if token, err = generateToken(); err != nil {
+					return err
+				}
+				fmt.Fprintf(os.Stderr, "Token: %s\n", token)
+			}
+		}
+
+		app, err := setupApp(cmd)
+		if err != nil {
+			return err
+		}
+		defer app.Shutdown()
+
+		ctx, cancel := context.WithCancel(cmd.Context())
+		defer cancel()
+
+		cfg := config.Get()
+		server := mcpserver.New(ctx, app, mcpserver.Options{
+			WorkingDir:  cfg.WorkingDir(),
+			Permissions: mode,
+			LSP:         len(cfg.LSP) > 0,
+		})
+
+		if httpAddr == "" {
+			return server.Run(ctx, &mcp.StdioTransport{})
+		}
+		return serveMCPHTTP(ctx, server, httpAddr, token)
+	},
+}
+
+func init() {
+	mcpServeCmd.Flags().String("http", "", "Serve over streamable HTTP on this address instead of stdio")
+	mcpServeCmd.Flags().String("token", "", "Token required to access the HTTP server (defaults to $CRUSH_SERVER_TOKEN or a random token)")
+	mcpServeCmd.Flags().String("permissions", string(mcpserver.PermissionModeDeny), "How permission requests are answered: deny or approve")
+	mcpCmd.AddCommand(mcpServeCmd)
+}
+
+func serveMCPHTTP(ctx context.Context, server *mcp.Server, addr, token string) error {
+	handler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return server }, nil)
+	srv := &http.Server{
+		Addr: addr,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				http.Error(w, "invalid or missing token", http.StatusUnauthorized)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			slog.Warn("Serving MCP outside of localhost", "addr", addr)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Listening on http://%s\n", addr)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.ListenAndServe() }()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			return srv.Close()
+		}
+		return nil
+	}
+}
@@ -44,6 +44,11 @@ func init() {
 		logsCmd,
 		schemaCmd,
 		loginCmd,
+		sessionsCmd,
+		serveCmd,
+		acpCmd,
+		mcpCmd,
+		evalCmd,
 	)
 }
 
@@ -147,6 +152,10 @@ func Execute() {
 		fang.WithVersion(version.Version),
 		fang.WithNotifySignal(os.Interrupt),
 	); err != nil {
+		var runErr *app.RunError
+		if errors.As(err, &runErr) {
+			os.Exit(runErr.ExitCode)
+		}
 		os.Exit(1)
 	}
 }
@@ -182,6 +191,7 @@ func setupApp(cmd *cobra.Command) (*app.App, error) {
 		cfg.Permissions = &config.Permissions{}
 	}
 	cfg.Permissions.SkipRequests = yolo
+	applyLimitFlags(cmd, cfg)
 
 	if err := createDotCrushDir(cfg.Options.DataDirectory); err != nil {
 		return nil, err
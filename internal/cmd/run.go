@@ -3,9 +3,12 @@ package cmd
 import (
 	"fmt"
 	"log/slog"
+	"math"
 	"os"
 	"strings"
 
+	"github.com/charmbracelet/crush/internal/app"
+	"github.com/charmbracelet/crush/internal/config"
 	"github.com/spf13/cobra"
 )
 
@@ -13,7 +16,16 @@ var runCmd = &cobra.Command{
 	Use:   "run [prompt...]",
 	Short: "Run a single non-interactive prompt",
 	Long: `Run a single prompt in non-interactive mode and exit.
-The prompt can be provided as arguments or piped from stdin.`,
+The prompt can be provided as arguments or piped from stdin.
+
+With --output-format json, a single JSON object with the result, tool calls
+and usage is printed once the run is over. With stream-json, one JSON event
+is printed per line as the run progresses.
+
+The exit code is 1 on generic errors, 2 on provider errors, 3 when a
+permission was denied, 4 when the agent was stopped for repeating the same
+tool calls, 5 when it reached one of the limits of the run and 130 when the
+run was cancelled.`,
 	Example: `
 # Run a simple prompt
 crush run Explain the use of context in Go
@@ -26,17 +38,28 @@ crush run "What is this code doing?" <<< prrr.go
 
 # Run in quiet mode (hide the spinner)
 crush run --quiet "Generate a README for this project"
+
+# Stream events as JSON lines, for scripts
+crush run --output-format stream-json "Fix the failing tests"
+
+# Limit how long the agent can work, for unattended runs
+crush run --max-steps 50 --max-duration 10m "Fix the failing tests"
   `,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		quiet, _ := cmd.Flags().GetBool("quiet")
+		outputFormatFlag, _ := cmd.Flags().GetString("output-format")
+		outputFormat, err := app.ParseOutputFormat(outputFormatFlag)
+		if err != nil {
+			return err
+		}
 
-		app, err := setupApp(cmd)
+		a, err := setupApp(cmd)
 		if err != nil {
 			return err
 		}
-		defer app.Shutdown()
+		defer a.Shutdown()
 
-		if !app.Config().IsConfigured() {
+		if !a.Config().IsConfigured() {
 			return fmt.Errorf("no providers configured - please run 'crush' to set up a provider interactively")
 		}
 
@@ -58,10 +81,36 @@ crush run --quiet "Generate a README for this project"
 		//     echo "Do something fancy" | crush run > output.txt
 		//
 		// TODO: We currently need to press ^c twice to cancel. Fix that.
-		return app.RunNonInteractive(cmd.Context(), os.Stdout, prompt, quiet)
+		return a.RunNonInteractive(cmd.Context(), os.Stdout, prompt, outputFormat, quiet)
 	},
 }
 
 func init() {
 	runCmd.Flags().BoolP("quiet", "q", false, "Hide spinner")
+	runCmd.Flags().String("output-format", string(app.OutputFormatText), "Output format: text, json or stream-json")
+	runCmd.Flags().Int("max-steps", 0, "Maximum number of steps (a model response and its tool calls), 0 for no limit")
+	runCmd.Flags().Duration("max-duration", 0, "Maximum time the agent can work on the prompt, 0 for no limit")
+	runCmd.Flags().Int("max-tool-calls-per-step", 0, "Maximum number of tool calls in a single model response, 0 for no limit")
+}
+
+// applyLimitFlags overrides the limits of the configuration with the ones
+// given on the command line.
+func applyLimitFlags(cmd *cobra.Command, cfg *config.Config) {
+	flags := cmd.Flags()
+	if !flags.Changed("max-steps") && !flags.Changed("max-duration") && !flags.Changed("max-tool-calls-per-step") {
+		return
+	}
+	if cfg.Options.Limits == nil {
+		cfg.Options.Limits = &config.Limits{}
+	}
+	if flags.Changed("max-steps") {
+		cfg.Options.Limits.MaxSteps, _ = flags.GetInt("max-steps")
+	}
+	if flags.Changed("max-duration") {
+		maxDuration, _ := flags.GetDuration("max-duration")
+		cfg.Options.Limits.MaxDuration = int(math.Ceil(maxDuration.Seconds()))
+	}
+	if flags.Changed("max-tool-calls-per-step") {
+		cfg.Options.Limits.MaxToolCallsPerStep, _ = flags.GetInt("max-tool-calls-per-step")
+	}
 }
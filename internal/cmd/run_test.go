@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/crush/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyLimitFlags(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().AddFlagSet(runCmd.Flags())
+		require.NoError(t, cmd.ParseFlags(args))
+		t.Cleanup(func() {
+			for _, name := range []string{"max-steps", "max-duration", "max-tool-calls-per-step"} {
+				flag := runCmd.Flags().Lookup(name)
+				_ = flag.Value.Set(flag.DefValue)
+				flag.Changed = false
+			}
+		})
+		return cmd
+	}
+
+	cfg := &config.Config{Options: &config.Options{}}
+	applyLimitFlags(newCmd(), cfg)
+	require.Nil(t, cfg.Options.Limits)
+
+	cfg.Options.Limits = &config.Limits{MaxSteps: 100, MaxToolCallsPerStep: 20}
+	applyLimitFlags(newCmd("--max-steps", "50", "--max-duration", "90s"), cfg)
+	require.Equal(t, &config.Limits{MaxSteps: 50, MaxDuration: 90, MaxToolCallsPerStep: 20}, cfg.Options.Limits)
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/crush/internal/server"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultServeHost = "127.0.0.1"
+	defaultServePort = 7654
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the Crush API over HTTP",
+	Long: `Serve an HTTP API to drive Crush without the interactive interface.
+
+Sessions can be created, listed and deleted, prompts sent, runs cancelled and
+sessions summarized. Updates are streamed as server-sent events on
+/v1/events, and permission requests are answered on /v1/permissions/{id}.
+
+Requests must carry the token with an "Authorization: Bearer <token>" header,
+or a token query parameter. The token is read from --token or the
+CRUSH_SERVER_TOKEN environment variable, and generated otherwise.
+
+The server only listens on localhost unless another host is given.`,
+	Example: `
+# Serve on localhost
+crush serve
+
+# Serve on another port with a fixed token
+CRUSH_SERVER_TOKEN=secret crush serve --port 8080
+
+# Send a prompt and wait for the reply
+curl -H "Authorization: Bearer secret" -d '{"prompt":"Hi","wait":true}' \
+  http://127.0.0.1:8080/v1/sessions/<session-id>/prompt
+  `,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		host, _ := cmd.Flags().GetString("host")
+		port, _ := cmd.Flags().GetInt("port")
+		token, _ := cmd.Flags().GetString("token")
+		if token == "" {
+			token = os.Getenv("CRUSH_SERVER_TOKEN")
+		}
+		if token == "" {
+			var err error
+			if token, err = generateToken(); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Token: %s\n", token)
+		}
+
+		app, err := setupApp(cmd)
+		if err != nil {
+			return err
+		}
+		defer app.Shutdown()
+
+		ctx, cancel := context.WithCancel(cmd.Context())
+		defer cancel()
+
+		addr := net.JoinHostPort(host, strconv.Itoa(port))
+		srv := &http.Server{
+			Addr:              addr,
+			Handler:           server.New(ctx, app, token),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			slog.Warn("Serving the API outside of localhost", "addr", addr)
+		}
+		fmt.Fprintf(os.Stderr, "Listening on http://%s\n", addr)
+
+		errCh := make(chan error, 1)
+		go func() { errCh <- srv.ListenAndServe() }()
+
+		select {
+		case err := <-errCh:
+			if !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		case <-ctx.Done():
+			// Event streams never end on their own, don't wait for them.
+			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer shutdownCancel()
+			if err := srv.Shutdown(shutdownCtx); err != nil {
+				return srv.Close()
+			}
+			return nil
+		}
+	},
+}
+
+func init() {
+	serveCmd.Flags().String("host", defaultServeHost, "Host to listen on")
+	serveCmd.Flags().IntP("port", "p", defaultServePort, "Port to listen on")
+	serveCmd.Flags().String("token", "", "Token required to access the API (defaults to $CRUSH_SERVER_TOKEN or a random token)")
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
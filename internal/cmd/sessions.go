@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/charmbracelet/crush/internal/config"
+	"github.com/charmbracelet/crush/internal/db"
+	"github.com/charmbracelet/crush/internal/message"
+	"github.com/charmbracelet/crush/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var sessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "Manage sessions",
+	Long:  `Manage the sessions stored in the Crush database of the current project.`,
+}
+
+var sessionsForkCmd = &cobra.Command{
+	Use:   "fork <session-id> [message-id]",
+	Short: "Fork a session from one of its messages",
+	Long: `Fork a session into a new session containing the conversation up to the
+given message, along with the file history recorded up to that point.
+When no message ID is given, the session is forked from its last message.`,
+	Example: `
+# Fork a session from its last message
+crush sessions fork 3f2b9c1e-...
+
+# Fork a session from a specific message
+crush sessions fork 3f2b9c1e-... 9a7d4e20-...
+  `,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, err := connectDB(cmd)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		ctx := cmd.Context()
+		q := db.New(conn)
+		sessions := session.NewService(q, conn)
+
+		sessionID := args[0]
+		var messageID string
+		if len(args) > 1 {
+			messageID = args[1]
+		} else {
+			msgs, err := message.NewService(q).List(ctx, sessionID)
+			if err != nil {
+				return fmt.Errorf("failed to list messages: %w", err)
+			}
+			if len(msgs) == 0 {
+				return fmt.Errorf("session %q has no messages to fork from", sessionID)
+			}
+			messageID = msgs[len(msgs)-1].ID
+		}
+
+		forked, err := sessions.Fork(ctx, sessionID, messageID)
+		if err != nil {
+			return fmt.Errorf("failed to fork session: %w", err)
+		}
+		cmd.Println(forked.ID)
+		return nil
+	},
+}
+
+func init() {
+	sessionsCmd.AddCommand(sessionsForkCmd)
+}
+
+// connectDB opens the database of the current project without starting the
+// rest of the application.
+func connectDB(cmd *cobra.Command) (*sql.DB, error) {
+	dataDir, _ := cmd.Flags().GetString("data-dir")
+	debug, _ := cmd.Flags().GetBool("debug")
+
+	cwd, err := ResolveCwd(cmd)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.Load(cwd, dataDir, debug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if err := createDotCrushDir(cfg.Options.DataDirectory); err != nil {
+		return nil, err
+	}
+	return db.Connect(cmd.Context(), cfg.Options.DataDirectory)
+}
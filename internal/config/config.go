@@ -163,6 +163,11 @@ type LSPConfig struct {
 	RootMarkers []string          `json:"root_markers,omitempty" jsonschema:"description=Files or directories that indicate the project root,example=go.mod,example=package.json,example=Cargo.toml"`
 	InitOptions map[string]any    `json:"init_options,omitempty" jsonschema:"description=Initialization options passed to the LSP server during initialize request"`
 	Options     map[string]any    `json:"options,omitempty" jsonschema:"description=LSP server-specific settings passed during initialization"`
+
+	FormatOnWrite      bool     `json:"format_on_write,omitempty" jsonschema:"description=Format the files this LSP server handles after the agent writes them,default=false"`
+	Formatter          string   `json:"formatter,omitempty" jsonschema:"description=Shell command formatting files on write instead of the LSP server; it reads a file on stdin and writes it formatted on stdout with $FILE set to its path,example=gofumpt,example=prettier --stdin-filepath $FILE"`
+	CodeActionsOnWrite []string `json:"code_actions_on_write,omitempty" jsonschema:"description=Kinds of code actions applied after formatting on write,default=source.organizeImports,example=source.organizeImports,example=source.fixAll"`
+	CodeActionKinds    []string `json:"code_action_kinds,omitempty" jsonschema:"description=Kinds of code actions the lsp_code_action tool lists,default=quickfix,example=quickfix,example=refactor,example=source"`
 }
 
 type TUIOptions struct {
@@ -186,7 +191,10 @@ func (c Completions) Limits() (depth, items int) {
 
 type Permissions struct {
 	AllowedTools []string `json:"allowed_tools,omitempty" jsonschema:"description=List of tools that don't require permission prompts,example=bash,example=view"` // Tools that don't require permission prompts
-	SkipRequests bool     `json:"-"`                                                                                                                              // Automatically accept all permissions (YOLO mode)
+	Allow        []string `json:"allow,omitempty" jsonschema:"description=Rules for the tool calls that run without a permission prompt,example=bash(go test:*),example=edit(src/**)"`
+	Ask          []string `json:"ask,omitempty" jsonschema:"description=Rules for the tool calls that always need a permission prompt,example=bash(git push:*)"`
+	Deny         []string `json:"deny,omitempty" jsonschema:"description=Rules for the tool calls that are never run,example=view(**/.env),example=fetch(domain:*.internal.corp)"`
+	SkipRequests bool     `json:"-"` // Automatically accept all permissions (YOLO mode)
 }
 
 type TrailerStyle string
@@ -213,17 +221,46 @@ func (Attribution) JSONSchemaExtend(schema *jsonschema.Schema) {
 }
 
 type Options struct {
-	ContextPaths              []string     `json:"context_paths,omitempty" jsonschema:"description=Paths to files containing context information for the AI,example=.cursorrules,example=CRUSH.md"`
-	TUI                       *TUIOptions  `json:"tui,omitempty" jsonschema:"description=Terminal user interface options"`
-	Debug                     bool         `json:"debug,omitempty" jsonschema:"description=Enable debug logging,default=false"`
-	DebugLSP                  bool         `json:"debug_lsp,omitempty" jsonschema:"description=Enable debug logging for LSP servers,default=false"`
-	DisableAutoSummarize      bool         `json:"disable_auto_summarize,omitempty" jsonschema:"description=Disable automatic conversation summarization,default=false"`
-	DataDirectory             string       `json:"data_directory,omitempty" jsonschema:"description=Directory for storing application data (relative to working directory),default=.crush,example=.crush"` // Relative to the cwd
-	DisabledTools             []string     `json:"disabled_tools" jsonschema:"description=Tools to disable"`
-	DisableProviderAutoUpdate bool         `json:"disable_provider_auto_update,omitempty" jsonschema:"description=Disable providers auto-update,default=false"`
-	Attribution               *Attribution `json:"attribution,omitempty" jsonschema:"description=Attribution settings for generated content"`
-	DisableMetrics            bool         `json:"disable_metrics,omitempty" jsonschema:"description=Disable sending metrics,default=false"`
-	InitializeAs              string       `json:"initialize_as,omitempty" jsonschema:"description=Name of the context file to create/update during project initialization,default=AGENTS.md,example=AGENTS.md,example=CRUSH.md,example=CLAUDE.md,example=docs/LLMs.md"`
+	ContextPaths              []string       `json:"context_paths,omitempty" jsonschema:"description=Paths to files containing context information for the AI,example=.cursorrules,example=CRUSH.md"`
+	TUI                       *TUIOptions    `json:"tui,omitempty" jsonschema:"description=Terminal user interface options"`
+	Debug                     bool           `json:"debug,omitempty" jsonschema:"description=Enable debug logging,default=false"`
+	DebugLSP                  bool           `json:"debug_lsp,omitempty" jsonschema:"description=Enable debug logging for LSP servers,default=false"`
+	DisableAutoSummarize      bool           `json:"disable_auto_summarize,omitempty" jsonschema:"description=Disable automatic conversation summarization,default=false"`
+	DataDirectory             string         `json:"data_directory,omitempty" jsonschema:"description=Directory for storing application data (relative to working directory),default=.crush,example=.crush"` // Relative to the cwd
+	DisabledTools             []string       `json:"disabled_tools" jsonschema:"description=Tools to disable"`
+	DisableProviderAutoUpdate bool           `json:"disable_provider_auto_update,omitempty" jsonschema:"description=Disable providers auto-update,default=false"`
+	Attribution               *Attribution   `json:"attribution,omitempty" jsonschema:"description=Attribution settings for generated content"`
+	DisableMetrics            bool           `json:"disable_metrics,omitempty" jsonschema:"description=Disable sending metrics,default=false"`
+	InitializeAs              string         `json:"initialize_as,omitempty" jsonschema:"description=Name of the context file to create/update during project initialization,default=AGENTS.md,example=AGENTS.md,example=CRUSH.md,example=CLAUDE.md,example=docs/LLMs.md"`
+	Compaction                *Compaction    `json:"compaction,omitempty" jsonschema:"description=Settings for the summarization of long conversations"`
+	LoopDetection             *LoopDetection `json:"loop_detection,omitempty" jsonschema:"description=Settings for the detection of the agent repeating the same tool calls"`
+	Limits                    *Limits        `json:"limits,omitempty" jsonschema:"description=Limits on how long the agent can work on a prompt"`
+	Sandbox                   *Sandbox       `json:"sandbox,omitempty" jsonschema:"description=Settings for running the bash commands in a sandbox on Linux"`
+}
+
+type Compaction struct {
+	ThresholdTokens int64   `json:"threshold_tokens,omitempty" jsonschema:"description=Summarize when fewer than this many tokens of the context window remain,example=20000"`
+	ThresholdRatio  float64 `json:"threshold_ratio,omitempty" jsonschema:"description=Summarize when less than this fraction of the context window remains; used when threshold_tokens is not set,default=0.2,example=0.15"`
+	KeepTurns       int     `json:"keep_turns,omitempty" jsonschema:"description=Number of recent turns kept verbatim when summarizing,default=2,example=4"`
+	KeepTokens      int64   `json:"keep_tokens,omitempty" jsonschema:"description=Maximum number of tokens kept verbatim when summarizing; defaults to a quarter of the context window,example=30000"`
+}
+
+type LoopDetection struct {
+	Disabled      bool `json:"disabled,omitempty" jsonschema:"description=Disable the detection of tool call loops,default=false"`
+	RepeatedCalls int  `json:"repeated_calls,omitempty" jsonschema:"description=Number of identical tool calls in a row after which the agent is told to change approach; the run stops if it keeps going,default=5,example=8"`
+	FailedCalls   int  `json:"failed_calls,omitempty" jsonschema:"description=Number of failed tool calls in a row after which the agent is told to change approach; the run stops if they keep failing,default=8,example=12"`
+}
+
+type Limits struct {
+	MaxSteps            int `json:"max_steps,omitempty" jsonschema:"description=Maximum number of steps (a model response and the tool calls it makes) per prompt,example=100"`
+	MaxDuration         int `json:"max_duration,omitempty" jsonschema:"description=Maximum time in seconds the agent can work on a prompt,example=1800"`
+	MaxToolCallsPerStep int `json:"max_tool_calls_per_step,omitempty" jsonschema:"description=Maximum number of tool calls in a single model response,example=20"`
+}
+
+type Sandbox struct {
+	Enabled        bool     `json:"enabled,omitempty" jsonschema:"description=Run the bash commands with the filesystem read-only except for the working directory and the temporary directory,default=false"`
+	WritablePaths  []string `json:"writable_paths,omitempty" jsonschema:"description=Other paths the commands can write to,example=~/.cache/go-build,example=~/.npm"`
+	IsolateNetwork bool     `json:"isolate_network,omitempty" jsonschema:"description=Cut the commands off the network,default=false"`
 }
 
 type MCPs map[string]MCPConfig
@@ -325,6 +362,20 @@ func (t ToolLs) Limits() (depth, items int) {
 	return ptrValOr(t.MaxDepth, 0), ptrValOr(t.MaxItems, 0)
 }
 
+type HookConfig struct {
+	Command string `json:"command" jsonschema:"description=Shell command to run; it receives a JSON payload on stdin,example=gofmt -w $(jq -r .params.file_path)"`
+	Matcher string `json:"matcher,omitempty" jsonschema:"description=Regular expression matched against the tool name (tool hooks only),example=edit|write"`
+	Timeout int    `json:"timeout,omitempty" jsonschema:"description=Timeout in seconds for the command,default=60,example=10"`
+}
+
+type Hooks struct {
+	PreToolUse       []HookConfig `json:"pre_tool_use,omitempty" jsonschema:"description=Hooks run before a tool is executed; they can approve or deny the call or rewrite its params"`
+	PostToolUse      []HookConfig `json:"post_tool_use,omitempty" jsonschema:"description=Hooks run after a tool is executed; they can append text to the tool result"`
+	UserPromptSubmit []HookConfig `json:"user_prompt_submit,omitempty" jsonschema:"description=Hooks run when a prompt is submitted; they can block the prompt or append context to it"`
+	SessionStart     []HookConfig `json:"session_start,omitempty" jsonschema:"description=Hooks run when the first prompt of a session is sent"`
+	Stop             []HookConfig `json:"stop,omitempty" jsonschema:"description=Hooks run when the agent finishes responding"`
+}
+
 // Config holds the configuration for crush.
 type Config struct {
 	Schema string `json:"$schema,omitempty"`
@@ -347,6 +398,8 @@ type Config struct {
 
 	Tools Tools `json:"tools,omitzero" jsonschema:"description=Tool configurations"`
 
+	Hooks Hooks `json:"hooks,omitzero" jsonschema:"description=Shell commands run around tool calls and prompts"`
+
 	Agents map[string]Agent `json:"-"`
 
 	// Internal
@@ -616,17 +669,28 @@ func allToolNames() []string {
 		"bash",
 		"job_output",
 		"job_kill",
+		"job_list",
+		"job_input",
 		"download",
 		"edit",
 		"multiedit",
+		"notebook_edit",
+		"apply_patch",
 		"lsp_diagnostics",
 		"lsp_references",
+		"lsp_definition",
+		"lsp_hover",
+		"lsp_document_symbols",
+		"lsp_workspace_symbols",
+		"lsp_rename",
+		"lsp_code_action",
 		"fetch",
 		"agentic_fetch",
 		"glob",
 		"grep",
 		"ls",
 		"sourcegraph",
+		"todos",
 		"view",
 		"write",
 	}
@@ -414,6 +414,14 @@ func (c *Config) applyLSPDefaults() {
 
 	// Apply defaults to each LSP configuration
 	for name, cfg := range c.LSP {
+		if cfg.CodeActionsOnWrite == nil {
+			cfg.CodeActionsOnWrite = []string{"source.organizeImports"}
+		}
+		if cfg.CodeActionKinds == nil {
+			cfg.CodeActionKinds = []string{"quickfix"}
+		}
+		c.LSP[name] = cfg
+
 		// Try to get defaults from powernap based on name or command name.
 		base, ok := configManager.GetServer(name)
 		if !ok {
@@ -37,6 +37,19 @@ func TestConfig_LoadFromReaders(t *testing.T) {
 	require.Equal(t, "https://api.openai.com/v2", pc.BaseURL)
 }
 
+func TestConfig_LoadFromReadersMergesPermissionRules(t *testing.T) {
+	user := strings.NewReader(`{"permissions": {"allow": ["bash(go test:*)"], "deny": ["view(**/.env)"]}}`)
+	project := strings.NewReader(`{"permissions": {"allow": ["edit(src/**)"], "ask": ["bash(git push:*)"]}}`)
+	local := strings.NewReader(`{"permissions": {"allow": ["bash(git commit:*)"]}}`)
+
+	loadedConfig, err := loadFromReaders([]io.Reader{user, project, local})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"bash(go test:*)", "edit(src/**)", "bash(git commit:*)"}, loadedConfig.Permissions.Allow)
+	require.Equal(t, []string{"bash(git push:*)"}, loadedConfig.Permissions.Ask)
+	require.Equal(t, []string{"view(**/.env)"}, loadedConfig.Permissions.Deny)
+}
+
 func TestConfig_setDefaults(t *testing.T) {
 	cfg := &Config{}
 
@@ -487,7 +500,7 @@ func TestConfig_setupAgentsWithDisabledTools(t *testing.T) {
 	coderAgent, ok := cfg.Agents[AgentCoder]
 	require.True(t, ok)
 
-	assert.Equal(t, []string{"agent", "bash", "job_output", "job_kill", "multiedit", "lsp_diagnostics", "lsp_references", "fetch", "agentic_fetch", "glob", "ls", "sourcegraph", "view", "write"}, coderAgent.AllowedTools)
+	assert.Equal(t, []string{"agent", "bash", "job_output", "job_kill", "job_list", "job_input", "multiedit", "notebook_edit", "apply_patch", "lsp_diagnostics", "lsp_references", "lsp_definition", "lsp_hover", "lsp_document_symbols", "lsp_workspace_symbols", "lsp_rename", "lsp_code_action", "fetch", "agentic_fetch", "glob", "ls", "sourcegraph", "todos", "view", "write"}, coderAgent.AllowedTools)
 
 	taskAgent, ok := cfg.Agents[AgentTask]
 	require.True(t, ok)
@@ -510,7 +523,7 @@ func TestConfig_setupAgentsWithEveryReadOnlyToolDisabled(t *testing.T) {
 	cfg.SetupAgents()
 	coderAgent, ok := cfg.Agents[AgentCoder]
 	require.True(t, ok)
-	assert.Equal(t, []string{"agent", "bash", "job_output", "job_kill", "download", "edit", "multiedit", "lsp_diagnostics", "lsp_references", "fetch", "agentic_fetch", "write"}, coderAgent.AllowedTools)
+	assert.Equal(t, []string{"agent", "bash", "job_output", "job_kill", "job_list", "job_input", "download", "edit", "multiedit", "notebook_edit", "apply_patch", "lsp_diagnostics", "lsp_references", "lsp_definition", "lsp_hover", "lsp_document_symbols", "lsp_workspace_symbols", "lsp_rename", "lsp_code_action", "fetch", "agentic_fetch", "todos", "write"}, coderAgent.AllowedTools)
 
 	taskAgent, ok := cfg.Agents[AgentTask]
 	require.True(t, ok)
@@ -18,6 +18,9 @@ func TestApplyLSPDefaults(t *testing.T) {
 			"custom": {
 				Command:     "custom-lsp",
 				RootMarkers: []string{"custom.toml"}, // This should keep its explicit config
+				// Code actions on write can be turned off with an empty list
+				CodeActionsOnWrite: []string{},
+				CodeActionKinds:    []string{"quickfix", "refactor"},
 			},
 		},
 	}
@@ -28,8 +31,12 @@ func TestApplyLSPDefaults(t *testing.T) {
 	// Check that gopls got defaults (it should have some root markers now)
 	goplsConfig := config.LSP["gopls"]
 	require.NotEmpty(t, goplsConfig.RootMarkers, "gopls should have received default root markers")
+	require.Equal(t, []string{"source.organizeImports"}, goplsConfig.CodeActionsOnWrite, "gopls should organize imports on write by default")
+	require.Equal(t, []string{"quickfix"}, goplsConfig.CodeActionKinds, "gopls should list quick fixes by default")
 
 	// Check that custom LSP kept its explicit config
 	customConfig := config.LSP["custom"]
 	require.Equal(t, []string{"custom.toml"}, customConfig.RootMarkers, "custom LSP should keep its explicit root markers")
+	require.Empty(t, customConfig.CodeActionsOnWrite, "custom LSP should keep its code actions on write turned off")
+	require.Equal(t, []string{"quickfix", "refactor"}, customConfig.CodeActionKinds, "custom LSP should keep its explicit code action kinds")
 }
@@ -9,6 +9,54 @@ import (
 	"context"
 )
 
+const copyFile = `-- name: CopyFile :one
+INSERT INTO files (
+    id,
+    session_id,
+    path,
+    content,
+    version,
+    created_at,
+    updated_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING id, session_id, path, content, version, created_at, updated_at
+`
+
+type CopyFileParams struct {
+	ID        string `json:"id"`
+	SessionID string `json:"session_id"`
+	Path      string `json:"path"`
+	Content   string `json:"content"`
+	Version   int64  `json:"version"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+func (q *Queries) CopyFile(ctx context.Context, arg CopyFileParams) (File, error) {
+	row := q.queryRow(ctx, q.copyFileStmt, copyFile,
+		arg.ID,
+		arg.SessionID,
+		arg.Path,
+		arg.Content,
+		arg.Version,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i File
+	err := row.Scan(
+		&i.ID,
+		&i.SessionID,
+		&i.Path,
+		&i.Content,
+		&i.Version,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const createFile = `-- name: CreateFile :one
 INSERT INTO files (
     id,
@@ -10,6 +10,70 @@ import (
 	"database/sql"
 )
 
+const copyMessage = `-- name: CopyMessage :one
+INSERT INTO messages (
+    id,
+    session_id,
+    role,
+    parts,
+    model,
+    provider,
+    is_summary_message,
+    kept_from_message_id,
+    created_at,
+    updated_at,
+    finished_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING id, session_id, role, parts, model, created_at, updated_at, finished_at, provider, is_summary_message, kept_from_message_id
+`
+
+type CopyMessageParams struct {
+	ID                string         `json:"id"`
+	SessionID         string         `json:"session_id"`
+	Role              string         `json:"role"`
+	Parts             string         `json:"parts"`
+	Model             sql.NullString `json:"model"`
+	Provider          sql.NullString `json:"provider"`
+	IsSummaryMessage  int64          `json:"is_summary_message"`
+	KeptFromMessageID sql.NullString `json:"kept_from_message_id"`
+	CreatedAt         int64          `json:"created_at"`
+	UpdatedAt         int64          `json:"updated_at"`
+	FinishedAt        sql.NullInt64  `json:"finished_at"`
+}
+
+func (q *Queries) CopyMessage(ctx context.Context, arg CopyMessageParams) (Message, error) {
+	row := q.queryRow(ctx, q.copyMessageStmt, copyMessage,
+		arg.ID,
+		arg.SessionID,
+		arg.Role,
+		arg.Parts,
+		arg.Model,
+		arg.Provider,
+		arg.IsSummaryMessage,
+		arg.KeptFromMessageID,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		arg.FinishedAt,
+	)
+	var i Message
+	err := row.Scan(
+		&i.ID,
+		&i.SessionID,
+		&i.Role,
+		&i.Parts,
+		&i.Model,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.FinishedAt,
+		&i.Provider,
+		&i.IsSummaryMessage,
+		&i.KeptFromMessageID,
+	)
+	return i, err
+}
+
 const createMessage = `-- name: CreateMessage :one
 INSERT INTO messages (
     id,
@@ -19,22 +83,24 @@ INSERT INTO messages (
     model,
     provider,
     is_summary_message,
+    kept_from_message_id,
     created_at,
     updated_at
 ) VALUES (
-    ?, ?, ?, ?, ?, ?, ?, strftime('%s', 'now'), strftime('%s', 'now')
+    ?, ?, ?, ?, ?, ?, ?, ?, strftime('%s', 'now'), strftime('%s', 'now')
 )
-RETURNING id, session_id, role, parts, model, created_at, updated_at, finished_at, provider, is_summary_message
+RETURNING id, session_id, role, parts, model, created_at, updated_at, finished_at, provider, is_summary_message, kept_from_message_id
 `
 
 type CreateMessageParams struct {
-	ID               string         `json:"id"`
-	SessionID        string         `json:"session_id"`
-	Role             string         `json:"role"`
-	Parts            string         `json:"parts"`
-	Model            sql.NullString `json:"model"`
-	Provider         sql.NullString `json:"provider"`
-	IsSummaryMessage int64          `json:"is_summary_message"`
+	ID                string         `json:"id"`
+	SessionID         string         `json:"session_id"`
+	Role              string         `json:"role"`
+	Parts             string         `json:"parts"`
+	Model             sql.NullString `json:"model"`
+	Provider          sql.NullString `json:"provider"`
+	IsSummaryMessage  int64          `json:"is_summary_message"`
+	KeptFromMessageID sql.NullString `json:"kept_from_message_id"`
 }
 
 func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) (Message, error) {
@@ -46,6 +112,7 @@ func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) (M
 		arg.Model,
 		arg.Provider,
 		arg.IsSummaryMessage,
+		arg.KeptFromMessageID,
 	)
 	var i Message
 	err := row.Scan(
@@ -59,6 +126,7 @@ func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) (M
 		&i.FinishedAt,
 		&i.Provider,
 		&i.IsSummaryMessage,
+		&i.KeptFromMessageID,
 	)
 	return i, err
 }
@@ -84,7 +152,7 @@ func (q *Queries) DeleteSessionMessages(ctx context.Context, sessionID string) e
 }
 
 const getMessage = `-- name: GetMessage :one
-SELECT id, session_id, role, parts, model, created_at, updated_at, finished_at, provider, is_summary_message
+SELECT id, session_id, role, parts, model, created_at, updated_at, finished_at, provider, is_summary_message, kept_from_message_id
 FROM messages
 WHERE id = ? LIMIT 1
 `
@@ -103,12 +171,13 @@ func (q *Queries) GetMessage(ctx context.Context, id string) (Message, error) {
 		&i.FinishedAt,
 		&i.Provider,
 		&i.IsSummaryMessage,
+		&i.KeptFromMessageID,
 	)
 	return i, err
 }
 
 const listMessagesBySession = `-- name: ListMessagesBySession :many
-SELECT id, session_id, role, parts, model, created_at, updated_at, finished_at, provider, is_summary_message
+SELECT id, session_id, role, parts, model, created_at, updated_at, finished_at, provider, is_summary_message, kept_from_message_id
 FROM messages
 WHERE session_id = ?
 ORDER BY created_at ASC
@@ -134,6 +203,7 @@ func (q *Queries) ListMessagesBySession(ctx context.Context, sessionID string) (
 			&i.FinishedAt,
 			&i.Provider,
 			&i.IsSummaryMessage,
+			&i.KeptFromMessageID,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Track the message a forked session was branched from
+ALTER TABLE sessions ADD COLUMN forked_from_message_id TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sessions DROP COLUMN forked_from_message_id;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Track the first message kept verbatim after the summary of a session
+ALTER TABLE sessions ADD COLUMN kept_from_message_id TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sessions DROP COLUMN kept_from_message_id;
+-- +goose StatementEnd
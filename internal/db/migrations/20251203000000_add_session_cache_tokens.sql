@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Track the prompt tokens read from and written to the provider cache
+ALTER TABLE sessions ADD COLUMN cache_read_tokens INTEGER NOT NULL DEFAULT 0 CHECK (cache_read_tokens >= 0);
+ALTER TABLE sessions ADD COLUMN cache_creation_tokens INTEGER NOT NULL DEFAULT 0 CHECK (cache_creation_tokens >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sessions DROP COLUMN cache_creation_tokens;
+ALTER TABLE sessions DROP COLUMN cache_read_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Track the input tokens not read from the provider cache
+ALTER TABLE sessions ADD COLUMN input_tokens INTEGER NOT NULL DEFAULT 0 CHECK (input_tokens >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sessions DROP COLUMN input_tokens;
+-- +goose StatementEnd
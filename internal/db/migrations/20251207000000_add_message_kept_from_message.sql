@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Track the first message kept verbatim before each summary message, so that
+-- an older summary can take over when the session is rewound
+ALTER TABLE messages ADD COLUMN kept_from_message_id TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE messages DROP COLUMN kept_from_message_id;
+-- +goose StatementEnd
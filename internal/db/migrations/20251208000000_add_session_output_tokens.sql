@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Track the output tokens over the whole session
+ALTER TABLE sessions ADD COLUMN output_tokens INTEGER NOT NULL DEFAULT 0 CHECK (output_tokens >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sessions DROP COLUMN output_tokens;
+-- +goose StatementEnd
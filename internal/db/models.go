@@ -18,28 +18,60 @@ type File struct {
 	UpdatedAt int64  `json:"updated_at"`
 }
 
+type Job struct {
+	ID          string        `json:"id"`
+	SessionID   string        `json:"session_id"`
+	ShellID     string        `json:"shell_id"`
+	Command     string        `json:"command"`
+	Description string        `json:"description"`
+	WorkingDir  string        `json:"working_dir"`
+	StdoutPath  string        `json:"stdout_path"`
+	StderrPath  string        `json:"stderr_path"`
+	Status      string        `json:"status"`
+	ExitCode    sql.NullInt64 `json:"exit_code"`
+	StartedAt   int64         `json:"started_at"`
+	FinishedAt  sql.NullInt64 `json:"finished_at"`
+}
+
 type Message struct {
-	ID               string         `json:"id"`
-	SessionID        string         `json:"session_id"`
-	Role             string         `json:"role"`
-	Parts            string         `json:"parts"`
-	Model            sql.NullString `json:"model"`
-	CreatedAt        int64          `json:"created_at"`
-	UpdatedAt        int64          `json:"updated_at"`
-	FinishedAt       sql.NullInt64  `json:"finished_at"`
-	Provider         sql.NullString `json:"provider"`
-	IsSummaryMessage int64          `json:"is_summary_message"`
+	ID                string         `json:"id"`
+	SessionID         string         `json:"session_id"`
+	Role              string         `json:"role"`
+	Parts             string         `json:"parts"`
+	Model             sql.NullString `json:"model"`
+	CreatedAt         int64          `json:"created_at"`
+	UpdatedAt         int64          `json:"updated_at"`
+	FinishedAt        sql.NullInt64  `json:"finished_at"`
+	Provider          sql.NullString `json:"provider"`
+	IsSummaryMessage  int64          `json:"is_summary_message"`
+	KeptFromMessageID sql.NullString `json:"kept_from_message_id"`
 }
 
 type Session struct {
-	ID               string         `json:"id"`
-	ParentSessionID  sql.NullString `json:"parent_session_id"`
-	Title            string         `json:"title"`
-	MessageCount     int64          `json:"message_count"`
-	PromptTokens     int64          `json:"prompt_tokens"`
-	CompletionTokens int64          `json:"completion_tokens"`
-	Cost             float64        `json:"cost"`
-	UpdatedAt        int64          `json:"updated_at"`
-	CreatedAt        int64          `json:"created_at"`
-	SummaryMessageID sql.NullString `json:"summary_message_id"`
+	ID                  string         `json:"id"`
+	ParentSessionID     sql.NullString `json:"parent_session_id"`
+	Title               string         `json:"title"`
+	MessageCount        int64          `json:"message_count"`
+	PromptTokens        int64          `json:"prompt_tokens"`
+	CompletionTokens    int64          `json:"completion_tokens"`
+	Cost                float64        `json:"cost"`
+	UpdatedAt           int64          `json:"updated_at"`
+	CreatedAt           int64          `json:"created_at"`
+	SummaryMessageID    sql.NullString `json:"summary_message_id"`
+	ForkedFromMessageID sql.NullString `json:"forked_from_message_id"`
+	KeptFromMessageID   sql.NullString `json:"kept_from_message_id"`
+	CacheReadTokens     int64          `json:"cache_read_tokens"`
+	CacheCreationTokens int64          `json:"cache_creation_tokens"`
+	InputTokens         int64          `json:"input_tokens"`
+	OutputTokens        int64          `json:"output_tokens"`
+}
+
+type Todo struct {
+	ID        string `json:"id"`
+	SessionID string `json:"session_id"`
+	Position  int64  `json:"position"`
+	Content   string `json:"content"`
+	Status    string `json:"status"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
 }
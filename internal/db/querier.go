@@ -6,27 +6,37 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
 type Querier interface {
+	CopyFile(ctx context.Context, arg CopyFileParams) (File, error)
+	CopyMessage(ctx context.Context, arg CopyMessageParams) (Message, error)
 	CreateFile(ctx context.Context, arg CreateFileParams) (File, error)
+	CreateJob(ctx context.Context, arg CreateJobParams) (Job, error)
 	CreateMessage(ctx context.Context, arg CreateMessageParams) (Message, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateTodo(ctx context.Context, arg CreateTodoParams) (Todo, error)
 	DeleteFile(ctx context.Context, id string) error
 	DeleteMessage(ctx context.Context, id string) error
 	DeleteSession(ctx context.Context, id string) error
 	DeleteSessionFiles(ctx context.Context, sessionID string) error
 	DeleteSessionMessages(ctx context.Context, sessionID string) error
+	DeleteSessionTodos(ctx context.Context, sessionID string) error
+	FinishJob(ctx context.Context, arg FinishJobParams) (Job, error)
 	GetFile(ctx context.Context, id string) (File, error)
 	GetFileByPathAndSession(ctx context.Context, arg GetFileByPathAndSessionParams) (File, error)
 	GetMessage(ctx context.Context, id string) (Message, error)
 	GetSessionByID(ctx context.Context, id string) (Session, error)
+	KillRunningJobs(ctx context.Context, finishedAt sql.NullInt64) (int64, error)
 	ListFilesByPath(ctx context.Context, path string) ([]File, error)
 	ListFilesBySession(ctx context.Context, sessionID string) ([]File, error)
+	ListJobsBySession(ctx context.Context, sessionID string) ([]Job, error)
 	ListLatestSessionFiles(ctx context.Context, sessionID string) ([]File, error)
 	ListMessagesBySession(ctx context.Context, sessionID string) ([]Message, error)
 	ListNewFiles(ctx context.Context) ([]File, error)
 	ListSessions(ctx context.Context) ([]Session, error)
+	ListTodosBySession(ctx context.Context, sessionID string) ([]Todo, error)
 	UpdateMessage(ctx context.Context, arg UpdateMessageParams) error
 	UpdateSession(ctx context.Context, arg UpdateSessionParams) (Session, error)
 }
@@ -20,6 +20,7 @@ INSERT INTO sessions (
     completion_tokens,
     cost,
     summary_message_id,
+    forked_from_message_id,
     updated_at,
     created_at
 ) VALUES (
@@ -31,19 +32,21 @@ INSERT INTO sessions (
     ?,
     ?,
     null,
+    ?,
     strftime('%s', 'now'),
     strftime('%s', 'now')
-) RETURNING id, parent_session_id, title, message_count, prompt_tokens, completion_tokens, cost, updated_at, created_at, summary_message_id
+) RETURNING id, parent_session_id, title, message_count, prompt_tokens, completion_tokens, cost, updated_at, created_at, summary_message_id, forked_from_message_id, kept_from_message_id, cache_read_tokens, cache_creation_tokens, input_tokens, output_tokens
 `
 
 type CreateSessionParams struct {
-	ID               string         `json:"id"`
-	ParentSessionID  sql.NullString `json:"parent_session_id"`
-	Title            string         `json:"title"`
-	MessageCount     int64          `json:"message_count"`
-	PromptTokens     int64          `json:"prompt_tokens"`
-	CompletionTokens int64          `json:"completion_tokens"`
-	Cost             float64        `json:"cost"`
+	ID                  string         `json:"id"`
+	ParentSessionID     sql.NullString `json:"parent_session_id"`
+	Title               string         `json:"title"`
+	MessageCount        int64          `json:"message_count"`
+	PromptTokens        int64          `json:"prompt_tokens"`
+	CompletionTokens    int64          `json:"completion_tokens"`
+	Cost                float64        `json:"cost"`
+	ForkedFromMessageID sql.NullString `json:"forked_from_message_id"`
 }
 
 func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
@@ -55,6 +58,7 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 		arg.PromptTokens,
 		arg.CompletionTokens,
 		arg.Cost,
+		arg.ForkedFromMessageID,
 	)
 	var i Session
 	err := row.Scan(
@@ -68,6 +72,12 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 		&i.UpdatedAt,
 		&i.CreatedAt,
 		&i.SummaryMessageID,
+		&i.ForkedFromMessageID,
+		&i.KeptFromMessageID,
+		&i.CacheReadTokens,
+		&i.CacheCreationTokens,
+		&i.InputTokens,
+		&i.OutputTokens,
 	)
 	return i, err
 }
@@ -83,7 +93,7 @@ func (q *Queries) DeleteSession(ctx context.Context, id string) error {
 }
 
 const getSessionByID = `-- name: GetSessionByID :one
-SELECT id, parent_session_id, title, message_count, prompt_tokens, completion_tokens, cost, updated_at, created_at, summary_message_id
+SELECT id, parent_session_id, title, message_count, prompt_tokens, completion_tokens, cost, updated_at, created_at, summary_message_id, forked_from_message_id, kept_from_message_id, cache_read_tokens, cache_creation_tokens, input_tokens, output_tokens
 FROM sessions
 WHERE id = ? LIMIT 1
 `
@@ -102,14 +112,20 @@ func (q *Queries) GetSessionByID(ctx context.Context, id string) (Session, error
 		&i.UpdatedAt,
 		&i.CreatedAt,
 		&i.SummaryMessageID,
+		&i.ForkedFromMessageID,
+		&i.KeptFromMessageID,
+		&i.CacheReadTokens,
+		&i.CacheCreationTokens,
+		&i.InputTokens,
+		&i.OutputTokens,
 	)
 	return i, err
 }
 
 const listSessions = `-- name: ListSessions :many
-SELECT id, parent_session_id, title, message_count, prompt_tokens, completion_tokens, cost, updated_at, created_at, summary_message_id
+SELECT id, parent_session_id, title, message_count, prompt_tokens, completion_tokens, cost, updated_at, created_at, summary_message_id, forked_from_message_id, kept_from_message_id, cache_read_tokens, cache_creation_tokens, input_tokens, output_tokens
 FROM sessions
-WHERE parent_session_id is NULL
+WHERE parent_session_id is NULL OR forked_from_message_id is NOT NULL
 ORDER BY created_at DESC
 `
 
@@ -133,6 +149,12 @@ func (q *Queries) ListSessions(ctx context.Context) ([]Session, error) {
 			&i.UpdatedAt,
 			&i.CreatedAt,
 			&i.SummaryMessageID,
+			&i.ForkedFromMessageID,
+			&i.KeptFromMessageID,
+			&i.CacheReadTokens,
+			&i.CacheCreationTokens,
+			&i.InputTokens,
+			&i.OutputTokens,
 		); err != nil {
 			return nil, err
 		}
@@ -153,19 +175,29 @@ SET
     title = ?,
     prompt_tokens = ?,
     completion_tokens = ?,
+    cache_read_tokens = ?,
+    cache_creation_tokens = ?,
+    input_tokens = ?,
+    output_tokens = ?,
     summary_message_id = ?,
+    kept_from_message_id = ?,
     cost = ?
 WHERE id = ?
-RETURNING id, parent_session_id, title, message_count, prompt_tokens, completion_tokens, cost, updated_at, created_at, summary_message_id
+RETURNING id, parent_session_id, title, message_count, prompt_tokens, completion_tokens, cost, updated_at, created_at, summary_message_id, forked_from_message_id, kept_from_message_id, cache_read_tokens, cache_creation_tokens, input_tokens, output_tokens
 `
 
 type UpdateSessionParams struct {
-	Title            string         `json:"title"`
-	PromptTokens     int64          `json:"prompt_tokens"`
-	CompletionTokens int64          `json:"completion_tokens"`
-	SummaryMessageID sql.NullString `json:"summary_message_id"`
-	Cost             float64        `json:"cost"`
-	ID               string         `json:"id"`
+	Title               string         `json:"title"`
+	PromptTokens        int64          `json:"prompt_tokens"`
+	CompletionTokens    int64          `json:"completion_tokens"`
+	CacheReadTokens     int64          `json:"cache_read_tokens"`
+	CacheCreationTokens int64          `json:"cache_creation_tokens"`
+	InputTokens         int64          `json:"input_tokens"`
+	OutputTokens        int64          `json:"output_tokens"`
+	SummaryMessageID    sql.NullString `json:"summary_message_id"`
+	KeptFromMessageID   sql.NullString `json:"kept_from_message_id"`
+	Cost                float64        `json:"cost"`
+	ID                  string         `json:"id"`
 }
 
 func (q *Queries) UpdateSession(ctx context.Context, arg UpdateSessionParams) (Session, error) {
@@ -173,7 +205,12 @@ func (q *Queries) UpdateSession(ctx context.Context, arg UpdateSessionParams) (S
 		arg.Title,
 		arg.PromptTokens,
 		arg.CompletionTokens,
+		arg.CacheReadTokens,
+		arg.CacheCreationTokens,
+		arg.InputTokens,
+		arg.OutputTokens,
 		arg.SummaryMessageID,
+		arg.KeptFromMessageID,
 		arg.Cost,
 		arg.ID,
 	)
@@ -189,6 +226,12 @@ func (q *Queries) UpdateSession(ctx context.Context, arg UpdateSessionParams) (S
 		&i.UpdatedAt,
 		&i.CreatedAt,
 		&i.SummaryMessageID,
+		&i.ForkedFromMessageID,
+		&i.KeptFromMessageID,
+		&i.CacheReadTokens,
+		&i.CacheCreationTokens,
+		&i.InputTokens,
+		&i.OutputTokens,
 	)
 	return i, err
 }
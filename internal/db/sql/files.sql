@@ -36,6 +36,20 @@ INSERT INTO files (
 )
 RETURNING *;
 
+-- name: CopyFile :one
+INSERT INTO files (
+    id,
+    session_id,
+    path,
+    content,
+    version,
+    created_at,
+    updated_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
 -- name: DeleteFile :exec
 DELETE FROM files
 WHERE id = ?;
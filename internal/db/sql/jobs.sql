@@ -0,0 +1,38 @@
+-- name: ListJobsBySession :many
+SELECT *
+FROM jobs
+WHERE session_id = ?
+ORDER BY started_at ASC;
+
+-- name: CreateJob :one
+INSERT INTO jobs (
+    id,
+    session_id,
+    shell_id,
+    command,
+    description,
+    working_dir,
+    stdout_path,
+    stderr_path,
+    status,
+    started_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, 'running', ?
+)
+RETURNING *;
+
+-- name: FinishJob :one
+UPDATE jobs
+SET
+    status = ?,
+    exit_code = ?,
+    finished_at = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: KillRunningJobs :execrows
+UPDATE jobs
+SET
+    status = 'killed',
+    finished_at = ?
+WHERE status = 'running';
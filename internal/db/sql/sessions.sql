@@ -8,6 +8,7 @@ INSERT INTO sessions (
     completion_tokens,
     cost,
     summary_message_id,
+    forked_from_message_id,
     updated_at,
     created_at
 ) VALUES (
@@ -19,6 +20,7 @@ INSERT INTO sessions (
     ?,
     ?,
     null,
+    ?,
     strftime('%s', 'now'),
     strftime('%s', 'now')
 ) RETURNING *;
@@ -31,7 +33,7 @@ WHERE id = ? LIMIT 1;
 -- name: ListSessions :many
 SELECT *
 FROM sessions
-WHERE parent_session_id is NULL
+WHERE parent_session_id is NULL OR forked_from_message_id is NOT NULL
 ORDER BY created_at DESC;
 
 -- name: UpdateSession :one
@@ -40,7 +42,12 @@ SET
     title = ?,
     prompt_tokens = ?,
     completion_tokens = ?,
+    cache_read_tokens = ?,
+    cache_creation_tokens = ?,
+    input_tokens = ?,
+    output_tokens = ?,
     summary_message_id = ?,
+    kept_from_message_id = ?,
     cost = ?
 WHERE id = ?
 RETURNING *;
@@ -0,0 +1,163 @@
+// Package eval replays recorded sessions against other models to compare
+// them on the same tasks.
+package eval
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/crush/internal/agent"
+	"github.com/charmbracelet/crush/internal/app"
+	"github.com/charmbracelet/crush/internal/diff"
+	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/message"
+	"github.com/charmbracelet/crush/internal/session"
+)
+
+// Prompt is a prompt sent by the user in a recorded session.
+type Prompt struct {
+	Text        string
+	Attachments []message.Attachment
+}
+
+// Prompts returns the prompts the user sent in the session, leaving out the
+// ones queued by the agent to resume after a summarization.
+func Prompts(msgs []message.Message) []Prompt {
+	var prompts []Prompt
+	for _, msg := range msgs {
+		if msg.Role != message.User {
+			continue
+		}
+		text := msg.Content().Text
+		if strings.TrimSpace(text) == "" || agent.IsContinuationPrompt(text) {
+			continue
+		}
+		prompt := Prompt{Text: text}
+		for _, bc := range msg.BinaryContent() {
+			prompt.Attachments = append(prompt.Attachments, message.Attachment{
+				FilePath: bc.Path,
+				MimeType: bc.MIMEType,
+				Content:  bc.Data,
+			})
+		}
+		prompts = append(prompts, prompt)
+	}
+	return prompts
+}
+
+// Stats are the figures compared between a session and its replay.
+type Stats struct {
+	SessionID           string         `json:"session_id"`
+	Model               string         `json:"model"`
+	Prompts             int            `json:"prompts"`
+	InputTokens         int64          `json:"input_tokens"`
+	CacheReadTokens     int64          `json:"cache_read_tokens"`
+	CacheCreationTokens int64          `json:"cache_creation_tokens"`
+	OutputTokens        int64          `json:"output_tokens"`
+	Cost                float64        `json:"cost"`
+	WallTime            time.Duration  `json:"wall_time"`
+	ToolCalls           int            `json:"tool_calls"`
+	ToolCallsByName     map[string]int `json:"tool_calls_by_name"`
+	FilesChanged        int            `json:"files_changed"`
+	Additions           int            `json:"additions"`
+	Removals            int            `json:"removals"`
+	Diff                string         `json:"diff"`
+}
+
+// SessionStats computes the stats of a session from its messages. The wall
+// time is the time spent answering each prompt, without the time the user
+// took to write the next one.
+func SessionStats(sess session.Session, msgs []message.Message) Stats {
+	stats := Stats{
+		SessionID:           sess.ID,
+		Prompts:             len(Prompts(msgs)),
+		InputTokens:         sess.InputTokens,
+		CacheReadTokens:     sess.CacheReadTokens,
+		CacheCreationTokens: sess.CacheCreationTokens,
+		OutputTokens:        sess.OutputTokens,
+		Cost:                sess.Cost,
+		ToolCallsByName:     make(map[string]int),
+	}
+
+	var turnStart, turnEnd int64
+	for _, msg := range msgs {
+		switch msg.Role {
+		case message.User:
+			stats.WallTime += time.Duration(turnEnd-turnStart) * time.Second
+			turnStart, turnEnd = msg.CreatedAt, msg.CreatedAt
+			continue
+		case message.Assistant:
+			if msg.Model != "" {
+				stats.Model = msg.Provider + "/" + msg.Model
+			}
+			for _, tc := range msg.ToolCalls() {
+				stats.ToolCalls++
+				stats.ToolCallsByName[tc.Name]++
+			}
+		}
+		turnEnd = max(turnEnd, msg.UpdatedAt)
+	}
+	stats.WallTime += time.Duration(turnEnd-turnStart) * time.Second
+	return stats
+}
+
+// HistoryDiff returns the diff of the files changed in a session, from their
+// first to their last recorded version. Changes made outside of the file
+// tools, such as by shell commands, are not recorded.
+func HistoryDiff(files []history.File) (string, int, int, int) {
+	first := make(map[string]history.File)
+	last := make(map[string]history.File)
+	for _, file := range files {
+		if f, ok := first[file.Path]; !ok || file.Version < f.Version {
+			first[file.Path] = file
+		}
+		if f, ok := last[file.Path]; !ok || file.Version > f.Version {
+			last[file.Path] = file
+		}
+	}
+
+	var b strings.Builder
+	var changed, additions, removals int
+	for _, path := range slices.Sorted(maps.Keys(first)) {
+		d, add, rem := diff.GenerateDiff(first[path].Content, last[path].Content, path)
+		if add == 0 && rem == 0 {
+			continue
+		}
+		changed++
+		additions += add
+		removals += rem
+		b.WriteString(d)
+	}
+	return b.String(), changed, additions, removals
+}
+
+// Replay sends the prompts one after the other in a new session, with every
+// permission request approved, and returns the session once done along with
+// the time it took.
+func Replay(ctx context.Context, a *app.App, title string, prompts []Prompt) (session.Session, time.Duration, error) {
+	if a.AgentCoordinator == nil {
+		return session.Session{}, 0, errors.New("no providers configured")
+	}
+	sess, err := a.Sessions.Create(ctx, "Replay: "+cmp.Or(title, "Untitled Session"))
+	if err != nil {
+		return session.Session{}, 0, fmt.Errorf("failed to create session: %w", err)
+	}
+	a.Permissions.AutoApproveSession(sess.ID)
+
+	start := time.Now()
+	for i, prompt := range prompts {
+		if _, err := a.AgentCoordinator.Run(ctx, sess.ID, prompt.Text, prompt.Attachments...); err != nil {
+			return sess, time.Since(start), fmt.Errorf("failed to replay prompt %d: %w", i+1, err)
+		}
+	}
+	elapsed := time.Since(start)
+
+	sess, err = a.Sessions.Get(ctx, sess.ID)
+	return sess, elapsed, err
+}
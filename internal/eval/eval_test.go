@@ -0,0 +1,264 @@
+package eval
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/agent"
+	"github.com/charmbracelet/crush/internal/app"
+	"github.com/charmbracelet/crush/internal/db"
+	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/message"
+	"github.com/charmbracelet/crush/internal/permission"
+	"github.com/charmbracelet/crush/internal/session"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrompts(t *testing.T) {
+	t.Parallel()
+
+	msgs := []message.Message{
+		userMessage("fix the tests", 0),
+		assistantMessage(1, 2, "view"),
+		userMessage("The conversation was summarized because it got too long. Continue with the task you were working on.", 3),
+		userMessage("   ", 4),
+		userMessage("now add docs", 5),
+	}
+	msgs[4].Parts = append(msgs[4].Parts, message.BinaryContent{Path: "a.png", MIMEType: "image/png", Data: []byte("png")})
+
+	prompts := Prompts(msgs)
+	require.Len(t, prompts, 2)
+	require.Equal(t, "fix the tests", prompts[0].Text)
+	require.Equal(t, "now add docs", prompts[1].Text)
+	require.Len(t, prompts[1].Attachments, 1)
+	require.Equal(t, "a.png", prompts[1].Attachments[0].FilePath)
+	require.True(t, agent.IsContinuationPrompt(msgs[2].Content().Text))
+}
+
+func TestSessionStats(t *testing.T) {
+	t.Parallel()
+
+	sess := session.Session{
+		ID:                  "s1",
+		PromptTokens:        100,
+		CompletionTokens:    20,
+		InputTokens:         300,
+		CacheReadTokens:     50,
+		CacheCreationTokens: 80,
+		OutputTokens:        60,
+		Cost:                0.5,
+	}
+	msgs := []message.Message{
+		userMessage("first", 0),
+		assistantMessage(1, 10, "view", "edit"),
+		// The user takes a minute before the next prompt.
+		userMessage("second", 70),
+		assistantMessage(71, 75, "edit"),
+	}
+
+	stats := SessionStats(sess, msgs)
+	require.Equal(t, "s1", stats.SessionID)
+	require.Equal(t, "anthropic/claude", stats.Model)
+	require.Equal(t, 2, stats.Prompts)
+	require.Equal(t, int64(300), stats.InputTokens)
+	require.Equal(t, int64(50), stats.CacheReadTokens)
+	require.Equal(t, int64(80), stats.CacheCreationTokens)
+	require.Equal(t, int64(60), stats.OutputTokens)
+	require.Equal(t, 15*time.Second, stats.WallTime)
+	require.Equal(t, 3, stats.ToolCalls)
+	require.Equal(t, map[string]int{"view": 1, "edit": 2}, stats.ToolCallsByName)
+}
+
+func TestHistoryDiff(t *testing.T) {
+	t.Parallel()
+
+	files := []history.File{
+		{Path: "/repo/b.go", Version: 0, Content: "package b\n"},
+		{Path: "/repo/a.go", Version: 1, Content: "package a\n\nfunc A() {}\n"},
+		{Path: "/repo/a.go", Version: 0, Content: "package a\n"},
+		{Path: "/repo/b.go", Version: 1, Content: "package b\n"},
+	}
+
+	d, changed, additions, removals := HistoryDiff(files)
+	require.Equal(t, 1, changed)
+	require.Equal(t, 2, additions)
+	require.Equal(t, 0, removals)
+	require.Contains(t, d, "+func A() {}")
+	require.NotContains(t, d, "b.go")
+}
+
+func TestWorktree(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	ctx := t.Context()
+
+	repo := t.TempDir()
+	gitRun(t, repo, time.Time{}, "init", "--quiet")
+	first := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "main.go"), []byte("package main\n"), 0o644))
+	gitRun(t, repo, first, "add", "main.go")
+	gitRun(t, repo, first, "commit", "--quiet", "-m", "first")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o644))
+	gitRun(t, repo, first.Add(time.Hour), "commit", "--quiet", "-am", "second")
+
+	commit, err := CommitAt(ctx, repo, first.Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, strings.TrimSpace(gitRun(t, repo, time.Time{}, "rev-parse", "HEAD~1")), commit)
+	_, err = CommitAt(ctx, repo, first.Add(-time.Minute))
+	require.Error(t, err)
+
+	worktree := filepath.Join(t.TempDir(), "repo")
+	require.NoError(t, AddWorktree(ctx, repo, worktree, commit))
+	t.Cleanup(func() { _ = RemoveWorktree(context.Background(), repo, worktree) })
+
+	content, err := os.ReadFile(filepath.Join(worktree, "main.go"))
+	require.NoError(t, err)
+	require.Equal(t, "package main\n", string(content))
+
+	require.NoError(t, os.WriteFile(filepath.Join(worktree, "main.go"), []byte("package app\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(worktree, "new.go"), []byte("package app\n\nvar x = 1\n"), 0o644))
+	d, changed, additions, removals, err := WorktreeDiff(ctx, worktree)
+	require.NoError(t, err)
+	require.Equal(t, 2, changed)
+	require.Equal(t, 4, additions)
+	require.Equal(t, 1, removals)
+	require.Contains(t, d, "+var x = 1")
+
+	require.NoError(t, RemoveWorktree(ctx, repo, worktree))
+	require.NoDirExists(t, worktree)
+}
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+
+	conn, err := db.Connect(t.Context(), t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	q := db.New(conn)
+	a := &app.App{
+		Sessions:    session.NewService(q, conn),
+		Messages:    message.NewService(q),
+		Permissions: permission.NewPermissionService(t.TempDir(), false, nil, permission.Rules{}),
+	}
+	coordinator := &fakeCoordinator{messages: a.Messages, permissions: a.Permissions}
+	a.AgentCoordinator = coordinator
+
+	sess, _, err := Replay(t.Context(), a, "Fix bug", []Prompt{{Text: "one"}, {Text: "two"}})
+	require.NoError(t, err)
+	require.Equal(t, "Replay: Fix bug", sess.Title)
+	require.Equal(t, []string{"one", "two"}, coordinator.prompts)
+	require.True(t, coordinator.granted)
+
+	msgs, err := a.Messages.List(t.Context(), sess.ID)
+	require.NoError(t, err)
+	require.Len(t, Prompts(msgs), 2)
+}
+
+func TestWriteText(t *testing.T) {
+	t.Parallel()
+
+	report := Report{
+		Commit: "abc123",
+		Original: Stats{
+			Model:           "anthropic/claude",
+			ToolCallsByName: map[string]int{"edit": 2},
+			Diff:            "--- a\n+++ b\n",
+		},
+		Replay: Stats{
+			Model:           "openai/gpt-5",
+			ToolCallsByName: map[string]int{"bash": 1},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteText(&buf))
+	out := buf.String()
+	require.Contains(t, out, "openai/gpt-5")
+	require.Contains(t, out, "  bash")
+	require.Contains(t, out, "  edit")
+	require.Contains(t, out, "Replayed at commit abc123")
+	require.Contains(t, out, "=== Replay diff ===\n(no changes)\n")
+}
+
+// fakeCoordinator records the prompts it is sent, and whether the
+// permission it asks for is granted.
+type fakeCoordinator struct {
+	agent.Coordinator
+	messages    message.Service
+	permissions permission.Service
+	prompts     []string
+	granted     bool
+}
+
+func (c *fakeCoordinator) Run(ctx context.Context, sessionID, prompt string, _ ...message.Attachment) (*fantasy.AgentResult, error) {
+	c.prompts = append(c.prompts, prompt)
+	if _, err := c.messages.Create(ctx, sessionID, message.CreateMessageParams{
+		Role:  message.User,
+		Parts: []message.ContentPart{message.TextContent{Text: prompt}},
+	}); err != nil {
+		return nil, err
+	}
+	c.granted = c.permissions.Request(permission.CreatePermissionRequest{
+		SessionID:  sessionID,
+		ToolCallID: "call-" + prompt,
+		ToolName:   "bash",
+		Action:     "execute",
+	})
+	_, err := c.messages.Create(ctx, sessionID, message.CreateMessageParams{
+		Role: message.Assistant,
+		Parts: []message.ContentPart{
+			message.TextContent{Text: "done"},
+			message.Finish{Reason: message.FinishReasonEndTurn},
+		},
+	})
+	return &fantasy.AgentResult{}, err
+}
+
+func userMessage(text string, at int64) message.Message {
+	return message.Message{
+		Role:      message.User,
+		Parts:     []message.ContentPart{message.TextContent{Text: text}},
+		CreatedAt: at,
+		UpdatedAt: at,
+	}
+}
+
+func assistantMessage(start, end int64, tools ...string) message.Message {
+	msg := message.Message{
+		Role:      message.Assistant,
+		Provider:  "anthropic",
+		Model:     "claude",
+		CreatedAt: start,
+		UpdatedAt: end,
+	}
+	for _, name := range tools {
+		msg.Parts = append(msg.Parts, message.ToolCall{ID: name, Name: name, Finished: true})
+	}
+	return msg
+}
+
+func gitRun(t *testing.T, dir string, at time.Time, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	if !at.IsZero() {
+		date := at.Format(time.RFC3339)
+		cmd.Env = append(cmd.Env, "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+	}
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return string(out)
+}
@@ -0,0 +1,93 @@
+package eval
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CommitAt returns the commit HEAD pointed to at the given time, the best
+// guess of the state of the repository when a session started.
+func CommitAt(ctx context.Context, dir string, t time.Time) (string, error) {
+	out, err := git(ctx, dir, "rev-list", "-1", "--before=@"+strconv.FormatInt(t.Unix(), 10), "HEAD")
+	if err != nil {
+		return "", err
+	}
+	commit := strings.TrimSpace(out)
+	if commit == "" {
+		return "", fmt.Errorf("no commit before %s", t.Format(time.RFC3339))
+	}
+	return commit, nil
+}
+
+// Prefix returns the path of dir relative to the root of its repository,
+// with a trailing slash, or an empty string at the root.
+func Prefix(ctx context.Context, dir string) (string, error) {
+	out, err := git(ctx, dir, "rev-parse", "--show-prefix")
+	return strings.TrimSpace(out), err
+}
+
+// AddWorktree checks out the commit of the repository of repoDir in a new
+// detached worktree at dir.
+func AddWorktree(ctx context.Context, repoDir, dir, commit string) error {
+	_, err := git(ctx, repoDir, "worktree", "add", "--detach", dir, commit)
+	return err
+}
+
+// RemoveWorktree removes a worktree added with AddWorktree, with its
+// changes.
+func RemoveWorktree(ctx context.Context, repoDir, dir string) error {
+	_, err := git(ctx, repoDir, "worktree", "remove", "--force", dir)
+	return err
+}
+
+// WorktreeDiff returns the diff of the changes in a worktree, new files
+// included, with the number of files changed, added and removed lines.
+func WorktreeDiff(ctx context.Context, dir string) (string, int, int, int, error) {
+	// Mark the new files so that they show in the diff.
+	if _, err := git(ctx, dir, "add", "--all", "--intent-to-add"); err != nil {
+		return "", 0, 0, 0, err
+	}
+	numstat, err := git(ctx, dir, "diff", "--numstat")
+	if err != nil {
+		return "", 0, 0, 0, err
+	}
+	var changed, additions, removals int
+	for line := range strings.Lines(numstat) {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		changed++
+		// Binary files have "-" counts.
+		add, _ := strconv.Atoi(fields[0])
+		rem, _ := strconv.Atoi(fields[1])
+		additions += add
+		removals += rem
+	}
+	d, err := git(ctx, dir, "diff")
+	return d, changed, additions, removals, err
+}
+
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %w: %s", args[0], err, msg)
+		}
+		var execErr *exec.Error
+		if errors.As(err, &execErr) {
+			return "", fmt.Errorf("git is required to replay sessions: %w", err)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,72 @@
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"text/tabwriter"
+	"time"
+)
+
+// Report compares a recorded session with its replay.
+type Report struct {
+	Commit   string `json:"commit"`
+	Original Stats  `json:"original"`
+	Replay   Stats  `json:"replay"`
+}
+
+// WriteJSON writes the report as JSON.
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteText writes the stats side by side, followed by both diffs.
+func (r Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	row := func(name string, original, replay any) {
+		fmt.Fprintf(tw, "%s\t%v\t%v\n", name, original, replay)
+	}
+	o, p := r.Original, r.Replay
+	row("", "Original", "Replay")
+	row("Session", o.SessionID, p.SessionID)
+	row("Model", o.Model, p.Model)
+	row("Prompts", o.Prompts, p.Prompts)
+	row("Input tokens", o.InputTokens, p.InputTokens)
+	row("Cache read tokens", o.CacheReadTokens, p.CacheReadTokens)
+	row("Cache write tokens", o.CacheCreationTokens, p.CacheCreationTokens)
+	row("Output tokens", o.OutputTokens, p.OutputTokens)
+	row("Cost", fmt.Sprintf("$%.2f", o.Cost), fmt.Sprintf("$%.2f", p.Cost))
+	row("Wall time", o.WallTime.Round(time.Second), p.WallTime.Round(time.Second))
+	row("Tool calls", o.ToolCalls, p.ToolCalls)
+	names := slices.Sorted(maps.Keys(o.ToolCallsByName))
+	for name := range p.ToolCallsByName {
+		if _, ok := o.ToolCallsByName[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		row("  "+name, o.ToolCallsByName[name], p.ToolCallsByName[name])
+	}
+	row("Files changed",
+		fmt.Sprintf("%d (+%d -%d)", o.FilesChanged, o.Additions, o.Removals),
+		fmt.Sprintf("%d (+%d -%d)", p.FilesChanged, p.Additions, p.Removals),
+	)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\nReplayed at commit %s\n\n=== Original diff (file tools only) ===\n%s\n=== Replay diff ===\n%s", r.Commit, orNone(o.Diff), orNone(p.Diff))
+	return err
+}
+
+func orNone(diff string) string {
+	if diff == "" {
+		return "(no changes)\n"
+	}
+	return diff
+}
@@ -0,0 +1,221 @@
+// Package hooks runs the user configured shell commands around prompts and
+// tool calls.
+//
+// Each hook receives a JSON encoded [Input] on stdin. A hook can influence
+// what happens next by printing a JSON encoded [Output] on stdout, or by
+// exiting with status 2, in which case the action is denied and stderr is
+// used as the reason. Any other non-zero exit status is logged and ignored.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/crush/internal/config"
+	"github.com/charmbracelet/crush/internal/shell"
+)
+
+// Event identifies the point at which a hook is run.
+type Event string
+
+const (
+	PreToolUse       Event = "pre_tool_use"
+	PostToolUse      Event = "post_tool_use"
+	UserPromptSubmit Event = "user_prompt_submit"
+	SessionStart     Event = "session_start"
+	Stop             Event = "stop"
+)
+
+// Decision is the verdict of a hook about the action it was run for.
+type Decision string
+
+const (
+	Approve Decision = "approve"
+	Deny    Decision = "deny"
+)
+
+// DenyExitCode is the exit status a hook uses to deny an action.
+const DenyExitCode = 2
+
+const defaultTimeout = 60 * time.Second
+
+// ErrDenied is returned when a hook denied a prompt.
+var ErrDenied = errors.New("denied by hook")
+
+// Input is the payload sent to hooks on stdin.
+type Input struct {
+	Event        Event           `json:"event"`
+	SessionID    string          `json:"session_id"`
+	WorkingDir   string          `json:"cwd"`
+	ToolName     string          `json:"tool_name,omitempty"`
+	ToolCallID   string          `json:"tool_call_id,omitempty"`
+	Params       json.RawMessage `json:"params,omitempty"`
+	Result       *ToolResult     `json:"result,omitempty"`
+	Prompt       string          `json:"prompt,omitempty"`
+	FinishReason string          `json:"finish_reason,omitempty"`
+}
+
+// ToolResult is the result of a tool call, sent to post_tool_use hooks.
+type ToolResult struct {
+	Content string `json:"content"`
+	IsError bool   `json:"is_error"`
+}
+
+// Output is what a hook can print on stdout.
+type Output struct {
+	// Decision approves or denies a tool call or a prompt.
+	Decision Decision `json:"decision,omitempty"`
+	// Reason explains a denial, it is sent back to the model.
+	Reason string `json:"reason,omitempty"`
+	// Params replaces the params of a tool call.
+	Params json.RawMessage `json:"params,omitempty"`
+	// Append is added to the tool result or to the prompt.
+	Append string `json:"append,omitempty"`
+}
+
+// Result is the combined outcome of all the hooks run for an event.
+type Result struct {
+	Approved bool
+	Denied   bool
+	Reason   string
+	Params   json.RawMessage
+	Append   string
+}
+
+type hook struct {
+	command string
+	matcher *regexp.Regexp
+	timeout time.Duration
+}
+
+// Runner runs the configured hooks. A nil Runner runs nothing.
+type Runner struct {
+	workingDir string
+	hooks      map[Event][]hook
+}
+
+// NewRunner creates a runner for the given hooks configuration.
+func NewRunner(cfg config.Hooks, workingDir string) *Runner {
+	r := &Runner{
+		workingDir: workingDir,
+		hooks:      make(map[Event][]hook),
+	}
+	for event, configs := range map[Event][]config.HookConfig{
+		PreToolUse:       cfg.PreToolUse,
+		PostToolUse:      cfg.PostToolUse,
+		UserPromptSubmit: cfg.UserPromptSubmit,
+		SessionStart:     cfg.SessionStart,
+		Stop:             cfg.Stop,
+	} {
+		for _, c := range configs {
+			if strings.TrimSpace(c.Command) == "" {
+				continue
+			}
+			h := hook{
+				command: c.Command,
+				timeout: defaultTimeout,
+			}
+			if c.Timeout > 0 {
+				h.timeout = time.Duration(c.Timeout) * time.Second
+			}
+			if c.Matcher != "" {
+				re, err := regexp.Compile("^(?:" + c.Matcher + ")$")
+				if err != nil {
+					slog.Warn("Ignoring hook with invalid matcher", "event", event, "matcher", c.Matcher, "error", err)
+					continue
+				}
+				h.matcher = re
+			}
+			r.hooks[event] = append(r.hooks[event], h)
+		}
+	}
+	return r
+}
+
+// Has reports whether any hook is configured for the event.
+func (r *Runner) Has(event Event) bool {
+	return r != nil && len(r.hooks[event]) > 0
+}
+
+// Run runs the hooks configured for the event of the input in order. Params
+// rewritten by a hook are passed on to the next one, and the first denial
+// stops the chain.
+func (r *Runner) Run(ctx context.Context, input Input) Result {
+	var result Result
+	if !r.Has(input.Event) {
+		return result
+	}
+	input.WorkingDir = r.workingDir
+
+	var appended []string
+	for _, h := range r.hooks[input.Event] {
+		if h.matcher != nil && !h.matcher.MatchString(input.ToolName) {
+			continue
+		}
+		out, err := r.run(ctx, h, input)
+		if err != nil {
+			slog.Warn("Hook failed", "event", input.Event, "command", h.command, "error", err)
+			continue
+		}
+		if out.Append != "" {
+			appended = append(appended, out.Append)
+		}
+		if len(out.Params) > 0 {
+			result.Params = out.Params
+			input.Params = out.Params
+		}
+		switch out.Decision {
+		case Deny:
+			result.Denied = true
+			result.Approved = false
+			result.Reason = out.Reason
+			result.Append = strings.Join(appended, "\n")
+			return result
+		case Approve:
+			result.Approved = true
+		}
+	}
+	result.Append = strings.Join(appended, "\n")
+	return result
+}
+
+func (r *Runner) run(ctx context.Context, h hook, input Input) (Output, error) {
+	var out Output
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return out, fmt.Errorf("failed to marshal hook input: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	sh := shell.NewShell(&shell.Options{WorkingDir: r.workingDir})
+	stdout, stderr, err := sh.ExecStdin(ctx, h.command, bytes.NewReader(payload))
+	if err != nil {
+		if shell.IsInterrupt(err) {
+			return out, err
+		}
+		if shell.ExitCode(err) == DenyExitCode {
+			out.Decision = Deny
+			out.Reason = strings.TrimSpace(stderr)
+			return out, nil
+		}
+		return out, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr))
+	}
+
+	stdout = strings.TrimSpace(stdout)
+	if !strings.HasPrefix(stdout, "{") {
+		return out, nil
+	}
+	if err := json.Unmarshal([]byte(stdout), &out); err != nil {
+		return out, fmt.Errorf("failed to parse hook output: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,127 @@
+package hooks
+
+import (
+	"encoding/json"
+	"runtime"
+	"testing"
+
+	"github.com/charmbracelet/crush/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunner(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands rely on POSIX utilities")
+	}
+
+	bashInput := Input{
+		Event:     PreToolUse,
+		SessionID: "session",
+		ToolName:  "bash",
+		Params:    json.RawMessage(`{"command":"rm -rf /"}`),
+	}
+
+	tests := []struct {
+		name   string
+		hooks  config.Hooks
+		input  Input
+		expect Result
+	}{
+		{
+			name:   "no hooks",
+			input:  bashInput,
+			expect: Result{},
+		},
+		{
+			name: "deny with exit code",
+			hooks: config.Hooks{PreToolUse: []config.HookConfig{
+				{Command: "echo 'not allowed' >&2; exit 2"},
+			}},
+			input:  bashInput,
+			expect: Result{Denied: true, Reason: "not allowed"},
+		},
+		{
+			name: "deny with output",
+			hooks: config.Hooks{PreToolUse: []config.HookConfig{
+				{Command: `echo '{"decision":"deny","reason":"generated file"}'`},
+				{Command: `echo '{"decision":"approve"}'`},
+			}},
+			input:  bashInput,
+			expect: Result{Denied: true, Reason: "generated file"},
+		},
+		{
+			name: "approve",
+			hooks: config.Hooks{PreToolUse: []config.HookConfig{
+				{Command: `echo '{"decision":"approve"}'`},
+			}},
+			input:  bashInput,
+			expect: Result{Approved: true},
+		},
+		{
+			name: "rewritten params are passed to the next hook",
+			hooks: config.Hooks{PreToolUse: []config.HookConfig{
+				{Command: `echo '{"params":{"command":"ls"}}'`},
+				{Command: `grep -q '"command":"ls"' && echo '{"append":"saw ls"}'`},
+			}},
+			input: bashInput,
+			expect: Result{
+				Params: json.RawMessage(`{"command":"ls"}`),
+				Append: "saw ls",
+			},
+		},
+		{
+			name: "matcher",
+			hooks: config.Hooks{PreToolUse: []config.HookConfig{
+				{Command: `echo '{"append":"edit"}'`, Matcher: "edit|write"},
+				{Command: `echo '{"append":"bash"}'`, Matcher: "bash"},
+				{Command: `echo '{"append":"partial"}'`, Matcher: "ba"},
+			}},
+			input:  bashInput,
+			expect: Result{Append: "bash"},
+		},
+		{
+			name: "payload on stdin",
+			hooks: config.Hooks{PostToolUse: []config.HookConfig{
+				{Command: `grep -q '"result":{"content":"done","is_error":false}' && echo '{"append":"formatted"}'`},
+			}},
+			input: Input{
+				Event:    PostToolUse,
+				ToolName: "edit",
+				Result:   &ToolResult{Content: "done"},
+			},
+			expect: Result{Append: "formatted"},
+		},
+		{
+			name: "failures and plain output are ignored",
+			hooks: config.Hooks{UserPromptSubmit: []config.HookConfig{
+				{Command: "exit 1"},
+				{Command: "echo 'just logging'"},
+				{Command: "echo '{not json'"},
+			}},
+			input:  Input{Event: UserPromptSubmit, Prompt: "hi"},
+			expect: Result{},
+		},
+		{
+			name: "timeout",
+			hooks: config.Hooks{Stop: []config.HookConfig{
+				{Command: `sleep 5; echo '{"decision":"deny"}'`, Timeout: 1},
+			}},
+			input:  Input{Event: Stop},
+			expect: Result{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			runner := NewRunner(tt.hooks, t.TempDir())
+			require.Equal(t, tt.expect, runner.Run(t.Context(), tt.input))
+		})
+	}
+}
+
+func TestNilRunner(t *testing.T) {
+	var runner *Runner
+	require.False(t, runner.Has(PreToolUse))
+	require.Equal(t, Result{}, runner.Run(t.Context(), Input{Event: PreToolUse}))
+}
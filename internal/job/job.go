@@ -0,0 +1,156 @@
+// Package job records the commands the agent runs in the background, such as
+// dev servers and watchers, so that they can be listed per session along with
+// their output logs, exit code and timings.
+package job
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/crush/internal/db"
+	"github.com/charmbracelet/crush/internal/pubsub"
+)
+
+type Status string
+
+const (
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	StatusKilled    Status = "killed"
+)
+
+// Valid reports whether the status is a known one.
+func (s Status) Valid() bool {
+	switch s {
+	case StatusRunning, StatusCompleted, StatusFailed, StatusKilled:
+		return true
+	}
+	return false
+}
+
+type Job struct {
+	ID        string
+	SessionID string
+	// ShellID is the ID the agent uses to refer to the job, it is only unique
+	// within a run of the application.
+	ShellID     string
+	Command     string
+	Description string
+	WorkingDir  string
+	StdoutPath  string
+	StderrPath  string
+	Status      Status
+	// ExitCode is only set once the job has finished.
+	ExitCode   int
+	StartedAt  int64 // Unix timestamp in milliseconds
+	FinishedAt int64 // Unix timestamp in milliseconds, 0 while running
+}
+
+// Duration returns how long the job ran, or has been running for.
+func (j Job) Duration() time.Duration {
+	end := j.FinishedAt
+	if end == 0 {
+		end = time.Now().UnixMilli()
+	}
+	return time.Duration(end-j.StartedAt) * time.Millisecond
+}
+
+type Service interface {
+	pubsub.Suscriber[Job]
+	Create(ctx context.Context, job Job) (Job, error)
+	// Finish records the end of the job with its status and exit code.
+	Finish(ctx context.Context, id string, status Status, exitCode int, finishedAt time.Time) (Job, error)
+	List(ctx context.Context, sessionID string) ([]Job, error)
+	// KillRunning records the jobs still running as killed, for the jobs of a
+	// previous run of the application that stopped without recording their
+	// end. It returns how many there were.
+	KillRunning(ctx context.Context, finishedAt time.Time) (int64, error)
+}
+
+type service struct {
+	*pubsub.Broker[Job]
+	db *sql.DB
+	q  *db.Queries
+}
+
+func NewService(q *db.Queries, db *sql.DB) Service {
+	return &service{
+		Broker: pubsub.NewBroker[Job](),
+		q:      q,
+		db:     db,
+	}
+}
+
+func (s *service) Create(ctx context.Context, job Job) (Job, error) {
+	dbJob, err := s.q.CreateJob(ctx, db.CreateJobParams{
+		ID:          job.ID,
+		SessionID:   job.SessionID,
+		ShellID:     job.ShellID,
+		Command:     job.Command,
+		Description: job.Description,
+		WorkingDir:  job.WorkingDir,
+		StdoutPath:  job.StdoutPath,
+		StderrPath:  job.StderrPath,
+		StartedAt:   job.StartedAt,
+	})
+	if err != nil {
+		return Job{}, err
+	}
+	created := fromDBItem(dbJob)
+	s.Publish(pubsub.CreatedEvent, created)
+	return created, nil
+}
+
+func (s *service) Finish(ctx context.Context, id string, status Status, exitCode int, finishedAt time.Time) (Job, error) {
+	if !status.Valid() || status == StatusRunning {
+		return Job{}, fmt.Errorf("invalid status %q for a finished job", status)
+	}
+	dbJob, err := s.q.FinishJob(ctx, db.FinishJobParams{
+		ID:         id,
+		Status:     string(status),
+		ExitCode:   sql.NullInt64{Int64: int64(exitCode), Valid: true},
+		FinishedAt: sql.NullInt64{Int64: finishedAt.UnixMilli(), Valid: true},
+	})
+	if err != nil {
+		return Job{}, err
+	}
+	finished := fromDBItem(dbJob)
+	s.Publish(pubsub.UpdatedEvent, finished)
+	return finished, nil
+}
+
+func (s *service) List(ctx context.Context, sessionID string) ([]Job, error) {
+	dbJobs, err := s.q.ListJobsBySession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]Job, len(dbJobs))
+	for i, dbJob := range dbJobs {
+		jobs[i] = fromDBItem(dbJob)
+	}
+	return jobs, nil
+}
+
+func (s *service) KillRunning(ctx context.Context, finishedAt time.Time) (int64, error) {
+	return s.q.KillRunningJobs(ctx, sql.NullInt64{Int64: finishedAt.UnixMilli(), Valid: true})
+}
+
+func fromDBItem(item db.Job) Job {
+	return Job{
+		ID:          item.ID,
+		SessionID:   item.SessionID,
+		ShellID:     item.ShellID,
+		Command:     item.Command,
+		Description: item.Description,
+		WorkingDir:  item.WorkingDir,
+		StdoutPath:  item.StdoutPath,
+		StderrPath:  item.StderrPath,
+		Status:      Status(item.Status),
+		ExitCode:    int(item.ExitCode.Int64),
+		StartedAt:   item.StartedAt,
+		FinishedAt:  item.FinishedAt.Int64,
+	}
+}
@@ -0,0 +1,98 @@
+package job_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/crush/internal/db"
+	"github.com/charmbracelet/crush/internal/job"
+	"github.com/charmbracelet/crush/internal/pubsub"
+	"github.com/charmbracelet/crush/internal/session"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobs(t *testing.T) {
+	conn, err := db.Connect(t.Context(), t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	q := db.New(conn)
+	sessions := session.NewService(q, conn)
+	jobs := job.NewService(q, conn)
+	events := jobs.Subscribe(t.Context())
+
+	sess, err := sessions.Create(t.Context(), "jobs")
+	require.NoError(t, err)
+
+	started := time.Now().Add(-time.Minute)
+	created, err := jobs.Create(t.Context(), job.Job{
+		ID:          "job-1",
+		SessionID:   sess.ID,
+		ShellID:     "001",
+		Command:     "npm run dev",
+		Description: "Start the dev server",
+		WorkingDir:  "/project",
+		StdoutPath:  "/data/jobs/job-1.stdout.log",
+		StderrPath:  "/data/jobs/job-1.stderr.log",
+		StartedAt:   started.UnixMilli(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, job.StatusRunning, created.Status)
+	require.Zero(t, created.FinishedAt)
+	require.GreaterOrEqual(t, created.Duration(), time.Minute)
+	event := <-events
+	require.Equal(t, pubsub.CreatedEvent, event.Type)
+	require.Equal(t, "job-1", event.Payload.ID)
+
+	_, err = jobs.Create(t.Context(), job.Job{
+		ID:         "job-2",
+		SessionID:  sess.ID,
+		ShellID:    "002",
+		Command:    "go test ./...",
+		WorkingDir: "/project",
+		StartedAt:  started.Add(time.Second).UnixMilli(),
+	})
+	require.NoError(t, err)
+	<-events
+
+	finished, err := jobs.Finish(t.Context(), "job-2", job.StatusFailed, 2, started.Add(11*time.Second))
+	require.NoError(t, err)
+	require.Equal(t, job.StatusFailed, finished.Status)
+	require.Equal(t, 2, finished.ExitCode)
+	require.Equal(t, 10*time.Second, finished.Duration())
+	event = <-events
+	require.Equal(t, pubsub.UpdatedEvent, event.Type)
+	require.Equal(t, finished, event.Payload)
+
+	list, err := jobs.List(t.Context(), sess.ID)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, created, list[0])
+	require.Equal(t, finished, list[1])
+
+	t.Run("invalid status", func(t *testing.T) {
+		_, err := jobs.Finish(t.Context(), "job-1", job.StatusRunning, 0, time.Now())
+		require.Error(t, err)
+		_, err = jobs.Finish(t.Context(), "job-1", "crashed", 0, time.Now())
+		require.Error(t, err)
+	})
+
+	t.Run("left running", func(t *testing.T) {
+		killed, err := jobs.KillRunning(t.Context(), started.Add(time.Hour))
+		require.NoError(t, err)
+		require.EqualValues(t, 1, killed)
+
+		list, err := jobs.List(t.Context(), sess.ID)
+		require.NoError(t, err)
+		require.Equal(t, job.StatusKilled, list[0].Status)
+		require.Equal(t, time.Hour, list[0].Duration())
+		require.Equal(t, finished, list[1])
+	})
+
+	t.Run("deleted with the session", func(t *testing.T) {
+		require.NoError(t, sessions.Delete(t.Context(), sess.ID))
+		list, err := jobs.List(t.Context(), sess.ID)
+		require.NoError(t, err)
+		require.Empty(t, list)
+	})
+}
@@ -181,6 +181,11 @@ func (c *Client) GetName() string {
 	return c.name
 }
 
+// GetConfig returns the configuration of the client.
+func (c *Client) GetConfig() config.LSPConfig {
+	return c.config
+}
+
 // SetDiagnosticsCallback sets the callback function for diagnostic changes
 func (c *Client) SetDiagnosticsCallback(callback func(name string, count int)) {
 	c.onDiagnosticsChanged = callback
@@ -292,12 +297,17 @@ func (c *Client) OpenFile(ctx context.Context, filepath string) error {
 
 // NotifyChange notifies the server about a file change.
 func (c *Client) NotifyChange(ctx context.Context, filepath string) error {
-	uri := string(protocol.URIFromPath(filepath))
-
 	content, err := os.ReadFile(filepath)
 	if err != nil {
 		return fmt.Errorf("error reading file: %w", err)
 	}
+	return c.NotifyContent(ctx, filepath, string(content))
+}
+
+// NotifyContent notifies the server that a file now has the given content,
+// which may not be on disk yet.
+func (c *Client) NotifyContent(ctx context.Context, filepath, content string) error {
+	uri := string(protocol.URIFromPath(filepath))
 
 	fileInfo, isOpen := c.openFiles.Get(uri)
 	if !isOpen {
@@ -311,7 +321,7 @@ func (c *Client) NotifyChange(ctx context.Context, filepath string) error {
 	changes := []protocol.TextDocumentContentChangeEvent{
 		{
 			Value: protocol.TextDocumentContentChangeWholeDocument{
-				Text: string(content),
+				Text: content,
 			},
 		},
 	}
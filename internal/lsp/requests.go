@@ -0,0 +1,291 @@
+package lsp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/x/powernap/pkg/lsp/protocol"
+)
+
+// Definition returns the locations where the symbol at the given position is
+// defined.
+func (c *Client) Definition(ctx context.Context, filepath string, line, character int) ([]protocol.Location, error) {
+	if err := c.OpenFileOnDemand(ctx, filepath); err != nil {
+		return nil, err
+	}
+	var result json.RawMessage
+	if err := c.call(ctx, "textDocument/definition", positionParams(filepath, line, character), &result); err != nil {
+		return nil, fmt.Errorf("definition request failed: %w", err)
+	}
+	return parseLocations(result)
+}
+
+// Hover returns the hover text of the symbol at the given position, usually
+// its signature and documentation, or an empty string if there's none.
+func (c *Client) Hover(ctx context.Context, filepath string, line, character int) (string, error) {
+	if err := c.OpenFileOnDemand(ctx, filepath); err != nil {
+		return "", err
+	}
+	params := positionParams(filepath, line, character)
+	hover, err := c.client.RequestHover(ctx, string(params.TextDocument.URI), params.Position)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(hover.Contents.Value), nil
+}
+
+// DocumentSymbols returns the symbols of a file. Servers that don't return a
+// hierarchy get a flat list.
+func (c *Client) DocumentSymbols(ctx context.Context, filepath string) ([]protocol.DocumentSymbol, error) {
+	if err := c.OpenFileOnDemand(ctx, filepath); err != nil {
+		return nil, err
+	}
+	params := protocol.DocumentSymbolParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: protocol.URIFromPath(filepath)},
+	}
+	var result json.RawMessage
+	if err := c.call(ctx, "textDocument/documentSymbol", params, &result); err != nil {
+		return nil, fmt.Errorf("document symbol request failed: %w", err)
+	}
+	return parseDocumentSymbols(result)
+}
+
+// WorkspaceSymbols returns the symbols of the workspace matching the query.
+func (c *Client) WorkspaceSymbols(ctx context.Context, query string) ([]protocol.SymbolInformation, error) {
+	var result json.RawMessage
+	if err := c.call(ctx, "workspace/symbol", protocol.WorkspaceSymbolParams{Query: query}, &result); err != nil {
+		return nil, fmt.Errorf("workspace symbol request failed: %w", err)
+	}
+	return parseWorkspaceSymbols(result)
+}
+
+// Rename returns the edit renaming the symbol at the given position, without
+// applying it.
+func (c *Client) Rename(ctx context.Context, filepath string, line, character int, newName string) (*protocol.WorkspaceEdit, error) {
+	if err := c.OpenFileOnDemand(ctx, filepath); err != nil {
+		return nil, err
+	}
+	pos := positionParams(filepath, line, character)
+	params := protocol.RenameParams{
+		TextDocument: pos.TextDocument,
+		Position:     pos.Position,
+		NewName:      newName,
+	}
+	var result *protocol.WorkspaceEdit
+	if err := c.call(ctx, "textDocument/rename", params, &result); err != nil {
+		return nil, fmt.Errorf("rename request failed: %w", err)
+	}
+	if result == nil {
+		return nil, fmt.Errorf("the server found nothing to rename")
+	}
+	return result, nil
+}
+
+// Format returns the edits formatting a file.
+func (c *Client) Format(ctx context.Context, filepath string, options protocol.FormattingOptions) ([]protocol.TextEdit, error) {
+	if err := c.OpenFileOnDemand(ctx, filepath); err != nil {
+		return nil, err
+	}
+	params := protocol.DocumentFormattingParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: protocol.URIFromPath(filepath)},
+		Options:      options,
+	}
+	var result []protocol.TextEdit
+	if err := c.call(ctx, "textDocument/formatting", params, &result); err != nil {
+		return nil, fmt.Errorf("formatting request failed: %w", err)
+	}
+	return result, nil
+}
+
+// CodeActions returns the code actions of the given kinds for a range of a
+// file, given the diagnostics of that range. Commands returned instead of
+// code actions are turned into code actions running them.
+func (c *Client) CodeActions(ctx context.Context, filepath string, rng protocol.Range, diagnostics []protocol.Diagnostic, kinds []string) ([]protocol.CodeAction, error) {
+	if err := c.OpenFileOnDemand(ctx, filepath); err != nil {
+		return nil, err
+	}
+	params := protocol.CodeActionParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: protocol.URIFromPath(filepath)},
+		Range:        rng,
+		Context: protocol.CodeActionContext{
+			Diagnostics: diagnostics,
+		},
+	}
+	if params.Context.Diagnostics == nil {
+		params.Context.Diagnostics = []protocol.Diagnostic{}
+	}
+	for _, kind := range kinds {
+		params.Context.Only = append(params.Context.Only, protocol.CodeActionKind(kind))
+	}
+	var result json.RawMessage
+	if err := c.call(ctx, "textDocument/codeAction", params, &result); err != nil {
+		return nil, fmt.Errorf("code action request failed: %w", err)
+	}
+	return parseCodeActions(result)
+}
+
+// ResolveCodeAction fills in the edit of a code action the server left out
+// of the list, to compute it only when it's chosen.
+func (c *Client) ResolveCodeAction(ctx context.Context, action protocol.CodeAction) (protocol.CodeAction, error) {
+	if action.Edit != nil || action.Data == nil {
+		return action, nil
+	}
+	var result protocol.CodeAction
+	if err := c.call(ctx, "codeAction/resolve", action, &result); err != nil {
+		return action, fmt.Errorf("code action resolve request failed: %w", err)
+	}
+	return result, nil
+}
+
+// positionParams returns the params of a request at the given position.
+func positionParams(filepath string, line, character int) protocol.TextDocumentPositionParams {
+	// NOTE: line and character should be 0-based.
+	// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#position
+	return protocol.TextDocumentPositionParams{
+		TextDocument: protocol.TextDocumentIdentifier{URI: protocol.URIFromPath(filepath)},
+		Position: protocol.Position{
+			Line:      uint32(max(line-1, 0)),
+			Character: uint32(max(character-1, 0)),
+		},
+	}
+}
+
+// call sends a request to the server and waits for its result.
+func (c *Client) call(ctx context.Context, method string, params, result any) error {
+	if !c.client.IsRunning() {
+		return fmt.Errorf("%s is not running", c.name)
+	}
+	return c.client.Call(ctx, method, params, result)
+}
+
+// parseLocations parses a result that is a location, a list of locations or
+// a list of location links.
+func parseLocations(raw json.RawMessage) ([]protocol.Location, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+	if raw[0] == '{' {
+		raw = append(append([]byte("["), raw...), ']')
+	}
+	var items []struct {
+		URI                  protocol.DocumentURI `json:"uri"`
+		Range                protocol.Range       `json:"range"`
+		TargetURI            protocol.DocumentURI `json:"targetUri"`
+		TargetSelectionRange protocol.Range       `json:"targetSelectionRange"`
+	}
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, fmt.Errorf("invalid locations: %w", err)
+	}
+	locations := make([]protocol.Location, 0, len(items))
+	for _, item := range items {
+		if item.TargetURI != "" {
+			locations = append(locations, protocol.Location{URI: item.TargetURI, Range: item.TargetSelectionRange})
+			continue
+		}
+		locations = append(locations, protocol.Location{URI: item.URI, Range: item.Range})
+	}
+	return locations, nil
+}
+
+// parseDocumentSymbols parses a list of document symbols or of symbol
+// information, turning the latter into document symbols.
+func parseDocumentSymbols(raw json.RawMessage) ([]protocol.DocumentSymbol, error) {
+	var probe []struct {
+		Location *protocol.Location `json:"location"`
+	}
+	if err := json.Unmarshal(raw, &probe); err != nil {
+		return nil, fmt.Errorf("invalid document symbols: %w", err)
+	}
+	if len(probe) == 0 || probe[0].Location == nil {
+		var symbols []protocol.DocumentSymbol
+		if err := json.Unmarshal(raw, &symbols); err != nil {
+			return nil, fmt.Errorf("invalid document symbols: %w", err)
+		}
+		return symbols, nil
+	}
+
+	var infos []protocol.SymbolInformation
+	if err := json.Unmarshal(raw, &infos); err != nil {
+		return nil, fmt.Errorf("invalid document symbols: %w", err)
+	}
+	symbols := make([]protocol.DocumentSymbol, 0, len(infos))
+	for _, info := range infos {
+		symbols = append(symbols, protocol.DocumentSymbol{
+			Name:           info.Name,
+			Kind:           info.Kind,
+			Tags:           info.Tags,
+			Deprecated:     info.Deprecated,
+			Range:          info.Location.Range,
+			SelectionRange: info.Location.Range,
+		})
+	}
+	return symbols, nil
+}
+
+// parseWorkspaceSymbols parses a list of symbol information or of workspace
+// symbols, whose location may only have a URI.
+func parseWorkspaceSymbols(raw json.RawMessage) ([]protocol.SymbolInformation, error) {
+	var items []struct {
+		Name          string               `json:"name"`
+		Kind          protocol.SymbolKind  `json:"kind"`
+		Tags          []protocol.SymbolTag `json:"tags,omitempty"`
+		ContainerName string               `json:"containerName"`
+		Location      struct {
+			URI   protocol.DocumentURI `json:"uri"`
+			Range protocol.Range       `json:"range"`
+		} `json:"location"`
+	}
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, fmt.Errorf("invalid workspace symbols: %w", err)
+	}
+	symbols := make([]protocol.SymbolInformation, 0, len(items))
+	for _, item := range items {
+		symbols = append(symbols, protocol.SymbolInformation{
+			Name:          item.Name,
+			Kind:          item.Kind,
+			Tags:          item.Tags,
+			ContainerName: item.ContainerName,
+			Location: protocol.Location{
+				URI:   item.Location.URI,
+				Range: item.Location.Range,
+			},
+		})
+	}
+	return symbols, nil
+}
+
+// parseCodeActions parses a list of code actions and commands.
+func parseCodeActions(raw json.RawMessage) ([]protocol.CodeAction, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, fmt.Errorf("invalid code actions: %w", err)
+	}
+	actions := make([]protocol.CodeAction, 0, len(items))
+	for _, item := range items {
+		// A command has a string command, a code action an object one.
+		var probe struct {
+			Command json.RawMessage `json:"command"`
+		}
+		if err := json.Unmarshal(item, &probe); err != nil {
+			return nil, fmt.Errorf("invalid code actions: %w", err)
+		}
+		if bytes.HasPrefix(bytes.TrimSpace(probe.Command), []byte(`"`)) {
+			var command protocol.Command
+			if err := json.Unmarshal(item, &command); err != nil {
+				return nil, fmt.Errorf("invalid code actions: %w", err)
+			}
+			actions = append(actions, protocol.CodeAction{Title: command.Title, Command: &command})
+			continue
+		}
+		var action protocol.CodeAction
+		if err := json.Unmarshal(item, &action); err != nil {
+			return nil, fmt.Errorf("invalid code actions: %w", err)
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
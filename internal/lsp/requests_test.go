@@ -0,0 +1,141 @@
+package lsp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/charmbracelet/x/powernap/pkg/lsp/protocol"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLocations(t *testing.T) {
+	t.Parallel()
+
+	rng := protocol.Range{
+		Start: protocol.Position{Line: 1, Character: 2},
+		End:   protocol.Position{Line: 1, Character: 5},
+	}
+	for name, tc := range map[string]struct {
+		raw  string
+		want []protocol.Location
+	}{
+		"null": {raw: "null"},
+		"location": {
+			raw:  `{"uri":"file:///a.go","range":{"start":{"line":1,"character":2},"end":{"line":1,"character":5}}}`,
+			want: []protocol.Location{{URI: "file:///a.go", Range: rng}},
+		},
+		"locations": {
+			raw: `[{"uri":"file:///a.go","range":{"start":{"line":1,"character":2},"end":{"line":1,"character":5}}},{"uri":"file:///b.go"}]`,
+			want: []protocol.Location{
+				{URI: "file:///a.go", Range: rng},
+				{URI: "file:///b.go"},
+			},
+		},
+		"links": {
+			raw:  `[{"targetUri":"file:///a.go","targetRange":{"start":{"line":0,"character":0},"end":{"line":3,"character":1}},"targetSelectionRange":{"start":{"line":1,"character":2},"end":{"line":1,"character":5}}}]`,
+			want: []protocol.Location{{URI: "file:///a.go", Range: rng}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseLocations(json.RawMessage(tc.raw))
+			require.NoError(t, err)
+			if tc.want == nil {
+				require.Empty(t, got)
+				return
+			}
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseDocumentSymbols(t *testing.T) {
+	t.Parallel()
+
+	t.Run("hierarchy", func(t *testing.T) {
+		t.Parallel()
+		symbols, err := parseDocumentSymbols(json.RawMessage(`[{"name":"Server","kind":23,"range":{"start":{"line":2,"character":0},"end":{"line":5,"character":1}},"selectionRange":{"start":{"line":2,"character":5},"end":{"line":2,"character":11}},"children":[{"name":"addr","detail":"string","kind":8,"range":{"start":{"line":3,"character":1},"end":{"line":3,"character":12}},"selectionRange":{"start":{"line":3,"character":1},"end":{"line":3,"character":5}}}]}]`))
+		require.NoError(t, err)
+		require.Len(t, symbols, 1)
+		require.Equal(t, "Server", symbols[0].Name)
+		require.Equal(t, protocol.Struct, symbols[0].Kind)
+		require.Len(t, symbols[0].Children, 1)
+		require.Equal(t, "string", symbols[0].Children[0].Detail)
+	})
+
+	t.Run("symbol information", func(t *testing.T) {
+		t.Parallel()
+		symbols, err := parseDocumentSymbols(json.RawMessage(`[{"name":"main","kind":12,"location":{"uri":"file:///a.go","range":{"start":{"line":4,"character":0},"end":{"line":6,"character":1}}}}]`))
+		require.NoError(t, err)
+		require.Len(t, symbols, 1)
+		require.Equal(t, "main", symbols[0].Name)
+		require.Equal(t, protocol.Function, symbols[0].Kind)
+		require.Equal(t, uint32(4), symbols[0].Range.Start.Line)
+		require.Equal(t, uint32(6), symbols[0].Range.End.Line)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		t.Parallel()
+		symbols, err := parseDocumentSymbols(json.RawMessage(`null`))
+		require.NoError(t, err)
+		require.Empty(t, symbols)
+	})
+}
+
+func TestParseWorkspaceSymbols(t *testing.T) {
+	t.Parallel()
+
+	symbols, err := parseWorkspaceSymbols(json.RawMessage(`[
+		{"name":"Run","kind":6,"containerName":"Server","location":{"uri":"file:///a.go","range":{"start":{"line":9,"character":0},"end":{"line":12,"character":1}}}},
+		{"name":"helper","kind":12,"location":{"uri":"file:///b.go"}}
+	]`))
+	require.NoError(t, err)
+	require.Equal(t, []protocol.SymbolInformation{
+		{
+			Name:          "Run",
+			Kind:          protocol.Method,
+			ContainerName: "Server",
+			Location: protocol.Location{
+				URI: "file:///a.go",
+				Range: protocol.Range{
+					Start: protocol.Position{Line: 9},
+					End:   protocol.Position{Line: 12, Character: 1},
+				},
+			},
+		},
+		{
+			Name:     "helper",
+			Kind:     protocol.Function,
+			Location: protocol.Location{URI: "file:///b.go"},
+		},
+	}, symbols)
+}
+
+func TestParseCodeActions(t *testing.T) {
+	t.Parallel()
+
+	actions, err := parseCodeActions(json.RawMessage(`[
+		{"title":"Add import \"fmt\"","kind":"quickfix","isPreferred":true,"edit":{"changes":{"file:///a.go":[{"range":{"start":{"line":2,"character":0},"end":{"line":2,"character":0}},"newText":"import \"fmt\"\n"}]}}},
+		{"title":"Fill struct","kind":"refactor.rewrite","data":{"id":1}},
+		{"title":"Run generate","command":"gopls.generate","arguments":["./..."]}
+	]`))
+	require.NoError(t, err)
+	require.Len(t, actions, 3)
+
+	require.Equal(t, "Add import \"fmt\"", actions[0].Title)
+	require.Equal(t, protocol.CodeActionKind("quickfix"), actions[0].Kind)
+	require.True(t, actions[0].IsPreferred)
+	require.NotNil(t, actions[0].Edit)
+	require.Len(t, actions[0].Edit.Changes["file:///a.go"], 1)
+
+	require.Nil(t, actions[1].Edit)
+	require.NotNil(t, actions[1].Data)
+
+	require.Equal(t, "Run generate", actions[2].Title)
+	require.NotNil(t, actions[2].Command)
+	require.Equal(t, "gopls.generate", actions[2].Command.Command)
+
+	actions, err = parseCodeActions(json.RawMessage("null"))
+	require.NoError(t, err)
+	require.Empty(t, actions)
+}
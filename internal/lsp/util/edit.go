@@ -1,7 +1,6 @@
 package util
 
 import (
-	"bytes"
 	"fmt"
 	"os"
 	"sort"
@@ -22,25 +21,39 @@ func applyTextEdits(uri protocol.DocumentURI, edits []protocol.TextEdit) error {
 		return fmt.Errorf("failed to read file: %w", err)
 	}
 
+	newContent, err := ApplyTextEditsToContent(string(content), edits)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, []byte(newContent), 0o644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+// ApplyTextEditsToContent returns the content with the edits applied.
+func ApplyTextEditsToContent(content string, edits []protocol.TextEdit) (string, error) {
 	// Detect line ending style
 	var lineEnding string
-	if bytes.Contains(content, []byte("\r\n")) {
+	if strings.Contains(content, "\r\n") {
 		lineEnding = "\r\n"
 	} else {
 		lineEnding = "\n"
 	}
 
 	// Track if file ends with a newline
-	endsWithNewline := len(content) > 0 && bytes.HasSuffix(content, []byte(lineEnding))
+	endsWithNewline := len(content) > 0 && strings.HasSuffix(content, lineEnding)
 
 	// Split into lines without the endings
-	lines := strings.Split(string(content), lineEnding)
+	lines := strings.Split(content, lineEnding)
 
 	// Check for overlapping edits
 	for i, edit1 := range edits {
 		for j := i + 1; j < len(edits); j++ {
 			if rangesOverlap(edit1.Range, edits[j].Range) {
-				return fmt.Errorf("overlapping edits detected between edit %d and %d", i, j)
+				return "", fmt.Errorf("overlapping edits detected between edit %d and %d", i, j)
 			}
 		}
 	}
@@ -59,7 +72,7 @@ func applyTextEdits(uri protocol.DocumentURI, edits []protocol.TextEdit) error {
 	for _, edit := range sortedEdits {
 		newLines, err := applyTextEdit(lines, edit)
 		if err != nil {
-			return fmt.Errorf("failed to apply edit: %w", err)
+			return "", fmt.Errorf("failed to apply edit: %w", err)
 		}
 		lines = newLines
 	}
@@ -78,11 +91,7 @@ func applyTextEdits(uri protocol.DocumentURI, edits []protocol.TextEdit) error {
 		newContent.WriteString(lineEnding)
 	}
 
-	if err := os.WriteFile(path, []byte(newContent.String()), 0o644); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
-	}
-
-	return nil
+	return newContent.String(), nil
 }
 
 func applyTextEdit(lines []string, edit protocol.TextEdit) ([]string, error) {
@@ -148,8 +157,8 @@ func applyTextEdit(lines []string, edit protocol.TextEdit) ([]string, error) {
 	return result, nil
 }
 
-// applyDocumentChange applies a DocumentChange (create/rename/delete operations)
-func applyDocumentChange(change protocol.DocumentChange) error {
+// ApplyDocumentChange applies a DocumentChange (create/rename/delete operations)
+func ApplyDocumentChange(change protocol.DocumentChange) error {
 	if change.CreateFile != nil {
 		path, err := change.CreateFile.URI.Path()
 		if err != nil {
@@ -239,7 +248,7 @@ func ApplyWorkspaceEdit(edit protocol.WorkspaceEdit) error {
 
 	// Handle DocumentChanges field
 	for _, change := range edit.DocumentChanges {
-		if err := applyDocumentChange(change); err != nil {
+		if err := ApplyDocumentChange(change); err != nil {
 			return fmt.Errorf("failed to apply document change: %w", err)
 		}
 	}
@@ -247,6 +256,29 @@ func ApplyWorkspaceEdit(edit protocol.WorkspaceEdit) error {
 	return nil
 }
 
+// TextEdits returns the text edits of a WorkspaceEdit by document, leaving
+// out the creation, renaming and deletion of files.
+func TextEdits(edit protocol.WorkspaceEdit) (map[protocol.DocumentURI][]protocol.TextEdit, error) {
+	edits := make(map[protocol.DocumentURI][]protocol.TextEdit)
+	for uri, textEdits := range edit.Changes {
+		edits[uri] = append(edits[uri], textEdits...)
+	}
+	for _, change := range edit.DocumentChanges {
+		if change.TextDocumentEdit == nil {
+			continue
+		}
+		uri := change.TextDocumentEdit.TextDocument.URI
+		for _, e := range change.TextDocumentEdit.Edits {
+			textEdit, err := e.AsTextEdit()
+			if err != nil {
+				return nil, fmt.Errorf("invalid edit type: %w", err)
+			}
+			edits[uri] = append(edits[uri], textEdit)
+		}
+	}
+	return edits, nil
+}
+
 func rangesOverlap(r1, r2 protocol.Range) bool {
 	if r1.Start.Line > r2.End.Line || r2.Start.Line > r1.End.Line {
 		return false
@@ -0,0 +1,192 @@
+// Package mcpserver exposes the file and LSP tools of Crush to other agents
+// as a Model Context Protocol server.
+package mcpserver
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/agent/tools"
+	"github.com/charmbracelet/crush/internal/app"
+	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/charmbracelet/crush/internal/permission"
+	"github.com/charmbracelet/crush/internal/pubsub"
+	"github.com/charmbracelet/crush/internal/version"
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// PermissionMode is how the permission requests of the tools are answered,
+// as there is nobody to ask.
+type PermissionMode string
+
+const (
+	// PermissionModeDeny denies the requests, only the tools and paths
+	// allowed in the configuration can be used.
+	PermissionModeDeny PermissionMode = "deny"
+	// PermissionModeApprove approves all the requests.
+	PermissionModeApprove PermissionMode = "approve"
+)
+
+// ParsePermissionMode parses a permission mode name.
+func ParsePermissionMode(s string) (PermissionMode, error) {
+	switch mode := PermissionMode(s); mode {
+	case PermissionModeDeny, PermissionModeApprove:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid permission mode %q, must be one of: deny, approve", s)
+	}
+}
+
+// Options configures the server.
+type Options struct {
+	WorkingDir  string
+	Permissions PermissionMode
+	// LSP adds the LSP tools, when language servers are configured.
+	LSP bool
+}
+
+type server struct {
+	app  *app.App
+	mode PermissionMode
+
+	// mu serializes the creation of sessions.
+	mu sync.Mutex
+	// sessions maps the MCP sessions to the Crush sessions their tool calls
+	// run in.
+	sessions *csync.Map[*mcp.ServerSession, string]
+	// owned holds the Crush sessions created for MCP sessions.
+	owned *csync.Map[string, struct{}]
+}
+
+// New creates the MCP server of the application. The tools are the ones the
+// coordinator gives to the agent, they share the LSP clients and the file
+// history of the application.
+func New(ctx context.Context, a *app.App, opts Options) *mcp.Server {
+	s := &server{
+		app:      a,
+		mode:     opts.Permissions,
+		sessions: csync.NewMap[*mcp.ServerSession, string](),
+		owned:    csync.NewMap[string, struct{}](),
+	}
+	if s.mode == PermissionModeDeny {
+		go s.denyPermissions(a.Permissions.Subscribe(ctx))
+	}
+
+	srv := mcp.NewServer(&mcp.Implementation{Name: "crush", Version: version.Version}, nil)
+	for _, tool := range Tools(a, opts) {
+		info := tool.Info()
+		srv.AddTool(&mcp.Tool{
+			Name:        info.Name,
+			Description: info.Description,
+			InputSchema: inputSchema(info),
+		}, s.handler(tool))
+	}
+	return srv
+}
+
+// Tools returns the tools served.
+func Tools(a *app.App, opts Options) []fantasy.AgentTool {
+	all := []fantasy.AgentTool{
+		tools.NewEditTool(a.LSPClients, a.Permissions, a.History, opts.WorkingDir),
+		tools.NewMultiEditTool(a.LSPClients, a.Permissions, a.History, opts.WorkingDir),
+		tools.NewGrepTool(a.Permissions, opts.WorkingDir),
+		tools.NewGlobTool(a.Permissions, opts.WorkingDir),
+		tools.NewViewTool(a.LSPClients, a.Permissions, opts.WorkingDir, true),
+	}
+	if opts.LSP {
+		all = append(all, tools.NewDiagnosticsTool(a.LSPClients), tools.NewReferencesTool(a.LSPClients))
+	}
+	return all
+}
+
+func inputSchema(info fantasy.ToolInfo) map[string]any {
+	schema := map[string]any{
+		"type":       "object",
+		"properties": info.Parameters,
+	}
+	if len(info.Required) > 0 {
+		schema["required"] = info.Required
+	}
+	return schema
+}
+
+func (s *server) handler(tool fantasy.AgentTool) mcp.ToolHandler {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		sessionID, err := s.session(ctx, req.Session)
+		if err != nil {
+			return nil, err
+		}
+		ctx = context.WithValue(ctx, tools.SessionIDContextKey, sessionID)
+
+		input := "{}"
+		if len(req.Params.Arguments) > 0 {
+			input = string(req.Params.Arguments)
+		}
+		resp, err := tool.Run(ctx, fantasy.ToolCall{
+			ID:    uuid.NewString(),
+			Name:  req.Params.Name,
+			Input: input,
+		})
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}},
+				IsError: true,
+			}, nil
+		}
+		content := []mcp.Content{&mcp.TextContent{Text: resp.Content}}
+		if media, _ := tools.TakeResponseMedia(resp.Metadata); media != nil {
+			if data, err := base64.StdEncoding.DecodeString(media.Data); err == nil {
+				content = append(content, &mcp.ImageContent{Data: data, MIMEType: media.MIMEType})
+			}
+		}
+		return &mcp.CallToolResult{
+			Content: content,
+			IsError: resp.IsError,
+		}, nil
+	}
+}
+
+// session returns the Crush session of an MCP session, creating it on its
+// first tool call. It is forgotten once the MCP session closes.
+func (s *server) session(ctx context.Context, ss *mcp.ServerSession) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id, ok := s.sessions.Get(ss); ok {
+		return id, nil
+	}
+	title := "MCP"
+	if params := ss.InitializeParams(); params != nil && params.ClientInfo != nil && params.ClientInfo.Name != "" {
+		title = "MCP: " + params.ClientInfo.Name
+	}
+	sess, err := s.app.Sessions.Create(ctx, title)
+	if err != nil {
+		return "", fmt.Errorf("failed to create session: %w", err)
+	}
+	s.owned.Set(sess.ID, struct{}{})
+	if s.mode == PermissionModeApprove {
+		s.app.Permissions.AutoApproveSession(sess.ID)
+	}
+	s.sessions.Set(ss, sess.ID)
+	go func() {
+		_ = ss.Wait()
+		s.sessions.Del(ss)
+		s.owned.Del(sess.ID)
+	}()
+	return sess.ID, nil
+}
+
+// denyPermissions denies the permission requests of the tool calls.
+func (s *server) denyPermissions(requests <-chan pubsub.Event[permission.PermissionRequest]) {
+	for event := range requests {
+		req := event.Payload
+		if _, ok := s.owned.Get(req.SessionID); ok {
+			slog.Info("Denied permission request", "tool", req.ToolName, "path", req.Path)
+			s.app.Permissions.Deny(req)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/crush/internal/agent/tools"
+	"github.com/charmbracelet/crush/internal/app"
+	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/charmbracelet/crush/internal/db"
+	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/lsp"
+	"github.com/charmbracelet/crush/internal/permission"
+	"github.com/charmbracelet/crush/internal/session"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func newApp(t *testing.T) (*app.App, string) {
+	t.Helper()
+	conn, err := db.Connect(t.Context(), t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	workingDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, "main.go"), []byte("package main\n"), 0o644))
+
+	q := db.New(conn)
+	a := &app.App{
+		Sessions:    session.NewService(q, conn),
+		History:     history.NewService(q, conn),
+		Permissions: permission.NewPermissionService(workingDir, false, nil, permission.Rules{}),
+		LSPClients:  csync.NewMap[string, *lsp.Client](),
+	}
+	return a, workingDir
+}
+
+func setup(t *testing.T, mode PermissionMode) (*mcp.ClientSession, string) {
+	t.Helper()
+	a, workingDir := newApp(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	server := New(ctx, a, Options{WorkingDir: workingDir, Permissions: mode})
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { serverSession.Close() })
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test"}, nil)
+	clientSession, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { clientSession.Close() })
+	return clientSession, workingDir
+}
+
+func call(t *testing.T, cs *mcp.ClientSession, name string, args map[string]any) (string, bool) {
+	t.Helper()
+	result, err := cs.CallTool(t.Context(), &mcp.CallToolParams{Name: name, Arguments: args})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	return result.Content[0].(*mcp.TextContent).Text, result.IsError
+}
+
+func TestListTools(t *testing.T) {
+	cs, _ := setup(t, PermissionModeDeny)
+
+	result, err := cs.ListTools(t.Context(), nil)
+	require.NoError(t, err)
+	var names []string
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+		schema, err := json.Marshal(tool.InputSchema)
+		require.NoError(t, err)
+		require.Contains(t, string(schema), `"type":"object"`)
+	}
+	require.ElementsMatch(t, []string{
+		tools.EditToolName,
+		tools.MultiEditToolName,
+		tools.GrepToolName,
+		tools.GlobToolName,
+		tools.ViewToolName,
+	}, names)
+}
+
+func TestPermissionModes(t *testing.T) {
+	for _, tt := range []struct {
+		mode    PermissionMode
+		content string
+	}{
+		{PermissionModeDeny, "package main\n"},
+		{PermissionModeApprove, "package crush\n"},
+	} {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			cs, workingDir := setup(t, tt.mode)
+			path := filepath.Join(workingDir, "main.go")
+
+			out, isError := call(t, cs, tools.ViewToolName, map[string]any{"file_path": path})
+			require.False(t, isError, out)
+			require.Contains(t, out, "package main")
+
+			out, isError = call(t, cs, tools.EditToolName, map[string]any{
+				"file_path":  path,
+				"old_string": "package main",
+				"new_string": "package crush",
+			})
+			require.Equal(t, tt.mode == PermissionModeDeny, isError, out)
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, tt.content, string(content))
+		})
+	}
+}
+
+func TestSessionsForgotten(t *testing.T) {
+	a, _ := newApp(t)
+	s := &server{
+		app:      a,
+		mode:     PermissionModeDeny,
+		sessions: csync.NewMap[*mcp.ServerSession, string](),
+		owned:    csync.NewMap[string, struct{}](),
+	}
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	ss, err := mcp.NewServer(&mcp.Implementation{Name: "crush"}, nil).Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+	cs, err := mcp.NewClient(&mcp.Implementation{Name: "test"}, nil).Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+
+	id, err := s.session(t.Context(), ss)
+	require.NoError(t, err)
+	again, err := s.session(t.Context(), ss)
+	require.NoError(t, err)
+	require.Equal(t, id, again)
+	_, ok := s.owned.Get(id)
+	require.True(t, ok)
+
+	require.NoError(t, cs.Close())
+	require.Eventually(t, func() bool {
+		return s.sessions.Len() == 0 && s.owned.Len() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestParsePermissionMode(t *testing.T) {
+	mode, err := ParsePermissionMode("approve")
+	require.NoError(t, err)
+	require.Equal(t, PermissionModeApprove, mode)
+
+	_, err = ParsePermissionMode("ask")
+	require.Error(t, err)
+}
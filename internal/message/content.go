@@ -32,11 +32,27 @@ const (
 	FinishReasonCanceled         FinishReason = "canceled"
 	FinishReasonError            FinishReason = "error"
 	FinishReasonPermissionDenied FinishReason = "permission_denied"
+	// The agent was stopped for repeating the same tool calls.
+	FinishReasonLoopDetected FinishReason = "loop_detected"
+	// The agent was stopped by one of the limits of the run.
+	FinishReasonMaxSteps     FinishReason = "max_steps"
+	FinishReasonMaxDuration  FinishReason = "max_duration"
+	FinishReasonMaxToolCalls FinishReason = "max_tool_calls"
 
 	// Should never happen
 	FinishReasonUnknown FinishReason = "unknown"
 )
 
+// Stopped reports whether the agent was stopped before it was done, for
+// looping or reaching one of the limits of the run.
+func (r FinishReason) Stopped() bool {
+	switch r {
+	case FinishReasonLoopDetected, FinishReasonMaxSteps, FinishReasonMaxDuration, FinishReasonMaxToolCalls:
+		return true
+	}
+	return false
+}
+
 type ContentPart interface {
 	isPart()
 }
@@ -134,6 +150,9 @@ type Message struct {
 	CreatedAt        int64
 	UpdatedAt        int64
 	IsSummaryMessage bool
+	// KeptFromMessageID is, for a summary message, the first message kept
+	// verbatim before it.
+	KeptFromMessageID string
 }
 
 func (m *Message) Content() TextContent {
@@ -491,12 +510,10 @@ func (m *Message) ToAIMessage() []fantasy.Message {
 				content = fantasy.ToolResultOutputContentError{
 					Error: errors.New(result.Content),
 				}
-			} else if result.Data != "" {
-				content = fantasy.ToolResultOutputContentMedia{
-					Data:      result.Data,
-					MediaType: result.MIMEType,
-				}
 			} else {
+				// The media in Data isn't part of the output, not every
+				// provider takes it in tool results. The agent attaches it
+				// separately.
 				content = fantasy.ToolResultOutputContentText{
 					Text: result.Content,
 				}
@@ -513,3 +530,20 @@ func (m *Message) ToAIMessage() []fantasy.Message {
 	}
 	return messages
 }
+
+// EstimateTokens gives a rough token count for the given messages using the
+// common four characters per token heuristic.
+func EstimateTokens(msgs ...Message) int64 {
+	var chars int
+	for _, msg := range msgs {
+		chars += len(msg.Content().Text)
+		chars += len(msg.ReasoningContent().Thinking)
+		for _, call := range msg.ToolCalls() {
+			chars += len(call.Name) + len(call.Input)
+		}
+		for _, result := range msg.ToolResults() {
+			chars += len(result.Content)
+		}
+	}
+	return int64(chars / 4)
+}
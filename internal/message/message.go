@@ -13,11 +13,12 @@ import (
 )
 
 type CreateMessageParams struct {
-	Role             MessageRole
-	Parts            []ContentPart
-	Model            string
-	Provider         string
-	IsSummaryMessage bool
+	Role              MessageRole
+	Parts             []ContentPart
+	Model             string
+	Provider          string
+	IsSummaryMessage  bool
+	KeptFromMessageID string
 }
 
 type Service interface {
@@ -77,6 +78,10 @@ func (s *service) Create(ctx context.Context, sessionID string, params CreateMes
 		Model:            sql.NullString{String: string(params.Model), Valid: true},
 		Provider:         sql.NullString{String: params.Provider, Valid: params.Provider != ""},
 		IsSummaryMessage: isSummary,
+		KeptFromMessageID: sql.NullString{
+			String: params.KeptFromMessageID,
+			Valid:  params.KeptFromMessageID != "",
+		},
 	})
 	if err != nil {
 		return Message{}, err
@@ -157,15 +162,16 @@ func (s *service) fromDBItem(item db.Message) (Message, error) {
 		return Message{}, err
 	}
 	return Message{
-		ID:               item.ID,
-		SessionID:        item.SessionID,
-		Role:             MessageRole(item.Role),
-		Parts:            parts,
-		Model:            item.Model.String,
-		Provider:         item.Provider.String,
-		CreatedAt:        item.CreatedAt,
-		UpdatedAt:        item.UpdatedAt,
-		IsSummaryMessage: item.IsSummaryMessage != 0,
+		ID:                item.ID,
+		SessionID:         item.SessionID,
+		Role:              MessageRole(item.Role),
+		Parts:             parts,
+		Model:             item.Model.String,
+		Provider:          item.Provider.String,
+		CreatedAt:         item.CreatedAt,
+		UpdatedAt:         item.UpdatedAt,
+		IsSummaryMessage:  item.IsSummaryMessage != 0,
+		KeptFromMessageID: item.KeptFromMessageID.String,
 	}, nil
 }
 
@@ -186,6 +192,12 @@ type partWrapper struct {
 	Data ContentPart `json:"data"`
 }
 
+// MarshalParts encodes the parts of a message the way they are stored, as a
+// list of objects holding the type and the data of each part.
+func MarshalParts(parts []ContentPart) ([]byte, error) {
+	return marshallParts(parts)
+}
+
 func marshallParts(parts []ContentPart) ([]byte, error) {
 	wrappedParts := make([]partWrapper, len(parts))
 
@@ -23,6 +23,13 @@ type CreatePermissionRequest struct {
 	Action      string `json:"action"`
 	Params      any    `json:"params"`
 	Path        string `json:"path"`
+	// Targets are what the permission rules for the tool are matched
+	// against: the commands of a bash call, the path of a file tool or the
+	// URL of a fetch.
+	Targets []string `json:"targets,omitempty"`
+	// ReadOnly is set for the calls that don't need a permission, which are
+	// only asked for when a rule says so.
+	ReadOnly bool `json:"read_only,omitempty"`
 }
 
 type PermissionNotification struct {
@@ -48,7 +55,10 @@ type Service interface {
 	Grant(permission PermissionRequest)
 	Deny(permission PermissionRequest)
 	Request(opts CreatePermissionRequest) bool
+	Denied(opts CreatePermissionRequest) (rule string, denied bool)
 	AutoApproveSession(sessionID string)
+	ApproveToolCall(toolCallID string)
+	RevokeToolCall(toolCallID string)
 	SetSkipRequests(skip bool)
 	SkipRequests() bool
 	SubscribeNotifications(ctx context.Context) <-chan pubsub.Event[PermissionNotification]
@@ -64,8 +74,10 @@ type permissionService struct {
 	pendingRequests       *csync.Map[string, chan bool]
 	autoApproveSessions   map[string]bool
 	autoApproveSessionsMu sync.RWMutex
+	approvedToolCalls     *csync.Map[string, bool]
 	skip                  bool
 	allowedTools          []string
+	rules                 parsedRules
 
 	// used to make sure we only process one request at a time
 	requestMu     sync.Mutex
@@ -122,8 +134,22 @@ func (s *permissionService) Deny(permission PermissionRequest) {
 	}
 }
 
+// Denied reports whether a deny rule applies to the call, returning the rule.
+func (s *permissionService) Denied(opts CreatePermissionRequest) (string, bool) {
+	decision, rule := s.rules.decide(opts, s.workingDir)
+	return rule, decision == DecisionDeny
+}
+
 func (s *permissionService) Request(opts CreatePermissionRequest) bool {
-	if s.skip {
+	decision, _ := s.rules.decide(opts, s.workingDir)
+	switch {
+	case decision == DecisionDeny:
+		return false
+	case s.skip:
+		return true
+	case decision == DecisionAllow:
+		return true
+	case opts.ReadOnly && decision != DecisionAsk:
 		return true
 	}
 
@@ -134,9 +160,10 @@ func (s *permissionService) Request(opts CreatePermissionRequest) bool {
 	s.requestMu.Lock()
 	defer s.requestMu.Unlock()
 
-	// Check if the tool/action combination is in the allowlist
+	// Check if the tool/action combination is in the allowlist, unless a rule
+	// says to ask anyway
 	commandKey := opts.ToolName + ":" + opts.Action
-	if slices.Contains(s.allowedTools, commandKey) || slices.Contains(s.allowedTools, opts.ToolName) {
+	if decision != DecisionAsk && (slices.Contains(s.allowedTools, commandKey) || slices.Contains(s.allowedTools, opts.ToolName)) {
 		return true
 	}
 
@@ -148,6 +175,10 @@ func (s *permissionService) Request(opts CreatePermissionRequest) bool {
 		return true
 	}
 
+	if _, ok := s.approvedToolCalls.Get(opts.ToolCallID); ok && opts.ToolCallID != "" {
+		return true
+	}
+
 	fileInfo, err := os.Stat(opts.Path)
 	dir := opts.Path
 	if err == nil {
@@ -174,16 +205,7 @@ func (s *permissionService) Request(opts CreatePermissionRequest) bool {
 
 	s.sessionPermissionsMu.RLock()
 	for _, p := range s.sessionPermissions {
-		if p.ToolName == permission.ToolName && p.Action == permission.Action && p.SessionID == permission.SessionID && p.Path == permission.Path {
-			s.sessionPermissionsMu.RUnlock()
-			return true
-		}
-	}
-	s.sessionPermissionsMu.RUnlock()
-
-	s.sessionPermissionsMu.RLock()
-	for _, p := range s.sessionPermissions {
-		if p.ToolName == permission.ToolName && p.Action == permission.Action && p.SessionID == permission.SessionID && p.Path == permission.Path {
+		if decision != DecisionAsk && p.ToolName == permission.ToolName && p.Action == permission.Action && p.SessionID == permission.SessionID && p.Path == permission.Path {
 			s.sessionPermissionsMu.RUnlock()
 			return true
 		}
@@ -208,6 +230,16 @@ func (s *permissionService) AutoApproveSession(sessionID string) {
 	s.autoApproveSessionsMu.Unlock()
 }
 
+// ApproveToolCall approves the permission requests made by the given tool call
+// until it is revoked.
+func (s *permissionService) ApproveToolCall(toolCallID string) {
+	s.approvedToolCalls.Set(toolCallID, true)
+}
+
+func (s *permissionService) RevokeToolCall(toolCallID string) {
+	s.approvedToolCalls.Del(toolCallID)
+}
+
 func (s *permissionService) SubscribeNotifications(ctx context.Context) <-chan pubsub.Event[PermissionNotification] {
 	return s.notificationBroker.Subscribe(ctx)
 }
@@ -220,7 +252,7 @@ func (s *permissionService) SkipRequests() bool {
 	return s.skip
 }
 
-func NewPermissionService(workingDir string, skip bool, allowedTools []string) Service {
+func NewPermissionService(workingDir string, skip bool, allowedTools []string, rules Rules) Service {
 	return &permissionService{
 		Broker:              pubsub.NewBroker[PermissionRequest](),
 		notificationBroker:  pubsub.NewBroker[PermissionNotification](),
@@ -229,6 +261,8 @@ func NewPermissionService(workingDir string, skip bool, allowedTools []string) S
 		autoApproveSessions: make(map[string]bool),
 		skip:                skip,
 		allowedTools:        allowedTools,
+		rules:               newParsedRules(rules),
 		pendingRequests:     csync.NewMap[string, chan bool](),
+		approvedToolCalls:   csync.NewMap[string, bool](),
 	}
 }
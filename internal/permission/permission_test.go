@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPermissionService_AllowedCommands(t *testing.T) {
@@ -54,7 +55,7 @@ func TestPermissionService_AllowedCommands(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			service := NewPermissionService("/tmp", false, tt.allowedTools)
+			service := NewPermissionService("/tmp", false, tt.allowedTools, Rules{})
 
 			// Create a channel to capture the permission request
 			// Since we're testing the allowlist logic, we need to simulate the request
@@ -79,7 +80,7 @@ func TestPermissionService_AllowedCommands(t *testing.T) {
 }
 
 func TestPermissionService_SkipMode(t *testing.T) {
-	service := NewPermissionService("/tmp", true, []string{})
+	service := NewPermissionService("/tmp", true, []string{}, Rules{})
 
 	result := service.Request(CreatePermissionRequest{
 		SessionID:   "test-session",
@@ -96,7 +97,7 @@ func TestPermissionService_SkipMode(t *testing.T) {
 
 func TestPermissionService_SequentialProperties(t *testing.T) {
 	t.Run("Sequential permission requests with persistent grants", func(t *testing.T) {
-		service := NewPermissionService("/tmp", false, []string{})
+		service := NewPermissionService("/tmp", false, []string{}, Rules{})
 
 		req1 := CreatePermissionRequest{
 			SessionID:   "session1",
@@ -140,7 +141,7 @@ func TestPermissionService_SequentialProperties(t *testing.T) {
 		assert.True(t, result2, "Second request should be auto-approved")
 	})
 	t.Run("Sequential requests with temporary grants", func(t *testing.T) {
-		service := NewPermissionService("/tmp", false, []string{})
+		service := NewPermissionService("/tmp", false, []string{}, Rules{})
 
 		req := CreatePermissionRequest{
 			SessionID:   "session2",
@@ -180,7 +181,7 @@ func TestPermissionService_SequentialProperties(t *testing.T) {
 		assert.False(t, result2, "Second request should be denied")
 	})
 	t.Run("Concurrent requests with different outcomes", func(t *testing.T) {
-		service := NewPermissionService("/tmp", false, []string{})
+		service := NewPermissionService("/tmp", false, []string{}, Rules{})
 
 		events := service.Subscribe(t.Context())
 
@@ -245,3 +246,62 @@ func TestPermissionService_SequentialProperties(t *testing.T) {
 		assert.True(t, result, "Repeated request should be auto-approved due to persistent permission")
 	})
 }
+
+func TestPermissionService_Rules(t *testing.T) {
+	t.Parallel()
+
+	rules := Rules{
+		Allow: []string{"bash(go test:*)"},
+		Ask:   []string{"view(**/secrets/**)", "bash(git push:*)"},
+		Deny:  []string{"view(**/.env)", "bash(rm:*)"},
+	}
+
+	t.Run("deny", func(t *testing.T) {
+		t.Parallel()
+		service := NewPermissionService("/project", false, []string{"bash"}, rules)
+		req := CreatePermissionRequest{SessionID: "s", ToolName: "bash", Action: "execute", Targets: []string{"rm -rf build"}}
+		rule, denied := service.Denied(req)
+		require.True(t, denied)
+		require.Equal(t, "bash(rm:*)", rule)
+		require.False(t, service.Request(req))
+
+		// Deny rules apply to read-only calls and in yolo mode too.
+		service.SetSkipRequests(true)
+		require.False(t, service.Request(CreatePermissionRequest{ToolName: "view", Targets: []string{"/project/.env"}, ReadOnly: true}))
+	})
+
+	t.Run("allow", func(t *testing.T) {
+		t.Parallel()
+		service := NewPermissionService("/project", false, nil, rules)
+		req := CreatePermissionRequest{SessionID: "s", ToolName: "bash", Action: "execute", Targets: []string{"go test ./..."}}
+		_, denied := service.Denied(req)
+		require.False(t, denied)
+		require.True(t, service.Request(req))
+	})
+
+	t.Run("read only", func(t *testing.T) {
+		t.Parallel()
+		service := NewPermissionService("/project", false, nil, rules)
+		require.True(t, service.Request(CreatePermissionRequest{ToolName: "view", Targets: []string{"/project/main.go"}, ReadOnly: true}))
+	})
+
+	for name, req := range map[string]CreatePermissionRequest{
+		"ask over read only":     {SessionID: "s", ToolName: "view", Action: "read", Targets: []string{"/project/secrets/key"}, ReadOnly: true},
+		"ask over allowed tools": {SessionID: "s", ToolName: "bash", Action: "execute", Targets: []string{"git push origin main"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			service := NewPermissionService("/project", false, []string{"bash"}, rules)
+			events := service.Subscribe(t.Context())
+
+			result := make(chan bool, 1)
+			go func() {
+				result <- service.Request(req)
+			}()
+			event := <-events
+			require.Equal(t, req.ToolName, event.Payload.ToolName)
+			service.Deny(event.Payload)
+			require.False(t, <-result)
+		})
+	}
+}
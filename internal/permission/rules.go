@@ -0,0 +1,204 @@
+package permission
+
+import (
+	"log/slog"
+	"net/url"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// Rules are the permission rules from the config, each one being a tool name
+// optionally followed by a specifier between parentheses which is matched
+// against the targets of the call:
+//
+//   - bash(go test:*) matches the commands starting with the given words.
+//   - edit(src/**) matches the paths with a glob, relative paths being
+//     relative to the working directory.
+//   - fetch(domain:*.internal.corp) matches the host of the URL with a glob.
+//   - bash and bash(*) match any call to the tool.
+//
+// The rules of edit and view apply to all the tools that write or read files.
+type Rules struct {
+	Allow []string
+	Ask   []string
+	Deny  []string
+}
+
+// Decision is what the rules decide for a call.
+type Decision int
+
+const (
+	// DecisionNone means no rule applies, the permission is asked as usual.
+	DecisionNone Decision = iota
+	DecisionAllow
+	DecisionAsk
+	DecisionDeny
+)
+
+type rule struct {
+	raw       string
+	tool      string
+	specifier string
+}
+
+// fileRuleTools are the tools the rules of edit and view also apply to, so
+// that edit(secrets/**) isn't worked around with write or apply_patch, nor
+// view(**/.env) with grep.
+var fileRuleTools = map[string][]string{
+	"edit": {"write", "multiedit", "apply_patch", "notebook_edit", "lsp_rename", "lsp_code_action"},
+	"view": {"grep", "glob", "ls"},
+}
+
+// appliesTo reports whether the rule is about the given tool.
+func (r rule) appliesTo(tool string) bool {
+	return r.tool == tool || slices.Contains(fileRuleTools[r.tool], tool)
+}
+
+func parseRules(raw []string) []rule {
+	rules := make([]rule, 0, len(raw))
+	for _, r := range raw {
+		parsed, ok := parseRule(r)
+		if !ok {
+			slog.Warn("Ignoring invalid permission rule", "rule", r)
+			continue
+		}
+		rules = append(rules, parsed)
+	}
+	return rules
+}
+
+func parseRule(raw string) (rule, bool) {
+	r := rule{raw: raw, tool: strings.TrimSpace(raw)}
+	if open := strings.IndexByte(r.tool, '('); open >= 0 {
+		if !strings.HasSuffix(r.tool, ")") {
+			return rule{}, false
+		}
+		r.specifier = strings.TrimSpace(r.tool[open+1 : len(r.tool)-1])
+		r.tool = strings.TrimSpace(r.tool[:open])
+	}
+	if r.specifier == "*" {
+		r.specifier = ""
+	}
+	return r, r.tool != "" && !strings.ContainsAny(r.tool, "()")
+}
+
+// matches reports whether the rule applies to the given call target.
+func (r rule) matches(target, workingDir string) bool {
+	if r.specifier == "" {
+		return true
+	}
+	if pattern, ok := strings.CutPrefix(r.specifier, "domain:"); ok {
+		u, err := url.Parse(target)
+		if err != nil || u.Hostname() == "" {
+			return false
+		}
+		matched, _ := path.Match(strings.ToLower(pattern), strings.ToLower(u.Hostname()))
+		return matched
+	}
+	if prefix, ok := strings.CutSuffix(r.specifier, ":*"); ok {
+		return target == prefix || strings.HasPrefix(target, prefix+" ")
+	}
+	for _, candidate := range ruleCandidates(target, workingDir) {
+		if matched, _ := doublestar.Match(r.specifier, candidate); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleCandidates returns the forms of the target the glob rules are matched
+// against: the target itself and, for a path in the working directory, the
+// path relative to it.
+func ruleCandidates(target, workingDir string) []string {
+	candidates := []string{filepath.ToSlash(target)}
+	if !filepath.IsAbs(target) || workingDir == "" {
+		return candidates
+	}
+	rel, err := filepath.Rel(workingDir, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return candidates
+	}
+	return append(candidates, filepath.ToSlash(rel))
+}
+
+// parsedRules are the rules ready to be matched.
+type parsedRules struct {
+	allow []rule
+	ask   []rule
+	deny  []rule
+}
+
+func newParsedRules(rules Rules) parsedRules {
+	return parsedRules{
+		allow: parseRules(rules.Allow),
+		ask:   parseRules(rules.Ask),
+		deny:  parseRules(rules.Deny),
+	}
+}
+
+// decide applies the rules to a call, deny rules taking precedence over ask
+// rules and ask rules over allow rules. Deny and ask rules apply when they
+// match any target of the call, while allow rules have to cover all of them,
+// so that allowing bash(go test:*) doesn't allow go test && rm -rf build.
+func (p parsedRules) decide(opts CreatePermissionRequest, workingDir string) (Decision, string) {
+	if r, ok := matchAny(p.deny, opts, workingDir); ok {
+		return DecisionDeny, r.raw
+	}
+	if r, ok := matchAny(p.ask, opts, workingDir); ok {
+		return DecisionAsk, r.raw
+	}
+	if matchAll(p.allow, opts, workingDir) {
+		return DecisionAllow, ""
+	}
+	return DecisionNone, ""
+}
+
+func matchAny(rules []rule, opts CreatePermissionRequest, workingDir string) (rule, bool) {
+	for _, r := range rules {
+		if !r.appliesTo(opts.ToolName) {
+			continue
+		}
+		if r.specifier == "" {
+			return r, true
+		}
+		for _, target := range opts.Targets {
+			if r.matches(target, workingDir) {
+				return r, true
+			}
+		}
+	}
+	return rule{}, false
+}
+
+func matchAll(rules []rule, opts CreatePermissionRequest, workingDir string) bool {
+	var toolRules []rule
+	for _, r := range rules {
+		if !r.appliesTo(opts.ToolName) {
+			continue
+		}
+		if r.specifier == "" {
+			return true
+		}
+		toolRules = append(toolRules, r)
+	}
+	if len(toolRules) == 0 || len(opts.Targets) == 0 {
+		return false
+	}
+	for _, target := range opts.Targets {
+		matched := false
+		for _, r := range toolRules {
+			if r.matches(target, workingDir) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package permission
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw       string
+		tool      string
+		specifier string
+		ok        bool
+	}{
+		{"bash", "bash", "", true},
+		{"bash(*)", "bash", "", true},
+		{"bash(go test:*)", "bash", "go test:*", true},
+		{" edit( src/** ) ", "edit", "src/**", true},
+		{"fetch(domain:*.internal.corp)", "fetch", "domain:*.internal.corp", true},
+		{"bash(go test", "", "", false},
+		{"(go test)", "", "", false},
+		{"", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			t.Parallel()
+			r, ok := parseRule(tt.raw)
+			require.Equal(t, tt.ok, ok)
+			if ok {
+				require.Equal(t, tt.tool, r.tool)
+				require.Equal(t, tt.specifier, r.specifier)
+			}
+		})
+	}
+}
+
+func TestRuleMatches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		rule   string
+		target string
+		want   bool
+	}{
+		{"bash(go test:*)", "go test ./...", true},
+		{"bash(go test:*)", "go test", true},
+		{"bash(go test:*)", "go testify", false},
+		{"bash(go test:*)", "go vet ./...", false},
+		{"bash(git status)", "git status", true},
+		{"bash(git status)", "git status --short", false},
+		{"edit(src/**)", "/project/src/main.go", true},
+		{"edit(src/**)", "/project/src/pkg/main.go", true},
+		{"edit(src/**)", "src/main.go", true},
+		{"edit(src/**)", "/project/main.go", false},
+		{"edit(src/**)", "/other/src/main.go", false},
+		{"view(**/.env)", "/project/.env", true},
+		{"view(**/.env)", "/project/config/.env", true},
+		{"view(**/.env)", "/home/user/.env", true},
+		{"view(**/.env)", "/project/.env.example", false},
+		{"view(/etc/**)", "/etc/passwd", true},
+		{"fetch(domain:*.internal.corp)", "https://wiki.internal.corp/page", true},
+		{"fetch(domain:*.internal.corp)", "https://WIKI.Internal.Corp:8080/", true},
+		{"fetch(domain:*.internal.corp)", "https://internal.corp/", false},
+		{"fetch(domain:*.internal.corp)", "https://example.com/?q=x.internal.corp", false},
+		{"fetch(domain:example.com)", "not a url", false},
+		{"bash", "anything", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule+" "+tt.target, func(t *testing.T) {
+			t.Parallel()
+			r, ok := parseRule(tt.rule)
+			require.True(t, ok)
+			require.Equal(t, tt.want, r.matches(tt.target, "/project"))
+		})
+	}
+}
+
+func TestRulesDecide(t *testing.T) {
+	t.Parallel()
+
+	rules := newParsedRules(Rules{
+		Allow: []string{"bash(go test:*)", "bash(git commit:*)", "edit(src/**)", "view", "fetch(domain:*.internal.corp)", "invalid("},
+		Ask:   []string{"bash(git commit --amend:*)", "edit(src/generated/**)"},
+		Deny:  []string{"view(**/.env)", "bash(rm:*)", "fetch(domain:secrets.internal.corp)"},
+	})
+
+	tests := []struct {
+		name     string
+		tool     string
+		targets  []string
+		decision Decision
+		rule     string
+	}{
+		{"allowed command", "bash", []string{"go test ./..."}, DecisionAllow, ""},
+		{"all commands allowed", "bash", []string{"go test ./...", "git commit -m fix"}, DecisionAllow, ""},
+		{"one command not allowed", "bash", []string{"go test ./...", "curl example.com"}, DecisionNone, ""},
+		{"denied command among allowed ones", "bash", []string{"go test ./...", "rm -rf build"}, DecisionDeny, "bash(rm:*)"},
+		{"ask over allow", "bash", []string{"git commit --amend -m fix"}, DecisionAsk, "bash(git commit --amend:*)"},
+		{"no rule", "bash", []string{"make"}, DecisionNone, ""},
+		{"no targets", "bash", nil, DecisionNone, ""},
+		{"allowed path", "edit", []string{"/project/src/main.go"}, DecisionAllow, ""},
+		{"ask path", "edit", []string{"/project/src/generated/api.go"}, DecisionAsk, "edit(src/generated/**)"},
+		{"edit rules cover write", "write", []string{"/project/src/main.go"}, DecisionAllow, ""},
+		{"edit rules cover apply_patch", "apply_patch", []string{"/project/src/generated/api.go"}, DecisionAsk, "edit(src/generated/**)"},
+		{"view rules cover grep", "grep", []string{"/project/config/.env"}, DecisionDeny, "view(**/.env)"},
+		{"rules are per tool", "download", []string{"/project/src/main.go"}, DecisionNone, ""},
+		{"deny over a whole tool allow", "view", []string{"/project/.env"}, DecisionDeny, "view(**/.env)"},
+		{"whole tool allow", "view", []string{"/project/main.go"}, DecisionAllow, ""},
+		{"allowed domain", "fetch", []string{"https://wiki.internal.corp/"}, DecisionAllow, ""},
+		{"denied domain", "fetch", []string{"https://secrets.internal.corp/"}, DecisionDeny, "fetch(domain:secrets.internal.corp)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			decision, rule := rules.decide(CreatePermissionRequest{ToolName: tt.tool, Targets: tt.targets}, "/project")
+			require.Equal(t, tt.decision, decision)
+			require.Equal(t, tt.rule, rule)
+		})
+	}
+}
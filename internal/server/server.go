@@ -0,0 +1,497 @@
+// Package server exposes the application services over HTTP so that Crush
+// can be driven without the TUI. Requests and responses are JSON, and live
+// updates are streamed with server-sent events.
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/crush/internal/agent"
+	"github.com/charmbracelet/crush/internal/app"
+	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/message"
+	"github.com/charmbracelet/crush/internal/permission"
+	"github.com/charmbracelet/crush/internal/pubsub"
+	"github.com/charmbracelet/crush/internal/session"
+)
+
+// keepAliveInterval is how often a comment is sent on idle event streams so
+// that proxies don't close them.
+const keepAliveInterval = 30 * time.Second
+
+// Server is the HTTP API over the application services.
+type Server struct {
+	app   *app.App
+	token string
+	mux   *http.ServeMux
+
+	// ctx outlives the requests, prompts run with it so that they are not
+	// cancelled when the client that posted them goes away.
+	ctx context.Context
+
+	// pending holds the permission requests waiting for an answer.
+	pending *csync.Map[string, permission.PermissionRequest]
+}
+
+// New creates the API server of the application. Requests must carry the
+// token as a bearer token, or as the token query parameter for clients that
+// can't set headers, such as browser event sources. An empty token disables
+// authentication.
+func New(ctx context.Context, a *app.App, token string) *Server {
+	s := &Server{
+		app:     a,
+		token:   token,
+		mux:     http.NewServeMux(),
+		ctx:     ctx,
+		pending: csync.NewMap[string, permission.PermissionRequest](),
+	}
+
+	s.mux.HandleFunc("GET /v1/sessions", s.listSessions)
+	s.mux.HandleFunc("POST /v1/sessions", s.createSession)
+	s.mux.HandleFunc("GET /v1/sessions/{id}", s.getSession)
+	s.mux.HandleFunc("DELETE /v1/sessions/{id}", s.deleteSession)
+	s.mux.HandleFunc("GET /v1/sessions/{id}/messages", s.listMessages)
+	s.mux.HandleFunc("POST /v1/sessions/{id}/prompt", s.prompt)
+	s.mux.HandleFunc("POST /v1/sessions/{id}/cancel", s.cancel)
+	s.mux.HandleFunc("POST /v1/sessions/{id}/summarize", s.summarize)
+	s.mux.HandleFunc("GET /v1/permissions", s.listPermissions)
+	s.mux.HandleFunc("POST /v1/permissions/{id}", s.answerPermission)
+	s.mux.HandleFunc("GET /v1/events", s.events)
+
+	go s.trackPermissions(ctx)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, errors.New("invalid or missing token"))
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		token = r.URL.Query().Get("token")
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+// trackPermissions keeps the list of pending permission requests up to date.
+func (s *Server) trackPermissions(ctx context.Context) {
+	requests := s.app.Permissions.Subscribe(ctx)
+	notifications := s.app.Permissions.SubscribeNotifications(ctx)
+	for {
+		select {
+		case event, ok := <-requests:
+			if !ok {
+				return
+			}
+			s.pending.Set(event.Payload.ID, event.Payload)
+		case event, ok := <-notifications:
+			if !ok {
+				return
+			}
+			n := event.Payload
+			if !n.Granted && !n.Denied {
+				continue
+			}
+			for id, req := range s.pending.Seq2() {
+				if req.ToolCallID == n.ToolCallID {
+					s.pending.Del(id)
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Server) listSessions(w http.ResponseWriter, r *http.Request) {
+	sessions, err := s.app.Sessions.List(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	out := make([]sessionJSON, 0, len(sessions))
+	for _, sess := range sessions {
+		out = append(out, newSessionJSON(sess))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+type createSessionRequest struct {
+	Title string `json:"title"`
+}
+
+func (s *Server) createSession(w http.ResponseWriter, r *http.Request) {
+	var req createSessionRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	sess, err := s.app.Sessions.Create(r.Context(), req.Title)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newSessionJSON(sess))
+}
+
+func (s *Server) getSession(w http.ResponseWriter, r *http.Request) {
+	sess, ok := s.session(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, newSessionJSON(sess))
+}
+
+func (s *Server) deleteSession(w http.ResponseWriter, r *http.Request) {
+	sess, ok := s.session(w, r)
+	if !ok {
+		return
+	}
+	if s.app.AgentCoordinator != nil && s.app.AgentCoordinator.IsSessionBusy(sess.ID) {
+		writeError(w, http.StatusConflict, agent.ErrSessionBusy)
+		return
+	}
+	if err := s.app.Sessions.Delete(r.Context(), sess.ID); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listMessages(w http.ResponseWriter, r *http.Request) {
+	sess, ok := s.session(w, r)
+	if !ok {
+		return
+	}
+	msgs, err := s.app.Messages.List(r.Context(), sess.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	out := make([]messageJSON, 0, len(msgs))
+	for _, msg := range msgs {
+		out = append(out, newMessageJSON(msg))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+type promptRequest struct {
+	Prompt string `json:"prompt"`
+	// Wait makes the request return once the agent is done, with the last
+	// message of the assistant. Otherwise the request returns right away
+	// and the progress is reported on the event stream.
+	Wait bool `json:"wait,omitempty"`
+}
+
+type promptResponse struct {
+	SessionID string       `json:"session_id"`
+	Queued    bool         `json:"queued"`
+	Message   *messageJSON `json:"message,omitempty"`
+}
+
+func (s *Server) prompt(w http.ResponseWriter, r *http.Request) {
+	coordinator, ok := s.coordinator(w)
+	if !ok {
+		return
+	}
+	sess, ok := s.session(w, r)
+	if !ok {
+		return
+	}
+	var req promptRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if strings.TrimSpace(req.Prompt) == "" {
+		writeError(w, http.StatusBadRequest, agent.ErrEmptyPrompt)
+		return
+	}
+
+	resp := promptResponse{
+		SessionID: sess.ID,
+		Queued:    coordinator.IsSessionBusy(sess.ID),
+	}
+	if !req.Wait || resp.Queued {
+		go func() {
+			if _, err := coordinator.Run(s.ctx, sess.ID, req.Prompt); err != nil && !isCancelled(err) {
+				slog.Error("Failed to run prompt", "session_id", sess.ID, "error", err)
+			}
+		}()
+		writeJSON(w, http.StatusAccepted, resp)
+		return
+	}
+
+	// A run stopped in a loop or by a limit still answers with its
+	// messages, the last one telling why.
+	if _, err := coordinator.Run(r.Context(), sess.ID, req.Prompt); err != nil && !agent.IsStoppedErr(err) {
+		status := http.StatusBadGateway
+		if isCancelled(err) {
+			status = http.StatusConflict
+		}
+		writeError(w, status, err)
+		return
+	}
+	msgs, err := s.app.Messages.List(r.Context(), sess.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if msgs[i].Role == message.Assistant {
+			msg := newMessageJSON(msgs[i])
+			resp.Message = &msg
+			break
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) cancel(w http.ResponseWriter, r *http.Request) {
+	coordinator, ok := s.coordinator(w)
+	if !ok {
+		return
+	}
+	sess, ok := s.session(w, r)
+	if !ok {
+		return
+	}
+	coordinator.Cancel(sess.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type summarizeRequest struct {
+	Instructions string `json:"instructions,omitempty"`
+}
+
+func (s *Server) summarize(w http.ResponseWriter, r *http.Request) {
+	coordinator, ok := s.coordinator(w)
+	if !ok {
+		return
+	}
+	sess, ok := s.session(w, r)
+	if !ok {
+		return
+	}
+	var req summarizeRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if err := coordinator.Summarize(r.Context(), sess.ID, req.Instructions); err != nil {
+		status := http.StatusBadGateway
+		if errors.Is(err, agent.ErrSessionBusy) {
+			status = http.StatusConflict
+		}
+		writeError(w, status, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listPermissions(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.URL.Query().Get("session_id")
+	out := []permission.PermissionRequest{}
+	for req := range s.pending.Seq() {
+		if sessionID == "" || req.SessionID == sessionID {
+			out = append(out, req)
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// Answers to permission requests.
+const (
+	permissionGrant           = "grant"
+	permissionGrantPersistent = "grant_persistent"
+	permissionDeny            = "deny"
+)
+
+type answerPermissionRequest struct {
+	Action string `json:"action"`
+}
+
+func (s *Server) answerPermission(w http.ResponseWriter, r *http.Request) {
+	var req answerPermissionRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	switch req.Action {
+	case permissionGrant, permissionGrantPersistent, permissionDeny:
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid action %q, must be one of: grant, grant_persistent, deny", req.Action))
+		return
+	}
+
+	pending, ok := s.pending.Take(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.New("permission request not found"))
+		return
+	}
+	switch req.Action {
+	case permissionGrant:
+		s.app.Permissions.Grant(pending)
+	case permissionGrantPersistent:
+		s.app.Permissions.GrantPersistent(pending)
+	case permissionDeny:
+		s.app.Permissions.Deny(pending)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// session returns the session of the request path, writing the error
+// response when it doesn't exist.
+func (s *Server) session(w http.ResponseWriter, r *http.Request) (session.Session, bool) {
+	sess, err := s.app.Sessions.Get(r.Context(), r.PathValue("id"))
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, errors.New("session not found"))
+		return session.Session{}, false
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return session.Session{}, false
+	}
+	return sess, true
+}
+
+// coordinator returns the agent coordinator, writing the error response
+// when no provider is configured.
+func (s *Server) coordinator(w http.ResponseWriter) (agent.Coordinator, bool) {
+	if s.app.AgentCoordinator == nil {
+		writeError(w, http.StatusServiceUnavailable, errors.New("no providers configured, run crush to set one up"))
+		return nil, false
+	}
+	return s.app.AgentCoordinator, true
+}
+
+type sseEvent struct {
+	Name string
+	Data any
+}
+
+// events streams the updates of the sessions, messages, files and
+// permissions. The session_id query parameter restricts the stream to the
+// updates of a single session.
+func (s *Server) events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming is not supported"))
+		return
+	}
+
+	ctx := r.Context()
+	sessionID := r.URL.Query().Get("session_id")
+	matches := func(id string) bool { return sessionID == "" || id == sessionID }
+
+	events := make(chan sseEvent)
+	forward(ctx, "session", s.app.Sessions.Subscribe(ctx), events, func(sess session.Session) (any, bool) {
+		// The sub-agent sessions come along with their parent, but not the
+		// forks.
+		return newSessionJSON(sess), matches(sess.ID) || (!sess.IsFork() && matches(sess.ParentSessionID))
+	})
+	forward(ctx, "message", s.app.Messages.Subscribe(ctx), events, func(msg message.Message) (any, bool) {
+		return newMessageJSON(msg), matches(msg.SessionID)
+	})
+	forward(ctx, "file", s.app.History.Subscribe(ctx), events, func(file history.File) (any, bool) {
+		return newFileJSON(file), matches(file.SessionID)
+	})
+	forward(ctx, "permission", s.app.Permissions.Subscribe(ctx), events, func(req permission.PermissionRequest) (any, bool) {
+		return req, matches(req.SessionID)
+	})
+	forward(ctx, "permission_notification", s.app.Permissions.SubscribeNotifications(ctx), events, func(n permission.PermissionNotification) (any, bool) {
+		return n, true
+	})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case event := <-events:
+			data, err := json.Marshal(event.Data)
+			if err != nil {
+				slog.Error("Failed to encode event", "event", event.Name, "error", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Name, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// forward sends the events of a subscription that pass the filter to out,
+// named after the topic and the event type, e.g. "message.updated".
+func forward[T any](ctx context.Context, topic string, ch <-chan pubsub.Event[T], out chan<- sseEvent, convert func(T) (any, bool)) {
+	go func() {
+		for event := range ch {
+			data, ok := convert(event.Payload)
+			if !ok {
+				continue
+			}
+			select {
+			case out <- sseEvent{Name: topic + "." + string(event.Type), Data: data}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func isCancelled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, agent.ErrRequestCancelled)
+}
+
+// readJSON decodes the request body into v, an empty body leaving v as is.
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("Failed to write response", "error", err)
+	}
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
@@ -0,0 +1,231 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"charm.land/fantasy"
+	"github.com/charmbracelet/crush/internal/agent"
+	"github.com/charmbracelet/crush/internal/app"
+	"github.com/charmbracelet/crush/internal/db"
+	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/message"
+	"github.com/charmbracelet/crush/internal/permission"
+	"github.com/charmbracelet/crush/internal/session"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "secret"
+
+// fakeCoordinator answers prompts without a provider. The "needs
+// permission" prompt asks for a permission before answering.
+type fakeCoordinator struct {
+	agent.Coordinator
+	messages    message.Service
+	permissions permission.Service
+	workingDir  string
+}
+
+func (c *fakeCoordinator) Run(ctx context.Context, sessionID, prompt string, _ ...message.Attachment) (*fantasy.AgentResult, error) {
+	if _, err := c.messages.Create(ctx, sessionID, message.CreateMessageParams{
+		Role:  message.User,
+		Parts: []message.ContentPart{message.TextContent{Text: prompt}},
+	}); err != nil {
+		return nil, err
+	}
+	reply := "echo: " + prompt
+	if prompt == "needs permission" {
+		granted := c.permissions.Request(permission.CreatePermissionRequest{
+			SessionID:  sessionID,
+			ToolCallID: "call-1",
+			ToolName:   "bash",
+			Action:     "execute",
+			Path:       c.workingDir,
+		})
+		reply = fmt.Sprintf("granted: %v", granted)
+	}
+	_, err := c.messages.Create(ctx, sessionID, message.CreateMessageParams{
+		Role: message.Assistant,
+		Parts: []message.ContentPart{
+			message.TextContent{Text: reply},
+			message.Finish{Reason: message.FinishReasonEndTurn},
+		},
+	})
+	return &fantasy.AgentResult{}, err
+}
+
+func (c *fakeCoordinator) IsSessionBusy(string) bool { return false }
+
+func setupServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	conn, err := db.Connect(t.Context(), t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	q := db.New(conn)
+	workingDir := t.TempDir()
+	a := &app.App{
+		Sessions:    session.NewService(q, conn),
+		Messages:    message.NewService(q),
+		History:     history.NewService(q, conn),
+		Permissions: permission.NewPermissionService(workingDir, false, nil, permission.Rules{}),
+	}
+	a.AgentCoordinator = &fakeCoordinator{
+		messages:    a.Messages,
+		permissions: a.Permissions,
+		workingDir:  workingDir,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	srv := httptest.NewServer(New(ctx, a, testToken))
+	t.Cleanup(func() {
+		cancel()
+		srv.Close()
+	})
+	return srv
+}
+
+func do(t *testing.T, srv *httptest.Server, method, path, body string, out any) int {
+	t.Helper()
+	req, err := http.NewRequestWithContext(t.Context(), method, srv.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if out != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+func TestAuth(t *testing.T) {
+	srv := setupServer(t)
+
+	resp, err := srv.Client().Get(srv.URL + "/v1/sessions")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, err = srv.Client().Get(srv.URL + "/v1/sessions?token=wrong")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, err = srv.Client().Get(srv.URL + "/v1/sessions?token=" + testToken)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestSessions(t *testing.T) {
+	srv := setupServer(t)
+
+	var sess sessionJSON
+	require.Equal(t, http.StatusCreated, do(t, srv, http.MethodPost, "/v1/sessions", `{"title":"api"}`, &sess))
+	require.Equal(t, "api", sess.Title)
+
+	var sessions []sessionJSON
+	require.Equal(t, http.StatusOK, do(t, srv, http.MethodGet, "/v1/sessions", "", &sessions))
+	require.Len(t, sessions, 1)
+
+	var resp promptResponse
+	require.Equal(t, http.StatusOK, do(t, srv, http.MethodPost, "/v1/sessions/"+sess.ID+"/prompt", `{"prompt":"hello","wait":true}`, &resp))
+	require.NotNil(t, resp.Message)
+	require.Contains(t, string(resp.Message.Parts), "echo: hello")
+
+	var msgs []messageJSON
+	require.Equal(t, http.StatusOK, do(t, srv, http.MethodGet, "/v1/sessions/"+sess.ID+"/messages", "", &msgs))
+	require.Len(t, msgs, 2)
+	require.Equal(t, message.User, msgs[0].Role)
+
+	require.Equal(t, http.StatusBadRequest, do(t, srv, http.MethodPost, "/v1/sessions/"+sess.ID+"/prompt", `{"prompt":" "}`, nil))
+	require.Equal(t, http.StatusNoContent, do(t, srv, http.MethodDelete, "/v1/sessions/"+sess.ID, "", nil))
+	require.Equal(t, http.StatusNotFound, do(t, srv, http.MethodGet, "/v1/sessions/"+sess.ID, "", nil))
+}
+
+func TestEventsAndPermissions(t *testing.T) {
+	srv := setupServer(t)
+
+	var sess sessionJSON
+	require.Equal(t, http.StatusCreated, do(t, srv, http.MethodPost, "/v1/sessions", `{}`, &sess))
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL+"/v1/events?session_id="+sess.ID, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	events, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer events.Body.Close()
+	require.Equal(t, "text/event-stream", events.Header.Get("Content-Type"))
+	next := sseReader(t, events.Body)
+
+	require.Equal(t, http.StatusAccepted, do(t, srv, http.MethodPost, "/v1/sessions/"+sess.ID+"/prompt", `{"prompt":"needs permission"}`, nil))
+
+	// Events of different topics are not ordered between each other.
+	var requested permission.PermissionRequest
+	require.NoError(t, json.Unmarshal(next("permission.created"), &requested))
+	require.Equal(t, "call-1", requested.ToolCallID)
+
+	require.Eventually(t, func() bool {
+		var pending []permission.PermissionRequest
+		do(t, srv, http.MethodGet, "/v1/permissions?session_id="+sess.ID, "", &pending)
+		return len(pending) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Equal(t, http.StatusBadRequest, do(t, srv, http.MethodPost, "/v1/permissions/"+requested.ID, `{"action":"maybe"}`, nil))
+	require.Equal(t, http.StatusNoContent, do(t, srv, http.MethodPost, "/v1/permissions/"+requested.ID, `{"action":"grant"}`, nil))
+	require.Equal(t, http.StatusNotFound, do(t, srv, http.MethodPost, "/v1/permissions/"+requested.ID, `{"action":"grant"}`, nil))
+
+	var reply messageJSON
+	for reply.Role != message.Assistant {
+		require.NoError(t, json.Unmarshal(next("message.created"), &reply))
+	}
+	require.Contains(t, string(reply.Parts), "granted: true")
+}
+
+// sseReader returns a function reading the stream until the next event with
+// the given name, and returning its data.
+func sseReader(t *testing.T, r io.Reader) func(name string) []byte {
+	type event struct {
+		name string
+		data []byte
+	}
+	ch := make(chan event)
+	go func() {
+		defer close(ch)
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(nil, 1024*1024)
+		var name string
+		for scanner.Scan() {
+			line := scanner.Text()
+			if v, ok := strings.CutPrefix(line, "event: "); ok {
+				name = v
+			} else if v, ok := strings.CutPrefix(line, "data: "); ok {
+				ch <- event{name: name, data: []byte(v)}
+			}
+		}
+	}()
+	return func(name string) []byte {
+		t.Helper()
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case e, ok := <-ch:
+				require.True(t, ok, "event stream closed before %s", name)
+				if e.name == name {
+					return e.data
+				}
+			case <-timeout:
+				t.Fatalf("timed out waiting for %s", name)
+			}
+		}
+	}
+}
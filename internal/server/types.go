@@ -0,0 +1,87 @@
+package server
+
+import (
+	"encoding/json"
+
+	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/message"
+	"github.com/charmbracelet/crush/internal/session"
+)
+
+type sessionJSON struct {
+	ID                  string  `json:"id"`
+	ParentSessionID     string  `json:"parent_session_id,omitempty"`
+	ForkedFromMessageID string  `json:"forked_from_message_id,omitempty"`
+	Title               string  `json:"title"`
+	MessageCount        int64   `json:"message_count"`
+	PromptTokens        int64   `json:"prompt_tokens"`
+	CompletionTokens    int64   `json:"completion_tokens"`
+	CacheReadTokens     int64   `json:"cache_read_tokens"`
+	CacheCreationTokens int64   `json:"cache_creation_tokens"`
+	SummaryMessageID    string  `json:"summary_message_id,omitempty"`
+	Cost                float64 `json:"cost"`
+	CreatedAt           int64   `json:"created_at"`
+	UpdatedAt           int64   `json:"updated_at"`
+}
+
+func newSessionJSON(s session.Session) sessionJSON {
+	return sessionJSON{
+		ID:                  s.ID,
+		ParentSessionID:     s.ParentSessionID,
+		ForkedFromMessageID: s.ForkedFromMessageID,
+		Title:               s.Title,
+		MessageCount:        s.MessageCount,
+		PromptTokens:        s.PromptTokens,
+		CompletionTokens:    s.CompletionTokens,
+		CacheReadTokens:     s.CacheReadTokens,
+		CacheCreationTokens: s.CacheCreationTokens,
+		SummaryMessageID:    s.SummaryMessageID,
+		Cost:                s.Cost,
+		CreatedAt:           s.CreatedAt,
+		UpdatedAt:           s.UpdatedAt,
+	}
+}
+
+type messageJSON struct {
+	ID               string              `json:"id"`
+	SessionID        string              `json:"session_id"`
+	Role             message.MessageRole `json:"role"`
+	Parts            json.RawMessage     `json:"parts"`
+	Model            string              `json:"model,omitempty"`
+	Provider         string              `json:"provider,omitempty"`
+	IsSummaryMessage bool                `json:"is_summary_message,omitempty"`
+	CreatedAt        int64               `json:"created_at"`
+	UpdatedAt        int64               `json:"updated_at"`
+}
+
+func newMessageJSON(m message.Message) messageJSON {
+	parts, err := message.MarshalParts(m.Parts)
+	if err != nil {
+		parts = []byte("[]")
+	}
+	return messageJSON{
+		ID:               m.ID,
+		SessionID:        m.SessionID,
+		Role:             m.Role,
+		Parts:            parts,
+		Model:            m.Model,
+		Provider:         m.Provider,
+		IsSummaryMessage: m.IsSummaryMessage,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+}
+
+type fileJSON struct {
+	ID        string `json:"id"`
+	SessionID string `json:"session_id"`
+	Path      string `json:"path"`
+	Content   string `json:"content"`
+	Version   int64  `json:"version"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+func newFileJSON(f history.File) fileJSON {
+	return fileJSON(f)
+}
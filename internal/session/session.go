@@ -3,26 +3,49 @@ package session
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/crush/internal/db"
 	"github.com/charmbracelet/crush/internal/event"
+	"github.com/charmbracelet/crush/internal/message"
 	"github.com/charmbracelet/crush/internal/pubsub"
 	"github.com/google/uuid"
 )
 
+// ErrForkMessageNotFound is returned by Fork when the message to fork from
+// doesn't belong to the session.
+var ErrForkMessageNotFound = errors.New("message not found in session")
+
 type Session struct {
-	ID               string
-	ParentSessionID  string
-	Title            string
-	MessageCount     int64
-	PromptTokens     int64
-	CompletionTokens int64
-	SummaryMessageID string
-	Cost             float64
-	CreatedAt        int64
-	UpdatedAt        int64
+	ID                  string
+	ParentSessionID     string
+	ForkedFromMessageID string
+	Title               string
+	MessageCount        int64
+	PromptTokens        int64
+	CompletionTokens    int64
+	// CacheReadTokens and CacheCreationTokens are the prompt tokens read
+	// from and written to the provider cache over the whole session,
+	// InputTokens the others and OutputTokens the tokens generated.
+	CacheReadTokens     int64
+	CacheCreationTokens int64
+	InputTokens         int64
+	OutputTokens        int64
+	SummaryMessageID    string
+	// KeptFromMessageID is the first message kept verbatim after the summary.
+	// When empty the summary covers every message before it.
+	KeptFromMessageID string
+	Cost              float64
+	CreatedAt         int64
+	UpdatedAt         int64
+}
+
+// IsFork reports whether the session was forked from another session.
+func (s Session) IsFork() bool {
+	return s.ForkedFromMessageID != ""
 }
 
 type Service interface {
@@ -30,6 +53,7 @@ type Service interface {
 	Create(ctx context.Context, title string) (Session, error)
 	CreateTitleSession(ctx context.Context, parentSessionID string) (Session, error)
 	CreateTaskSession(ctx context.Context, toolCallID, parentSessionID, title string) (Session, error)
+	Fork(ctx context.Context, sessionID, messageID string) (Session, error)
 	Get(ctx context.Context, id string) (Session, error)
 	List(ctx context.Context) ([]Session, error)
 	Save(ctx context.Context, session Session) (Session, error)
@@ -43,7 +67,8 @@ type Service interface {
 
 type service struct {
 	*pubsub.Broker[Session]
-	q db.Querier
+	db *sql.DB
+	q  *db.Queries
 }
 
 func (s *service) Create(ctx context.Context, title string) (Session, error) {
@@ -88,6 +113,139 @@ func (s *service) CreateTitleSession(ctx context.Context, parentSessionID string
 	return session, nil
 }
 
+// Fork copies the messages of a session up to and including the given
+// message into a new session, along with the summary pointer and the file
+// history recorded up to that point. If the message has tool calls, the tool
+// results that follow it are copied too, so the fork starts from a valid
+// conversation state.
+func (s *service) Fork(ctx context.Context, sessionID, messageID string) (Session, error) {
+	parent, err := s.Get(ctx, sessionID)
+	if err != nil {
+		return Session{}, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Session{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+	qtx := s.q.WithTx(tx)
+
+	messages, err := qtx.ListMessagesBySession(ctx, parent.ID)
+	if err != nil {
+		return Session{}, err
+	}
+	cut := slices.IndexFunc(messages, func(m db.Message) bool {
+		return m.ID == messageID
+	})
+	if cut == -1 {
+		return Session{}, ErrForkMessageNotFound
+	}
+	for cut+1 < len(messages) && messages[cut+1].Role == string(message.Tool) {
+		cut++
+	}
+	messages = messages[:cut+1]
+
+	dbSession, err := qtx.CreateSession(ctx, db.CreateSessionParams{
+		ID:                  uuid.New().String(),
+		ParentSessionID:     sql.NullString{String: parent.ID, Valid: true},
+		Title:               parent.Title + " (fork)",
+		ForkedFromMessageID: sql.NullString{String: messageID, Valid: true},
+	})
+	if err != nil {
+		return Session{}, err
+	}
+
+	// The summary of the fork is the last one copied, which is older than
+	// the one of the parent when the fork is made before it.
+	var summaryMessageID, keptFromMessageID string
+	copiedIDs := make(map[string]string, len(messages))
+	for _, msg := range messages {
+		keptFrom := msg.KeptFromMessageID.String
+		if msg.ID == parent.SummaryMessageID && keptFrom == "" {
+			// Summaries made before messages recorded it only have it on
+			// the session.
+			keptFrom = parent.KeptFromMessageID
+		}
+		keptFrom = copiedIDs[keptFrom]
+		copied, err := qtx.CopyMessage(ctx, db.CopyMessageParams{
+			ID:                uuid.New().String(),
+			SessionID:         dbSession.ID,
+			Role:              msg.Role,
+			Parts:             msg.Parts,
+			Model:             msg.Model,
+			Provider:          msg.Provider,
+			IsSummaryMessage:  msg.IsSummaryMessage,
+			KeptFromMessageID: sql.NullString{String: keptFrom, Valid: keptFrom != ""},
+			CreatedAt:         msg.CreatedAt,
+			UpdatedAt:         msg.UpdatedAt,
+			FinishedAt:        msg.FinishedAt,
+		})
+		if err != nil {
+			return Session{}, fmt.Errorf("failed to copy message: %w", err)
+		}
+		copiedIDs[msg.ID] = copied.ID
+		if copied.IsSummaryMessage != 0 && copied.FinishedAt.Valid {
+			summaryMessageID = copied.ID
+			keptFromMessageID = copied.KeptFromMessageID.String
+		}
+	}
+
+	// Only keep the file versions that existed when the last copied message
+	// was written.
+	cutoff := messages[len(messages)-1].UpdatedAt
+	files, err := qtx.ListFilesBySession(ctx, parent.ID)
+	if err != nil {
+		return Session{}, err
+	}
+	for _, file := range files {
+		if file.CreatedAt > cutoff {
+			continue
+		}
+		if _, err := qtx.CopyFile(ctx, db.CopyFileParams{
+			ID:        uuid.New().String(),
+			SessionID: dbSession.ID,
+			Path:      file.Path,
+			Content:   file.Content,
+			Version:   file.Version,
+			CreatedAt: file.CreatedAt,
+			UpdatedAt: file.UpdatedAt,
+		}); err != nil {
+			return Session{}, fmt.Errorf("failed to copy file history: %w", err)
+		}
+	}
+
+	if summaryMessageID != "" {
+		dbSession, err = qtx.UpdateSession(ctx, db.UpdateSessionParams{
+			ID:               dbSession.ID,
+			Title:            dbSession.Title,
+			SummaryMessageID: sql.NullString{String: summaryMessageID, Valid: true},
+			KeptFromMessageID: sql.NullString{
+				String: keptFromMessageID,
+				Valid:  keptFromMessageID != "",
+			},
+		})
+		if err != nil {
+			return Session{}, err
+		}
+	} else {
+		// Re-read the session so the message count reflects the copies.
+		dbSession, err = qtx.GetSessionByID(ctx, dbSession.ID)
+		if err != nil {
+			return Session{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Session{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	session := s.fromDBItem(dbSession)
+	s.Publish(pubsub.CreatedEvent, session)
+	event.SessionCreated()
+	return session, nil
+}
+
 func (s *service) Delete(ctx context.Context, id string) error {
 	session, err := s.Get(ctx, id)
 	if err != nil {
@@ -112,14 +270,22 @@ func (s *service) Get(ctx context.Context, id string) (Session, error) {
 
 func (s *service) Save(ctx context.Context, session Session) (Session, error) {
 	dbSession, err := s.q.UpdateSession(ctx, db.UpdateSessionParams{
-		ID:               session.ID,
-		Title:            session.Title,
-		PromptTokens:     session.PromptTokens,
-		CompletionTokens: session.CompletionTokens,
+		ID:                  session.ID,
+		Title:               session.Title,
+		PromptTokens:        session.PromptTokens,
+		CompletionTokens:    session.CompletionTokens,
+		CacheReadTokens:     session.CacheReadTokens,
+		CacheCreationTokens: session.CacheCreationTokens,
+		InputTokens:         session.InputTokens,
+		OutputTokens:        session.OutputTokens,
 		SummaryMessageID: sql.NullString{
 			String: session.SummaryMessageID,
 			Valid:  session.SummaryMessageID != "",
 		},
+		KeptFromMessageID: sql.NullString{
+			String: session.KeptFromMessageID,
+			Valid:  session.KeptFromMessageID != "",
+		},
 		Cost: session.Cost,
 	})
 	if err != nil {
@@ -144,24 +310,31 @@ func (s *service) List(ctx context.Context) ([]Session, error) {
 
 func (s service) fromDBItem(item db.Session) Session {
 	return Session{
-		ID:               item.ID,
-		ParentSessionID:  item.ParentSessionID.String,
-		Title:            item.Title,
-		MessageCount:     item.MessageCount,
-		PromptTokens:     item.PromptTokens,
-		CompletionTokens: item.CompletionTokens,
-		SummaryMessageID: item.SummaryMessageID.String,
-		Cost:             item.Cost,
-		CreatedAt:        item.CreatedAt,
-		UpdatedAt:        item.UpdatedAt,
+		ID:                  item.ID,
+		ParentSessionID:     item.ParentSessionID.String,
+		ForkedFromMessageID: item.ForkedFromMessageID.String,
+		Title:               item.Title,
+		MessageCount:        item.MessageCount,
+		PromptTokens:        item.PromptTokens,
+		CompletionTokens:    item.CompletionTokens,
+		CacheReadTokens:     item.CacheReadTokens,
+		CacheCreationTokens: item.CacheCreationTokens,
+		InputTokens:         item.InputTokens,
+		OutputTokens:        item.OutputTokens,
+		SummaryMessageID:    item.SummaryMessageID.String,
+		KeptFromMessageID:   item.KeptFromMessageID.String,
+		Cost:                item.Cost,
+		CreatedAt:           item.CreatedAt,
+		UpdatedAt:           item.UpdatedAt,
 	}
 }
 
-func NewService(q db.Querier) Service {
+func NewService(q *db.Queries, db *sql.DB) Service {
 	broker := pubsub.NewBroker[Session]()
 	return &service{
-		broker,
-		q,
+		Broker: broker,
+		db:     db,
+		q:      q,
 	}
 }
 
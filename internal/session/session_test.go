@@ -0,0 +1,167 @@
+package session_test
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/crush/internal/db"
+	"github.com/charmbracelet/crush/internal/history"
+	"github.com/charmbracelet/crush/internal/message"
+	"github.com/charmbracelet/crush/internal/session"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFork(t *testing.T) {
+	conn, err := db.Connect(t.Context(), t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	q := db.New(conn)
+	sessions := session.NewService(q, conn)
+	messages := message.NewService(q)
+	files := history.NewService(q, conn)
+
+	parent, err := sessions.Create(t.Context(), "parent")
+	require.NoError(t, err)
+
+	first, err := messages.Create(t.Context(), parent.ID, message.CreateMessageParams{
+		Role:  message.User,
+		Parts: []message.ContentPart{message.TextContent{Text: "first"}},
+	})
+	require.NoError(t, err)
+	_, err = files.Create(t.Context(), parent.ID, "main.go", "package main")
+	require.NoError(t, err)
+	reply, err := messages.Create(t.Context(), parent.ID, message.CreateMessageParams{
+		Role:  message.Assistant,
+		Parts: []message.ContentPart{message.TextContent{Text: "reply"}},
+	})
+	require.NoError(t, err)
+	_, err = messages.Create(t.Context(), parent.ID, message.CreateMessageParams{
+		Role:  message.User,
+		Parts: []message.ContentPart{message.TextContent{Text: "second"}},
+	})
+	require.NoError(t, err)
+
+	t.Run("copies messages up to the fork point", func(t *testing.T) {
+		forked, err := sessions.Fork(t.Context(), parent.ID, reply.ID)
+		require.NoError(t, err)
+		require.True(t, forked.IsFork())
+		require.Equal(t, parent.ID, forked.ParentSessionID)
+		require.Equal(t, reply.ID, forked.ForkedFromMessageID)
+		require.Equal(t, "parent (fork)", forked.Title)
+		require.EqualValues(t, 2, forked.MessageCount)
+
+		msgs, err := messages.List(t.Context(), forked.ID)
+		require.NoError(t, err)
+		require.Len(t, msgs, 2)
+		require.NotEqual(t, first.ID, msgs[0].ID)
+		require.Equal(t, "first", msgs[0].Content().Text)
+		require.Equal(t, "reply", msgs[1].Content().Text)
+
+		forkedFiles, err := files.ListBySession(t.Context(), forked.ID)
+		require.NoError(t, err)
+		require.Len(t, forkedFiles, 1)
+		require.Equal(t, "main.go", forkedFiles[0].Path)
+
+		parentMsgs, err := messages.List(t.Context(), parent.ID)
+		require.NoError(t, err)
+		require.Len(t, parentMsgs, 3)
+	})
+
+	t.Run("forks appear in the session list", func(t *testing.T) {
+		all, err := sessions.List(t.Context())
+		require.NoError(t, err)
+		var forks int
+		for _, s := range all {
+			if s.IsFork() {
+				forks++
+			}
+		}
+		require.Equal(t, 1, forks)
+	})
+
+	t.Run("unknown message", func(t *testing.T) {
+		_, err := sessions.Fork(t.Context(), parent.ID, "missing")
+		require.ErrorIs(t, err, session.ErrForkMessageNotFound)
+	})
+}
+
+func TestForkSummary(t *testing.T) {
+	conn, err := db.Connect(t.Context(), t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	q := db.New(conn)
+	sessions := session.NewService(q, conn)
+	messages := message.NewService(q)
+
+	parent, err := sessions.Create(t.Context(), "parent")
+	require.NoError(t, err)
+	prompt := func(text string) message.Message {
+		msg, err := messages.Create(t.Context(), parent.ID, message.CreateMessageParams{
+			Role:  message.User,
+			Parts: []message.ContentPart{message.TextContent{Text: text}},
+		})
+		require.NoError(t, err)
+		return msg
+	}
+	summary := func(text, keptFrom string) message.Message {
+		msg, err := messages.Create(t.Context(), parent.ID, message.CreateMessageParams{
+			Role:              message.Assistant,
+			IsSummaryMessage:  true,
+			KeptFromMessageID: keptFrom,
+		})
+		require.NoError(t, err)
+		msg.AppendContent(text)
+		msg.AddFinish(message.FinishReasonEndTurn, "", "")
+		require.NoError(t, messages.Update(t.Context(), msg))
+		return msg
+	}
+
+	first := prompt("first")
+	summary("older summary", first.ID)
+	second := prompt("second")
+	latest := summary("latest summary", second.ID)
+	parent.SummaryMessageID = latest.ID
+	parent.KeptFromMessageID = second.ID
+	parent, err = sessions.Save(t.Context(), parent)
+	require.NoError(t, err)
+
+	forked, err := sessions.Fork(t.Context(), parent.ID, first.ID)
+	require.NoError(t, err)
+	require.Empty(t, forked.SummaryMessageID)
+
+	// Forking between the summaries keeps the older one.
+	forked, err = sessions.Fork(t.Context(), parent.ID, second.ID)
+	require.NoError(t, err)
+	msgs, err := messages.List(t.Context(), forked.ID)
+	require.NoError(t, err)
+	require.Len(t, msgs, 3)
+	require.Equal(t, msgs[1].ID, forked.SummaryMessageID)
+	require.Equal(t, msgs[0].ID, forked.KeptFromMessageID)
+	require.Equal(t, msgs[0].ID, msgs[1].KeptFromMessageID)
+}
+
+func TestSaveCacheTokens(t *testing.T) {
+	conn, err := db.Connect(t.Context(), t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	sessions := session.NewService(db.New(conn), conn)
+	sess, err := sessions.Create(t.Context(), "cached")
+	require.NoError(t, err)
+	require.Zero(t, sess.CacheReadTokens)
+
+	sess.CacheReadTokens = 9000
+	sess.CacheCreationTokens = 1000
+	sess.InputTokens = 500
+	sess.OutputTokens = 200
+	_, err = sessions.Save(t.Context(), sess)
+	require.NoError(t, err)
+
+	saved, err := sessions.Get(t.Context(), sess.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(9000), saved.CacheReadTokens)
+	require.Equal(t, int64(1000), saved.CacheCreationTokens)
+	require.Equal(t, int64(500), saved.InputTokens)
+	require.Equal(t, int64(200), saved.OutputTokens)
+}
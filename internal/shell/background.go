@@ -1,14 +1,19 @@
 package shell
 
 import (
-	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/charmbracelet/crush/internal/ansiext"
 	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/google/uuid"
 )
 
 const (
@@ -16,27 +21,63 @@ const (
 	MaxBackgroundJobs = 50
 	// CompletedJobRetentionMinutes is how long to keep completed jobs before auto-cleanup (8 hours)
 	CompletedJobRetentionMinutes = 8 * 60
+	// ptyDrainTimeout is how long the output of a pseudo-terminal is still
+	// read once its command finished, as the commands it left running in the
+	// background can keep it open.
+	ptyDrainTimeout = time.Second
+	// maxLogRead bounds how much of the end of a log file is read at once,
+	// as the output of a long running command grows without limit.
+	maxLogRead = 256 * 1024
 )
 
 // BackgroundShell represents a shell running in the background.
 type BackgroundShell struct {
-	ID          string
+	ID string
+	// JobID identifies the shell across runs of the application, unlike ID
+	// which is only unique within a run. It names the log files.
+	JobID       string
+	SessionID   string
 	Command     string
 	Description string
 	Shell       *Shell
 	WorkingDir  string
+	// StdoutPath and StderrPath are the log files the output is streamed to.
+	StdoutPath string
+	StderrPath string
+	// PTY reports whether the command runs in a pseudo-terminal, its output
+	// all going to the standard output log.
+	PTY         bool
+	StartedAt   time.Time
+	ptmx        *os.File
 	ctx         context.Context
 	cancel      context.CancelFunc
-	stdout      *bytes.Buffer
-	stderr      *bytes.Buffer
 	done        chan struct{}
 	exitErr     error
 	completedAt int64 // Unix timestamp when job completed (0 if still running)
+
+	// mu serializes the recording of the start and the end of the job.
+	mu         sync.Mutex
+	recorder   JobRecorder
+	background bool
+	finished   bool
+	finishedAt time.Time
+}
+
+// JobRecorder records the shells moved to the background, as they start and
+// as they finish.
+type JobRecorder interface {
+	JobStarted(info BackgroundShellInfo)
+	JobFinished(info BackgroundShellInfo)
 }
 
 // BackgroundShellManager manages background shell instances.
 type BackgroundShellManager struct {
-	shells *csync.Map[string, *BackgroundShell]
+	shells  *csync.Map[string, *BackgroundShell]
+	sandbox atomic.Pointer[Sandbox]
+
+	mu       sync.RWMutex
+	logDir   string
+	recorder JobRecorder
 }
 
 var (
@@ -55,33 +96,87 @@ func GetBackgroundShellManager() *BackgroundShellManager {
 	return backgroundManager
 }
 
-// Start creates and starts a new background shell with the given command.
-func (m *BackgroundShellManager) Start(ctx context.Context, workingDir string, blockFuncs []BlockFunc, command string, description string) (*BackgroundShell, error) {
+// Start creates and starts a new background shell with the given command,
+// streaming its output to log files.
+func (m *BackgroundShellManager) Start(ctx context.Context, sessionID, workingDir string, blockFuncs []BlockFunc, command string, description string) (*BackgroundShell, error) {
+	return m.start(ctx, sessionID, workingDir, blockFuncs, command, description, nil)
+}
+
+// StartPTY creates and starts a new background shell running the given
+// command in a pseudo-terminal of the given size, for the commands which
+// behave differently or wait for input when run in a terminal.
+func (m *BackgroundShellManager) StartPTY(ctx context.Context, sessionID, workingDir string, blockFuncs []BlockFunc, command string, description string, size PTYSize) (*BackgroundShell, error) {
+	return m.start(ctx, sessionID, workingDir, blockFuncs, command, description, &size)
+}
+
+func (m *BackgroundShellManager) start(ctx context.Context, sessionID, workingDir string, blockFuncs []BlockFunc, command string, description string, size *PTYSize) (*BackgroundShell, error) {
 	// Check job limit
 	if m.shells.Len() >= MaxBackgroundJobs {
 		return nil, fmt.Errorf("maximum number of background jobs (%d) reached. Please terminate or wait for some jobs to complete", MaxBackgroundJobs)
 	}
 
 	id := fmt.Sprintf("%03X", idCounter.Add(1))
+	jobID := uuid.New().String()
+
+	m.mu.RLock()
+	logDir, recorder := m.logDir, m.recorder
+	m.mu.RUnlock()
+	if logDir == "" {
+		logDir = filepath.Join(os.TempDir(), "crush-jobs")
+	}
+	if sessionID != "" {
+		logDir = filepath.Join(logDir, sessionID)
+	}
+	if err := os.MkdirAll(logDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create the job logs directory: %w", err)
+	}
+	stdout, err := os.Create(filepath.Join(logDir, jobID+".stdout.log"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the job log: %w", err)
+	}
+	stderr, err := os.Create(filepath.Join(logDir, jobID+".stderr.log"))
+	if err != nil {
+		stdout.Close()
+		os.Remove(stdout.Name())
+		return nil, fmt.Errorf("failed to create the job log: %w", err)
+	}
+	var ptmx, tty *os.File
+	if size != nil {
+		ptmx, tty, err = openPTY(*size)
+		if err != nil {
+			stdout.Close()
+			stderr.Close()
+			os.Remove(stdout.Name())
+			os.Remove(stderr.Name())
+			return nil, err
+		}
+	}
 
 	shell := NewShell(&Options{
 		WorkingDir: workingDir,
 		BlockFuncs: blockFuncs,
+		Sandbox:    m.sandbox.Load(),
 	})
 
 	shellCtx, cancel := context.WithCancel(ctx)
 
 	bgShell := &BackgroundShell{
 		ID:          id,
+		JobID:       jobID,
+		SessionID:   sessionID,
 		Command:     command,
 		Description: description,
 		WorkingDir:  workingDir,
+		StdoutPath:  stdout.Name(),
+		StderrPath:  stderr.Name(),
+		PTY:         ptmx != nil,
+		StartedAt:   time.Now(),
+		ptmx:        ptmx,
 		Shell:       shell,
 		ctx:         shellCtx,
 		cancel:      cancel,
-		stdout:      &bytes.Buffer{},
-		stderr:      &bytes.Buffer{},
 		done:        make(chan struct{}),
+		recorder:    recorder,
 	}
 
 	m.shells.Set(id, bgShell)
@@ -89,27 +184,114 @@ func (m *BackgroundShellManager) Start(ctx context.Context, workingDir string, b
 	go func() {
 		defer close(bgShell.done)
 
-		err := shell.ExecStream(shellCtx, command, bgShell.stdout, bgShell.stderr)
+		var err error
+		if ptmx != nil {
+			err = execPTY(shellCtx, shell, command, ptmx, tty, stdout)
+		} else {
+			err = shell.ExecStream(shellCtx, command, stdout, stderr)
+		}
+		stdout.Close()
+		stderr.Close()
 
+		bgShell.mu.Lock()
+		defer bgShell.mu.Unlock()
 		bgShell.exitErr = err
-		atomic.StoreInt64(&bgShell.completedAt, time.Now().Unix())
+		bgShell.finishedAt = time.Now()
+		atomic.StoreInt64(&bgShell.completedAt, bgShell.finishedAt.Unix())
+		bgShell.finished = true
+		if bgShell.background && bgShell.recorder != nil {
+			bgShell.recorder.JobFinished(bgShell.info())
+		}
 	}()
 
 	return bgShell, nil
 }
 
+// execPTY executes the command in the pseudo-terminal, copying its output to
+// the log.
+func execPTY(ctx context.Context, shell *Shell, command string, ptmx, tty *os.File, log io.Writer) error {
+	copied := make(chan struct{})
+	go func() {
+		defer close(copied)
+		_, _ = io.Copy(log, ptmx)
+	}()
+
+	err := shell.ExecTerminal(ctx, command, tty)
+	tty.Close()
+	// The copy stops once every process is done with the terminal.
+	select {
+	case <-copied:
+	case <-time.After(ptyDrainTimeout):
+	}
+	ptmx.Close()
+	<-copied
+	return err
+}
+
+// Background marks the shell as a job left running in the background, as
+// opposed to the commands whose output is returned as soon as they finish.
+// The jobs are recorded and keep their logs once removed from the manager.
+func (m *BackgroundShellManager) Background(bs *BackgroundShell) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	if bs.background {
+		return
+	}
+	bs.background = true
+	if bs.recorder == nil {
+		return
+	}
+	bs.recorder.JobStarted(bs.info())
+	if bs.finished {
+		bs.recorder.JobFinished(bs.info())
+	}
+}
+
+// SetLogDir sets the directory the output of the shells started from now on
+// is logged to, in a subdirectory per session.
+func (m *BackgroundShellManager) SetLogDir(dir string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logDir = dir
+}
+
+// SetRecorder sets what records the shells started from now on once moved to
+// the background, nil meaning nothing.
+func (m *BackgroundShellManager) SetRecorder(recorder JobRecorder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.recorder = recorder
+}
+
+// SetSandbox sets the sandbox the shells started from now on run their
+// commands in, nil meaning none.
+func (m *BackgroundShellManager) SetSandbox(sandbox *Sandbox) {
+	m.sandbox.Store(sandbox)
+}
+
 // Get retrieves a background shell by ID.
 func (m *BackgroundShellManager) Get(id string) (*BackgroundShell, bool) {
 	return m.shells.Get(id)
 }
 
+// GetByJobID retrieves a background shell by job ID.
+func (m *BackgroundShellManager) GetByJobID(jobID string) (*BackgroundShell, bool) {
+	for shell := range m.shells.Seq() {
+		if shell.JobID == jobID {
+			return shell, true
+		}
+	}
+	return nil, false
+}
+
 // Remove removes a background shell from the manager without terminating it.
 // This is useful when a shell has already completed and you just want to clean up tracking.
 func (m *BackgroundShellManager) Remove(id string) error {
-	_, ok := m.shells.Take(id)
+	shell, ok := m.shells.Take(id)
 	if !ok {
 		return fmt.Errorf("background shell not found: %s", id)
 	}
+	shell.removeLogs()
 	return nil
 }
 
@@ -122,14 +304,65 @@ func (m *BackgroundShellManager) Kill(id string) error {
 
 	shell.cancel()
 	<-shell.done
+	shell.removeLogs()
 	return nil
 }
 
 // BackgroundShellInfo contains information about a background shell.
 type BackgroundShellInfo struct {
 	ID          string
+	JobID       string
+	SessionID   string
 	Command     string
 	Description string
+	WorkingDir  string
+	StdoutPath  string
+	StderrPath  string
+	StartedAt   time.Time
+	// FinishedAt is zero while the shell is running.
+	FinishedAt time.Time
+	ExitCode   int
+	// Killed reports whether the shell was terminated before finishing.
+	Killed bool
+}
+
+// Info returns the information about the shell.
+func (bs *BackgroundShell) Info() BackgroundShellInfo {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	return bs.info()
+}
+
+func (bs *BackgroundShell) info() BackgroundShellInfo {
+	info := BackgroundShellInfo{
+		ID:          bs.ID,
+		JobID:       bs.JobID,
+		SessionID:   bs.SessionID,
+		Command:     bs.Command,
+		Description: bs.Description,
+		WorkingDir:  bs.WorkingDir,
+		StdoutPath:  bs.StdoutPath,
+		StderrPath:  bs.StderrPath,
+		StartedAt:   bs.StartedAt,
+	}
+	if bs.finished {
+		info.FinishedAt = bs.finishedAt
+		info.ExitCode = ExitCode(bs.exitErr)
+		info.Killed = bs.ctx.Err() != nil
+	}
+	return info
+}
+
+// removeLogs removes the log files of a shell that wasn't moved to the
+// background, once done with it.
+func (bs *BackgroundShell) removeLogs() {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	if bs.background || !bs.finished {
+		return
+	}
+	os.Remove(bs.StdoutPath)
+	os.Remove(bs.StderrPath)
 }
 
 // List returns all background shell IDs.
@@ -172,17 +405,96 @@ func (m *BackgroundShellManager) KillAll() {
 	for _, shell := range shells {
 		shell.cancel()
 		<-shell.done
+		shell.removeLogs()
 	}
 }
 
-// GetOutput returns the current output of a background shell.
+// GetOutput returns the current output of a background shell, read from the
+// end of its log files.
 func (bs *BackgroundShell) GetOutput() (stdout string, stderr string, done bool, err error) {
-	select {
-	case <-bs.done:
-		return bs.stdout.String(), bs.stderr.String(), true, bs.exitErr
-	default:
-		return bs.stdout.String(), bs.stderr.String(), false, nil
+	// Check first, so that the whole output is read once done.
+	done = bs.IsDone()
+	stdout, stderr = bs.readStdout(0), readLog(bs.StderrPath, 0)
+	if done {
+		err = bs.exitErr
+	}
+	return stdout, stderr, done, err
+}
+
+// StdoutOffset returns how much was written to stdout so far, to get only
+// what comes next with GetStdoutSince.
+func (bs *BackgroundShell) StdoutOffset() int64 {
+	info, err := os.Stat(bs.StdoutPath)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// GetStdoutSince is like GetOutput, but only returns what was written to
+// stdout after offset.
+func (bs *BackgroundShell) GetStdoutSince(offset int64) (stdout string, done bool, err error) {
+	done = bs.IsDone()
+	stdout = bs.readStdout(offset)
+	if done {
+		err = bs.exitErr
+	}
+	return stdout, done, err
+}
+
+func (bs *BackgroundShell) readStdout(offset int64) string {
+	stdout := readLog(bs.StdoutPath, offset)
+	if bs.PTY {
+		stdout = ansiext.Strip(stdout)
+	}
+	return stdout
+}
+
+// WriteInput writes input to the pseudo-terminal the command of the shell runs
+// in, as if typed in it.
+func (bs *BackgroundShell) WriteInput(input string) error {
+	if bs.ptmx == nil {
+		return fmt.Errorf("background shell %s doesn't run in a pseudo-terminal", bs.ID)
+	}
+	if bs.IsDone() {
+		return fmt.Errorf("background shell %s has finished", bs.ID)
+	}
+	if _, err := io.WriteString(bs.ptmx, input); err != nil {
+		return fmt.Errorf("failed to write to the pseudo-terminal: %w", err)
+	}
+	return nil
+}
+
+// readLog reads a log file from offset, or only its last maxLogRead bytes
+// when more was written since, starting at a whole line.
+func readLog(path string, offset int64) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return ""
+	}
+	start := max(offset, info.Size()-maxLogRead)
+	if start >= info.Size() {
+		return ""
+	}
+	buf := make([]byte, info.Size()-start)
+	n, err := f.ReadAt(buf, start)
+	if err != nil && err != io.EOF {
+		return ""
+	}
+	content := string(buf[:n])
+	if start == offset {
+		return content
+	}
+	// The first line is likely cut.
+	if i := strings.IndexByte(content, '\n'); i >= 0 {
+		content = content[i+1:]
 	}
+	return fmt.Sprintf("... [%d bytes of earlier output skipped] ...\n%s", info.Size()-offset-int64(len(content)), content)
 }
 
 // IsDone checks if the background shell has finished execution.
@@ -2,9 +2,15 @@ package shell
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBackgroundShellManager_Start(t *testing.T) {
@@ -15,7 +21,7 @@ func TestBackgroundShellManager_Start(t *testing.T) {
 	workingDir := t.TempDir()
 	manager := GetBackgroundShellManager()
 
-	bgShell, err := manager.Start(ctx, workingDir, nil, "echo 'hello world'", "")
+	bgShell, err := manager.Start(ctx, "", workingDir, nil, "echo 'hello world'", "")
 	if err != nil {
 		t.Fatalf("failed to start background shell: %v", err)
 	}
@@ -52,7 +58,7 @@ func TestBackgroundShellManager_Get(t *testing.T) {
 	workingDir := t.TempDir()
 	manager := GetBackgroundShellManager()
 
-	bgShell, err := manager.Start(ctx, workingDir, nil, "echo 'test'", "")
+	bgShell, err := manager.Start(ctx, "", workingDir, nil, "echo 'test'", "")
 	if err != nil {
 		t.Fatalf("failed to start background shell: %v", err)
 	}
@@ -79,7 +85,7 @@ func TestBackgroundShellManager_Kill(t *testing.T) {
 	manager := GetBackgroundShellManager()
 
 	// Start a long-running command
-	bgShell, err := manager.Start(ctx, workingDir, nil, "sleep 10", "")
+	bgShell, err := manager.Start(ctx, "", workingDir, nil, "sleep 10", "")
 	if err != nil {
 		t.Fatalf("failed to start background shell: %v", err)
 	}
@@ -120,7 +126,7 @@ func TestBackgroundShell_IsDone(t *testing.T) {
 	workingDir := t.TempDir()
 	manager := GetBackgroundShellManager()
 
-	bgShell, err := manager.Start(ctx, workingDir, nil, "echo 'quick'", "")
+	bgShell, err := manager.Start(ctx, "", workingDir, nil, "echo 'quick'", "")
 	if err != nil {
 		t.Fatalf("failed to start background shell: %v", err)
 	}
@@ -147,7 +153,7 @@ func TestBackgroundShell_WithBlockFuncs(t *testing.T) {
 		CommandsBlocker([]string{"curl", "wget"}),
 	}
 
-	bgShell, err := manager.Start(ctx, workingDir, blockFuncs, "curl example.com", "")
+	bgShell, err := manager.Start(ctx, "", workingDir, blockFuncs, "curl example.com", "")
 	if err != nil {
 		t.Fatalf("failed to start background shell: %v", err)
 	}
@@ -178,12 +184,12 @@ func TestBackgroundShellManager_List(t *testing.T) {
 	manager := GetBackgroundShellManager()
 
 	// Start two shells
-	bgShell1, err := manager.Start(ctx, workingDir, nil, "sleep 1", "")
+	bgShell1, err := manager.Start(ctx, "", workingDir, nil, "sleep 1", "")
 	if err != nil {
 		t.Fatalf("failed to start first background shell: %v", err)
 	}
 
-	bgShell2, err := manager.Start(ctx, workingDir, nil, "sleep 1", "")
+	bgShell2, err := manager.Start(ctx, "", workingDir, nil, "sleep 1", "")
 	if err != nil {
 		t.Fatalf("failed to start second background shell: %v", err)
 	}
@@ -222,17 +228,17 @@ func TestBackgroundShellManager_KillAll(t *testing.T) {
 	manager := GetBackgroundShellManager()
 
 	// Start multiple long-running shells
-	shell1, err := manager.Start(ctx, workingDir, nil, "sleep 10", "")
+	shell1, err := manager.Start(ctx, "", workingDir, nil, "sleep 10", "")
 	if err != nil {
 		t.Fatalf("failed to start shell 1: %v", err)
 	}
 
-	shell2, err := manager.Start(ctx, workingDir, nil, "sleep 10", "")
+	shell2, err := manager.Start(ctx, "", workingDir, nil, "sleep 10", "")
 	if err != nil {
 		t.Fatalf("failed to start shell 2: %v", err)
 	}
 
-	shell3, err := manager.Start(ctx, workingDir, nil, "sleep 10", "")
+	shell3, err := manager.Start(ctx, "", workingDir, nil, "sleep 10", "")
 	if err != nil {
 		t.Fatalf("failed to start shell 3: %v", err)
 	}
@@ -275,3 +281,159 @@ func TestBackgroundShellManager_KillAll(t *testing.T) {
 		}
 	}
 }
+
+// fakeRecorder keeps the jobs recorded, in order.
+type fakeRecorder struct {
+	mu       sync.Mutex
+	started  []BackgroundShellInfo
+	finished []BackgroundShellInfo
+}
+
+func (r *fakeRecorder) JobStarted(info BackgroundShellInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.started = append(r.started, info)
+}
+
+func (r *fakeRecorder) JobFinished(info BackgroundShellInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.finished = append(r.finished, info)
+}
+
+func newJobsTest(t *testing.T) (*BackgroundShellManager, *fakeRecorder, string) {
+	logDir := t.TempDir()
+	recorder := &fakeRecorder{}
+	manager := &BackgroundShellManager{shells: csync.NewMap[string, *BackgroundShell]()}
+	manager.SetLogDir(logDir)
+	manager.SetRecorder(recorder)
+	return manager, recorder, logDir
+}
+
+func TestBackgroundShellManager_Logs(t *testing.T) {
+	t.Parallel()
+
+	manager, recorder, logDir := newJobsTest(t)
+	bgShell, err := manager.Start(t.Context(), "session", t.TempDir(), nil, "echo out; echo err >&2", "")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(logDir, "session", bgShell.JobID+".stdout.log"), bgShell.StdoutPath)
+	bgShell.Wait()
+
+	stdout, stderr, done, err := bgShell.GetOutput()
+	require.NoError(t, err)
+	require.True(t, done)
+	require.Equal(t, "out\n", stdout)
+	require.Equal(t, "err\n", stderr)
+	content, err := os.ReadFile(bgShell.StdoutPath)
+	require.NoError(t, err)
+	require.Equal(t, "out\n", string(content))
+
+	// The commands that weren't moved to the background leave nothing behind.
+	require.NoError(t, manager.Remove(bgShell.ID))
+	require.NoFileExists(t, bgShell.StdoutPath)
+	require.NoFileExists(t, bgShell.StderrPath)
+	require.Empty(t, recorder.started)
+	require.Empty(t, recorder.finished)
+}
+
+func TestReadLog(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "job.stdout.log")
+	require.NoError(t, os.WriteFile(path, []byte("first\nsecond\n"), 0o644))
+	require.Equal(t, "first\nsecond\n", readLog(path, 0))
+	require.Equal(t, "second\n", readLog(path, 6))
+	require.Empty(t, readLog(path, 13))
+	require.Empty(t, readLog(filepath.Join(t.TempDir(), "missing.log"), 0))
+
+	// Only the end of a long log is read.
+	line := strings.Repeat("x", 99) + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(strings.Repeat(line, 2*maxLogRead/len(line))+"last\n"), 0o644))
+	content := readLog(path, 0)
+	require.Less(t, len(content), maxLogRead+100)
+	require.True(t, strings.HasPrefix(content, "... ["), content[:100])
+	require.True(t, strings.HasSuffix(content, line+"last\n"))
+}
+
+func TestBackgroundShellManager_GetStdoutSince(t *testing.T) {
+	t.Parallel()
+
+	manager, _, _ := newJobsTest(t)
+	bgShell, err := manager.Start(t.Context(), "session", t.TempDir(), nil, "echo before; sleep 0.5; echo after", "")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return bgShell.StdoutOffset() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	offset := bgShell.StdoutOffset()
+	bgShell.Wait()
+
+	stdout, done, err := bgShell.GetStdoutSince(offset)
+	require.NoError(t, err)
+	require.True(t, done)
+	require.Equal(t, "after\n", stdout)
+	require.NoError(t, manager.Remove(bgShell.ID))
+}
+
+func TestBackgroundShellManager_Background(t *testing.T) {
+	t.Parallel()
+
+	t.Run("finished", func(t *testing.T) {
+		t.Parallel()
+
+		manager, recorder, _ := newJobsTest(t)
+		bgShell, err := manager.Start(t.Context(), "session", t.TempDir(), nil, "sleep 0.2; exit 3", "Fails")
+		require.NoError(t, err)
+		manager.Background(bgShell)
+		manager.Background(bgShell)
+		require.Len(t, recorder.started, 1)
+		require.Equal(t, "session", recorder.started[0].SessionID)
+		require.Equal(t, "Fails", recorder.started[0].Description)
+		require.True(t, recorder.started[0].FinishedAt.IsZero())
+
+		bgShell.Wait()
+		recorder.mu.Lock()
+		require.Len(t, recorder.finished, 1)
+		info := recorder.finished[0]
+		recorder.mu.Unlock()
+		require.Equal(t, bgShell.JobID, info.JobID)
+		require.Equal(t, 3, info.ExitCode)
+		require.False(t, info.Killed)
+		require.False(t, info.FinishedAt.Before(info.StartedAt.Add(200*time.Millisecond)))
+
+		// The jobs keep their logs once removed.
+		require.NoError(t, manager.Remove(bgShell.ID))
+		require.FileExists(t, bgShell.StdoutPath)
+	})
+
+	t.Run("killed", func(t *testing.T) {
+		t.Parallel()
+
+		manager, recorder, _ := newJobsTest(t)
+		bgShell, err := manager.Start(t.Context(), "session", t.TempDir(), nil, "sleep 10", "")
+		require.NoError(t, err)
+		manager.Background(bgShell)
+		got, ok := manager.GetByJobID(bgShell.JobID)
+		require.True(t, ok)
+		require.Equal(t, bgShell, got)
+
+		require.NoError(t, manager.Kill(bgShell.ID))
+		require.Len(t, recorder.finished, 1)
+		require.True(t, recorder.finished[0].Killed)
+		require.FileExists(t, bgShell.StdoutPath)
+		_, ok = manager.GetByJobID(bgShell.JobID)
+		require.False(t, ok)
+	})
+
+	t.Run("already done", func(t *testing.T) {
+		t.Parallel()
+
+		manager, recorder, _ := newJobsTest(t)
+		bgShell, err := manager.Start(t.Context(), "session", t.TempDir(), nil, "true", "")
+		require.NoError(t, err)
+		bgShell.Wait()
+		manager.Background(bgShell)
+		require.Len(t, recorder.started, 1)
+		require.Len(t, recorder.finished, 1)
+		require.Equal(t, 0, recorder.finished[0].ExitCode)
+	})
+}
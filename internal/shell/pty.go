@@ -0,0 +1,33 @@
+package shell
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/creack/pty"
+)
+
+// PTYSize is the size of the pseudo-terminal a command runs in.
+type PTYSize struct {
+	Rows uint16
+	Cols uint16
+}
+
+// DefaultPTYSize is the size of the pseudo-terminals when none is given.
+var DefaultPTYSize = PTYSize{Rows: 24, Cols: 80}
+
+// openPTY opens a pseudo-terminal of the given size, returning its master
+// side, which the input is written to and the output read from, and the
+// terminal the command runs in.
+func openPTY(size PTYSize) (ptmx, tty *os.File, err error) {
+	ptmx, tty, err = pty.Open()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open a pseudo-terminal: %w", err)
+	}
+	if err := pty.Setsize(ptmx, &pty.Winsize{Rows: size.Rows, Cols: size.Cols}); err != nil {
+		ptmx.Close()
+		tty.Close()
+		return nil, nil, fmt.Errorf("failed to set the size of the pseudo-terminal: %w", err)
+	}
+	return ptmx, tty, nil
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package shell
+
+import "mvdan.cc/sh/v3/interp"
+
+// terminalHandler is never used as the pseudo-terminals are only available
+// on Unix.
+func (s *Shell) terminalHandler() func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc {
+	return func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc {
+		return next
+	}
+}
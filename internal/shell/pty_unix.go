@@ -0,0 +1,117 @@
+//go:build unix
+
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+	"mvdan.cc/sh/v3/interp"
+)
+
+// killTimeout is how long an interrupted command has to stop before being
+// killed, as for the commands run by the default handler of the interpreter.
+const killTimeout = 2 * time.Second
+
+// terminalHandler runs the commands in a session of their own, with the
+// pseudo-terminal as controlling terminal, in place of the default handler of
+// the interpreter. Otherwise, the commands opening /dev/tty would reach the
+// terminal Crush runs in.
+func (s *Shell) terminalHandler() func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc {
+	return func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc {
+		return func(ctx context.Context, args []string) error {
+			hc := interp.HandlerCtx(ctx)
+			path, err := interp.LookPathDir(hc.Dir, hc.Env, args[0])
+			if err != nil {
+				fmt.Fprintln(hc.Stderr, err)
+				return interp.ExitStatus(127)
+			}
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(hc.Dir, path)
+			}
+			return runCommand(ctx, hc, true, func() (*exec.Cmd, error) {
+				return &exec.Cmd{
+					Path: path,
+					Args: args,
+					Env:  environ(hc.Env),
+					Dir:  hc.Dir,
+				}, nil
+			})
+		}
+	}
+}
+
+// runCommand runs the command returned by newCmd with the standard input and
+// outputs of the interpreter, returning its exit status. Run in a terminal,
+// the command gets it as controlling terminal, unless another command of a
+// pipeline already has it.
+func runCommand(ctx context.Context, hc interp.HandlerContext, terminal bool, newCmd func() (*exec.Cmd, error)) error {
+	start := func(controlling bool) (*exec.Cmd, error) {
+		cmd, err := newCmd()
+		if err != nil {
+			return nil, err
+		}
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = hc.Stdin, hc.Stdout, hc.Stderr
+		if terminal {
+			if cmd.SysProcAttr == nil {
+				cmd.SysProcAttr = &syscall.SysProcAttr{}
+			}
+			cmd.SysProcAttr.Setsid = true
+			fd, ok := terminalFd(hc)
+			cmd.SysProcAttr.Ctty = fd
+			cmd.SysProcAttr.Setctty = ok && controlling
+		}
+		return cmd, cmd.Start()
+	}
+
+	cmd, err := start(terminal)
+	if terminal && errors.Is(err, syscall.EPERM) {
+		cmd, err = start(false)
+	}
+	if cmd == nil {
+		return err
+	}
+	if err == nil {
+		stop := context.AfterFunc(ctx, func() {
+			_ = cmd.Process.Signal(os.Interrupt)
+			time.Sleep(killTimeout)
+			_ = cmd.Process.Kill()
+		})
+		defer stop()
+		err = cmd.Wait()
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return interp.ExitStatus(128 + status.Signal())
+		}
+		return interp.ExitStatus(exitErr.ExitCode())
+	case err != nil:
+		fmt.Fprintln(hc.Stderr, err)
+		return interp.ExitStatus(127)
+	}
+	return nil
+}
+
+// terminalFd returns the first of the standard input and outputs given to the
+// command which is a terminal, if any.
+func terminalFd(hc interp.HandlerContext) (int, bool) {
+	for fd, stdio := range []any{hc.Stdin, hc.Stdout, hc.Stderr} {
+		if f, ok := stdio.(*os.File); ok && term.IsTerminal(f.Fd()) {
+			return fd, true
+		}
+	}
+	return 0, false
+}
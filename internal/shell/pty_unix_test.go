@@ -0,0 +1,148 @@
+//go:build unix
+
+package shell
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeScript writes an executable shell script to the directory.
+func writeScript(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+content), 0o755))
+	return path
+}
+
+// waitOutput waits for the output of the shell to contain s.
+func waitOutput(t *testing.T, bgShell *BackgroundShell, s string) string {
+	var stdout string
+	require.Eventually(t, func() bool {
+		stdout, _, _, _ = bgShell.GetOutput()
+		return strings.Contains(stdout, s)
+	}, 5*time.Second, 20*time.Millisecond, "output never contained %q", s)
+	return stdout
+}
+
+func TestBackgroundShellManager_StartPTY(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeScript(t, dir, "isatty.sh", `if [ -t 0 ] && [ -t 1 ] && [ -t 2 ]; then echo tty; else echo notty; fi
+`)
+
+	t.Run("terminal", func(t *testing.T) {
+		t.Parallel()
+		manager, _, _ := newJobsTest(t)
+		bgShell, err := manager.StartPTY(t.Context(), "", dir, nil, "./isatty.sh", "", DefaultPTYSize)
+		require.NoError(t, err)
+		require.True(t, bgShell.PTY)
+		bgShell.Wait()
+
+		stdout, stderr, done, err := bgShell.GetOutput()
+		require.NoError(t, err)
+		require.True(t, done)
+		require.Equal(t, "tty\n", stdout)
+		require.Empty(t, stderr)
+	})
+
+	t.Run("no terminal", func(t *testing.T) {
+		t.Parallel()
+		manager, _, _ := newJobsTest(t)
+		bgShell, err := manager.Start(t.Context(), "", dir, nil, "./isatty.sh", "")
+		require.NoError(t, err)
+		bgShell.Wait()
+
+		stdout, _, _, err := bgShell.GetOutput()
+		require.NoError(t, err)
+		require.Equal(t, "notty\n", stdout)
+		require.ErrorContains(t, bgShell.WriteInput("input\n"), "doesn't run in a pseudo-terminal")
+	})
+
+	t.Run("size", func(t *testing.T) {
+		t.Parallel()
+		manager, _, _ := newJobsTest(t)
+		bgShell, err := manager.StartPTY(t.Context(), "", dir, nil, "stty size", "", PTYSize{Rows: 30, Cols: 100})
+		require.NoError(t, err)
+		bgShell.Wait()
+
+		stdout, _, _, err := bgShell.GetOutput()
+		require.NoError(t, err)
+		require.Equal(t, "30 100\n", stdout)
+	})
+
+	t.Run("control sequences", func(t *testing.T) {
+		t.Parallel()
+		manager, _, _ := newJobsTest(t)
+		bgShell, err := manager.StartPTY(t.Context(), "", dir, nil, `printf '\033[1;32mok\033[0m\n'`, "", DefaultPTYSize)
+		require.NoError(t, err)
+		bgShell.Wait()
+
+		stdout, _, _, err := bgShell.GetOutput()
+		require.NoError(t, err)
+		require.Equal(t, "ok\n", stdout)
+	})
+
+	t.Run("pipeline", func(t *testing.T) {
+		t.Parallel()
+		manager, _, _ := newJobsTest(t)
+		bgShell, err := manager.StartPTY(t.Context(), "", dir, nil, "./isatty.sh | tr a-z A-Z", "", DefaultPTYSize)
+		require.NoError(t, err)
+		bgShell.Wait()
+
+		stdout, _, _, err := bgShell.GetOutput()
+		require.NoError(t, err)
+		require.Equal(t, "NOTTY\n", stdout)
+	})
+}
+
+func TestBackgroundShell_WriteInput(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeScript(t, dir, "ask.sh", `printf 'name? '
+read name
+echo "hello $name"
+`)
+
+	manager, _, _ := newJobsTest(t)
+	bgShell, err := manager.StartPTY(t.Context(), "", dir, nil, "./ask.sh", "", DefaultPTYSize)
+	require.NoError(t, err)
+	defer manager.Kill(bgShell.ID)
+
+	waitOutput(t, bgShell, "name? ")
+	require.False(t, bgShell.IsDone())
+	require.NoError(t, bgShell.WriteInput("crush\r"))
+	bgShell.Wait()
+
+	stdout, _, _, err := bgShell.GetOutput()
+	require.NoError(t, err)
+	// The terminal echoes the input.
+	require.Equal(t, "name? crush\nhello crush\n", stdout)
+	require.ErrorContains(t, bgShell.WriteInput("again\r"), "has finished")
+}
+
+func TestBackgroundShell_PTYInterrupt(t *testing.T) {
+	t.Parallel()
+
+	manager, _, _ := newJobsTest(t)
+	bgShell, err := manager.StartPTY(t.Context(), "", t.TempDir(), nil, "echo started; sleep 100", "", DefaultPTYSize)
+	require.NoError(t, err)
+	defer manager.Kill(bgShell.ID)
+
+	waitOutput(t, bgShell, "started")
+	// Ctrl+C interrupts the command in the foreground of the terminal.
+	require.NoError(t, bgShell.WriteInput("\x03"))
+	select {
+	case <-bgShell.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the command was not interrupted")
+	}
+	_, _, _, err = bgShell.GetOutput()
+	require.Equal(t, 130, ExitCode(err))
+}
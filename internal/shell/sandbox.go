@@ -0,0 +1,129 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"mvdan.cc/sh/v3/expand"
+	"mvdan.cc/sh/v3/interp"
+)
+
+// Sandbox restricts what the commands run by a shell can change. Its working
+// directory and the temporary directory stay writable, the rest of the
+// filesystem is read-only.
+//
+// It is only available on Linux, where it relies on unprivileged user
+// namespaces. Elsewhere, or when they are disabled, the commands run without
+// it after a warning.
+type Sandbox struct {
+	// WorkingDir is the directory of the project, which stays writable. It
+	// doesn't follow the directory the commands are run from, which they can
+	// change.
+	WorkingDir string
+	// WritablePaths are the paths that stay writable besides the working
+	// directory and the temporary directory. Relative ones are in the
+	// working directory.
+	WritablePaths []string
+	// IsolateNetwork cuts the commands off the network, leaving them only
+	// the loopback interface.
+	IsolateNetwork bool
+}
+
+// SandboxAvailable returns why the sandbox can't be used on this system, if
+// it can't.
+func SandboxAvailable() error {
+	return sandboxAvailable()
+}
+
+var sandboxWarning sync.Once
+
+// enabled reports whether the commands have to run in the sandbox, warning
+// once when they should but can't.
+func (sb *Sandbox) enabled() bool {
+	if sb == nil {
+		return false
+	}
+	if err := sandboxAvailable(); err != nil {
+		sandboxWarning.Do(func() {
+			slog.Warn("The sandbox is not available, commands run without it", "error", err)
+		})
+		return false
+	}
+	return true
+}
+
+// writablePaths returns the cleaned paths the commands can write to.
+func (sb *Sandbox) writablePaths() []string {
+	paths := append([]string{sb.WorkingDir, os.TempDir()}, sb.WritablePaths...)
+	writable := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		if !filepath.IsAbs(path) {
+			if sb.WorkingDir == "" {
+				continue
+			}
+			path = filepath.Join(sb.WorkingDir, path)
+		}
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			path = resolved
+		}
+		writable = append(writable, filepath.Clean(path))
+	}
+	return writable
+}
+
+// isWritable reports whether the path is one of the writable paths or is in
+// one of them.
+func isWritable(path string, writable []string) bool {
+	for _, w := range writable {
+		if path == w || strings.HasPrefix(path, w+string(filepath.Separator)) || w == string(filepath.Separator) {
+			return true
+		}
+	}
+	return false
+}
+
+// openHandler keeps the redirections done by the interpreter itself, which
+// the sandbox of the commands doesn't cover, from writing outside of the
+// writable paths.
+func (sb *Sandbox) openHandler() interp.OpenHandlerFunc {
+	open := interp.DefaultOpenHandler()
+	return func(ctx context.Context, path string, flag int, perm os.FileMode) (io.ReadWriteCloser, error) {
+		if flag&(os.O_WRONLY|os.O_RDWR|os.O_APPEND|os.O_CREATE|os.O_TRUNC) == 0 || path == os.DevNull {
+			return open(ctx, path, flag, perm)
+		}
+		hc := interp.HandlerCtx(ctx)
+		abs := path
+		if !filepath.IsAbs(abs) {
+			abs = filepath.Join(hc.Dir, abs)
+		}
+		// The file may not exist yet, resolve the links of its directory.
+		if dir, err := filepath.EvalSymlinks(filepath.Dir(abs)); err == nil {
+			abs = filepath.Join(dir, filepath.Base(abs))
+		}
+		if !isWritable(filepath.Clean(abs), sb.writablePaths()) {
+			return nil, fmt.Errorf("%s: read-only file system (sandbox)", path)
+		}
+		return open(ctx, path, flag, perm)
+	}
+}
+
+// environ returns the exported variables of the interpreter, as given to the
+// commands it runs.
+func environ(env expand.Environ) []string {
+	var list []string
+	for name, vr := range env.Each {
+		if vr.IsSet() && vr.Exported && vr.Kind == expand.String {
+			list = append(list, name+"="+vr.String())
+		}
+	}
+	return list
+}
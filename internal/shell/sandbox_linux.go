@@ -0,0 +1,297 @@
+package shell
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+	"mvdan.cc/sh/v3/interp"
+)
+
+// The commands are run through the crush binary itself, in new user and
+// mount namespaces, which sets the mounts up before running the command.
+const (
+	sandboxEnv  = "CRUSH_SANDBOX_INIT"
+	sandboxArg0 = "crush-sandbox"
+)
+
+// sandboxSetup is what the sandboxed process needs to set the mounts up.
+type sandboxSetup struct {
+	Writable       []string `json:"writable"`
+	IsolateNetwork bool     `json:"isolate_network"`
+}
+
+func init() {
+	if setup := os.Getenv(sandboxEnv); setup != "" {
+		runSandboxed(setup)
+	}
+}
+
+// runSandboxed sets the sandbox up and runs the command in place of the
+// current process.
+func runSandboxed(setupJSON string) {
+	fail := func(err error) {
+		fmt.Fprintf(os.Stderr, "crush: sandbox: %v\n", err)
+		os.Exit(126)
+	}
+	var setup sandboxSetup
+	if err := json.Unmarshal([]byte(setupJSON), &setup); err != nil {
+		fail(err)
+	}
+	if len(os.Args) < 2 {
+		fail(errors.New("missing command"))
+	}
+	if err := setupMounts(setup.Writable); err != nil {
+		fail(err)
+	}
+	if setup.IsolateNetwork {
+		if err := loopbackUp(); err != nil {
+			fail(err)
+		}
+	}
+	if err := dropCapabilities(); err != nil {
+		fail(err)
+	}
+
+	env := make([]string, 0, len(os.Environ()))
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, sandboxEnv+"=") {
+			env = append(env, kv)
+		}
+	}
+	// A probe of the sandbox runs no command.
+	if os.Args[1] == "" {
+		os.Exit(0)
+	}
+	if err := unix.Exec(os.Args[1], os.Args[2:], env); err != nil {
+		fail(err)
+	}
+}
+
+// setupMounts makes every mount read-only, except for the writable paths
+// which are bind mounted onto themselves beforehand so that they are mounts
+// of their own.
+func setupMounts(writable []string) error {
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("making mounts private: %w", err)
+	}
+	for _, path := range writable {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if err := unix.Mount(path, path, "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+			return fmt.Errorf("bind mounting %s: %w", path, err)
+		}
+	}
+
+	mounts, err := readMountInfo()
+	if err != nil {
+		return err
+	}
+	for _, m := range mounts {
+		if isWritable(m.point, writable) || slices.Contains(m.options, "ro") {
+			continue
+		}
+		flags := uintptr(unix.MS_REMOUNT | unix.MS_BIND | unix.MS_RDONLY)
+		// The flags locked by the parent namespace have to be kept.
+		for _, opt := range m.options {
+			flags |= mountFlags[opt]
+		}
+		if err := unix.Mount("", m.point, "", flags, ""); err != nil {
+			// The pseudo filesystems hold nothing for the sandbox to protect.
+			if isWritable(m.point, []string{"/proc", "/sys", "/dev"}) {
+				continue
+			}
+			return fmt.Errorf("remounting %s read-only: %w", m.point, err)
+		}
+	}
+
+	// The working directory still points to the mount it was in before.
+	wd, err := unix.Getwd()
+	if err != nil {
+		return err
+	}
+	return unix.Chdir(wd)
+}
+
+var mountFlags = map[string]uintptr{
+	"nosuid":      unix.MS_NOSUID,
+	"nodev":       unix.MS_NODEV,
+	"noexec":      unix.MS_NOEXEC,
+	"noatime":     unix.MS_NOATIME,
+	"nodiratime":  unix.MS_NODIRATIME,
+	"relatime":    unix.MS_RELATIME,
+	"strictatime": unix.MS_STRICTATIME,
+}
+
+type mountInfo struct {
+	point   string
+	options []string
+}
+
+// readMountInfo returns the mount points with their per-mount options.
+func readMountInfo() ([]mountInfo, error) {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var mounts []mountInfo
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 {
+			continue
+		}
+		mounts = append(mounts, mountInfo{
+			point:   unescapeMountPoint(fields[4]),
+			options: strings.Split(fields[5], ","),
+		})
+	}
+	return mounts, scanner.Err()
+}
+
+// unescapeMountPoint decodes the octal escapes of the spaces, tabs, newlines
+// and backslashes of the mount points.
+func unescapeMountPoint(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if c, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				sb.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+// loopbackUp brings the loopback interface of the new network namespace up,
+// so that the commands can still reach the servers they start themselves.
+func loopbackUp() error {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	ifreq, err := unix.NewIfreq("lo")
+	if err != nil {
+		return err
+	}
+	if err := unix.IoctlIfreq(fd, unix.SIOCGIFFLAGS, ifreq); err != nil {
+		return fmt.Errorf("reading the loopback flags: %w", err)
+	}
+	ifreq.SetUint16(ifreq.Uint16() | unix.IFF_UP)
+	if err := unix.IoctlIfreq(fd, unix.SIOCSIFFLAGS, ifreq); err != nil {
+		return fmt.Errorf("bringing the loopback up: %w", err)
+	}
+	return nil
+}
+
+// dropCapabilities keeps the command from getting back the capabilities of
+// the namespace, which would let it remount the filesystem read-write.
+func dropCapabilities() error {
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return fmt.Errorf("setting no new privileges: %w", err)
+	}
+	if err := unix.Prctl(unix.PR_CAP_AMBIENT, unix.PR_CAP_AMBIENT_CLEAR_ALL, 0, 0, 0); err != nil {
+		return fmt.Errorf("clearing ambient capabilities: %w", err)
+	}
+	for c := 0; c <= unix.CAP_LAST_CAP; c++ {
+		if err := unix.Prctl(unix.PR_CAPBSET_DROP, uintptr(c), 0, 0, 0); err != nil && !errors.Is(err, unix.EINVAL) {
+			return fmt.Errorf("dropping capability %d: %w", c, err)
+		}
+	}
+	// Root would get the inheritable capabilities back when running the
+	// command.
+	header := unix.CapUserHeader{Version: unix.LINUX_CAPABILITY_VERSION_3}
+	var data [2]unix.CapUserData
+	if err := unix.Capset(&header, &data[0]); err != nil {
+		return fmt.Errorf("clearing capabilities: %w", err)
+	}
+	return nil
+}
+
+// sandboxCommand returns the command running the given one in the sandbox.
+func sandboxCommand(sb *Sandbox, dir string, env []string, path string, args []string) (*exec.Cmd, error) {
+	setup, err := json.Marshal(sandboxSetup{
+		Writable:       sb.writablePaths(),
+		IsolateNetwork: sb.IsolateNetwork,
+	})
+	if err != nil {
+		return nil, err
+	}
+	cloneFlags := uintptr(syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS)
+	if sb.IsolateNetwork {
+		cloneFlags |= syscall.CLONE_NEWNET
+	}
+	return &exec.Cmd{
+		Path: "/proc/self/exe",
+		Args: append([]string{sandboxArg0, path}, args...),
+		Env:  append(env, sandboxEnv+"="+string(setup)),
+		Dir:  dir,
+		SysProcAttr: &syscall.SysProcAttr{
+			Cloneflags: cloneFlags,
+			// The user keeps the same IDs in the namespace, so that the
+			// files keep their owner, with the capabilities needed to set
+			// the mounts up until the command is run.
+			UidMappings:                []syscall.SysProcIDMap{{ContainerID: os.Getuid(), HostID: os.Getuid(), Size: 1}},
+			GidMappings:                []syscall.SysProcIDMap{{ContainerID: os.Getgid(), HostID: os.Getgid(), Size: 1}},
+			GidMappingsEnableSetgroups: false,
+			AmbientCaps:                []uintptr{unix.CAP_SYS_ADMIN, unix.CAP_NET_ADMIN, unix.CAP_SETPCAP},
+		},
+	}, nil
+}
+
+var sandboxAvailable = sync.OnceValue(func() error {
+	// Run a probe, which sets the sandbox up without running a command.
+	dir, err := os.Getwd()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	cmd, err := sandboxCommand(&Sandbox{IsolateNetwork: true}, dir, os.Environ(), "", nil)
+	if err != nil {
+		return err
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+})
+
+// sandboxHandler runs the commands in the sandbox, in place of the default
+// handler of the interpreter.
+func (s *Shell) sandboxHandler() func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc {
+	return func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc {
+		return func(ctx context.Context, args []string) error {
+			hc := interp.HandlerCtx(ctx)
+			path, err := interp.LookPathDir(hc.Dir, hc.Env, args[0])
+			if err != nil {
+				fmt.Fprintln(hc.Stderr, err)
+				return interp.ExitStatus(127)
+			}
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(hc.Dir, path)
+			}
+			return runCommand(ctx, hc, s.terminal, func() (*exec.Cmd, error) {
+				return sandboxCommand(s.sandbox, hc.Dir, environ(hc.Env), path, args)
+			})
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package shell
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/stretchr/testify/require"
+)
+
+// newSandboxTest returns the working directory and a directory outside of it
+// the sandbox doesn't let the commands write to.
+func newSandboxTest(t *testing.T) (workingDir, outside string) {
+	if err := SandboxAvailable(); err != nil {
+		t.Skipf("Sandbox not available: %v", err)
+	}
+	root := t.TempDir()
+	workingDir = filepath.Join(root, "work")
+	outside = filepath.Join(root, "outside")
+	tmp := filepath.Join(root, "tmp")
+	for _, dir := range []string{workingDir, outside, tmp} {
+		require.NoError(t, os.Mkdir(dir, 0o755))
+	}
+	// The temporary directory is writable, keep it apart.
+	t.Setenv("TMPDIR", tmp)
+	return workingDir, outside
+}
+
+func TestSandboxFilesystem(t *testing.T) {
+	workingDir, outside := newSandboxTest(t)
+	sh := NewShell(&Options{WorkingDir: workingDir, Sandbox: &Sandbox{WorkingDir: workingDir}})
+
+	_, stderr, err := sh.Exec(t.Context(), "touch inside.txt && mkdir -p sub && echo hi > sub/file.txt")
+	require.NoError(t, err, stderr)
+	require.FileExists(t, filepath.Join(workingDir, "inside.txt"))
+	require.FileExists(t, filepath.Join(workingDir, "sub", "file.txt"))
+
+	_, _, err = sh.Exec(t.Context(), "echo hi > $TMPDIR/tmp.txt")
+	require.NoError(t, err)
+
+	_, stderr, err = sh.Exec(t.Context(), "touch "+filepath.Join(outside, "touched.txt"))
+	require.Error(t, err)
+	require.Contains(t, stderr, "Read-only file system")
+	require.NoFileExists(t, filepath.Join(outside, "touched.txt"))
+
+	// Redirections are done by the interpreter itself.
+	_, _, err = sh.Exec(t.Context(), "echo hi > ../outside/redirected.txt")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "read-only file system")
+	require.NoFileExists(t, filepath.Join(outside, "redirected.txt"))
+
+	// Reading is fine, as well as writing to /dev/null.
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "read.txt"), []byte("content"), 0o644))
+	stdout, _, err := sh.Exec(t.Context(), "cat ../outside/read.txt 2>/dev/null")
+	require.NoError(t, err)
+	require.Equal(t, "content", stdout)
+
+	// The shell state is kept as without the sandbox.
+	_, _, err = sh.Exec(t.Context(), "cd sub && export FOO=bar")
+	require.NoError(t, err)
+	stdout, _, err = sh.Exec(t.Context(), "pwd && echo $FOO && sh -c 'echo $FOO'")
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(workingDir, "sub"), "bar", "bar"}, strings.Fields(stdout))
+}
+
+func TestSandboxWritablePaths(t *testing.T) {
+	workingDir, outside := newSandboxTest(t)
+	sh := NewShell(&Options{WorkingDir: workingDir, Sandbox: &Sandbox{WorkingDir: workingDir, WritablePaths: []string{"../outside"}}})
+
+	_, _, err := sh.Exec(t.Context(), "touch ../outside/touched.txt && echo hi > ../outside/redirected.txt")
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(outside, "touched.txt"))
+	require.FileExists(t, filepath.Join(outside, "redirected.txt"))
+}
+
+func TestSandboxChangedDirectory(t *testing.T) {
+	workingDir, outside := newSandboxTest(t)
+	t.Setenv("HOME", outside)
+	sb := &Sandbox{WorkingDir: workingDir}
+
+	// Changing directory doesn't make the new one writable.
+	sh := NewShell(&Options{WorkingDir: workingDir, Sandbox: sb})
+	_, _, err := sh.Exec(t.Context(), "cd / && touch ~/touched.txt")
+	require.Error(t, err)
+	_, _, err = sh.Exec(t.Context(), "cd ~ && echo hi > redirected.txt")
+	require.Error(t, err)
+
+	// Neither does starting the shell somewhere else.
+	sh = NewShell(&Options{WorkingDir: outside, Sandbox: sb})
+	_, _, err = sh.Exec(t.Context(), "touch started.txt")
+	require.Error(t, err)
+
+	require.NoFileExists(t, filepath.Join(outside, "touched.txt"))
+	require.NoFileExists(t, filepath.Join(outside, "redirected.txt"))
+	require.NoFileExists(t, filepath.Join(outside, "started.txt"))
+}
+
+func TestSandboxIsolateNetwork(t *testing.T) {
+	workingDir, _ := newSandboxTest(t)
+	sh := NewShell(&Options{WorkingDir: workingDir, Sandbox: &Sandbox{WorkingDir: workingDir, IsolateNetwork: true}})
+
+	stdout, _, err := sh.Exec(t.Context(), "cat /proc/net/dev")
+	require.NoError(t, err)
+	var interfaces []string
+	for _, line := range strings.Split(stdout, "\n") {
+		if name, _, ok := strings.Cut(line, ":"); ok {
+			interfaces = append(interfaces, strings.TrimSpace(name))
+		}
+	}
+	require.Equal(t, []string{"lo"}, interfaces)
+}
+
+func TestSandboxExitStatus(t *testing.T) {
+	workingDir, _ := newSandboxTest(t)
+	sh := NewShell(&Options{WorkingDir: workingDir, Sandbox: &Sandbox{WorkingDir: workingDir}})
+
+	_, _, err := sh.Exec(t.Context(), "sh -c 'exit 3'")
+	require.Equal(t, 3, ExitCode(err))
+
+	_, stderr, err := sh.Exec(t.Context(), "no-such-command-xyz")
+	require.Equal(t, 127, ExitCode(err))
+	require.Contains(t, stderr, "not found")
+}
+
+func TestSandboxBackgroundShell(t *testing.T) {
+	workingDir, outside := newSandboxTest(t)
+	manager := &BackgroundShellManager{shells: csync.NewMap[string, *BackgroundShell]()}
+	manager.SetSandbox(&Sandbox{WorkingDir: workingDir})
+
+	bgShell, err := manager.Start(t.Context(), "", workingDir, nil, "touch inside.txt ../outside/touched.txt", "")
+	require.NoError(t, err)
+	bgShell.Wait()
+
+	_, _, done, err := bgShell.GetOutput()
+	require.True(t, done)
+	require.Error(t, err)
+	require.FileExists(t, filepath.Join(workingDir, "inside.txt"))
+	require.NoFileExists(t, filepath.Join(outside, "touched.txt"))
+}
+
+func TestSandboxGoCoreUtils(t *testing.T) {
+	workingDir, outside := newSandboxTest(t)
+	goCoreUtils := useGoCoreUtils
+	useGoCoreUtils = true
+	t.Cleanup(func() { useGoCoreUtils = goCoreUtils })
+	kept := filepath.Join(outside, "kept.txt")
+	require.NoError(t, os.WriteFile(kept, []byte("content"), 0o644))
+
+	sh := NewShell(&Options{WorkingDir: workingDir, Sandbox: &Sandbox{WorkingDir: workingDir}})
+	_, _, err := sh.Exec(t.Context(), "rm ../outside/kept.txt")
+	require.Error(t, err)
+	require.FileExists(t, kept)
+}
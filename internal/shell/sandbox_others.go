@@ -0,0 +1,20 @@
+//go:build !linux
+
+package shell
+
+import (
+	"errors"
+
+	"mvdan.cc/sh/v3/interp"
+)
+
+func sandboxAvailable() error {
+	return errors.New("the sandbox is only available on Linux")
+}
+
+// sandboxHandler is never used as the sandbox is never available.
+func (s *Shell) sandboxHandler() func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc {
+	return func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc {
+		return next
+	}
+}
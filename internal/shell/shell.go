@@ -56,6 +56,9 @@ type Shell struct {
 	mu         sync.Mutex
 	logger     Logger
 	blockFuncs []BlockFunc
+	sandbox    *Sandbox
+	// terminal is set while running a command in a pseudo-terminal.
+	terminal bool
 }
 
 // Options for creating a new shell
@@ -64,6 +67,8 @@ type Options struct {
 	Env        []string
 	Logger     Logger
 	BlockFuncs []BlockFunc
+	// Sandbox runs the commands in a sandbox when set.
+	Sandbox *Sandbox
 }
 
 // NewShell creates a new shell instance with the given options
@@ -92,6 +97,7 @@ func NewShell(opts *Options) *Shell {
 		env:        env,
 		logger:     logger,
 		blockFuncs: opts.BlockFuncs,
+		sandbox:    opts.Sandbox,
 	}
 }
 
@@ -111,6 +117,27 @@ func (s *Shell) ExecStream(ctx context.Context, command string, stdout, stderr i
 	return s.execStream(ctx, command, stdout, stderr)
 }
 
+// ExecStdin executes a command in the shell, feeding stdin to its standard input
+func (s *Shell) ExecStdin(ctx context.Context, command string, stdin io.Reader) (string, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var stdout, stderr bytes.Buffer
+	err := s.execCommon(ctx, command, stdin, &stdout, &stderr)
+	return stdout.String(), stderr.String(), err
+}
+
+// ExecTerminal executes a command in the shell with the given pseudo-terminal
+// as its standard input and outputs
+func (s *Shell) ExecTerminal(ctx context.Context, command string, tty *os.File) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.terminal = true
+	defer func() { s.terminal = false }()
+	return s.execCommon(ctx, command, tty, tty, tty)
+}
+
 // GetWorkingDir returns the current working directory
 func (s *Shell) GetWorkingDir() string {
 	s.mu.Lock()
@@ -237,14 +264,19 @@ func (s *Shell) blockHandler() func(next interp.ExecHandlerFunc) interp.ExecHand
 }
 
 // newInterp creates a new interpreter with the current shell state
-func (s *Shell) newInterp(stdout, stderr io.Writer) (*interp.Runner, error) {
-	return interp.New(
-		interp.StdIO(nil, stdout, stderr),
+func (s *Shell) newInterp(stdin io.Reader, stdout, stderr io.Writer) (*interp.Runner, error) {
+	sandboxed := s.sandbox.enabled()
+	opts := []interp.RunnerOption{
+		interp.StdIO(stdin, stdout, stderr),
 		interp.Interactive(false),
 		interp.Env(expand.ListEnviron(s.env...)),
 		interp.Dir(s.cwd),
-		interp.ExecHandlers(s.execHandlers()...),
-	)
+		interp.ExecHandlers(s.execHandlers(sandboxed)...),
+	}
+	if sandboxed {
+		opts = append(opts, interp.OpenHandler(s.sandbox.openHandler()))
+	}
+	return interp.New(opts...)
 }
 
 // updateShellFromRunner updates the shell from the interpreter after execution
@@ -257,13 +289,13 @@ func (s *Shell) updateShellFromRunner(runner *interp.Runner) {
 }
 
 // execCommon is the shared implementation for executing commands
-func (s *Shell) execCommon(ctx context.Context, command string, stdout, stderr io.Writer) error {
+func (s *Shell) execCommon(ctx context.Context, command string, stdin io.Reader, stdout, stderr io.Writer) error {
 	line, err := syntax.NewParser().Parse(strings.NewReader(command), "")
 	if err != nil {
 		return fmt.Errorf("could not parse command: %w", err)
 	}
 
-	runner, err := s.newInterp(stdout, stderr)
+	runner, err := s.newInterp(stdin, stdout, stderr)
 	if err != nil {
 		return fmt.Errorf("could not run command: %w", err)
 	}
@@ -277,22 +309,30 @@ func (s *Shell) execCommon(ctx context.Context, command string, stdout, stderr i
 // exec executes commands using a cross-platform shell interpreter.
 func (s *Shell) exec(ctx context.Context, command string) (string, string, error) {
 	var stdout, stderr bytes.Buffer
-	err := s.execCommon(ctx, command, &stdout, &stderr)
+	err := s.execCommon(ctx, command, nil, &stdout, &stderr)
 	return stdout.String(), stderr.String(), err
 }
 
 // execStream executes commands using POSIX shell emulation with streaming output
 func (s *Shell) execStream(ctx context.Context, command string, stdout, stderr io.Writer) error {
-	return s.execCommon(ctx, command, stdout, stderr)
+	return s.execCommon(ctx, command, nil, stdout, stderr)
 }
 
-func (s *Shell) execHandlers() []func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc {
+func (s *Shell) execHandlers(sandboxed bool) []func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc {
 	handlers := []func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc{
 		s.blockHandler(),
 	}
-	if useGoCoreUtils {
+	// The Go coreutils run in this process, where the sandbox can't reach
+	// them.
+	if useGoCoreUtils && !sandboxed {
 		handlers = append(handlers, coreutils.ExecHandler)
 	}
+	switch {
+	case sandboxed:
+		handlers = append(handlers, s.sandboxHandler())
+	case s.terminal:
+		handlers = append(handlers, s.terminalHandler())
+	}
 	return handlers
 }
 
@@ -46,6 +46,17 @@ func TestTestTimeout(t *testing.T) {
 	}
 }
 
+func TestExecStdin(t *testing.T) {
+	shell := NewShell(&Options{WorkingDir: t.TempDir()})
+	stdout, _, err := shell.ExecStdin(t.Context(), "read line; echo \"got $line\"", strings.NewReader("hello\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.TrimSpace(stdout) != "got hello" {
+		t.Fatalf("Expected 'got hello', got %q", stdout)
+	}
+}
+
 func TestTestCancel(t *testing.T) {
 	ctx, cancel := context.WithCancel(t.Context())
 	cancel() // immediately cancel the context
@@ -0,0 +1,136 @@
+// Package todo stores the todo lists the agent keeps to track the steps of
+// long tasks.
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/crush/internal/db"
+	"github.com/charmbracelet/crush/internal/pubsub"
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	StatusPending    Status = "pending"
+	StatusInProgress Status = "in_progress"
+	StatusDone       Status = "done"
+)
+
+// Valid reports whether the status is a known one.
+func (s Status) Valid() bool {
+	switch s {
+	case StatusPending, StatusInProgress, StatusDone:
+		return true
+	}
+	return false
+}
+
+type Todo struct {
+	ID        string
+	SessionID string
+	Content   string
+	Status    Status
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+// List is the todo list of a session, published whenever it changes.
+type List struct {
+	SessionID string
+	Todos     []Todo
+}
+
+type Service interface {
+	pubsub.Suscriber[List]
+	List(ctx context.Context, sessionID string) ([]Todo, error)
+	// Set replaces the todo list of the session.
+	Set(ctx context.Context, sessionID string, todos []Todo) ([]Todo, error)
+}
+
+type service struct {
+	*pubsub.Broker[List]
+	db *sql.DB
+	q  *db.Queries
+}
+
+func NewService(q *db.Queries, db *sql.DB) Service {
+	return &service{
+		Broker: pubsub.NewBroker[List](),
+		q:      q,
+		db:     db,
+	}
+}
+
+func (s *service) List(ctx context.Context, sessionID string) ([]Todo, error) {
+	dbTodos, err := s.q.ListTodosBySession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	todos := make([]Todo, len(dbTodos))
+	for i, dbTodo := range dbTodos {
+		todos[i] = fromDBItem(dbTodo)
+	}
+	return todos, nil
+}
+
+func (s *service) Set(ctx context.Context, sessionID string, todos []Todo) ([]Todo, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+	qtx := s.q.WithTx(tx)
+
+	if err := qtx.DeleteSessionTodos(ctx, sessionID); err != nil {
+		return nil, err
+	}
+	created := make([]Todo, 0, len(todos))
+	for i, todo := range todos {
+		if !todo.Status.Valid() {
+			return nil, fmt.Errorf("invalid status %q", todo.Status)
+		}
+		dbTodo, err := qtx.CreateTodo(ctx, db.CreateTodoParams{
+			ID:        uuid.New().String(),
+			SessionID: sessionID,
+			Position:  int64(i),
+			Content:   strings.TrimSpace(todo.Content),
+			Status:    string(todo.Status),
+		})
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, fromDBItem(dbTodo))
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.Publish(pubsub.UpdatedEvent, List{SessionID: sessionID, Todos: created})
+	return created, nil
+}
+
+func fromDBItem(item db.Todo) Todo {
+	return Todo{
+		ID:        item.ID,
+		SessionID: item.SessionID,
+		Content:   item.Content,
+		Status:    Status(item.Status),
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+	}
+}
+
+// Format renders the todo list for the model, one numbered item per line
+// with its status.
+func Format(todos []Todo) string {
+	var sb strings.Builder
+	for i, todo := range todos {
+		fmt.Fprintf(&sb, "%d. [%s] %s\n", i+1, todo.Status, todo.Content)
+	}
+	return sb.String()
+}
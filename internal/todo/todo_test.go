@@ -0,0 +1,62 @@
+package todo_test
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/crush/internal/db"
+	"github.com/charmbracelet/crush/internal/session"
+	"github.com/charmbracelet/crush/internal/todo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSet(t *testing.T) {
+	conn, err := db.Connect(t.Context(), t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	q := db.New(conn)
+	sessions := session.NewService(q, conn)
+	todos := todo.NewService(q, conn)
+	events := todos.Subscribe(t.Context())
+
+	sess, err := sessions.Create(t.Context(), "todos")
+	require.NoError(t, err)
+
+	_, err = todos.Set(t.Context(), sess.ID, []todo.Todo{
+		{Content: "write the parser", Status: todo.StatusDone},
+		{Content: "add tests", Status: todo.StatusPending},
+	})
+	require.NoError(t, err)
+	event := <-events
+	require.Equal(t, sess.ID, event.Payload.SessionID)
+	require.Len(t, event.Payload.Todos, 2)
+
+	saved, err := todos.Set(t.Context(), sess.ID, []todo.Todo{
+		{Content: "write the parser", Status: todo.StatusDone},
+		{Content: " add tests ", Status: todo.StatusInProgress},
+		{Content: "update the docs", Status: todo.StatusPending},
+	})
+	require.NoError(t, err)
+	require.Len(t, saved, 3)
+	<-events
+
+	list, err := todos.List(t.Context(), sess.ID)
+	require.NoError(t, err)
+	require.Equal(t, saved, list)
+	require.Equal(t, "1. [done] write the parser\n2. [in_progress] add tests\n3. [pending] update the docs\n", todo.Format(list))
+
+	t.Run("invalid status", func(t *testing.T) {
+		_, err := todos.Set(t.Context(), sess.ID, []todo.Todo{{Content: "oops", Status: "blocked"}})
+		require.Error(t, err)
+		list, err := todos.List(t.Context(), sess.ID)
+		require.NoError(t, err)
+		require.Len(t, list, 3, "a failed update keeps the previous list")
+	})
+
+	t.Run("deleted with the session", func(t *testing.T) {
+		require.NoError(t, sessions.Delete(t.Context(), sess.ID))
+		list, err := todos.List(t.Context(), sess.ID)
+		require.NoError(t, err)
+		require.Empty(t, list)
+	})
+}
@@ -368,11 +368,23 @@ func (m *messageListCmp) messageExists(messageID string) bool {
 
 // handleDeleteMessage removes a message from the list.
 func (m *messageListCmp) handleDeleteMessage(msg message.Message) tea.Cmd {
+	// Tool calls and the section footer of an assistant message are separate
+	// items, remove them along with the message.
 	items := m.listCmp.Items()
 	for i := len(items) - 1; i >= 0; i-- {
-		if msgCmp, ok := items[i].(messages.MessageCmp); ok && msgCmp.GetMessage().ID == msg.ID {
-			m.listCmp.DeleteItem(items[i].ID())
-			return nil
+		switch item := items[i].(type) {
+		case messages.MessageCmp:
+			if item.GetMessage().ID == msg.ID {
+				m.listCmp.DeleteItem(item.ID())
+			}
+		case messages.ToolCallCmp:
+			if item.ParentMessageID() == msg.ID {
+				m.listCmp.DeleteItem(item.ID())
+			}
+		case messages.AssistantSection:
+			if item.MessageID() == msg.ID {
+				m.listCmp.DeleteItem(item.ID())
+			}
 		}
 	}
 	return nil
@@ -29,6 +29,31 @@ import (
 // CopyKey is the key binding for copying message content to the clipboard.
 var CopyKey = key.NewBinding(key.WithKeys("c", "y", "C", "Y"), key.WithHelp("c/y", "copy"))
 
+// ForkKey is the key binding for forking the session from the selected message.
+var ForkKey = key.NewBinding(key.WithKeys("F"), key.WithHelp("F", "fork"))
+
+// ForkSessionMsg requests forking the current session at the given message.
+type ForkSessionMsg struct {
+	MessageID string
+}
+
+// OpenOutputKey is the key binding for opening the full output of a tool call
+// in a pager, when it was too long to be sent to the model.
+var OpenOutputKey = key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "open full output"))
+
+// EditKey is the key binding for editing and resending a user message.
+var EditKey = key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "edit"))
+
+// EditRevertKey is the key binding for editing and resending a user message
+// while reverting the file changes made after it.
+var EditRevertKey = key.NewBinding(key.WithKeys("E"), key.WithHelp("E", "edit & revert files"))
+
+// EditMessageMsg requests editing the given user message and resending it.
+type EditMessageMsg struct {
+	Message     message.Message
+	RevertFiles bool
+}
+
 // ClearSelectionKey is the key binding for clearing the current selection in the chat interface.
 var ClearSelectionKey = key.NewBinding(key.WithKeys("esc", "alt+esc"), key.WithHelp("esc", "clear selection"))
 
@@ -114,6 +139,15 @@ func (m *messageCmp) Update(msg tea.Msg) (util.Model, tea.Cmd) {
 				util.ReportInfo("Message copied to clipboard"),
 			)
 		}
+		if key.Matches(msg, ForkKey) && m.message.ID != "" {
+			return m, util.CmdHandler(ForkSessionMsg{MessageID: m.message.ID})
+		}
+		if key.Matches(msg, EditKey, EditRevertKey) && m.message.Role == message.User {
+			return m, util.CmdHandler(EditMessageMsg{
+				Message:     m.message,
+				RevertFiles: key.Matches(msg, EditRevertKey),
+			})
+		}
 	}
 	return m, nil
 }
@@ -201,6 +235,14 @@ func (m *messageCmp) renderAssistantMessage() string {
 		details := t.S().Base.Foreground(t.FgSubtle).Width(m.textWidth() - 2).Render(finishedData.Details)
 		errorContent := fmt.Sprintf("%s\n\n%s", title, details)
 		return m.style().Render(errorContent)
+	} else if finished && content == "" && finishedData.Reason.Stopped() {
+		stopTag := t.S().Base.Padding(0, 1).Background(t.Warning).Foreground(t.White).Render("STOPPED")
+		truncated := ansi.Truncate(finishedData.Message, m.textWidth()-2-lipgloss.Width(stopTag), "...")
+		title := fmt.Sprintf("%s %s", stopTag, t.S().Base.Foreground(t.FgHalfMuted).Render(truncated))
+		details := t.S().Base.Foreground(t.FgSubtle).Width(m.textWidth() - 2).Render(
+			finishedData.Details + " Send a message to tell it how to go on.",
+		)
+		return m.style().Render(fmt.Sprintf("%s\n\n%s", title, details))
 	}
 
 	if thinkingContent != "" {
@@ -372,6 +414,7 @@ func (m *messageCmp) Spinning() bool {
 type AssistantSection interface {
 	list.Item
 	layout.Sizeable
+	MessageID() string
 }
 type assistantSectionModel struct {
 	width               int
@@ -385,6 +428,11 @@ func (m *assistantSectionModel) ID() string {
 	return m.id
 }
 
+// MessageID returns the ID of the assistant message the section belongs to.
+func (m *assistantSectionModel) MessageID() string {
+	return m.message.ID
+}
+
 func NewAssistantSection(message message.Message, lastUserMessageTime time.Time) AssistantSection {
 	return &assistantSectionModel{
 		width:               0,
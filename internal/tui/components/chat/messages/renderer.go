@@ -13,7 +13,9 @@ import (
 	"github.com/charmbracelet/crush/internal/agent/tools"
 	"github.com/charmbracelet/crush/internal/ansiext"
 	"github.com/charmbracelet/crush/internal/fsext"
+	"github.com/charmbracelet/crush/internal/todo"
 	"github.com/charmbracelet/crush/internal/tui/components/core"
+	todocomponent "github.com/charmbracelet/crush/internal/tui/components/todos"
 	"github.com/charmbracelet/crush/internal/tui/highlight"
 	"github.com/charmbracelet/crush/internal/tui/styles"
 	"github.com/charmbracelet/x/ansi"
@@ -166,10 +168,14 @@ func init() {
 	registry.register(tools.BashToolName, func() renderer { return bashRenderer{} })
 	registry.register(tools.JobOutputToolName, func() renderer { return bashOutputRenderer{} })
 	registry.register(tools.JobKillToolName, func() renderer { return bashKillRenderer{} })
+	registry.register(tools.JobListToolName, func() renderer { return jobListRenderer{} })
+	registry.register(tools.JobInputToolName, func() renderer { return jobInputRenderer{} })
 	registry.register(tools.DownloadToolName, func() renderer { return downloadRenderer{} })
 	registry.register(tools.ViewToolName, func() renderer { return viewRenderer{} })
 	registry.register(tools.EditToolName, func() renderer { return editRenderer{} })
 	registry.register(tools.MultiEditToolName, func() renderer { return multiEditRenderer{} })
+	registry.register(tools.NotebookEditToolName, func() renderer { return notebookEditRenderer{} })
+	registry.register(tools.ApplyPatchToolName, func() renderer { return applyPatchRenderer{} })
 	registry.register(tools.WriteToolName, func() renderer { return writeRenderer{} })
 	registry.register(tools.FetchToolName, func() renderer { return simpleFetchRenderer{} })
 	registry.register(tools.AgenticFetchToolName, func() renderer { return agenticFetchRenderer{} })
@@ -178,7 +184,14 @@ func init() {
 	registry.register(tools.GrepToolName, func() renderer { return grepRenderer{} })
 	registry.register(tools.LSToolName, func() renderer { return lsRenderer{} })
 	registry.register(tools.SourcegraphToolName, func() renderer { return sourcegraphRenderer{} })
+	registry.register(tools.TodosToolName, func() renderer { return todosRenderer{} })
 	registry.register(tools.DiagnosticsToolName, func() renderer { return diagnosticsRenderer{} })
+	registry.register(tools.DefinitionToolName, func() renderer { return lspSymbolRenderer{} })
+	registry.register(tools.HoverToolName, func() renderer { return lspSymbolRenderer{} })
+	registry.register(tools.DocumentSymbolsToolName, func() renderer { return documentSymbolsRenderer{} })
+	registry.register(tools.WorkspaceSymbolsToolName, func() renderer { return workspaceSymbolsRenderer{} })
+	registry.register(tools.RenameToolName, func() renderer { return renameRenderer{} })
+	registry.register(tools.CodeActionToolName, func() renderer { return codeActionRenderer{} })
 	registry.register(agent.AgentToolName, func() renderer { return agentRenderer{} })
 }
 
@@ -219,6 +232,7 @@ func (br bashRenderer) Render(v *toolCallCmp) string {
 	args := newParamBuilder().
 		addMain(cmd).
 		addFlag("background", params.RunInBackground).
+		addFlag("pty", params.PTY).
 		build()
 	if v.call.Finished {
 		var meta tools.BashResponseMetadata
@@ -384,6 +398,76 @@ func (bkr bashKillRenderer) Render(v *toolCallCmp) string {
 	return joinHeaderBody(header, body)
 }
 
+// -----------------------------------------------------------------------------
+//  Job Input renderer
+// -----------------------------------------------------------------------------
+
+// jobInputRenderer handles the input sent to a background shell
+type jobInputRenderer struct {
+	baseRenderer
+}
+
+// Render displays the input sent and the output that followed
+func (jir jobInputRenderer) Render(v *toolCallCmp) string {
+	var params tools.JobInputParams
+	if err := jir.unmarshalParams(v.call.Input, &params); err != nil {
+		return jir.renderError(v, "Invalid job_input parameters")
+	}
+
+	input := ansiext.Escape(params.Input)
+	if params.Enter {
+		input += "⏎"
+	}
+
+	width := v.textWidth()
+	if v.isNested {
+		width -= 4 // Adjust for nested tool call indentation
+	}
+	header := makeJobHeader(v, "Input", fmt.Sprintf("PID %s", params.ShellID), input, width)
+	if v.isNested {
+		return v.style().Render(header)
+	}
+	if res, done := earlyState(header, v); done {
+		return res
+	}
+	body := renderPlainContent(v, v.result.Content)
+	return joinHeaderBody(header, body)
+}
+
+// -----------------------------------------------------------------------------
+//  Job List renderer
+// -----------------------------------------------------------------------------
+
+// jobListRenderer handles the listing of the background shells
+type jobListRenderer struct {
+	baseRenderer
+}
+
+// Render displays the number of jobs and the list
+func (jlr jobListRenderer) Render(v *toolCallCmp) string {
+	var meta tools.JobListResponseMetadata
+	var count string
+	if v.result.Metadata != "" {
+		if err := jlr.unmarshalParams(v.result.Metadata, &meta); err == nil {
+			count = fmt.Sprintf("%d jobs", len(meta.Jobs))
+		}
+	}
+
+	width := v.textWidth()
+	if v.isNested {
+		width -= 4 // Adjust for nested tool call indentation
+	}
+	header := makeJobHeader(v, "List", count, "", width)
+	if v.isNested {
+		return v.style().Render(header)
+	}
+	if res, done := earlyState(header, v); done {
+		return res
+	}
+	body := renderPlainContent(v, v.result.Content)
+	return joinHeaderBody(header, body)
+}
+
 // -----------------------------------------------------------------------------
 //  View renderer
 // -----------------------------------------------------------------------------
@@ -405,11 +489,13 @@ func (vr viewRenderer) Render(v *toolCallCmp) string {
 		addMain(file).
 		addKeyValue("limit", formatNonZero(params.Limit)).
 		addKeyValue("offset", formatNonZero(params.Offset)).
+		addKeyValue("pages", params.Pages).
 		build()
 
 	return vr.renderWithParams(v, "View", args, func() string {
 		var meta tools.ViewResponseMetadata
-		if err := vr.unmarshalParams(v.result.Metadata, &meta); err != nil {
+		// PDFs, images and notebooks have no file content to highlight.
+		if err := vr.unmarshalParams(v.result.Metadata, &meta); err != nil || meta.Kind != "" {
 			return renderPlainContent(v, v.result.Content)
 		}
 		return renderCodeContent(v, meta.FilePath, meta.Content, params.Offset)
@@ -533,6 +619,145 @@ func (mer multiEditRenderer) Render(v *toolCallCmp) string {
 	})
 }
 
+// -----------------------------------------------------------------------------
+//  Notebook edit renderer
+// -----------------------------------------------------------------------------
+
+// notebookEditRenderer handles notebook cell edits, showing the diff of the
+// cells rather than of the JSON
+type notebookEditRenderer struct {
+	baseRenderer
+}
+
+// Render displays the edited cell and the diff of the cells
+func (nr notebookEditRenderer) Render(v *toolCallCmp) string {
+	t := styles.CurrentTheme()
+	var params tools.NotebookEditParams
+	var args []string
+	if err := nr.unmarshalParams(v.call.Input, &params); err == nil {
+		cell := params.CellID
+		if cell == "" && params.CellIndex != nil {
+			cell = fmt.Sprintf("%d", *params.CellIndex)
+		}
+		mode := params.EditMode
+		if mode == "" {
+			mode = tools.NotebookEditReplace
+		}
+		args = newParamBuilder().
+			addMain(fsext.PrettyPath(params.FilePath)).
+			addKeyValue("mode", mode).
+			addKeyValue("cell", cell).
+			build()
+	}
+
+	return nr.renderWithParams(v, "Notebook Edit", args, func() string {
+		var meta tools.NotebookEditResponseMetadata
+		if err := nr.unmarshalParams(v.result.Metadata, &meta); err != nil {
+			return renderPlainContent(v, v.result.Content)
+		}
+
+		formatter := core.DiffFormatter().
+			Before(fsext.PrettyPath(params.FilePath), meta.OldContent).
+			After(fsext.PrettyPath(params.FilePath), meta.NewContent).
+			Width(v.textWidth() - 2) // -2 for padding
+		if v.textWidth() > 120 {
+			formatter = formatter.Split()
+		}
+		// add a message to the bottom if the content was truncated
+		formatted := formatter.String()
+		if lipgloss.Height(formatted) > responseContextHeight {
+			contentLines := strings.Split(formatted, "\n")
+			truncateMessage := t.S().Muted.
+				Background(t.BgBaseLighter).
+				PaddingLeft(2).
+				Width(v.textWidth() - 2).
+				Render(fmt.Sprintf("… (%d lines)", len(contentLines)-responseContextHeight))
+			formatted = strings.Join(contentLines[:responseContextHeight], "\n") + "\n" + truncateMessage
+		}
+		return formatted
+	})
+}
+
+// -----------------------------------------------------------------------------
+//  Apply patch renderer
+// -----------------------------------------------------------------------------
+
+// applyPatchRenderer handles patches, showing the diff of each file
+type applyPatchRenderer struct {
+	baseRenderer
+}
+
+// Render displays the patched files, one diff after the other
+func (ar applyPatchRenderer) Render(v *toolCallCmp) string {
+	var params tools.ApplyPatchParams
+	var args []string
+	if err := ar.unmarshalParams(v.call.Input, &params); err == nil {
+		if paths := tools.PatchPaths(params.Patch); len(paths) > 0 {
+			builder := newParamBuilder().addMain(fsext.PrettyPath(paths[0]))
+			if len(paths) > 1 {
+				builder = builder.addKeyValue("files", fmt.Sprintf("%d", len(paths)))
+			}
+			args = builder.build()
+		}
+	}
+
+	return ar.renderWithParams(v, "Apply Patch", args, func() string {
+		var meta tools.ApplyPatchResponseMetadata
+		if err := ar.unmarshalParams(v.result.Metadata, &meta); err != nil || len(meta.Files) == 0 {
+			return renderPlainContent(v, v.result.Content)
+		}
+		return renderFilesDiff(v, meta.Files)
+	})
+}
+
+// renderFilesDiff shows the diffs of several files one after the other,
+// truncated as a whole.
+func renderFilesDiff(v *toolCallCmp, files []tools.ApplyPatchFile) string {
+	t := styles.CurrentTheme()
+	var sections []string
+	for _, file := range files {
+		sections = append(sections, t.S().Muted.Width(v.textWidth()-2).Render(patchFileTitle(file)))
+		if file.OldContent == file.NewContent {
+			continue
+		}
+		formatter := core.DiffFormatter().
+			Before(fsext.PrettyPath(file.Path), file.OldContent).
+			After(fsext.PrettyPath(file.Path), file.NewContent).
+			Width(v.textWidth() - 2) // -2 for padding
+		if v.textWidth() > 120 {
+			formatter = formatter.Split()
+		}
+		sections = append(sections, formatter.String())
+	}
+	// add a message to the bottom if the content was truncated
+	formatted := lipgloss.JoinVertical(lipgloss.Left, sections...)
+	if lipgloss.Height(formatted) > responseContextHeight {
+		contentLines := strings.Split(formatted, "\n")
+		truncateMessage := t.S().Muted.
+			Background(t.BgBaseLighter).
+			PaddingLeft(2).
+			Width(v.textWidth() - 2).
+			Render(fmt.Sprintf("… (%d lines)", len(contentLines)-responseContextHeight))
+		formatted = strings.Join(contentLines[:responseContextHeight], "\n") + "\n" + truncateMessage
+	}
+	return formatted
+}
+
+// patchFileTitle describes the change a patch made to a file.
+func patchFileTitle(file tools.ApplyPatchFile) string {
+	path := fsext.PrettyPath(file.Path)
+	switch {
+	case file.Kind == "add":
+		return fmt.Sprintf("Added %s", path)
+	case file.Kind == "delete":
+		return fmt.Sprintf("Deleted %s", path)
+	case file.MovePath != "":
+		return fmt.Sprintf("Moved %s to %s", path, fsext.PrettyPath(file.MovePath))
+	default:
+		return fmt.Sprintf("Updated %s", path)
+	}
+}
+
 // -----------------------------------------------------------------------------
 //  Write renderer
 // -----------------------------------------------------------------------------
@@ -832,6 +1057,37 @@ func (sr sourcegraphRenderer) Render(v *toolCallCmp) string {
 	})
 }
 
+// -----------------------------------------------------------------------------
+//  Todos renderer
+// -----------------------------------------------------------------------------
+
+// todosRenderer handles the todo list updates
+type todosRenderer struct {
+	baseRenderer
+}
+
+// Render displays the progress of the todo list along with its items
+func (tr todosRenderer) Render(v *toolCallCmp) string {
+	var params tools.TodosParams
+	var args []string
+	items := make([]todo.Todo, 0)
+	if err := tr.unmarshalParams(v.call.Input, &params); err == nil {
+		for _, item := range params.Todos {
+			items = append(items, todo.Todo{Content: item.Content, Status: todo.Status(item.Status)})
+		}
+		args = newParamBuilder().addMain(todocomponent.Progress(items) + " done").build()
+	}
+
+	return tr.renderWithParams(v, "Todos", args, func() string {
+		if len(items) == 0 {
+			return ""
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, todocomponent.RenderTodoList(items, todocomponent.RenderOptions{
+			MaxWidth: v.textWidth() - 2,
+		})...)
+	})
+}
+
 // -----------------------------------------------------------------------------
 //  Diagnostics renderer
 // -----------------------------------------------------------------------------
@@ -850,6 +1106,125 @@ func (dr diagnosticsRenderer) Render(v *toolCallCmp) string {
 	})
 }
 
+// -----------------------------------------------------------------------------
+//  LSP renderers
+// -----------------------------------------------------------------------------
+
+// lspSymbolRenderer handles the tools looking up a symbol on a line of a file
+type lspSymbolRenderer struct {
+	baseRenderer
+}
+
+// Render displays the symbol and where it was looked up
+func (lr lspSymbolRenderer) Render(v *toolCallCmp) string {
+	var params tools.DefinitionParams
+	var args []string
+	if err := lr.unmarshalParams(v.call.Input, &params); err == nil {
+		args = newParamBuilder().
+			addMain(params.Symbol).
+			addKeyValue("file", fmt.Sprintf("%s:%d", fsext.PrettyPath(params.FilePath), params.Line)).
+			build()
+	}
+
+	return lr.renderWithParams(v, prettifyToolName(v.call.Name), args, func() string {
+		return renderPlainContent(v, v.result.Content)
+	})
+}
+
+// documentSymbolsRenderer handles the outline of a file
+type documentSymbolsRenderer struct {
+	baseRenderer
+}
+
+// Render displays the file and its outline
+func (dr documentSymbolsRenderer) Render(v *toolCallCmp) string {
+	var params tools.DocumentSymbolsParams
+	var args []string
+	if err := dr.unmarshalParams(v.call.Input, &params); err == nil {
+		args = newParamBuilder().addMain(fsext.PrettyPath(params.FilePath)).build()
+	}
+
+	return dr.renderWithParams(v, "Document Symbols", args, func() string {
+		return renderPlainContent(v, v.result.Content)
+	})
+}
+
+// workspaceSymbolsRenderer handles symbol searches across the project
+type workspaceSymbolsRenderer struct {
+	baseRenderer
+}
+
+// Render displays the query and the symbols found
+func (wr workspaceSymbolsRenderer) Render(v *toolCallCmp) string {
+	var params tools.WorkspaceSymbolsParams
+	var args []string
+	if err := wr.unmarshalParams(v.call.Input, &params); err == nil {
+		args = newParamBuilder().addMain(params.Query).build()
+	}
+
+	return wr.renderWithParams(v, "Workspace Symbols", args, func() string {
+		return renderPlainContent(v, v.result.Content)
+	})
+}
+
+// renameRenderer handles symbol renames with the diffs of the files changed
+type renameRenderer struct {
+	baseRenderer
+}
+
+// Render displays the rename and the diffs of the files it changed
+func (rr renameRenderer) Render(v *toolCallCmp) string {
+	var params tools.RenameParams
+	var args []string
+	if err := rr.unmarshalParams(v.call.Input, &params); err == nil {
+		args = newParamBuilder().
+			addMain(fmt.Sprintf("%s → %s", params.Symbol, params.NewName)).
+			addKeyValue("file", fsext.PrettyPath(params.FilePath)).
+			build()
+	}
+
+	return rr.renderWithParams(v, "Rename", args, func() string {
+		var meta tools.RenameResponseMetadata
+		if err := rr.unmarshalParams(v.result.Metadata, &meta); err != nil || len(meta.Files) == 0 {
+			return renderPlainContent(v, v.result.Content)
+		}
+		return renderFilesDiff(v, meta.Files)
+	})
+}
+
+// codeActionRenderer handles code actions, listed or applied
+type codeActionRenderer struct {
+	baseRenderer
+}
+
+// Render displays the code actions listed, or the diffs of the files changed
+// by the one applied
+func (cr codeActionRenderer) Render(v *toolCallCmp) string {
+	var params tools.CodeActionParams
+	var args []string
+	if err := cr.unmarshalParams(v.call.Input, &params); err == nil {
+		lines := fmt.Sprintf("%d", params.Line)
+		if params.EndLine > params.Line {
+			lines = fmt.Sprintf("%d-%d", params.Line, params.EndLine)
+		}
+		builder := newParamBuilder().
+			addMain(fsext.PrettyPath(params.FilePath)).
+			addKeyValue("lines", lines)
+		if params.Action > 0 {
+			builder = builder.addKeyValue("action", fmt.Sprintf("%d", params.Action))
+		}
+		args = builder.build()
+	}
+
+	return cr.renderWithParams(v, "Code Action", args, func() string {
+		var meta tools.CodeActionResponseMetadata
+		if err := cr.unmarshalParams(v.result.Metadata, &meta); err != nil || len(meta.Files) == 0 {
+			return renderPlainContent(v, v.result.Content)
+		}
+		return renderFilesDiff(v, meta.Files)
+	})
+}
+
 // -----------------------------------------------------------------------------
 //  Task renderer
 // -----------------------------------------------------------------------------
@@ -1169,12 +1544,34 @@ func prettifyToolName(name string) string {
 		return "Job: Output"
 	case tools.JobKillToolName:
 		return "Job: Kill"
+	case tools.JobListToolName:
+		return "Job: List"
+	case tools.JobInputToolName:
+		return "Job: Input"
 	case tools.DownloadToolName:
 		return "Download"
 	case tools.EditToolName:
 		return "Edit"
 	case tools.MultiEditToolName:
 		return "Multi-Edit"
+	case tools.NotebookEditToolName:
+		return "Notebook Edit"
+	case tools.ApplyPatchToolName:
+		return "Apply Patch"
+	case tools.ReferencesToolName:
+		return "References"
+	case tools.DefinitionToolName:
+		return "Definition"
+	case tools.HoverToolName:
+		return "Hover"
+	case tools.DocumentSymbolsToolName:
+		return "Document Symbols"
+	case tools.WorkspaceSymbolsToolName:
+		return "Workspace Symbols"
+	case tools.RenameToolName:
+		return "Rename"
+	case tools.CodeActionToolName:
+		return "Code Action"
 	case tools.FetchToolName:
 		return "Fetch"
 	case tools.AgenticFetchToolName:
@@ -1189,6 +1586,8 @@ func prettifyToolName(name string) string {
 		return "List"
 	case tools.SourcegraphToolName:
 		return "Sourcegraph"
+	case tools.TodosToolName:
+		return "Todos"
 	case tools.ViewToolName:
 		return "View"
 	case tools.WriteToolName:
@@ -1,9 +1,12 @@
 package messages
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"time"
 
@@ -168,10 +171,43 @@ func (m *toolCallCmp) Update(msg tea.Msg) (util.Model, tea.Cmd) {
 		if key.Matches(msg, CopyKey) {
 			return m, m.copyTool()
 		}
+		if key.Matches(msg, ForkKey) && !m.isNested && m.parentMessageID != "" {
+			return m, util.CmdHandler(ForkSessionMsg{MessageID: m.parentMessageID})
+		}
+		if key.Matches(msg, OpenOutputKey) {
+			return m, m.openArtifact()
+		}
 	}
 	return m, nil
 }
 
+// openArtifact opens the full output of the tool in a pager when it was
+// saved to an artifact file.
+func (m *toolCallCmp) openArtifact() tea.Cmd {
+	var meta tools.ArtifactMetadata
+	if m.result.Metadata == "" || json.Unmarshal([]byte(m.result.Metadata), &meta) != nil || meta.ArtifactPath == "" {
+		return nil
+	}
+	if _, err := os.Stat(meta.ArtifactPath); err != nil {
+		return util.ReportWarn("The full output is no longer available")
+	}
+	pager := os.Getenv("PAGER")
+	if pager == "" {
+		if runtime.GOOS == "windows" {
+			pager = "more"
+		} else {
+			pager = "less"
+		}
+	}
+	path := "'" + strings.ReplaceAll(meta.ArtifactPath, "'", `'\''`) + "'"
+	return util.ExecShell(context.TODO(), pager+" "+path, func(err error) tea.Msg {
+		if err != nil {
+			return util.ReportError(err)
+		}
+		return nil
+	})
+}
+
 // View renders the tool call component based on its current state.
 // Shows either a pending animation or the tool-specific rendered result.
 func (m *toolCallCmp) View() string {
@@ -264,6 +300,9 @@ func (m *toolCallCmp) formatParametersForCopy() string {
 			if params.Offset > 0 {
 				parts = append(parts, fmt.Sprintf("**Offset:** %d", params.Offset))
 			}
+			if params.Pages != "" {
+				parts = append(parts, fmt.Sprintf("**Pages:** %s", params.Pages))
+			}
 			return strings.Join(parts, "\n")
 		}
 	case tools.EditToolName:
@@ -279,6 +318,26 @@ func (m *toolCallCmp) formatParametersForCopy() string {
 			parts = append(parts, fmt.Sprintf("**Edits:** %d", len(params.Edits)))
 			return strings.Join(parts, "\n")
 		}
+	case tools.NotebookEditToolName:
+		var params tools.NotebookEditParams
+		if json.Unmarshal([]byte(m.call.Input), &params) == nil {
+			var parts []string
+			parts = append(parts, fmt.Sprintf("**File:** %s", fsext.PrettyPath(params.FilePath)))
+			if params.EditMode != "" {
+				parts = append(parts, fmt.Sprintf("**Mode:** %s", params.EditMode))
+			}
+			if params.CellID != "" {
+				parts = append(parts, fmt.Sprintf("**Cell:** %s", params.CellID))
+			} else if params.CellIndex != nil {
+				parts = append(parts, fmt.Sprintf("**Cell:** %d", *params.CellIndex))
+			}
+			return strings.Join(parts, "\n")
+		}
+	case tools.ApplyPatchToolName:
+		var params tools.ApplyPatchParams
+		if json.Unmarshal([]byte(m.call.Input), &params) == nil {
+			return fmt.Sprintf("**Patch:**\n```diff\n%s\n```", strings.TrimSuffix(params.Patch, "\n"))
+		}
 	case tools.WriteToolName:
 		var params tools.WriteParams
 		if json.Unmarshal([]byte(m.call.Input), &params) == nil {
@@ -373,6 +432,49 @@ func (m *toolCallCmp) formatParametersForCopy() string {
 		}
 	case tools.DiagnosticsToolName:
 		return "**Project:** diagnostics"
+	case tools.DefinitionToolName, tools.HoverToolName:
+		var params tools.DefinitionParams
+		if json.Unmarshal([]byte(m.call.Input), &params) == nil {
+			var parts []string
+			parts = append(parts, fmt.Sprintf("**File:** %s", fsext.PrettyPath(params.FilePath)))
+			parts = append(parts, fmt.Sprintf("**Line:** %d", params.Line))
+			parts = append(parts, fmt.Sprintf("**Symbol:** %s", params.Symbol))
+			return strings.Join(parts, "\n")
+		}
+	case tools.DocumentSymbolsToolName:
+		var params tools.DocumentSymbolsParams
+		if json.Unmarshal([]byte(m.call.Input), &params) == nil {
+			return fmt.Sprintf("**File:** %s", fsext.PrettyPath(params.FilePath))
+		}
+	case tools.WorkspaceSymbolsToolName:
+		var params tools.WorkspaceSymbolsParams
+		if json.Unmarshal([]byte(m.call.Input), &params) == nil {
+			return fmt.Sprintf("**Query:** %s", params.Query)
+		}
+	case tools.RenameToolName:
+		var params tools.RenameParams
+		if json.Unmarshal([]byte(m.call.Input), &params) == nil {
+			var parts []string
+			parts = append(parts, fmt.Sprintf("**File:** %s", fsext.PrettyPath(params.FilePath)))
+			parts = append(parts, fmt.Sprintf("**Line:** %d", params.Line))
+			parts = append(parts, fmt.Sprintf("**Symbol:** %s", params.Symbol))
+			parts = append(parts, fmt.Sprintf("**New Name:** %s", params.NewName))
+			return strings.Join(parts, "\n")
+		}
+	case tools.CodeActionToolName:
+		var params tools.CodeActionParams
+		if json.Unmarshal([]byte(m.call.Input), &params) == nil {
+			var parts []string
+			parts = append(parts, fmt.Sprintf("**File:** %s", fsext.PrettyPath(params.FilePath)))
+			parts = append(parts, fmt.Sprintf("**Line:** %d", params.Line))
+			if params.EndLine > params.Line {
+				parts = append(parts, fmt.Sprintf("**End Line:** %d", params.EndLine))
+			}
+			if params.Action > 0 {
+				parts = append(parts, fmt.Sprintf("**Action:** %d", params.Action))
+			}
+			return strings.Join(parts, "\n")
+		}
 	case agent.AgentToolName:
 		var params agent.AgentParams
 		if json.Unmarshal([]byte(m.call.Input), &params) == nil {
@@ -406,6 +508,14 @@ func (m *toolCallCmp) formatResultForCopy() string {
 		return m.formatEditResultForCopy()
 	case tools.MultiEditToolName:
 		return m.formatMultiEditResultForCopy()
+	case tools.NotebookEditToolName:
+		return m.formatNotebookEditResultForCopy()
+	case tools.ApplyPatchToolName:
+		return m.formatApplyPatchResultForCopy()
+	case tools.RenameToolName:
+		return m.formatRenameResultForCopy()
+	case tools.CodeActionToolName:
+		return m.formatCodeActionResultForCopy()
 	case tools.WriteToolName:
 		return m.formatWriteResultForCopy()
 	case tools.FetchToolName:
@@ -416,7 +526,7 @@ func (m *toolCallCmp) formatResultForCopy() string {
 		return m.formatWebFetchResultForCopy()
 	case agent.AgentToolName:
 		return m.formatAgentResultForCopy()
-	case tools.DownloadToolName, tools.GrepToolName, tools.GlobToolName, tools.LSToolName, tools.SourcegraphToolName, tools.DiagnosticsToolName:
+	case tools.DownloadToolName, tools.GrepToolName, tools.GlobToolName, tools.LSToolName, tools.SourcegraphToolName, tools.DiagnosticsToolName, tools.DefinitionToolName, tools.DocumentSymbolsToolName, tools.WorkspaceSymbolsToolName:
 		return fmt.Sprintf("```\n%s\n```", m.result.Content)
 	default:
 		return m.result.Content
@@ -561,6 +671,92 @@ func (m *toolCallCmp) formatMultiEditResultForCopy() string {
 	return result.String()
 }
 
+func (m *toolCallCmp) formatNotebookEditResultForCopy() string {
+	var meta tools.NotebookEditResponseMetadata
+	if m.result.Metadata == "" {
+		return m.result.Content
+	}
+
+	if json.Unmarshal([]byte(m.result.Metadata), &meta) != nil {
+		return m.result.Content
+	}
+
+	var params tools.NotebookEditParams
+	json.Unmarshal([]byte(m.call.Input), &params)
+
+	var result strings.Builder
+	if meta.OldContent != "" || meta.NewContent != "" {
+		fileName := params.FilePath
+		if fileName != "" {
+			fileName = fsext.PrettyPath(fileName)
+		}
+		diffContent, additions, removals := diff.GenerateDiff(meta.OldContent, meta.NewContent, fileName)
+
+		result.WriteString(fmt.Sprintf("Changes: +%d -%d\n", additions, removals))
+		result.WriteString("```diff\n")
+		result.WriteString(diffContent)
+		result.WriteString("\n```")
+	}
+
+	return result.String()
+}
+
+func (m *toolCallCmp) formatApplyPatchResultForCopy() string {
+	var meta tools.ApplyPatchResponseMetadata
+	if m.result.Metadata == "" {
+		return m.result.Content
+	}
+
+	if json.Unmarshal([]byte(m.result.Metadata), &meta) != nil {
+		return m.result.Content
+	}
+
+	return formatFilesDiffForCopy(meta.Files, meta.Additions, meta.Removals)
+}
+
+func (m *toolCallCmp) formatRenameResultForCopy() string {
+	var meta tools.RenameResponseMetadata
+	if m.result.Metadata == "" {
+		return m.result.Content
+	}
+
+	if json.Unmarshal([]byte(m.result.Metadata), &meta) != nil {
+		return m.result.Content
+	}
+
+	return formatFilesDiffForCopy(meta.Files, meta.Additions, meta.Removals)
+}
+
+func (m *toolCallCmp) formatCodeActionResultForCopy() string {
+	var meta tools.CodeActionResponseMetadata
+	if m.result.Metadata == "" {
+		return m.result.Content
+	}
+
+	if json.Unmarshal([]byte(m.result.Metadata), &meta) != nil || len(meta.Files) == 0 {
+		return m.result.Content
+	}
+
+	return formatFilesDiffForCopy(meta.Files, meta.Additions, meta.Removals)
+}
+
+func formatFilesDiffForCopy(files []tools.ApplyPatchFile, additions, removals int) string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Changes: +%d -%d\n", additions, removals))
+	result.WriteString("```diff\n")
+	for _, file := range files {
+		newPath := file.Path
+		if file.MovePath != "" {
+			newPath = file.MovePath
+		}
+		diffContent, _, _ := diff.GenerateDiff(file.OldContent, file.NewContent, fsext.PrettyPath(newPath))
+		result.WriteString(diffContent)
+	}
+	result.WriteString("```")
+
+	return result.String()
+}
+
 func (m *toolCallCmp) formatWriteResultForCopy() string {
 	var params tools.WriteParams
 	if json.Unmarshal([]byte(m.call.Input), &params) != nil {
@@ -18,6 +18,7 @@ import (
 	"github.com/charmbracelet/crush/internal/lsp"
 	"github.com/charmbracelet/crush/internal/pubsub"
 	"github.com/charmbracelet/crush/internal/session"
+	"github.com/charmbracelet/crush/internal/todo"
 	"github.com/charmbracelet/crush/internal/tui/components/chat"
 	"github.com/charmbracelet/crush/internal/tui/components/core"
 	"github.com/charmbracelet/crush/internal/tui/components/core/layout"
@@ -25,6 +26,7 @@ import (
 	"github.com/charmbracelet/crush/internal/tui/components/logo"
 	lspcomponent "github.com/charmbracelet/crush/internal/tui/components/lsp"
 	"github.com/charmbracelet/crush/internal/tui/components/mcp"
+	todocomponent "github.com/charmbracelet/crush/internal/tui/components/todos"
 	"github.com/charmbracelet/crush/internal/tui/styles"
 	"github.com/charmbracelet/crush/internal/tui/util"
 	"github.com/charmbracelet/crush/internal/version"
@@ -44,6 +46,7 @@ const (
 	DefaultMaxFilesShown = 10
 	DefaultMaxLSPsShown  = 8
 	DefaultMaxMCPsShown  = 8
+	DefaultMaxTodosShown = 10
 	MinItemsPerSection   = 2 // Minimum items to show per section
 )
 
@@ -57,6 +60,11 @@ type SessionFilesMsg struct {
 	Files []SessionFile
 }
 
+type SessionTodosMsg struct {
+	SessionID string
+	Todos     []todo.Todo
+}
+
 type Sidebar interface {
 	util.Model
 	layout.Sizeable
@@ -73,12 +81,15 @@ type sidebarCmp struct {
 	compactMode   bool
 	history       history.Service
 	files         *csync.Map[string, SessionFile]
+	todoService   todo.Service
+	todos         []todo.Todo
 }
 
-func New(history history.Service, lspClients *csync.Map[string, *lsp.Client], compact bool) Sidebar {
+func New(history history.Service, todos todo.Service, lspClients *csync.Map[string, *lsp.Client], compact bool) Sidebar {
 	return &sidebarCmp{
 		lspClients:  lspClients,
 		history:     history,
+		todoService: todos,
 		compactMode: compact,
 		files:       csync.NewMap[string, SessionFile](),
 	}
@@ -97,8 +108,20 @@ func (m *sidebarCmp) Update(msg tea.Msg) (util.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case SessionTodosMsg:
+		if msg.SessionID == m.session.ID {
+			m.todos = msg.Todos
+		}
+		return m, nil
+	case pubsub.Event[todo.List]:
+		if msg.Payload.SessionID == m.session.ID {
+			m.todos = msg.Payload.Todos
+		}
+		return m, nil
+
 	case chat.SessionClearedMsg:
 		m.session = session.Session{}
+		m.todos = nil
 	case pubsub.Event[history.File]:
 		return m, m.handleFileHistoryEvent(msg)
 	case pubsub.Event[session.Session]:
@@ -159,6 +182,9 @@ func (m *sidebarCmp) View() string {
 		}
 	} else {
 		// Vertical layout (default)
+		if len(m.todos) > 0 {
+			parts = append(parts, "", m.todosBlock())
+		}
 		if m.session.ID != "" {
 			parts = append(parts, "", m.filesBlock())
 		}
@@ -265,6 +291,21 @@ func (m *sidebarCmp) loadSessionFiles() tea.Msg {
 	}
 }
 
+func (m *sidebarCmp) loadSessionTodos() tea.Msg {
+	sessionID := m.session.ID
+	todos, err := m.todoService.List(context.Background(), sessionID)
+	if err != nil {
+		return util.InfoMsg{
+			Type: util.InfoTypeError,
+			Msg:  err.Error(),
+		}
+	}
+	return SessionTodosMsg{
+		SessionID: sessionID,
+		Todos:     todos,
+	}
+}
+
 func (m *sidebarCmp) SetSize(width, height int) tea.Cmd {
 	m.logo = m.logoBlock()
 	m.cwd = cwd()
@@ -320,12 +361,23 @@ func (m *sidebarCmp) calculateAvailableHeight() int {
 
 	usedHeight += 6 // 3 sections × 2 lines each (header + empty line)
 
+	if len(m.todos) > 0 {
+		// The todos come first, header, empty line and items included.
+		usedHeight += 2 + m.maxTodosShown()
+	}
+
 	// Base padding
 	usedHeight += 2 // Top and bottom padding
 
 	return max(0, m.height-usedHeight)
 }
 
+// maxTodosShown returns how many todos to show, they take priority over the
+// other sections.
+func (m *sidebarCmp) maxTodosShown() int {
+	return min(len(m.todos), DefaultMaxTodosShown)
+}
+
 // getDynamicLimits calculates how many items to show in each section based on available height
 func (m *sidebarCmp) getDynamicLimits() (maxFiles, maxLSPs, maxMCPs int) {
 	availableHeight := m.calculateAvailableHeight()
@@ -366,11 +418,15 @@ func (m *sidebarCmp) getDynamicLimits() (maxFiles, maxLSPs, maxMCPs int) {
 	return maxFiles, maxLSPs, maxMCPs
 }
 
-// renderSectionsHorizontal renders the files, LSPs, and MCPs sections horizontally
+// renderSectionsHorizontal renders the todos, files, LSPs, and MCPs sections horizontally
 func (m *sidebarCmp) renderSectionsHorizontal() string {
 	// Calculate available width for each section
 	totalWidth := m.width - 4 // Account for padding and spacing
-	sectionWidth := min(50, totalWidth/3)
+	sections := 3
+	if len(m.todos) > 0 {
+		sections++
+	}
+	sectionWidth := min(50, totalWidth/sections)
 
 	// Get the sections content with limited height
 	var filesContent, lspContent, mcpContent string
@@ -379,9 +435,30 @@ func (m *sidebarCmp) renderSectionsHorizontal() string {
 	lspContent = m.lspBlockCompact(sectionWidth)
 	mcpContent = m.mcpBlockCompact(sectionWidth)
 
+	if len(m.todos) > 0 {
+		todosContent := m.todosBlockCompact(sectionWidth)
+		return lipgloss.JoinHorizontal(lipgloss.Top, todosContent, " ", filesContent, " ", lspContent, " ", mcpContent)
+	}
 	return lipgloss.JoinHorizontal(lipgloss.Top, filesContent, " ", lspContent, " ", mcpContent)
 }
 
+// todosBlockCompact renders the todos block with limited width and height for horizontal layout
+func (m *sidebarCmp) todosBlockCompact(maxWidth int) string {
+	// Limit items for horizontal layout
+	maxItems := min(5, len(m.todos))
+	availableHeight := m.height - 8
+	if availableHeight > 0 {
+		maxItems = min(maxItems, availableHeight)
+	}
+
+	return todocomponent.RenderTodoBlock(m.todos, todocomponent.RenderOptions{
+		MaxWidth:    maxWidth,
+		MaxItems:    maxItems,
+		ShowSection: true,
+		SectionName: "Todos " + todocomponent.Progress(m.todos),
+	}, true)
+}
+
 // filesBlockCompact renders the files block with limited width and height for horizontal layout
 func (m *sidebarCmp) filesBlockCompact(maxWidth int) string {
 	// Convert map to slice and handle type conversion
@@ -449,6 +526,16 @@ func (m *sidebarCmp) mcpBlockCompact(maxWidth int) string {
 	}, true)
 }
 
+func (m *sidebarCmp) todosBlock() string {
+	t := styles.CurrentTheme()
+	return todocomponent.RenderTodoBlock(m.todos, todocomponent.RenderOptions{
+		MaxWidth:    m.getMaxWidth(),
+		MaxItems:    m.maxTodosShown(),
+		ShowSection: true,
+		SectionName: core.SectionWithInfo("Todos", m.getMaxWidth(), t.S().Subtle.Render(todocomponent.Progress(m.todos))),
+	}, true)
+}
+
 func (m *sidebarCmp) filesBlock() string {
 	// Convert map to slice and handle type conversion
 	sessionFiles := slices.Collect(m.files.Seq())
@@ -505,7 +592,16 @@ func (m *sidebarCmp) mcpBlock() string {
 	}, true)
 }
 
-func formatTokensAndCost(tokens, contextWindow int64, cost float64) string {
+// cacheHitRatio returns the share of the prompt tokens that were read from the
+// cache, and whether anything was cached.
+func cacheHitRatio(input, cacheRead, cacheCreation int64) (float64, bool) {
+	if cacheRead+cacheCreation == 0 {
+		return 0, false
+	}
+	return float64(cacheRead) / float64(input+cacheRead+cacheCreation), true
+}
+
+func formatTokensAndCost(tokens, contextWindow, input, cacheRead, cacheCreation int64, cost float64) string {
 	t := styles.CurrentTheme()
 	// Format tokens in human-readable format (e.g., 110K, 1.2M)
 	var formattedTokens string
@@ -540,6 +636,11 @@ func formatTokensAndCost(tokens, contextWindow int64, cost float64) string {
 		formattedTokens = fmt.Sprintf("%s %s", styles.WarningIcon, formattedTokens)
 	}
 
+	if ratio, ok := cacheHitRatio(input, cacheRead, cacheCreation); ok {
+		formattedCache := baseStyle.Foreground(t.FgSubtle).Render(fmt.Sprintf("%d%% cached", int(ratio*100)))
+		formattedTokens = fmt.Sprintf("%s %s", formattedTokens, formattedCache)
+	}
+
 	return fmt.Sprintf("%s %s", formattedTokens, formattedCost)
 }
 
@@ -585,6 +686,9 @@ func (s *sidebarCmp) currentModelBlock() string {
 			"  "+formatTokensAndCost(
 				s.session.CompletionTokens+s.session.PromptTokens,
 				model.ContextWindow,
+				s.session.InputTokens,
+				s.session.CacheReadTokens,
+				s.session.CacheCreationTokens,
 				s.session.Cost,
 			),
 		)
@@ -598,7 +702,8 @@ func (s *sidebarCmp) currentModelBlock() string {
 // SetSession implements Sidebar.
 func (m *sidebarCmp) SetSession(session session.Session) tea.Cmd {
 	m.session = session
-	return m.loadSessionFiles
+	m.todos = nil
+	return tea.Batch(m.loadSessionFiles, m.loadSessionTodos)
 }
 
 // SetCompactMode sets the compact mode for the sidebar.
@@ -81,10 +81,13 @@ type (
 	ToggleCompactModeMsg   struct{}
 	ToggleThinkingMsg      struct{}
 	OpenReasoningDialogMsg struct{}
+	OpenJobsDialogMsg      struct{}
 	OpenExternalEditorMsg  struct{}
 	ToggleYoloModeMsg      struct{}
 	CompactMsg             struct {
 		SessionID string
+		// Instructions tell what the summary should focus on.
+		Instructions string
 	}
 )
 
@@ -354,6 +357,39 @@ func (c *commandDialogCmp) defaultCommands() []Command {
 					SessionID: c.sessionID,
 				})
 			},
+		}, Command{
+			ID:          "compact",
+			Title:       "压缩会话",
+			Description: "总结较早的消息并保留最近的对话，可指定摘要重点",
+			Handler: func(cmd Command) tea.Cmd {
+				sessionID := c.sessionID
+				return util.CmdHandler(dialogs.OpenDialogMsg{
+					Model: NewCommandArgumentsDialog(
+						cmd.ID,
+						cmd.Title,
+						cmd.ID,
+						cmd.Description,
+						[]Argument{{
+							Name:        "focus",
+							Title:       "摘要重点",
+							Description: "摘要应关注的内容（可选）",
+						}},
+						func(args map[string]string) tea.Cmd {
+							return util.CmdHandler(CompactMsg{
+								SessionID:    sessionID,
+								Instructions: args["focus"],
+							})
+						},
+					),
+				})
+			},
+		}, Command{
+			ID:          "jobs",
+			Title:       "后台任务",
+			Description: "查看后台任务的输出或终止任务",
+			Handler: func(cmd Command) tea.Cmd {
+				return util.CmdHandler(OpenJobsDialogMsg{})
+			},
 		})
 	}
 
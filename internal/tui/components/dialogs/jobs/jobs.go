@@ -0,0 +1,382 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/help"
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/crush/internal/ansiext"
+	"github.com/charmbracelet/crush/internal/job"
+	"github.com/charmbracelet/crush/internal/pubsub"
+	"github.com/charmbracelet/crush/internal/shell"
+	"github.com/charmbracelet/crush/internal/tui/components/core"
+	"github.com/charmbracelet/crush/internal/tui/components/dialogs"
+	"github.com/charmbracelet/crush/internal/tui/styles"
+	"github.com/charmbracelet/crush/internal/tui/util"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	JobsDialogID dialogs.DialogID = "jobs"
+
+	// refreshInterval is how often the output of the selected job is read
+	// again while following it.
+	refreshInterval = time.Second
+	// tailBytes is how much of the end of the logs is read for the output.
+	tailBytes = 64 * 1024
+)
+
+// JobsDialog interface for the background jobs dialog
+type JobsDialog interface {
+	dialogs.DialogModel
+}
+
+type (
+	jobsLoadedMsg struct {
+		jobs []job.Job
+	}
+	outputLoadedMsg struct {
+		jobID  string
+		output []string
+	}
+	// refreshMsg is tied to the dialog that scheduled it and to the time it
+	// was opened, so that a dialog opened again doesn't follow the ticks
+	// scheduled before.
+	refreshMsg struct {
+		dialog     *jobsDialogCmp
+		generation int
+	}
+)
+
+type jobsDialogCmp struct {
+	wWidth    int
+	wHeight   int
+	width     int
+	service   job.Service
+	sessionID string
+	jobs      []job.Job
+	selected  int
+	output    []string
+	following bool
+	// generation counts the times the dialog was opened.
+	generation int
+	keyMap     KeyMap
+	help       help.Model
+}
+
+// NewJobsDialogCmp creates the dialog listing the background jobs of the
+// session, with the output of the selected one.
+func NewJobsDialogCmp(service job.Service, sessionID string) JobsDialog {
+	t := styles.CurrentTheme()
+	help := help.New()
+	help.Styles = t.S().Help
+	return &jobsDialogCmp{
+		service:   service,
+		sessionID: sessionID,
+		following: true,
+		keyMap:    DefaultKeyMap(),
+		help:      help,
+	}
+}
+
+func (j *jobsDialogCmp) Init() tea.Cmd {
+	j.generation++
+	return tea.Batch(j.loadJobs, j.tick())
+}
+
+func (j *jobsDialogCmp) Update(msg tea.Msg) (util.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		j.wWidth = msg.Width
+		j.wHeight = msg.Height
+		j.width = min(120, j.wWidth-8)
+		return j, nil
+	case jobsLoadedMsg:
+		var selectedID string
+		if selected, ok := j.selectedJob(); ok {
+			selectedID = selected.ID
+		}
+		// The most recent jobs come first.
+		j.jobs = slices.Clone(msg.jobs)
+		slices.Reverse(j.jobs)
+		j.selected = 0
+		for i, item := range j.jobs {
+			if item.ID == selectedID {
+				j.selected = i
+			}
+		}
+		return j, j.loadOutput()
+	case outputLoadedMsg:
+		if selected, ok := j.selectedJob(); ok && selected.ID == msg.jobID {
+			j.output = msg.output
+		}
+		return j, nil
+	case refreshMsg:
+		if msg.dialog != j || msg.generation != j.generation {
+			return j, nil
+		}
+		if !j.following {
+			return j, j.tick()
+		}
+		return j, tea.Batch(j.loadJobs, j.tick())
+	case pubsub.Event[job.Job]:
+		if msg.Payload.SessionID != j.sessionID {
+			return j, nil
+		}
+		return j, j.loadJobs
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, j.keyMap.Next):
+			if j.selected < len(j.jobs)-1 {
+				j.selected++
+				j.output = nil
+				return j, j.loadOutput()
+			}
+		case key.Matches(msg, j.keyMap.Previous):
+			if j.selected > 0 {
+				j.selected--
+				j.output = nil
+				return j, j.loadOutput()
+			}
+		case key.Matches(msg, j.keyMap.Follow):
+			j.following = !j.following
+			if j.following {
+				return j, tea.Batch(j.loadJobs, j.loadOutput())
+			}
+		case key.Matches(msg, j.keyMap.Kill):
+			return j, j.killSelected()
+		case key.Matches(msg, j.keyMap.Close):
+			return j, util.CmdHandler(dialogs.CloseDialogMsg{})
+		}
+	}
+	return j, nil
+}
+
+func (j *jobsDialogCmp) tick() tea.Cmd {
+	generation := j.generation
+	return tea.Tick(refreshInterval, func(time.Time) tea.Msg {
+		return refreshMsg{dialog: j, generation: generation}
+	})
+}
+
+func (j *jobsDialogCmp) loadJobs() tea.Msg {
+	jobs, err := j.service.List(context.Background(), j.sessionID)
+	if err != nil {
+		return util.ReportError(err)()
+	}
+	return jobsLoadedMsg{jobs: jobs}
+}
+
+func (j *jobsDialogCmp) loadOutput() tea.Cmd {
+	selected, ok := j.selectedJob()
+	if !ok {
+		return nil
+	}
+	lines := j.outputHeight()
+	return func() tea.Msg {
+		return outputLoadedMsg{jobID: selected.ID, output: jobOutput(selected, lines)}
+	}
+}
+
+func (j *jobsDialogCmp) killSelected() tea.Cmd {
+	selected, ok := j.selectedJob()
+	if !ok || selected.Status != job.StatusRunning {
+		return nil
+	}
+	manager := shell.GetBackgroundShellManager()
+	bgShell, ok := manager.GetByJobID(selected.ID)
+	if !ok {
+		return util.ReportWarn("The job is not running in this instance of Crush")
+	}
+	return func() tea.Msg {
+		if err := manager.Kill(bgShell.ID); err != nil {
+			return util.ReportError(err)()
+		}
+		return util.InfoMsg{
+			Type: util.InfoTypeInfo,
+			Msg:  fmt.Sprintf("Job %s killed", bgShell.ID),
+		}
+	}
+}
+
+func (j *jobsDialogCmp) selectedJob() (job.Job, bool) {
+	if j.selected < 0 || j.selected >= len(j.jobs) {
+		return job.Job{}, false
+	}
+	return j.jobs[j.selected], true
+}
+
+func (j *jobsDialogCmp) View() string {
+	t := styles.CurrentTheme()
+	parts := []string{
+		t.S().Base.Padding(0, 1, 1, 1).Render(core.Title("Background Jobs", j.width-4)),
+		j.renderJobs(),
+		"",
+		j.renderOutput(),
+		"",
+		t.S().Base.Width(j.width - 2).PaddingLeft(1).AlignHorizontal(lipgloss.Left).Render(j.help.View(j.keyMap)),
+	}
+	return j.style().Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+}
+
+func (j *jobsDialogCmp) renderJobs() string {
+	t := styles.CurrentTheme()
+	base := t.S().Base.PaddingLeft(1)
+	if len(j.jobs) == 0 {
+		return base.Foreground(t.FgMuted).Render("No background jobs in this session")
+	}
+
+	height := j.listHeight()
+	start := max(0, min(j.selected-height/2, len(j.jobs)-height))
+	end := min(len(j.jobs), start+height)
+	width := j.width - 4
+	lines := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		item := j.jobs[i]
+		shellID := "-"
+		if bgShell, ok := shell.GetBackgroundShellManager().GetByJobID(item.ID); ok {
+			shellID = bgShell.ID
+		}
+		prefix := fmt.Sprintf("%s %-4s %-9s %8s  ", statusIcon(item), shellID, item.Status, item.Duration().Round(time.Second))
+		label := item.Command
+		if item.Description != "" {
+			label = item.Description + ": " + item.Command
+		}
+		line := prefix + ansi.Truncate(label, width-lipgloss.Width(prefix), "…")
+		style := base.Width(j.width - 2)
+		if i == j.selected {
+			style = style.Background(t.Primary).Foreground(t.FgSelected)
+		}
+		lines = append(lines, style.Render(line))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (j *jobsDialogCmp) renderOutput() string {
+	t := styles.CurrentTheme()
+	base := t.S().Base.PaddingLeft(1)
+	title := "Output"
+	if !j.following {
+		title += " (paused)"
+	}
+	section := base.Render(core.Section(title, j.width-4))
+	selected, ok := j.selectedJob()
+	if !ok {
+		return section
+	}
+
+	lines := make([]string, 0, j.outputHeight())
+	for _, line := range j.output {
+		lines = append(lines, base.Foreground(t.FgHalfMuted).Render(ansi.Truncate(line, j.width-4, "…")))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, base.Foreground(t.FgMuted).Render("No output"))
+	}
+	switch selected.Status {
+	case job.StatusRunning:
+	case job.StatusKilled:
+		lines = append(lines, base.Foreground(t.FgMuted).Render(
+			fmt.Sprintf("Killed after %s", selected.Duration().Round(time.Second)),
+		))
+	default:
+		lines = append(lines, base.Foreground(t.FgMuted).Render(
+			fmt.Sprintf("Exited with code %d after %s", selected.ExitCode, selected.Duration().Round(time.Second)),
+		))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, append([]string{section}, lines...)...)
+}
+
+func statusIcon(j job.Job) string {
+	t := styles.CurrentTheme()
+	switch j.Status {
+	case job.StatusRunning:
+		return t.ItemBusyIcon.String()
+	case job.StatusCompleted:
+		return t.S().Base.Foreground(t.Green).Render(styles.CheckIcon)
+	case job.StatusFailed:
+		return t.S().Base.Foreground(t.RedDark).Render(styles.ErrorIcon)
+	default:
+		return t.S().Muted.Render(styles.ErrorIcon)
+	}
+}
+
+// jobOutput returns the last lines of the output of the job, the standard
+// error coming after the standard output.
+func jobOutput(j job.Job, lines int) []string {
+	stdout := tailLines(j.StdoutPath, lines)
+	stderr := tailLines(j.StderrPath, lines)
+	output := append(stdout, stderr...)
+	if len(output) > lines {
+		output = output[len(output)-lines:]
+	}
+	return output
+}
+
+// tailLines returns the last lines of a log file, reading only its end.
+func tailLines(path string, n int) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil
+	}
+	offset := max(0, info.Size()-tailBytes)
+	buf := make([]byte, info.Size()-offset)
+	if _, err := f.ReadAt(buf, offset); err != nil && err != io.EOF {
+		return nil
+	}
+	content := strings.TrimRight(ansiext.Strip(string(buf)), "\n")
+	if content == "" {
+		return nil
+	}
+	lines := strings.Split(content, "\n")
+	if offset > 0 && len(lines) > 1 {
+		// The first line is likely cut.
+		lines = lines[1:]
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
+
+func (j *jobsDialogCmp) style() lipgloss.Style {
+	t := styles.CurrentTheme()
+	return t.S().Base.
+		Width(j.width).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.BorderFocus)
+}
+
+func (j *jobsDialogCmp) listHeight() int {
+	return max(3, j.wHeight/4)
+}
+
+func (j *jobsDialogCmp) outputHeight() int {
+	// 10 for the border, title, section, exit code, help and the spacing
+	// between them, 4 for the margins.
+	return max(3, j.wHeight-j.listHeight()-14)
+}
+
+func (j *jobsDialogCmp) Position() (int, int) {
+	row := 2
+	col := j.wWidth / 2
+	col -= j.width / 2
+	return row, col
+}
+
+// ID implements JobsDialog.
+func (j *jobsDialogCmp) ID() dialogs.DialogID {
+	return JobsDialogID
+}
@@ -0,0 +1,73 @@
+package jobs
+
+import (
+	"charm.land/bubbles/v2/key"
+)
+
+type KeyMap struct {
+	Next,
+	Previous,
+	Follow,
+	Kill,
+	Close key.Binding
+}
+
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Next: key.NewBinding(
+			key.WithKeys("down", "j", "ctrl+n"),
+			key.WithHelp("↓", "next job"),
+		),
+		Previous: key.NewBinding(
+			key.WithKeys("up", "k", "ctrl+p"),
+			key.WithHelp("↑", "previous job"),
+		),
+		Follow: key.NewBinding(
+			key.WithKeys("f", "enter"),
+			key.WithHelp("f", "follow output"),
+		),
+		Kill: key.NewBinding(
+			key.WithKeys("x", "ctrl+x"),
+			key.WithHelp("x", "kill"),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("esc", "alt+esc"),
+			key.WithHelp("esc", "exit"),
+		),
+	}
+}
+
+// KeyBindings implements layout.KeyMapProvider
+func (k KeyMap) KeyBindings() []key.Binding {
+	return []key.Binding{
+		k.Next,
+		k.Previous,
+		k.Follow,
+		k.Kill,
+		k.Close,
+	}
+}
+
+// FullHelp implements help.KeyMap.
+func (k KeyMap) FullHelp() [][]key.Binding {
+	m := [][]key.Binding{}
+	slice := k.KeyBindings()
+	for i := 0; i < len(slice); i += 4 {
+		end := min(i+4, len(slice))
+		m = append(m, slice[i:end])
+	}
+	return m
+}
+
+// ShortHelp implements help.KeyMap.
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		key.NewBinding(
+			key.WithKeys("down", "up"),
+			key.WithHelp("↑↓", "choose"),
+		),
+		k.Follow,
+		k.Kill,
+		k.Close,
+	}
+}
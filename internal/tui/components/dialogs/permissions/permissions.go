@@ -92,7 +92,7 @@ func (p *permissionDialogCmp) Init() tea.Cmd {
 }
 
 func (p *permissionDialogCmp) supportsDiffView() bool {
-	return p.permission.ToolName == tools.EditToolName || p.permission.ToolName == tools.WriteToolName || p.permission.ToolName == tools.MultiEditToolName
+	return p.permission.ToolName == tools.EditToolName || p.permission.ToolName == tools.WriteToolName || p.permission.ToolName == tools.MultiEditToolName || p.permission.ToolName == tools.NotebookEditToolName || p.permission.ToolName == tools.ApplyPatchToolName || p.permission.ToolName == tools.RenameToolName || p.permission.ToolName == tools.CodeActionToolName
 }
 
 func (p *permissionDialogCmp) Update(msg tea.Msg) (util.Model, tea.Cmd) {
@@ -315,6 +315,21 @@ func (p *permissionDialogCmp) renderHeader() string {
 			baseStyle.Render(strings.Repeat(" ", p.width)),
 			t.S().Muted.Width(p.width).Render("Command"),
 		)
+	case tools.JobInputToolName:
+		params := p.permission.Params.(tools.JobInputPermissionsParams)
+		jobKey := t.S().Muted.Render("Job")
+		jobValue := t.S().Text.
+			Width(p.width - lipgloss.Width(jobKey)).
+			Render(fmt.Sprintf(" %s", params.Command))
+		headerParts = append(headerParts,
+			lipgloss.JoinHorizontal(
+				lipgloss.Left,
+				jobKey,
+				jobValue,
+			),
+			baseStyle.Render(strings.Repeat(" ", p.width)),
+			t.S().Muted.Width(p.width).Render("Input"),
+		)
 	case tools.DownloadToolName:
 		params := p.permission.Params.(tools.DownloadPermissionsParams)
 		urlKey := t.S().Muted.Render("URL")
@@ -381,6 +396,92 @@ func (p *permissionDialogCmp) renderHeader() string {
 			),
 			baseStyle.Render(strings.Repeat(" ", p.width)),
 		)
+	case tools.NotebookEditToolName:
+		params := p.permission.Params.(tools.NotebookEditPermissionsParams)
+		fileKey := t.S().Muted.Render("File")
+		filePath := t.S().Text.
+			Width(p.width - lipgloss.Width(fileKey)).
+			Render(fmt.Sprintf(" %s", fsext.PrettyPath(params.FilePath)))
+		headerParts = append(headerParts,
+			lipgloss.JoinHorizontal(
+				lipgloss.Left,
+				fileKey,
+				filePath,
+			),
+			baseStyle.Render(strings.Repeat(" ", p.width)),
+		)
+	case tools.ApplyPatchToolName:
+		params := p.permission.Params.(tools.ApplyPatchPermissionsParams)
+		filesKey := t.S().Muted.Render("Files")
+		var paths []string
+		for _, file := range params.Files {
+			paths = append(paths, " "+patchFileTitle(file))
+		}
+		filePaths := t.S().Text.
+			Width(p.width - lipgloss.Width(filesKey)).
+			Render(strings.Join(paths, "\n"))
+		headerParts = append(headerParts,
+			lipgloss.JoinHorizontal(
+				lipgloss.Left,
+				filesKey,
+				filePaths,
+			),
+			baseStyle.Render(strings.Repeat(" ", p.width)),
+		)
+	case tools.RenameToolName:
+		params := p.permission.Params.(tools.RenamePermissionsParams)
+		symbolKey := t.S().Muted.Render("Symbol")
+		symbolValue := t.S().Text.
+			Width(p.width - lipgloss.Width(symbolKey)).
+			Render(fmt.Sprintf(" %s → %s", params.Symbol, params.NewName))
+		filesKey := t.S().Muted.Render("Files")
+		var paths []string
+		for _, file := range params.Files {
+			paths = append(paths, " "+patchFileTitle(file))
+		}
+		filePaths := t.S().Text.
+			Width(p.width - lipgloss.Width(filesKey)).
+			Render(strings.Join(paths, "\n"))
+		headerParts = append(headerParts,
+			lipgloss.JoinHorizontal(
+				lipgloss.Left,
+				symbolKey,
+				symbolValue,
+			),
+			lipgloss.JoinHorizontal(
+				lipgloss.Left,
+				filesKey,
+				filePaths,
+			),
+			baseStyle.Render(strings.Repeat(" ", p.width)),
+		)
+	case tools.CodeActionToolName:
+		params := p.permission.Params.(tools.CodeActionPermissionsParams)
+		actionKey := t.S().Muted.Render("Action")
+		actionValue := t.S().Text.
+			Width(p.width - lipgloss.Width(actionKey)).
+			Render(" " + params.Title)
+		filesKey := t.S().Muted.Render("Files")
+		var paths []string
+		for _, file := range params.Files {
+			paths = append(paths, " "+patchFileTitle(file))
+		}
+		filePaths := t.S().Text.
+			Width(p.width - lipgloss.Width(filesKey)).
+			Render(strings.Join(paths, "\n"))
+		headerParts = append(headerParts,
+			lipgloss.JoinHorizontal(
+				lipgloss.Left,
+				actionKey,
+				actionValue,
+			),
+			lipgloss.JoinHorizontal(
+				lipgloss.Left,
+				filesKey,
+				filePaths,
+			),
+			baseStyle.Render(strings.Repeat(" ", p.width)),
+		)
 	case tools.FetchToolName:
 		headerParts = append(headerParts,
 			baseStyle.Render(strings.Repeat(" ", p.width)),
@@ -435,6 +536,8 @@ func (p *permissionDialogCmp) getOrGenerateContent() string {
 	switch p.permission.ToolName {
 	case tools.BashToolName:
 		content = p.generateBashContent()
+	case tools.JobInputToolName:
+		content = p.generateJobInputContent()
 	case tools.DownloadToolName:
 		content = p.generateDownloadContent()
 	case tools.EditToolName:
@@ -443,6 +546,14 @@ func (p *permissionDialogCmp) getOrGenerateContent() string {
 		content = p.generateWriteContent()
 	case tools.MultiEditToolName:
 		content = p.generateMultiEditContent()
+	case tools.NotebookEditToolName:
+		content = p.generateNotebookEditContent()
+	case tools.ApplyPatchToolName:
+		content = p.generateApplyPatchContent()
+	case tools.RenameToolName:
+		content = p.generateRenameContent()
+	case tools.CodeActionToolName:
+		content = p.generateCodeActionContent()
 	case tools.FetchToolName:
 		content = p.generateFetchContent()
 	case tools.AgenticFetchToolName:
@@ -463,46 +574,55 @@ func (p *permissionDialogCmp) getOrGenerateContent() string {
 }
 
 func (p *permissionDialogCmp) generateBashContent() string {
-	t := styles.CurrentTheme()
-	baseStyle := t.S().Base.Background(t.BgSubtle)
 	if pr, ok := p.permission.Params.(tools.BashPermissionsParams); ok {
-		content := pr.Command
-		t := styles.CurrentTheme()
-		content = strings.TrimSpace(content)
-		lines := strings.Split(content, "\n")
-
-		width := p.width - 4
-		var out []string
-		for _, ln := range lines {
-			out = append(out, t.S().Muted.
-				Width(width).
-				Padding(0, 3).
-				Foreground(t.FgBase).
-				Background(t.BgSubtle).
-				Render(ln))
-		}
+		return p.generateCommandContent(pr.Command)
+	}
+	return ""
+}
 
-		// Ensure minimum of 7 lines for command display
-		minLines := 7
-		for len(out) < minLines {
-			out = append(out, t.S().Muted.
-				Width(width).
-				Padding(0, 3).
-				Foreground(t.FgBase).
-				Background(t.BgSubtle).
-				Render(""))
-		}
+func (p *permissionDialogCmp) generateJobInputContent() string {
+	if pr, ok := p.permission.Params.(tools.JobInputPermissionsParams); ok {
+		return p.generateCommandContent(pr.Input)
+	}
+	return ""
+}
 
-		// Use the cache for markdown rendering
-		renderedContent := strings.Join(out, "\n")
-		finalContent := baseStyle.
-			Width(p.contentViewPort.Width()).
-			Padding(1, 0).
-			Render(renderedContent)
+// generateCommandContent renders a command, or the input typed in one.
+func (p *permissionDialogCmp) generateCommandContent(content string) string {
+	t := styles.CurrentTheme()
+	baseStyle := t.S().Base.Background(t.BgSubtle)
+	content = strings.TrimSpace(content)
+	lines := strings.Split(content, "\n")
 
-		return finalContent
+	width := p.width - 4
+	var out []string
+	for _, ln := range lines {
+		out = append(out, t.S().Muted.
+			Width(width).
+			Padding(0, 3).
+			Foreground(t.FgBase).
+			Background(t.BgSubtle).
+			Render(ln))
 	}
-	return ""
+
+	// Ensure minimum of 7 lines for command display
+	minLines := 7
+	for len(out) < minLines {
+		out = append(out, t.S().Muted.
+			Width(width).
+			Padding(0, 3).
+			Foreground(t.FgBase).
+			Background(t.BgSubtle).
+			Render(""))
+	}
+
+	renderedContent := strings.Join(out, "\n")
+	finalContent := baseStyle.
+		Width(p.contentViewPort.Width()).
+		Padding(1, 0).
+		Render(renderedContent)
+
+	return finalContent
 }
 
 func (p *permissionDialogCmp) generateEditContent() string {
@@ -588,6 +708,94 @@ func (p *permissionDialogCmp) generateMultiEditContent() string {
 	return ""
 }
 
+func (p *permissionDialogCmp) generateNotebookEditContent() string {
+	if pr, ok := p.permission.Params.(tools.NotebookEditPermissionsParams); ok {
+		formatter := core.DiffFormatter().
+			Before(fsext.PrettyPath(pr.FilePath), pr.OldContent).
+			After(fsext.PrettyPath(pr.FilePath), pr.NewContent).
+			Height(p.contentViewPort.Height()).
+			Width(p.contentViewPort.Width()).
+			XOffset(p.diffXOffset).
+			YOffset(p.diffYOffset)
+		if p.useDiffSplitMode() {
+			formatter = formatter.Split()
+		} else {
+			formatter = formatter.Unified()
+		}
+
+		diff := formatter.String()
+		return diff
+	}
+	return ""
+}
+
+// generateApplyPatchContent shows the diffs of all the files of a patch one
+// after the other, scrolled as a whole.
+func (p *permissionDialogCmp) generateApplyPatchContent() string {
+	if pr, ok := p.permission.Params.(tools.ApplyPatchPermissionsParams); ok {
+		return p.generateFilesDiffContent(pr.Files)
+	}
+	return ""
+}
+
+func (p *permissionDialogCmp) generateRenameContent() string {
+	if pr, ok := p.permission.Params.(tools.RenamePermissionsParams); ok {
+		return p.generateFilesDiffContent(pr.Files)
+	}
+	return ""
+}
+
+func (p *permissionDialogCmp) generateCodeActionContent() string {
+	if pr, ok := p.permission.Params.(tools.CodeActionPermissionsParams); ok {
+		return p.generateFilesDiffContent(pr.Files)
+	}
+	return ""
+}
+
+// generateFilesDiffContent shows the diffs of several files one after the
+// other, scrolled as a whole.
+func (p *permissionDialogCmp) generateFilesDiffContent(files []tools.ApplyPatchFile) string {
+	t := styles.CurrentTheme()
+	var lines []string
+	for _, file := range files {
+		lines = append(lines, t.S().Muted.Width(p.contentViewPort.Width()).Render(patchFileTitle(file)))
+		if file.OldContent == file.NewContent {
+			continue
+		}
+		formatter := core.DiffFormatter().
+			Before(fsext.PrettyPath(file.Path), file.OldContent).
+			After(fsext.PrettyPath(file.Path), file.NewContent).
+			Width(p.contentViewPort.Width()).
+			XOffset(p.diffXOffset)
+		if p.useDiffSplitMode() {
+			formatter = formatter.Split()
+		} else {
+			formatter = formatter.Unified()
+		}
+		lines = append(lines, strings.Split(formatter.String(), "\n")...)
+	}
+
+	height := max(p.contentViewPort.Height(), 1)
+	p.diffYOffset = max(0, min(p.diffYOffset, len(lines)-height))
+	end := min(p.diffYOffset+height, len(lines))
+	return strings.Join(lines[p.diffYOffset:end], "\n")
+}
+
+// patchFileTitle describes the change a patch makes to a file.
+func patchFileTitle(file tools.ApplyPatchFile) string {
+	path := fsext.PrettyPath(file.Path)
+	switch {
+	case file.Kind == "add":
+		return fmt.Sprintf("Add %s", path)
+	case file.Kind == "delete":
+		return fmt.Sprintf("Delete %s", path)
+	case file.MovePath != "":
+		return fmt.Sprintf("Move %s to %s", path, fsext.PrettyPath(file.MovePath))
+	default:
+		return fmt.Sprintf("Update %s", path)
+	}
+}
+
 func (p *permissionDialogCmp) generateFetchContent() string {
 	t := styles.CurrentTheme()
 	baseStyle := t.S().Base.Background(t.BgSubtle)
@@ -626,6 +834,9 @@ func (p *permissionDialogCmp) generateViewContent() string {
 		if pr.Limit > 0 && pr.Limit != 2000 { // 2000 is the default limit
 			content += fmt.Sprintf("\nLines to read: %d", pr.Limit)
 		}
+		if pr.Pages != "" {
+			content += fmt.Sprintf("\nPages to read: %s", pr.Pages)
+		}
 
 		finalContent := baseStyle.
 			Padding(1, 2).
@@ -803,7 +1014,7 @@ func (p *permissionDialogCmp) SetSize() tea.Cmd {
 	oldWidth, oldHeight := p.width, p.height
 
 	switch p.permission.ToolName {
-	case tools.BashToolName:
+	case tools.BashToolName, tools.JobInputToolName:
 		p.width = int(float64(p.wWidth) * 0.8)
 		p.height = int(float64(p.wHeight) * 0.3)
 	case tools.DownloadToolName:
@@ -818,6 +1029,18 @@ func (p *permissionDialogCmp) SetSize() tea.Cmd {
 	case tools.MultiEditToolName:
 		p.width = int(float64(p.wWidth) * 0.8)
 		p.height = int(float64(p.wHeight) * 0.8)
+	case tools.NotebookEditToolName:
+		p.width = int(float64(p.wWidth) * 0.8)
+		p.height = int(float64(p.wHeight) * 0.8)
+	case tools.ApplyPatchToolName:
+		p.width = int(float64(p.wWidth) * 0.8)
+		p.height = int(float64(p.wHeight) * 0.8)
+	case tools.RenameToolName:
+		p.width = int(float64(p.wWidth) * 0.8)
+		p.height = int(float64(p.wHeight) * 0.8)
+	case tools.CodeActionToolName:
+		p.width = int(float64(p.wWidth) * 0.8)
+		p.height = int(float64(p.wHeight) * 0.8)
 	case tools.FetchToolName:
 		p.width = int(float64(p.wWidth) * 0.8)
 		p.height = int(float64(p.wHeight) * 0.3)
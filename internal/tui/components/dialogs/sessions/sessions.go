@@ -1,6 +1,8 @@
 package sessions
 
 import (
+	"strings"
+
 	"charm.land/bubbles/v2/help"
 	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
@@ -45,11 +47,13 @@ func NewSessionDialogCmp(sessions []session.Session, selectedID string) SessionD
 	listKeyMap.DownOneItem = keyMap.Next
 	listKeyMap.UpOneItem = keyMap.Previous
 
-	items := make([]list.CompletionItem[session.Session], len(sessions))
-	if len(sessions) > 0 {
-		for i, session := range sessions {
-			items[i] = list.NewCompletionItem(session.Title, session, list.WithCompletionID(session.ID))
+	items := make([]list.CompletionItem[session.Session], 0, len(sessions))
+	for _, node := range sessionTree(sessions) {
+		title := node.session.Title
+		if node.depth > 0 {
+			title = strings.Repeat("  ", node.depth-1) + "└ " + title
 		}
+		items = append(items, list.NewCompletionItem(title, node.session, list.WithCompletionID(node.session.ID)))
 	}
 
 	inputStyle := t.S().Base.PaddingLeft(1).PaddingBottom(1)
@@ -179,3 +183,40 @@ func (s *sessionDialogCmp) moveCursor(cursor *tea.Cursor) *tea.Cursor {
 func (s *sessionDialogCmp) ID() dialogs.DialogID {
 	return SessionsDialogID
 }
+
+type sessionNode struct {
+	session session.Session
+	depth   int
+}
+
+// sessionTree orders sessions so that forks are listed right below the
+// session they were forked from. The relative order of the given sessions is
+// otherwise preserved.
+func sessionTree(sessions []session.Session) []sessionNode {
+	known := make(map[string]bool, len(sessions))
+	for _, s := range sessions {
+		known[s.ID] = true
+	}
+	children := make(map[string][]session.Session)
+	var roots []session.Session
+	for _, s := range sessions {
+		if s.IsFork() && known[s.ParentSessionID] && s.ParentSessionID != s.ID {
+			children[s.ParentSessionID] = append(children[s.ParentSessionID], s)
+			continue
+		}
+		roots = append(roots, s)
+	}
+
+	nodes := make([]sessionNode, 0, len(sessions))
+	var walk func(s session.Session, depth int)
+	walk = func(s session.Session, depth int) {
+		nodes = append(nodes, sessionNode{session: s, depth: depth})
+		for _, child := range children[s.ID] {
+			walk(child, depth+1)
+		}
+	}
+	for _, s := range roots {
+		walk(s, 0)
+	}
+	return nodes
+}
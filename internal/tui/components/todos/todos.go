@@ -0,0 +1,117 @@
+package todos
+
+import (
+	"fmt"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/crush/internal/todo"
+	"github.com/charmbracelet/crush/internal/tui/components/core"
+	"github.com/charmbracelet/crush/internal/tui/styles"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// RenderOptions contains options for rendering todo lists.
+type RenderOptions struct {
+	MaxWidth    int
+	MaxItems    int
+	ShowSection bool
+	SectionName string
+}
+
+// RenderTodoList renders a list of todos with the given options.
+func RenderTodoList(todos []todo.Todo, opts RenderOptions) []string {
+	t := styles.CurrentTheme()
+	todoList := []string{}
+
+	if opts.ShowSection {
+		sectionName := opts.SectionName
+		if sectionName == "" {
+			sectionName = "Todos"
+		}
+		section := t.S().Subtle.Render(sectionName)
+		todoList = append(todoList, section, "")
+	}
+
+	if len(todos) == 0 {
+		todoList = append(todoList, t.S().Base.Foreground(t.Border).Render("None"))
+		return todoList
+	}
+
+	// Keep the items being worked on in view when the list doesn't fit.
+	start := 0
+	maxItems := len(todos)
+	if opts.MaxItems > 0 {
+		maxItems = min(opts.MaxItems, len(todos))
+		for i, item := range todos {
+			if item.Status != todo.StatusDone {
+				start = min(i, len(todos)-maxItems)
+				break
+			}
+		}
+	}
+
+	for _, item := range todos[start : start+maxItems] {
+		icon := t.S().Base.Foreground(t.FgMuted).Render("○")
+		titleColor := t.FgMuted
+		switch item.Status {
+		case todo.StatusInProgress:
+			icon = t.ItemBusyIcon.String()
+			titleColor = t.FgBase
+		case todo.StatusDone:
+			icon = t.S().Base.Foreground(t.Success).Render(styles.CheckIcon)
+			titleColor = t.FgSubtle
+		}
+		title := item.Content
+		if opts.MaxWidth > 0 {
+			title = ansi.Truncate(title, opts.MaxWidth-lipgloss.Width(icon)-1, "…")
+		}
+		todoList = append(todoList,
+			core.Status(
+				core.StatusOpts{
+					Icon:       icon,
+					Title:      title,
+					TitleColor: titleColor,
+				},
+				opts.MaxWidth,
+			),
+		)
+	}
+
+	return todoList
+}
+
+// RenderTodoBlock renders a complete todo block with a progress count and
+// optional truncation indicator.
+func RenderTodoBlock(todos []todo.Todo, opts RenderOptions, showTruncationIndicator bool) string {
+	t := styles.CurrentTheme()
+	todoList := RenderTodoList(todos, opts)
+
+	// Add truncation indicator if needed
+	if showTruncationIndicator && opts.MaxItems > 0 && len(todos) > opts.MaxItems {
+		remaining := len(todos) - opts.MaxItems
+		if remaining == 1 {
+			todoList = append(todoList, t.S().Base.Foreground(t.FgMuted).Render("…"))
+		} else {
+			todoList = append(todoList,
+				t.S().Base.Foreground(t.FgSubtle).Render(fmt.Sprintf("…and %d more", remaining)),
+			)
+		}
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, todoList...)
+	if opts.MaxWidth > 0 {
+		return lipgloss.NewStyle().Width(opts.MaxWidth).Render(content)
+	}
+	return content
+}
+
+// Progress returns the number of done todos over the total, such as "2/5".
+func Progress(todos []todo.Todo) string {
+	done := 0
+	for _, item := range todos {
+		if item.Status == todo.StatusDone {
+			done++
+		}
+	}
+	return fmt.Sprintf("%d/%d", done, len(todos))
+}
@@ -11,6 +11,7 @@ import (
 	"charm.land/bubbles/v2/spinner"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/crush/internal/agent"
 	"github.com/charmbracelet/crush/internal/app"
 	"github.com/charmbracelet/crush/internal/config"
 	"github.com/charmbracelet/crush/internal/history"
@@ -18,6 +19,7 @@ import (
 	"github.com/charmbracelet/crush/internal/permission"
 	"github.com/charmbracelet/crush/internal/pubsub"
 	"github.com/charmbracelet/crush/internal/session"
+	"github.com/charmbracelet/crush/internal/todo"
 	"github.com/charmbracelet/crush/internal/tui/components/anim"
 	"github.com/charmbracelet/crush/internal/tui/components/chat"
 	"github.com/charmbracelet/crush/internal/tui/components/chat/editor"
@@ -110,6 +112,9 @@ type chatPage struct {
 	editor  editor.Editor
 	splash  splash.Splash
 
+	// Message being edited, resent in place of the original on send
+	editing *messages.EditMessageMsg
+
 	// Simple state flags
 	showingDetails   bool
 	isCanceling      bool
@@ -123,7 +128,7 @@ func New(app *app.App) ChatPage {
 		app:         app,
 		keyMap:      DefaultKeyMap(),
 		header:      header.New(app.LSPClients),
-		sidebar:     sidebar.New(app.History, app.LSPClients, false),
+		sidebar:     sidebar.New(app.History, app.Todos, app.LSPClients, false),
 		chat:        chat.New(app),
 		editor:      editor.New(app),
 		splash:      splash.New(),
@@ -238,9 +243,31 @@ func (p *chatPage) Update(msg tea.Msg) (util.Model, tea.Cmd) {
 		p.editor = u.(editor.Editor)
 		return p, cmd
 	case chat.SendMsg:
+		if p.editing != nil {
+			return p, p.resendMessage(msg.Text, msg.Attachments)
+		}
 		return p, p.sendMessage(msg.Text, msg.Attachments)
 	case chat.SessionSelectedMsg:
 		return p, p.setSession(msg)
+	case messages.ForkSessionMsg:
+		if p.app.AgentCoordinator != nil && p.app.AgentCoordinator.IsBusy() {
+			return p, util.ReportWarn("Agent is busy, please wait before forking the session...")
+		}
+		return p, p.forkSession(msg.MessageID)
+	case messages.EditMessageMsg:
+		if p.app.AgentCoordinator != nil && p.app.AgentCoordinator.IsBusy() {
+			return p, util.ReportWarn("Agent is busy, please wait before editing a message...")
+		}
+		p.editing = &msg
+		p.focusedPane = PanelTypeEditor
+		p.chat.Blur()
+		u, cmd := p.editor.Update(editor.OpenEditorMsg{Text: msg.Message.Content().Text})
+		p.editor = u.(editor.Editor)
+		info := "Editing message, press esc to cancel"
+		if msg.RevertFiles {
+			info = "Editing message, file changes made after it will be reverted, press esc to cancel"
+		}
+		return p, tea.Batch(cmd, p.editor.Focus(), util.ReportInfo(info))
 	case splash.SubmitAPIKeyMsg:
 		u, cmd := p.splash.Update(msg)
 		p.splash = u.(splash.Splash)
@@ -327,7 +354,8 @@ func (p *chatPage) Update(msg tea.Msg) (util.Model, tea.Cmd) {
 		u, cmd := p.editor.Update(msg)
 		p.editor = u.(editor.Editor)
 		return p, cmd
-	case pubsub.Event[history.File], sidebar.SessionFilesMsg:
+	case pubsub.Event[history.File], sidebar.SessionFilesMsg,
+		pubsub.Event[todo.List], sidebar.SessionTodosMsg:
 		u, cmd := p.sidebar.Update(msg)
 		p.sidebar = u.(sidebar.Sidebar)
 		cmds = append(cmds, cmd)
@@ -398,6 +426,12 @@ func (p *chatPage) Update(msg tea.Msg) (util.Model, tea.Cmd) {
 			if p.session.ID != "" && p.app.AgentCoordinator.IsBusy() {
 				return p, p.cancel()
 			}
+			if p.editing != nil && p.focusedPane == PanelTypeEditor && !p.editor.IsCompletionsOpen() {
+				p.editing = nil
+				u, cmd := p.editor.Update(editor.OpenEditorMsg{})
+				p.editor = u.(editor.Editor)
+				return p, tea.Batch(cmd, util.ReportInfo("Message edit cancelled"))
+			}
 		case key.Matches(msg, p.keyMap.Details):
 			p.toggleDetails()
 			return p, nil
@@ -672,6 +706,7 @@ func (p *chatPage) newSession() tea.Cmd {
 	}
 
 	p.session = session.Session{}
+	p.editing = nil
 	p.focusedPane = PanelTypeEditor
 	p.editor.Focus()
 	p.chat.Blur()
@@ -682,6 +717,23 @@ func (p *chatPage) newSession() tea.Cmd {
 	)
 }
 
+func (p *chatPage) forkSession(messageID string) tea.Cmd {
+	if p.session.ID == "" {
+		return nil
+	}
+	sessionID := p.session.ID
+	return func() tea.Msg {
+		forked, err := p.app.Sessions.Fork(context.Background(), sessionID, messageID)
+		if err != nil {
+			return util.ReportError(err)()
+		}
+		return tea.BatchMsg{
+			util.CmdHandler(chat.SessionSelectedMsg(forked)),
+			util.ReportInfo("Session forked"),
+		}
+	}
+}
+
 func (p *chatPage) setSession(session session.Session) tea.Cmd {
 	if p.session.ID == session.ID {
 		return nil
@@ -689,6 +741,7 @@ func (p *chatPage) setSession(session session.Session) tea.Cmd {
 
 	var cmds []tea.Cmd
 	p.session = session
+	p.editing = nil
 
 	cmds = append(cmds, p.SetSize(p.width, p.height))
 	cmds = append(cmds, p.chat.SetSession(session))
@@ -767,7 +820,9 @@ func (p *chatPage) sendMessage(text string, attachments []message.Attachment) te
 		if err != nil {
 			isCancelErr := errors.Is(err, context.Canceled)
 			isPermissionErr := errors.Is(err, permission.ErrorPermissionDenied)
-			if isCancelErr || isPermissionErr {
+			// The chat already explains why the agent was stopped.
+			isStoppedErr := agent.IsStoppedErr(err)
+			if isCancelErr || isPermissionErr || isStoppedErr {
 				return nil
 			}
 			return util.InfoMsg{
@@ -780,6 +835,24 @@ func (p *chatPage) sendMessage(text string, attachments []message.Attachment) te
 	return tea.Batch(cmds...)
 }
 
+// resendMessage rewinds the session to the message being edited and sends
+// the new text in its place.
+func (p *chatPage) resendMessage(text string, attachments []message.Attachment) tea.Cmd {
+	editing := *p.editing
+	p.editing = nil
+	if editing.Message.SessionID != p.session.ID {
+		return p.sendMessage(text, attachments)
+	}
+	if p.app.AgentCoordinator == nil {
+		return util.ReportError(fmt.Errorf("coder agent is not initialized"))
+	}
+	err := p.app.RewindSession(context.Background(), editing.Message.SessionID, editing.Message.ID, editing.RevertFiles)
+	if err != nil {
+		return util.ReportError(err)
+	}
+	return p.sendMessage(text, attachments)
+}
+
 func (p *chatPage) Bindings() []key.Binding {
 	bindings := []key.Binding{
 		p.keyMap.NewSession,
@@ -1056,6 +1129,8 @@ func (p *chatPage) Help() help.KeyMap {
 					key.WithHelp("↑↓", "scroll"),
 				),
 				messages.CopyKey,
+				messages.EditKey,
+				messages.ForkKey,
 			)
 			fullList = append(fullList,
 				[]key.Binding{
@@ -1096,6 +1171,10 @@ func (p *chatPage) Help() help.KeyMap {
 				},
 				[]key.Binding{
 					messages.CopyKey,
+					messages.EditKey,
+					messages.EditRevertKey,
+					messages.ForkKey,
+					messages.OpenOutputKey,
 					messages.ClearSelectionKey,
 				},
 			)
@@ -26,6 +26,7 @@ import (
 	"github.com/charmbracelet/crush/internal/tui/components/dialogs"
 	"github.com/charmbracelet/crush/internal/tui/components/dialogs/commands"
 	"github.com/charmbracelet/crush/internal/tui/components/dialogs/filepicker"
+	"github.com/charmbracelet/crush/internal/tui/components/dialogs/jobs"
 	"github.com/charmbracelet/crush/internal/tui/components/dialogs/models"
 	"github.com/charmbracelet/crush/internal/tui/components/dialogs/permissions"
 	"github.com/charmbracelet/crush/internal/tui/components/dialogs/quit"
@@ -236,6 +237,14 @@ func (a *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case commands.OpenJobsDialogMsg:
+		if a.selectedSessionID == "" {
+			return a, nil
+		}
+		return a, util.CmdHandler(dialogs.OpenDialogMsg{
+			Model: jobs.NewJobsDialogCmp(a.app.Jobs, a.selectedSessionID),
+		})
+
 	case commands.SwitchModelMsg:
 		return a, util.CmdHandler(
 			dialogs.OpenDialogMsg{
@@ -245,7 +254,7 @@ func (a *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Compact
 	case commands.CompactMsg:
 		return a, func() tea.Msg {
-			err := a.app.AgentCoordinator.Summarize(context.Background(), msg.SessionID)
+			err := a.app.AgentCoordinator.Summarize(context.Background(), msg.SessionID, msg.Instructions)
 			if err != nil {
 				return util.ReportError(err)()
 			}